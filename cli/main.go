@@ -12,7 +12,9 @@ var version, commit, date, trebSolCommit string
 func main() {
 	config.SetBuildFlags(version, commit, date, trebSolCommit)
 	rootCmd := cli.NewRootCmd()
-	if err := rootCmd.Execute(); err != nil {
+	cmd, err := rootCmd.ExecuteC()
+	cli.CloseEvents(cmd)
+	if err != nil {
 		os.Exit(1)
 	}
 }
@@ -3,8 +3,10 @@ package abi
 import (
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -104,6 +106,12 @@ func (p *EventParser) ParseEvent(rawLog *forge.EventLog) (Event, error) {
 		return proxyEvent, nil
 	}
 
+	// Try TimelockController events (not in ABI)
+	timelockEvent, err := p.parseTimelockEvent(*rawLog)
+	if err == nil {
+		return timelockEvent, nil
+	}
+
 	return nil, fmt.Errorf("unknown event signature: %s", eventSig.Hex())
 }
 
@@ -187,6 +195,84 @@ func (p *EventParser) parseBeaconUpgradedEvent(log forge.EventLog) (*domain.Beac
 	}, nil
 }
 
+// parseTimelockEvent attempts to parse OpenZeppelin TimelockController scheduling events
+func (p *EventParser) parseTimelockEvent(rawLog forge.EventLog) (Event, error) {
+	var (
+		callScheduledTopic = crypto.Keccak256Hash([]byte("CallScheduled(bytes32,uint256,address,uint256,bytes,bytes32,uint256)"))
+		callSaltTopic      = crypto.Keccak256Hash([]byte("CallSalt(bytes32,bytes32)"))
+	)
+
+	switch rawLog.Topics[0] {
+	case callScheduledTopic:
+		return p.parseCallScheduledEvent(rawLog)
+	case callSaltTopic:
+		return p.parseCallSaltEvent(rawLog)
+	}
+
+	return nil, fmt.Errorf("not a timelock event")
+}
+
+// parseCallScheduledEvent parses a CallScheduled event
+func (p *EventParser) parseCallScheduledEvent(log forge.EventLog) (*domain.CallScheduledEvent, error) {
+	if len(log.Topics) < 3 {
+		return nil, fmt.Errorf("invalid CallScheduled event: not enough topics")
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(log.Data, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode CallScheduled data: %w", err)
+	}
+
+	values, err := callScheduledArguments.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack CallScheduled event: %w", err)
+	}
+
+	return &domain.CallScheduledEvent{
+		Timelock:    log.Address,
+		OperationID: log.Topics[1],
+		Index:       log.Topics[2].Big(),
+		Target:      values[0].(common.Address),
+		Value:       values[1].(*big.Int),
+		Data:        values[2].([]byte),
+		Predecessor: common.Hash(values[3].([32]byte)),
+		Delay:       values[4].(*big.Int),
+	}, nil
+}
+
+// parseCallSaltEvent parses a CallSalt event
+func (p *EventParser) parseCallSaltEvent(log forge.EventLog) (*domain.CallSaltEvent, error) {
+	if len(log.Topics) < 2 {
+		return nil, fmt.Errorf("invalid CallSalt event: not enough topics")
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(log.Data, "0x"))
+	if err != nil || len(data) != 32 {
+		return nil, fmt.Errorf("invalid CallSalt event data")
+	}
+
+	return &domain.CallSaltEvent{
+		Timelock:    log.Address,
+		OperationID: log.Topics[1],
+		Salt:        common.BytesToHash(data),
+	}, nil
+}
+
+// callScheduledArguments are the non-indexed CallScheduled fields
+var callScheduledArguments = func() abi.Arguments {
+	addressType, _ := abi.NewType("address", "", nil)
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	bytesType, _ := abi.NewType("bytes", "", nil)
+	bytes32Type, _ := abi.NewType("bytes32", "", nil)
+	return abi.Arguments{
+		{Name: "target", Type: addressType},
+		{Name: "value", Type: uint256Type},
+		{Name: "data", Type: bytesType},
+		{Name: "predecessor", Type: bytes32Type},
+		{Name: "delay", Type: uint256Type},
+	}
+}()
+
 // ExtractDeploymentEvents filters deployment events from all events
 func ExtractDeploymentEvents(allEvents []any) []*bindings.TrebContractDeployed {
 	var deploymentEvents []*bindings.TrebContractDeployed
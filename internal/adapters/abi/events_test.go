@@ -0,0 +1,65 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+)
+
+func TestEventParser_ParseTimelockEvents(t *testing.T) {
+	parser := &EventParser{}
+	timelock := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	operationID := common.HexToHash("0xfeed")
+
+	t.Run("CallScheduled", func(t *testing.T) {
+		data, err := callScheduledArguments.Pack(
+			common.HexToAddress("0x10"), big.NewInt(5), []byte{0xca, 0xfe}, [32]byte{}, big.NewInt(3600))
+		require.NoError(t, err)
+
+		event, err := parser.parseTimelockEvent(forge.EventLog{
+			Address: timelock,
+			Topics: []common.Hash{
+				crypto.Keccak256Hash([]byte("CallScheduled(bytes32,uint256,address,uint256,bytes,bytes32,uint256)")),
+				operationID,
+				common.BigToHash(big.NewInt(1)),
+			},
+			Data: hexutil.Encode(data),
+		})
+		require.NoError(t, err)
+
+		scheduled, ok := event.(*domain.CallScheduledEvent)
+		require.True(t, ok)
+		assert.Equal(t, timelock, scheduled.Timelock)
+		assert.Equal(t, operationID, scheduled.OperationID)
+		assert.Equal(t, int64(1), scheduled.Index.Int64())
+		assert.Equal(t, common.HexToAddress("0x10"), scheduled.Target)
+		assert.Equal(t, int64(5), scheduled.Value.Int64())
+		assert.Equal(t, []byte{0xca, 0xfe}, scheduled.Data)
+		assert.Equal(t, int64(3600), scheduled.Delay.Int64())
+	})
+
+	t.Run("CallSalt", func(t *testing.T) {
+		salt := common.HexToHash("0x05")
+		event, err := parser.parseTimelockEvent(forge.EventLog{
+			Address: timelock,
+			Topics: []common.Hash{
+				crypto.Keccak256Hash([]byte("CallSalt(bytes32,bytes32)")),
+				operationID,
+			},
+			Data: hexutil.Encode(salt.Bytes()),
+		})
+		require.NoError(t, err)
+
+		callSalt, ok := event.(*domain.CallSaltEvent)
+		require.True(t, ok)
+		assert.Equal(t, operationID, callSalt.OperationID)
+		assert.Equal(t, salt, callSalt.Salt)
+	})
+}
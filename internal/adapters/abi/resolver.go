@@ -1,9 +1,13 @@
 package abi
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"maps"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -20,14 +24,16 @@ type ABIResolver struct {
 	config         *config.RuntimeConfig
 	contracts      usecase.ContractRepository
 	deploymentRepo usecase.DeploymentRepository
+	addressBook    usecase.AddressBookStore
 	execution      *forge.HydratedRunResult
 }
 
-func NewABIResolver(config *config.RuntimeConfig, contracts usecase.ContractRepository, deploymentRepo usecase.DeploymentRepository) *ABIResolver {
+func NewABIResolver(config *config.RuntimeConfig, contracts usecase.ContractRepository, deploymentRepo usecase.DeploymentRepository, addressBook usecase.AddressBookStore) *ABIResolver {
 	return &ABIResolver{
 		config:         config,
 		contracts:      contracts,
 		deploymentRepo: deploymentRepo,
+		addressBook:    addressBook,
 	}
 }
 
@@ -86,6 +92,9 @@ func (r *ABIResolver) FindByAddress(ctx context.Context, address common.Address)
 
 	deployment, err := r.deploymentRepo.GetDeploymentByAddress(ctx, r.config.Network.ChainID, address.String())
 	if err != nil {
+		if entryABI, found, bookErr := r.findInAddressBook(ctx, address); found || bookErr != nil {
+			return entryABI, bookErr
+		}
 		return nil, err
 	}
 	if deployment == nil {
@@ -110,4 +119,41 @@ func (r *ABIResolver) FindByAddress(ctx context.Context, address common.Address)
 	return abi, nil
 }
 
+// findInAddressBook loads the ABI of an address book entry from its artifact or ABI file
+func (r *ABIResolver) findInAddressBook(ctx context.Context, address common.Address) (*abi.ABI, bool, error) {
+	if r.addressBook == nil {
+		return nil, false, nil
+	}
+	entry, err := r.addressBook.GetByAddress(ctx, r.config.Network.ChainID, address.String())
+	if err != nil || (entry.Artifact == "" && entry.ABI == "") {
+		return nil, false, nil
+	}
+
+	if entry.Artifact != "" {
+		entryABI, err := r.FindByRef(ctx, entry.Artifact)
+		return entryABI, true, err
+	}
+
+	path := entry.ABI
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(r.config.ProjectRoot, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to read ABI of %s: %w", entry.Name, err)
+	}
+	// Accept forge artifacts as well as plain ABI arrays
+	var artifact struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	if json.Unmarshal(data, &artifact) == nil && len(artifact.ABI) > 0 {
+		data = artifact.ABI
+	}
+	entryABI, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, true, fmt.Errorf("invalid ABI file %s for %s: %w", entry.ABI, entry.Name, err)
+	}
+	return &entryABI, true, nil
+}
+
 var _ usecase.ABIResolver = (&ABIResolver{})
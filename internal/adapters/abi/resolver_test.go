@@ -0,0 +1,78 @@
+package abi
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// stubAddressBook serves a fixed list of entries
+type stubAddressBook struct {
+	usecase.AddressBookStore
+	entries []*models.AddressBookEntry
+}
+
+func (s stubAddressBook) GetByAddress(_ context.Context, chainID uint64, address string) (*models.AddressBookEntry, error) {
+	for _, entry := range s.entries {
+		if entry.ChainID == chainID && strings.EqualFold(entry.Address, address) {
+			return entry, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func TestAddressBookEntries(t *testing.T) {
+	root := t.TempDir()
+	feedABI := `[{"type":"function","name":"latestRoundData","inputs":[],"outputs":[{"name":"answer","type":"int256"}],"stateMutability":"view"}]`
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "abis"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "abis", "feed.json"), []byte(feedABI), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "abis", "artifact.json"), []byte(`{"abi":`+feedABI+`}`), 0644))
+
+	feed := common.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419")
+	feedArtifact := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	unknown := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	book := stubAddressBook{entries: []*models.AddressBookEntry{
+		{Name: "EthUsdFeed", ChainID: 1, Address: feed.Hex(), ABI: "abis/feed.json"},
+		{Name: "FeedFromArtifact", ChainID: 1, Address: feedArtifact.Hex(), ABI: "abis/artifact.json"},
+		{Name: "USDC", ChainID: 1, Address: usdc.Hex()},
+	}}
+
+	repo := &MockDeploymentRepository{}
+	repo.On("GetDeploymentByAddress", mock.Anything, uint64(1), mock.Anything).Return(nil, domain.ErrNotFound)
+
+	resolver := NewABIResolver(&config.RuntimeConfig{ProjectRoot: root, Network: &config.Network{ChainID: 1}}, nil, repo, book)
+
+	t.Run("ABI from a JSON file", func(t *testing.T) {
+		for _, address := range []common.Address{feed, feedArtifact} {
+			entryABI, err := resolver.FindByAddress(context.Background(), address)
+			require.NoError(t, err)
+			assert.Contains(t, entryABI.Methods, "latestRoundData")
+		}
+	})
+
+	t.Run("entries without an ABI are not found", func(t *testing.T) {
+		_, err := resolver.FindByAddress(context.Background(), usdc)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("decoder labels entries by name", func(t *testing.T) {
+		decoder := NewTransactionDecoder(resolver, repo, book, &forge.HydratedRunResult{RunResult: &forge.RunResult{ChainID: 1}}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		assert.Equal(t, "USDC", decoder.GetLabel(usdc))
+		assert.Equal(t, unknown.String(), decoder.GetLabel(unknown))
+	})
+}
@@ -30,16 +30,18 @@ var (
 type TransactionDecoder struct {
 	abiResolver     usecase.ABIResolver
 	deploymentsRepo usecase.DeploymentRepository
+	addressBook     usecase.AddressBookStore
 	log             *slog.Logger
 	execution       *forge.HydratedRunResult
 	label           map[common.Address]string
 }
 
 // NewTransactionDecoder creates a new transaction decoder
-func NewTransactionDecoder(abiResolver usecase.ABIResolver, deploymentsRepo usecase.DeploymentRepository, execution *forge.HydratedRunResult, log *slog.Logger) *TransactionDecoder {
+func NewTransactionDecoder(abiResolver usecase.ABIResolver, deploymentsRepo usecase.DeploymentRepository, addressBook usecase.AddressBookStore, execution *forge.HydratedRunResult, log *slog.Logger) *TransactionDecoder {
 	decoder := &TransactionDecoder{
 		abiResolver:     abiResolver,
 		deploymentsRepo: deploymentsRepo,
+		addressBook:     addressBook,
 		execution:       execution,
 		log:             log.With("component", "TxDecoder"),
 		label:           make(map[common.Address]string),
@@ -108,6 +110,12 @@ func (td *TransactionDecoder) GetLabel(to common.Address) string {
 	}
 
 	if artifact == "" {
+		// Third-party contracts are labelled by their address book name
+		if td.addressBook != nil {
+			if entry, err := td.addressBook.GetByAddress(context.Background(), td.execution.ChainID, to.String()); err == nil {
+				return entry.Name
+			}
+		}
 		return to.String()
 	}
 
@@ -206,6 +206,9 @@ func buildAnvilArgs(instance *domain.AnvilInstance) []string {
 	}
 	if instance.ForkURL != "" {
 		args = append(args, "--fork-url", instance.ForkURL)
+		if instance.ForkBlock > 0 {
+			args = append(args, "--fork-block-number", strconv.FormatUint(instance.ForkBlock, 10))
+		}
 	}
 	return args
 }
@@ -261,6 +264,79 @@ func (m *Manager) RevertSnapshot(ctx context.Context, instance *domain.AnvilInst
 	return nil
 }
 
+// DumpState returns the hex-encoded state of the anvil instance (anvil_dumpState)
+func (m *Manager) DumpState(ctx context.Context, instance *domain.AnvilInstance) (string, error) {
+	m.setFilePaths(instance)
+
+	req := rpcRequest{
+		Jsonrpc: "2.0",
+		Method:  "anvil_dumpState",
+		Params:  []interface{}{},
+		ID:      1,
+	}
+
+	var resp rpcResponse
+	if err := m.makeRPCCallWithResponse(instance, req, &resp); err != nil {
+		return "", fmt.Errorf("anvil_dumpState RPC call failed: %w", err)
+	}
+
+	state, ok := resp.Result.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected anvil_dumpState response type: %T", resp.Result)
+	}
+
+	return state, nil
+}
+
+// LoadState merges a state returned by DumpState into the anvil instance (anvil_loadState)
+func (m *Manager) LoadState(ctx context.Context, instance *domain.AnvilInstance, state string) error {
+	m.setFilePaths(instance)
+
+	req := rpcRequest{
+		Jsonrpc: "2.0",
+		Method:  "anvil_loadState",
+		Params:  []interface{}{state},
+		ID:      1,
+	}
+
+	var resp rpcResponse
+	if err := m.makeRPCCallWithResponse(instance, req, &resp); err != nil {
+		return fmt.Errorf("anvil_loadState RPC call failed: %w", err)
+	}
+
+	if success, ok := resp.Result.(bool); !ok || !success {
+		return fmt.Errorf("anvil_loadState did not load the state")
+	}
+
+	return nil
+}
+
+// GetBlockNumber returns the number of a block tag (latest, safe, finalized, ...) on the given RPC endpoint
+func (m *Manager) GetBlockNumber(ctx context.Context, rpcURL string, tag string) (uint64, error) {
+	req := rpcRequest{
+		Jsonrpc: "2.0",
+		Method:  "eth_getBlockByNumber",
+		Params:  []interface{}{tag, false},
+		ID:      1,
+	}
+
+	var resp rpcResponse
+	if err := postRPC(rpcURL, req, &resp); err != nil {
+		return 0, fmt.Errorf("eth_getBlockByNumber RPC call failed: %w", err)
+	}
+
+	block, ok := resp.Result.(map[string]interface{})
+	if !ok {
+		return 0, fmt.Errorf("block '%s' not found", tag)
+	}
+	number, ok := block["number"].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected block number type: %T", block["number"])
+	}
+
+	return strconv.ParseUint(strings.TrimPrefix(number, "0x"), 16, 64)
+}
+
 // setFilePaths sets the PID and log file paths for an instance
 func (m *Manager) setFilePaths(instance *domain.AnvilInstance) {
 	if instance.Name == "" {
@@ -465,12 +541,17 @@ func (m *Manager) makeRPCCall(instance *domain.AnvilInstance, req rpcRequest) er
 
 // makeRPCCallWithResponse makes an RPC call and parses the response
 func (m *Manager) makeRPCCallWithResponse(instance *domain.AnvilInstance, req rpcRequest, resp *rpcResponse) error {
+	return postRPC(fmt.Sprintf("http://localhost:%s", instance.Port), req, resp)
+}
+
+// postRPC posts an RPC request to an endpoint and parses the response
+func postRPC(url string, req rpcRequest, resp *rpcResponse) error {
 	jsonData, err := json.Marshal(req)
 	if err != nil {
 		return err
 	}
 
-	httpResp, err := http.Post(fmt.Sprintf("http://localhost:%s", instance.Port), "application/json", bytes.NewBuffer(jsonData))
+	httpResp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return err
 	}
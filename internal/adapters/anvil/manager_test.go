@@ -66,6 +66,21 @@ func TestBuildAnvilArgs_WithoutForkURL(t *testing.T) {
 	}
 }
 
+func TestBuildAnvilArgs_WithForkBlock(t *testing.T) {
+	instance := &domain.AnvilInstance{
+		Port:      "9000",
+		ForkURL:   "https://rpc.sepolia.org",
+		ForkBlock: 7500000,
+	}
+	args := buildAnvilArgs(instance)
+	assert.Equal(t, []string{
+		"--port", "9000",
+		"--host", "0.0.0.0",
+		"--fork-url", "https://rpc.sepolia.org",
+		"--fork-block-number", "7500000",
+	}, args)
+}
+
 func TestSetFilePaths_DefaultInstance(t *testing.T) {
 	m := NewManager()
 	instance := &domain.AnvilInstance{}
@@ -237,3 +252,58 @@ func TestRevertSnapshot_RPCError(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "revert failed")
 }
+
+func TestGetBlockNumber_Success(t *testing.T) {
+	server := newMockRPCServer(t, func(req rpcRequest) rpcResponse {
+		assert.Equal(t, "eth_getBlockByNumber", req.Method)
+		require.Len(t, req.Params, 2)
+		assert.Equal(t, "finalized", req.Params[0])
+		return rpcResponse{
+			Jsonrpc: "2.0",
+			Result:  map[string]interface{}{"number": "0x7270e0"},
+			ID:      req.ID,
+		}
+	})
+	defer server.Close()
+
+	m := NewManager()
+	number, err := m.GetBlockNumber(context.Background(), server.URL, "finalized")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7500000), number)
+}
+
+func TestGetBlockNumber_UnknownBlock(t *testing.T) {
+	server := newMockRPCServer(t, func(req rpcRequest) rpcResponse {
+		return rpcResponse{Jsonrpc: "2.0", ID: req.ID}
+	})
+	defer server.Close()
+
+	m := NewManager()
+	_, err := m.GetBlockNumber(context.Background(), server.URL, "safe")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "block 'safe' not found")
+}
+
+func TestDumpAndLoadState(t *testing.T) {
+	server := newMockRPCServer(t, func(req rpcRequest) rpcResponse {
+		switch req.Method {
+		case "anvil_dumpState":
+			return rpcResponse{Jsonrpc: "2.0", Result: "0x1f8b", ID: req.ID}
+		case "anvil_loadState":
+			require.Len(t, req.Params, 1)
+			assert.Equal(t, "0x1f8b", req.Params[0])
+			return rpcResponse{Jsonrpc: "2.0", Result: true, ID: req.ID}
+		}
+		t.Fatalf("unexpected method %s", req.Method)
+		return rpcResponse{}
+	})
+	defer server.Close()
+
+	m := NewManager()
+	instance := instanceForServer(t, server)
+
+	state, err := m.DumpState(context.Background(), instance)
+	require.NoError(t, err)
+	assert.Equal(t, "0x1f8b", state)
+	require.NoError(t, m.LoadState(context.Background(), instance, state))
+}
@@ -0,0 +1,146 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// accountsABI holds the Safe and Governor view functions used to describe sender accounts
+const accountsABI = `[
+	{"type":"function","name":"getOwners","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address[]"}]},
+	{"type":"function","name":"getThreshold","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"timelock","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address"}]}
+]`
+
+// AccountInspectorAdapter implements the AccountInspector interface using ethclient
+type AccountInspectorAdapter struct {
+	abi    abi.ABI
+	client *ethclient.Client
+	rpcURL string
+}
+
+// NewAccountInspectorAdapter creates a new account inspector adapter
+func NewAccountInspectorAdapter() *AccountInspectorAdapter {
+	parsed, err := abi.JSON(strings.NewReader(accountsABI))
+	if err != nil {
+		panic(fmt.Sprintf("invalid accounts ABI: %v", err))
+	}
+	return &AccountInspectorAdapter{abi: parsed}
+}
+
+// Connect establishes connection to the blockchain
+func (a *AccountInspectorAdapter) Connect(ctx context.Context, rpcURL string, chainID uint64) error {
+	if a.client != nil && a.rpcURL == rpcURL {
+		return nil
+	}
+
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to RPC: %w", err)
+	}
+
+	networkChainID, err := client.ChainID(ctx)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	if chainID != 0 && networkChainID.Uint64() != chainID {
+		client.Close()
+		return fmt.Errorf("chain ID mismatch: expected %d, got %d", chainID, networkChainID.Uint64())
+	}
+
+	a.client = client
+	a.rpcURL = rpcURL
+	return nil
+}
+
+// GetAccountState reads the balance and pending nonce of an account
+func (a *AccountInspectorAdapter) GetAccountState(ctx context.Context, address common.Address) (*domain.AccountState, error) {
+	if a.client == nil {
+		return nil, fmt.Errorf("not connected to blockchain")
+	}
+
+	balance, err := a.client.BalanceAt(ctx, address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance of %s: %w", address.Hex(), err)
+	}
+	nonce, err := a.client.PendingNonceAt(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce of %s: %w", address.Hex(), err)
+	}
+
+	return &domain.AccountState{Balance: balance, Nonce: nonce}, nil
+}
+
+// GetSafeInfo reads the owners and threshold of a Safe
+func (a *AccountInspectorAdapter) GetSafeInfo(ctx context.Context, safe common.Address) (*domain.SafeInfo, error) {
+	if a.client == nil {
+		return nil, fmt.Errorf("not connected to blockchain")
+	}
+
+	contract := bind.NewBoundContract(safe, a.abi, a.client, a.client, a.client)
+	opts := &bind.CallOpts{Context: ctx}
+
+	var owners []any
+	if err := contract.Call(opts, &owners, "getOwners"); err != nil {
+		return nil, fmt.Errorf("failed to read owners of Safe %s: %w", safe.Hex(), err)
+	}
+	var threshold []any
+	if err := contract.Call(opts, &threshold, "getThreshold"); err != nil {
+		return nil, fmt.Errorf("failed to read threshold of Safe %s: %w", safe.Hex(), err)
+	}
+
+	ownerAddresses, ok := owners[0].([]common.Address)
+	if !ok {
+		return nil, fmt.Errorf("unexpected getOwners result from Safe %s", safe.Hex())
+	}
+	thresholdValue, ok := threshold[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected getThreshold result from Safe %s", safe.Hex())
+	}
+
+	return &domain.SafeInfo{Owners: ownerAddresses, Threshold: thresholdValue.Uint64()}, nil
+}
+
+// GetGovernorTimelock reads the timelock of a Governor. Governors without the
+// GovernorTimelockControl extension have no timelock and return the zero address.
+func (a *AccountInspectorAdapter) GetGovernorTimelock(ctx context.Context, governor common.Address) (common.Address, error) {
+	if a.client == nil {
+		return common.Address{}, fmt.Errorf("not connected to blockchain")
+	}
+
+	contract := bind.NewBoundContract(governor, a.abi, a.client, a.client, a.client)
+	var results []any
+	if err := contract.Call(&bind.CallOpts{Context: ctx}, &results, "timelock"); err != nil {
+		return common.Address{}, nil
+	}
+	timelock, ok := results[0].(common.Address)
+	if !ok {
+		return common.Address{}, fmt.Errorf("unexpected timelock result from Governor %s", governor.Hex())
+	}
+	return timelock, nil
+}
+
+// SuggestGasPrice returns the node's suggested gas price
+func (a *AccountInspectorAdapter) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if a.client == nil {
+		return nil, fmt.Errorf("not connected to blockchain")
+	}
+
+	gasPrice, err := a.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	return gasPrice, nil
+}
+
+var _ usecase.AccountInspector = (*AccountInspectorAdapter)(nil)
@@ -0,0 +1,185 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// timelockABI is the part of the OpenZeppelin TimelockController ABI used to execute operations
+const timelockABI = `[
+	{"type":"function","name":"getTimestamp","stateMutability":"view","inputs":[{"name":"id","type":"bytes32"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"execute","stateMutability":"payable","inputs":[{"name":"target","type":"address"},{"name":"value","type":"uint256"},{"name":"payload","type":"bytes"},{"name":"predecessor","type":"bytes32"},{"name":"salt","type":"bytes32"}],"outputs":[]},
+	{"type":"function","name":"executeBatch","stateMutability":"payable","inputs":[{"name":"targets","type":"address[]"},{"name":"values","type":"uint256[]"},{"name":"payloads","type":"bytes[]"},{"name":"predecessor","type":"bytes32"},{"name":"salt","type":"bytes32"}],"outputs":[]}
+]`
+
+// doneTimestamp is the timestamp TimelockController records for executed operations
+const doneTimestamp = 1
+
+// TimelockAdapter implements the TimelockClient interface using ethclient
+type TimelockAdapter struct {
+	abi     abi.ABI
+	client  *ethclient.Client
+	rpcURL  string
+	chainID *big.Int
+}
+
+// NewTimelockAdapter creates a new timelock adapter
+func NewTimelockAdapter() *TimelockAdapter {
+	parsed, err := abi.JSON(strings.NewReader(timelockABI))
+	if err != nil {
+		panic(fmt.Sprintf("invalid timelock ABI: %v", err))
+	}
+	return &TimelockAdapter{abi: parsed}
+}
+
+// Connect establishes connection to the blockchain
+func (t *TimelockAdapter) Connect(ctx context.Context, rpcURL string, chainID uint64) error {
+	if t.client != nil && t.rpcURL == rpcURL {
+		return nil
+	}
+
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to RPC: %w", err)
+	}
+
+	networkChainID, err := client.ChainID(ctx)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	if chainID != 0 && networkChainID.Uint64() != chainID {
+		client.Close()
+		return fmt.Errorf("chain ID mismatch: expected %d, got %d", chainID, networkChainID.Uint64())
+	}
+
+	t.client = client
+	t.rpcURL = rpcURL
+	t.chainID = networkChainID
+	return nil
+}
+
+// GetOperationState reads the operation timestamp and compares it with the latest block
+func (t *TimelockAdapter) GetOperationState(ctx context.Context, timelock common.Address, operationID common.Hash) (*domain.TimelockOperationState, error) {
+	if t.client == nil {
+		return nil, fmt.Errorf("not connected to blockchain")
+	}
+
+	contract := bind.NewBoundContract(timelock, t.abi, t.client, t.client, t.client)
+	var results []any
+	if err := contract.Call(&bind.CallOpts{Context: ctx}, &results, "getTimestamp", operationID); err != nil {
+		return nil, fmt.Errorf("failed to read operation from timelock %s: %w", timelock.Hex(), err)
+	}
+	timestamp, ok := results[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected getTimestamp result from timelock %s", timelock.Hex())
+	}
+
+	state := &domain.TimelockOperationState{}
+	switch {
+	case timestamp.Sign() == 0:
+		// Never scheduled, or canceled
+	case timestamp.Cmp(big.NewInt(doneTimestamp)) == 0:
+		state.Done = true
+	default:
+		header, err := t.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest block: %w", err)
+		}
+		state.Scheduled = true
+		state.ReadyAt = time.Unix(timestamp.Int64(), 0)
+		state.Ready = timestamp.Uint64() <= header.Time
+	}
+
+	return state, nil
+}
+
+// Execute sends execute, or executeBatch for operations with several calls, from the given account.
+// The call values are sent along so operations moving ETH do not depend on the timelock's balance.
+func (t *TimelockAdapter) Execute(ctx context.Context, op *models.TimelockOperation, from common.Address, signer bind.SignerFn) (common.Hash, uint64, error) {
+	if t.client == nil {
+		return common.Hash{}, 0, fmt.Errorf("not connected to blockchain")
+	}
+
+	calldata, value, err := t.packExecute(op)
+	if err != nil {
+		return common.Hash{}, 0, err
+	}
+
+	timelock := common.HexToAddress(op.TimelockAddress)
+	contract := bind.NewBoundContract(timelock, t.abi, t.client, t.client, t.client)
+	tx, err := contract.RawTransact(&bind.TransactOpts{
+		From:    from,
+		Signer:  signer,
+		Value:   value,
+		Context: ctx,
+	}, calldata)
+	if err != nil {
+		return common.Hash{}, 0, fmt.Errorf("failed to send execution transaction: %w", err)
+	}
+
+	receipt, err := bind.WaitMined(ctx, t.client, tx.Hash())
+	if err != nil {
+		return tx.Hash(), 0, fmt.Errorf("failed waiting for transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return tx.Hash(), receipt.BlockNumber.Uint64(), fmt.Errorf("execution transaction %s reverted", tx.Hash().Hex())
+	}
+
+	return tx.Hash(), receipt.BlockNumber.Uint64(), nil
+}
+
+// packExecute encodes the execution call and returns it with the total value of the calls
+func (t *TimelockAdapter) packExecute(op *models.TimelockOperation) ([]byte, *big.Int, error) {
+	if len(op.Calls) == 0 {
+		return nil, nil, fmt.Errorf("timelock operation %s has no calls", op.OperationID)
+	}
+
+	targets := make([]common.Address, 0, len(op.Calls))
+	values := make([]*big.Int, 0, len(op.Calls))
+	payloads := make([][]byte, 0, len(op.Calls))
+	total := new(big.Int)
+	for i, call := range op.Calls {
+		value, ok := new(big.Int).SetString(call.Value, 10)
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid value %q in call %d", call.Value, i)
+		}
+		payload, err := hexutil.Decode(call.Data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid data in call %d: %w", i, err)
+		}
+		targets = append(targets, common.HexToAddress(call.Target))
+		values = append(values, value)
+		payloads = append(payloads, payload)
+		total.Add(total, value)
+	}
+	predecessor := common.HexToHash(op.Predecessor)
+	salt := common.HexToHash(op.Salt)
+
+	var calldata []byte
+	var err error
+	if len(op.Calls) == 1 {
+		calldata, err = t.abi.Pack("execute", targets[0], values[0], payloads[0], predecessor, salt)
+	} else {
+		calldata, err = t.abi.Pack("executeBatch", targets, values, payloads, predecessor, salt)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode execution call: %w", err)
+	}
+	return calldata, total, nil
+}
+
+var _ usecase.TimelockClient = (*TimelockAdapter)(nil)
@@ -0,0 +1,60 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+func TestTimelockAdapter_PackExecute(t *testing.T) {
+	adapter := NewTimelockAdapter()
+	op := &models.TimelockOperation{
+		OperationID: "0x01",
+		Predecessor: common.Hash{}.Hex(),
+		Salt:        common.HexToHash("0x05").Hex(),
+		Calls: []models.TimelockCall{
+			{Target: "0x0000000000000000000000000000000000000010", Value: "0", Data: "0x8129fc1c"},
+		},
+	}
+
+	t.Run("single call uses execute", func(t *testing.T) {
+		calldata, value, err := adapter.packExecute(op)
+		require.NoError(t, err)
+		assert.Equal(t, adapter.abi.Methods["execute"].ID, calldata[:4])
+		assert.Equal(t, int64(0), value.Int64())
+
+		args, err := adapter.abi.Methods["execute"].Inputs.Unpack(calldata[4:])
+		require.NoError(t, err)
+		assert.Equal(t, common.HexToAddress("0x10"), args[0])
+		assert.Equal(t, []byte{0x81, 0x29, 0xfc, 0x1c}, args[2])
+		assert.Equal(t, [32]byte(common.HexToHash("0x05")), args[4])
+	})
+
+	t.Run("several calls use executeBatch", func(t *testing.T) {
+		batch := *op
+		batch.Calls = append(batch.Calls, models.TimelockCall{
+			Target: "0x0000000000000000000000000000000000000020", Value: "7", Data: "0x",
+		})
+
+		calldata, value, err := adapter.packExecute(&batch)
+		require.NoError(t, err)
+		assert.Equal(t, adapter.abi.Methods["executeBatch"].ID, calldata[:4])
+		assert.Equal(t, big.NewInt(7), value)
+
+		args, err := adapter.abi.Methods["executeBatch"].Inputs.Unpack(calldata[4:])
+		require.NoError(t, err)
+		assert.Equal(t, []common.Address{common.HexToAddress("0x10"), common.HexToAddress("0x20")}, args[0])
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		invalid := *op
+		invalid.Calls = []models.TimelockCall{{Target: "0x10", Value: "lots", Data: "0x"}}
+
+		_, _, err := adapter.packExecute(&invalid)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,130 @@
+package environment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"strings"
+
+	trebconfig "github.com/trebuchet-org/treb-cli/internal/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// OperatorEnvVar overrides the operator recorded for a run, e.g. with the CI job identity
+const OperatorEnvVar = "TREB_OPERATOR"
+
+// commandRunner runs a command in a directory and returns its stdout
+type commandRunner func(ctx context.Context, dir string, name string, args ...string) ([]byte, error)
+
+// Inspector captures the environment of a run from git, forge and the OS
+type Inspector struct {
+	projectRoot string
+	run         commandRunner
+}
+
+// NewInspector creates a new environment inspector for the project
+func NewInspector(cfg *config.RuntimeConfig) *Inspector {
+	return &Inspector{
+		projectRoot: cfg.ProjectRoot,
+		run:         runCommand,
+	}
+}
+
+// Inspect captures tool versions, git state and operator. Tools that are unavailable
+// leave their fields empty rather than failing the run.
+func (i *Inspector) Inspect(ctx context.Context) (*models.RunEnvironment, error) {
+	env := &models.RunEnvironment{
+		TrebVersion:  trebconfig.Version,
+		ForgeVersion: i.forgeVersion(ctx),
+		Git:          i.gitState(ctx),
+		Operator:     i.operator(ctx),
+	}
+	return env, nil
+}
+
+// forgeVersion returns the first line of `forge --version`, e.g. "forge Version: 1.2.3-stable"
+func (i *Inspector) forgeVersion(ctx context.Context) string {
+	out, err := i.run(ctx, i.projectRoot, "forge", "--version")
+	if err != nil {
+		return ""
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	return strings.TrimSpace(line)
+}
+
+// gitState records HEAD and, for a dirty tree, a hash of the uncommitted changes so that
+// two runs from the same dirty state can be told apart from runs with different edits
+func (i *Inspector) gitState(ctx context.Context) models.RunGitState {
+	var state models.RunGitState
+
+	out, err := i.run(ctx, i.projectRoot, "git", "rev-parse", "HEAD")
+	if err != nil {
+		return state
+	}
+	state.Commit = strings.TrimSpace(string(out))
+
+	status, err := i.run(ctx, i.projectRoot, "git", "status", "--porcelain")
+	if err != nil || len(strings.TrimSpace(string(status))) == 0 {
+		return state
+	}
+	state.Dirty = true
+
+	diff, err := i.run(ctx, i.projectRoot, "git", "diff", "HEAD")
+	if err != nil {
+		return state
+	}
+	// Untracked files are not part of the diff, include their names so adding one changes the hash
+	untracked, _ := i.run(ctx, i.projectRoot, "git", "ls-files", "--others", "--exclude-standard")
+	sum := sha256.New()
+	sum.Write(diff)
+	sum.Write(untracked)
+	state.DiffHash = hex.EncodeToString(sum.Sum(nil))
+
+	return state
+}
+
+// operator identifies who produced the run: TREB_OPERATOR, then the git identity, then the OS user
+func (i *Inspector) operator(ctx context.Context) string {
+	if operator := os.Getenv(OperatorEnvVar); operator != "" {
+		return operator
+	}
+
+	name := i.gitConfig(ctx, "user.name")
+	email := i.gitConfig(ctx, "user.email")
+	switch {
+	case name != "" && email != "":
+		return fmt.Sprintf("%s <%s>", name, email)
+	case email != "":
+		return email
+	case name != "":
+		return name
+	}
+
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
+
+func (i *Inspector) gitConfig(ctx context.Context, key string) string {
+	out, err := i.run(ctx, i.projectRoot, "git", "config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func runCommand(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...) //nolint:gosec // fixed git/forge invocations
+	cmd.Dir = dir
+	return cmd.Output()
+}
+
+// Ensure Inspector implements RunEnvironmentInspector
+var _ usecase.RunEnvironmentInspector = (*Inspector)(nil)
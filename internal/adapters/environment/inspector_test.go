@@ -0,0 +1,73 @@
+package environment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCommands answers commands by their joined command line
+func fakeCommands(outputs map[string]string) commandRunner {
+	return func(_ context.Context, _ string, name string, args ...string) ([]byte, error) {
+		out, ok := outputs[strings.Join(append([]string{name}, args...), " ")]
+		if !ok {
+			return nil, errors.New("command failed")
+		}
+		return []byte(out), nil
+	}
+}
+
+func TestInspector_CleanTree(t *testing.T) {
+	t.Setenv(OperatorEnvVar, "")
+	inspector := &Inspector{run: fakeCommands(map[string]string{
+		"forge --version":             "forge Version: 1.2.3-stable\nCommit SHA: abc\n",
+		"git rev-parse HEAD":          "0123456789abcdef0123456789abcdef01234567\n",
+		"git status --porcelain":      "",
+		"git config --get user.name":  "Alice\n",
+		"git config --get user.email": "alice@example.com\n",
+	})}
+
+	env, err := inspector.Inspect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "forge Version: 1.2.3-stable", env.ForgeVersion)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", env.Git.Commit)
+	assert.False(t, env.Git.Dirty)
+	assert.Empty(t, env.Git.DiffHash)
+	assert.Equal(t, "Alice <alice@example.com>", env.Operator)
+}
+
+func TestInspector_DirtyTree(t *testing.T) {
+	t.Setenv(OperatorEnvVar, "ci-bot")
+	inspector := &Inspector{run: fakeCommands(map[string]string{
+		"git rev-parse HEAD":                       "0123456789abcdef0123456789abcdef01234567\n",
+		"git status --porcelain":                   " M src/Counter.sol\n",
+		"git diff HEAD":                            "diff --git a/src/Counter.sol b/src/Counter.sol\n",
+		"git ls-files --others --exclude-standard": "",
+	})}
+
+	env, err := inspector.Inspect(context.Background())
+	require.NoError(t, err)
+
+	sum := sha256.Sum256([]byte("diff --git a/src/Counter.sol b/src/Counter.sol\n"))
+	assert.True(t, env.Git.Dirty)
+	assert.Equal(t, hex.EncodeToString(sum[:]), env.Git.DiffHash)
+	assert.Equal(t, "ci-bot", env.Operator)
+	assert.Empty(t, env.ForgeVersion, "missing forge leaves the version empty")
+}
+
+func TestInspector_NoGit(t *testing.T) {
+	t.Setenv(OperatorEnvVar, "")
+	inspector := &Inspector{run: fakeCommands(map[string]string{})}
+
+	env, err := inspector.Inspect(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, env.Git.Commit)
+	assert.False(t, env.Git.Dirty)
+}
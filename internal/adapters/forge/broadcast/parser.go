@@ -10,6 +10,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
 )
 
 // Parser handles parsing of Foundry broadcast files
@@ -63,6 +65,32 @@ func (p *Parser) ParseLatestBroadcast(scriptName string, chainID uint64) (*Broad
 	return &broadcast, nil
 }
 
+// LatestBroadcastPath returns the path of run-latest.json for a script on a chain, in
+// broadcastDir (relative to the project root) when it is set
+func (p *Parser) LatestBroadcastPath(broadcastDir string, script *models.Contract, chainID uint64) string {
+	if broadcastDir != "" {
+		return filepath.Join(p.projectRoot, broadcastDir, filepath.Base(script.Path), fmt.Sprintf("%d", chainID), "run-latest.json")
+	}
+	return filepath.Join(p.getBroadcastPath(filepath.Base(script.Path), chainID), "run-latest.json")
+}
+
+// ReadBroadcast reads a broadcast file, recording when it was last written
+func (p *Parser) ReadBroadcast(path string) (*domain.BroadcastFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read broadcast file: %w", err)
+	}
+
+	broadcast, err := p.ParseBroadcastFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := domain.BroadcastFile(*broadcast)
+	file.UpdatedAt = info.ModTime()
+	return &file, nil
+}
+
 // getBroadcastPath returns the path to broadcast files for a script and chain
 func (p *Parser) getBroadcastPath(scriptName string, chainID uint64) string {
 	return filepath.Join(p.projectRoot, "broadcast", scriptName, fmt.Sprintf("%d", chainID))
@@ -150,3 +178,6 @@ func parseHexToUint64(hexStr string) (uint64, error) {
 	hexStr = strings.TrimPrefix(hexStr, "0x")
 	return strconv.ParseUint(hexStr, 16, 64)
 }
+
+// Ensure Parser implements BroadcastReader
+var _ usecase.BroadcastReader = (*Parser)(nil)
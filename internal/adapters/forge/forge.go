@@ -10,12 +10,14 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/creack/pty"
+	"github.com/trebuchet-org/treb-cli/internal/adapters/signer"
 	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
 	"github.com/trebuchet-org/treb-cli/internal/usecase"
 )
@@ -64,6 +66,17 @@ func (f *ForgeAdapter) RunScript(ctx context.Context, config usecase.RunScriptCo
 	// Build environment variables
 	env := f.buildEnv(config)
 
+	// Remote signer transactions go through a local signing proxy in front of the network RPC
+	if f.useSigningProxy(config) {
+		proxy := signer.NewProxy(upstreamRPCURL(config), config.SenderScriptConfig.RemoteSigners, f.log)
+		proxyURL, err := proxy.Start()
+		if err != nil {
+			return nil, err
+		}
+		defer proxy.Close() //nolint:errcheck
+		args = withRPCURL(args, proxyURL)
+	}
+
 	f.log.Debug("Running forge script", "args", args, "env", env)
 
 	// Execute the script
@@ -266,6 +279,9 @@ func (f *ForgeAdapter) buildArgs(config usecase.RunScriptConfig) []string {
 	// Broadcast/dry run
 	if !config.DryRun {
 		args = append(args, "--broadcast")
+		if config.Resume {
+			args = append(args, "--resume")
+		}
 	}
 
 	// Ledger flag if required
@@ -281,6 +297,12 @@ func (f *ForgeAdapter) buildArgs(config usecase.RunScriptConfig) []string {
 		args = append(args, "--mnemonic-derivation-paths", strings.Join(config.SenderScriptConfig.DerivationPaths, ","))
 	}
 
+	// Remote signers have no local key, forge sends their transactions with eth_sendTransaction
+	// to the signing proxy. Simulations sign nothing and need no proxy.
+	if f.useSigningProxy(config) {
+		args = append(args, "--unlocked")
+	}
+
 	// Libraries for linking
 	for _, lib := range config.Libraries {
 		args = append(args, "--libraries", lib)
@@ -299,6 +321,30 @@ func (f *ForgeAdapter) buildArgs(config usecase.RunScriptConfig) []string {
 	return args
 }
 
+// useSigningProxy reports whether a broadcast needs the remote signing proxy
+func (f *ForgeAdapter) useSigningProxy(config usecase.RunScriptConfig) bool {
+	return len(config.SenderScriptConfig.RemoteSigners) > 0 && !config.DryRun
+}
+
+// upstreamRPCURL returns the RPC the script broadcasts to, the fork when one is active
+func upstreamRPCURL(config usecase.RunScriptConfig) string {
+	for _, forkURL := range config.ForkEnvOverrides {
+		return forkURL
+	}
+	return config.Network.RPCURL
+}
+
+// withRPCURL replaces the --rpc-url value in forge arguments
+func withRPCURL(args []string, rpcURL string) []string {
+	replaced := slices.Clone(args)
+	for i := 0; i < len(replaced)-1; i++ {
+		if replaced[i] == "--rpc-url" {
+			replaced[i+1] = rpcURL
+		}
+	}
+	return replaced
+}
+
 // buildEnv builds environment variable array
 func (f *ForgeAdapter) buildEnv(config usecase.RunScriptConfig) []string {
 	env := make(map[string]string)
@@ -315,6 +361,11 @@ func (f *ForgeAdapter) buildEnv(config usecase.RunScriptConfig) []string {
 		env["QUIET"] = "true"
 	}
 
+	// Runs executing in parallel each get their own broadcast directory
+	if config.BroadcastDir != "" {
+		env["FOUNDRY_BROADCAST"] = filepath.Join(f.projectRoot, config.BroadcastDir)
+	}
+
 	// Fork mode: override RPC env vars so foundry.toml ${VAR} resolves to the fork URL
 	// and signal fork mode to Solidity scripts via TREB_FORK_MODE
 	if len(config.ForkEnvOverrides) > 0 {
@@ -127,6 +127,62 @@ func TestBuildArgs_SlowWithExplicitFlag(t *testing.T) {
 	assert.Contains(t, args, "--slow", "explicit --slow flag should work")
 }
 
+func TestBuildArgs_Resume(t *testing.T) {
+	adapter := newTestForgeAdapter()
+	cfg := baseRunScriptConfig()
+	cfg.Resume = true
+
+	args := adapter.buildArgs(cfg)
+
+	assert.Contains(t, args, "--broadcast")
+	assert.Contains(t, args, "--resume", "resume should be passed through to forge")
+}
+
+func TestBuildArgs_NoResumeOnDryRun(t *testing.T) {
+	adapter := newTestForgeAdapter()
+	cfg := baseRunScriptConfig()
+	cfg.Resume = true
+	cfg.DryRun = true
+
+	args := adapter.buildArgs(cfg)
+
+	assert.NotContains(t, args, "--resume", "dry runs never broadcast so there is nothing to resume")
+}
+
+func TestBuildArgs_RemoteSignerUnlocked(t *testing.T) {
+	adapter := newTestForgeAdapter()
+	cfg := baseRunScriptConfig()
+	cfg.SenderScriptConfig.RemoteSigners = []config.RemoteSignerConfig{
+		{Name: "deployer", URL: "http://signer.internal:8550"},
+	}
+
+	args := adapter.buildArgs(cfg)
+
+	assert.Contains(t, args, "--unlocked", "remote signers broadcast through eth_sendTransaction")
+	assert.True(t, adapter.useSigningProxy(cfg))
+
+	cfg.DryRun = true
+	assert.False(t, adapter.useSigningProxy(cfg), "dry runs do not need the signing proxy")
+	assert.NotContains(t, adapter.buildArgs(cfg), "--unlocked", "simulations send nothing through eth_sendTransaction")
+}
+
+func TestWithRPCURL(t *testing.T) {
+	args := []string{"script", "script/Deploy.s.sol", "--rpc-url", "sepolia", "--broadcast"}
+
+	replaced := withRPCURL(args, "http://127.0.0.1:8545")
+
+	assert.Equal(t, []string{"script", "script/Deploy.s.sol", "--rpc-url", "http://127.0.0.1:8545", "--broadcast"}, replaced)
+	assert.Equal(t, "sepolia", args[3], "original args are left untouched")
+}
+
+func TestUpstreamRPCURL(t *testing.T) {
+	cfg := baseRunScriptConfig()
+	assert.Equal(t, "https://sepolia.example.com", upstreamRPCURL(cfg))
+
+	cfg.ForkEnvOverrides = map[string]string{"SEPOLIA_RPC_URL": "http://127.0.0.1:54321"}
+	assert.Equal(t, "http://127.0.0.1:54321", upstreamRPCURL(cfg), "broadcasts go to the active fork")
+}
+
 func TestBuildEnv_NilForkOverrides(t *testing.T) {
 	adapter := newTestForgeAdapter()
 	cfg := baseRunScriptConfig()
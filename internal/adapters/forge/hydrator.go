@@ -1,6 +1,7 @@
 package forge
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log/slog"
@@ -60,6 +61,7 @@ func (h *RunResultHydrator) Hydrate(
 		Transactions:       []*forge.Transaction{},
 		SafeTransactions:   []*forge.SafeTransaction{},
 		GovernorProposals:  []*forge.GovernorProposal{},
+		TimelockOperations: []*forge.TimelockOperation{},
 		Deployments:        []*forge.Deployment{},
 		ProxyRelationships: make(map[common.Address]*forge.ProxyRelationship),
 		Collisions:         make(map[common.Address]*bindings.TrebDeploymentCollision),
@@ -86,6 +88,10 @@ func (h *RunResultHydrator) Hydrate(
 				h.processSafeTransactionExecuted(e, hydrated)
 			case *bindings.TrebGovernorProposalCreated:
 				h.processGovernorProposalCreated(e, hydrated)
+			case *domain.CallScheduledEvent:
+				h.processCallScheduled(e, hydrated)
+			case *domain.CallSaltEvent:
+				h.processCallSalt(e, hydrated)
 			case *bindings.TrebContractDeployed:
 				deployment := &forge.Deployment{
 					TransactionID: e.TransactionId,
@@ -115,6 +121,9 @@ func (h *RunResultHydrator) Hydrate(
 			h.processProxyEvent(event)
 		}
 
+		// Link the transactions simulated as the timelock to the operations scheduling them
+		h.linkTimelockOperations(hydrated)
+
 		// Extract simulation traces BEFORE processing broadcast traces
 		h.extractSimulationTraces(runResult.ParsedOutput.ScriptOutput)
 
@@ -226,6 +235,79 @@ func (h *RunResultHydrator) processGovernorProposalCreated(event *bindings.TrebG
 	}
 }
 
+// processCallScheduled adds a call to the TimelockController operation it belongs to
+func (h *RunResultHydrator) processCallScheduled(event *domain.CallScheduledEvent, hydrated *HydratedRunResult) {
+	operation := findTimelockOperation(hydrated, event.Timelock, event.OperationID)
+	if operation == nil {
+		operation = &forge.TimelockOperation{
+			OperationID: event.OperationID,
+			Timelock:    event.Timelock,
+			Predecessor: event.Predecessor,
+			Delay:       event.Delay,
+		}
+		hydrated.TimelockOperations = append(hydrated.TimelockOperations, operation)
+	}
+
+	operation.Calls = append(operation.Calls, forge.TimelockCall{
+		Target: event.Target,
+		Value:  event.Value,
+		Data:   event.Data,
+	})
+}
+
+// processCallSalt records the salt of a scheduled TimelockController operation.
+// The timelock emits CallSalt after the CallScheduled events of the operation.
+func (h *RunResultHydrator) processCallSalt(event *domain.CallSaltEvent, hydrated *HydratedRunResult) {
+	if operation := findTimelockOperation(hydrated, event.Timelock, event.OperationID); operation != nil {
+		operation.Salt = event.Salt
+	}
+}
+
+// linkTimelockOperations marks the transactions simulated from the timelock as queued
+// in the operation whose call they match
+func (h *RunResultHydrator) linkTimelockOperations(hydrated *HydratedRunResult) {
+	linked := make(map[[32]byte]bool)
+	for _, operation := range hydrated.TimelockOperations {
+		for idx, call := range operation.Calls {
+			for _, txID := range h.transactionOrder {
+				tx := h.transactions[txID]
+				if linked[txID] || tx.Sender != operation.Timelock ||
+					tx.Transaction.To != call.Target || !bytes.Equal(tx.Transaction.Data, call.Data) {
+					continue
+				}
+
+				linked[txID] = true
+				tx.Status = models.TransactionStatusQueued
+				tx.TimelockOperation = operation
+				batchIdx := idx
+				tx.TimelockBatchIdx = &batchIdx
+				operation.TransactionIds = append(operation.TransactionIds, txID)
+				break
+			}
+		}
+		operation.Proposer = h.timelockProposer(operation.Timelock)
+	}
+}
+
+// timelockProposer returns the sender of the transaction that scheduled calls on the timelock
+func (h *RunResultHydrator) timelockProposer(timelock common.Address) common.Address {
+	for _, txID := range h.transactionOrder {
+		if tx := h.transactions[txID]; tx.Transaction.To == timelock {
+			return tx.Sender
+		}
+	}
+	return common.Address{}
+}
+
+func findTimelockOperation(hydrated *HydratedRunResult, timelock common.Address, operationID common.Hash) *forge.TimelockOperation {
+	for _, operation := range hydrated.TimelockOperations {
+		if operation.Timelock == timelock && operation.OperationID == operationID {
+			return operation
+		}
+	}
+	return nil
+}
+
 // processProxyEvent processes proxy-related events
 func (h *RunResultHydrator) processProxyEvent(event domain.ParsedEvent) {
 	switch e := event.(type) {
@@ -0,0 +1,165 @@
+package fs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// addressBookFile maps chain IDs to the entries of that chain, keyed by name
+type addressBookFile map[string]map[string]*models.AddressBookEntry
+
+// AddressBookStoreAdapter implements AddressBookStore with .treb/addressbook.json
+type AddressBookStoreAdapter struct {
+	path string
+}
+
+// NewAddressBookStoreAdapter creates a new AddressBookStoreAdapter
+func NewAddressBookStoreAdapter(cfg *config.RuntimeConfig) *AddressBookStoreAdapter {
+	return &AddressBookStoreAdapter{
+		path: filepath.Join(cfg.DataDir, "addressbook.json"),
+	}
+}
+
+// List returns every entry, ordered by chain ID and name.
+func (s *AddressBookStoreAdapter) List(_ context.Context) ([]*models.AddressBookEntry, error) {
+	book, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*models.AddressBookEntry{}
+	for _, chainEntries := range book {
+		for _, entry := range chainEntries {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ChainID != entries[j].ChainID {
+			return entries[i].ChainID < entries[j].ChainID
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// Get returns the entry with the given name on a chain.
+func (s *AddressBookStoreAdapter) Get(_ context.Context, chainID uint64, name string) (*models.AddressBookEntry, error) {
+	book, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok := book[chainKey(chainID)][name]
+	if !ok {
+		return nil, fmt.Errorf("address book entry %s on chain %d: %w", name, chainID, domain.ErrNotFound)
+	}
+	return entry, nil
+}
+
+// GetByAddress returns the entry for an address on a chain.
+func (s *AddressBookStoreAdapter) GetByAddress(_ context.Context, chainID uint64, address string) (*models.AddressBookEntry, error) {
+	book, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range book[chainKey(chainID)] {
+		if strings.EqualFold(entry.Address, address) {
+			return entry, nil
+		}
+	}
+	return nil, fmt.Errorf("address book entry for %s on chain %d: %w", address, chainID, domain.ErrNotFound)
+}
+
+// Save adds or replaces an entry.
+func (s *AddressBookStoreAdapter) Save(_ context.Context, entry *models.AddressBookEntry) error {
+	book, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	key := chainKey(entry.ChainID)
+	if book[key] == nil {
+		book[key] = make(map[string]*models.AddressBookEntry)
+	}
+	book[key][entry.Name] = entry
+	return s.save(book)
+}
+
+// Delete removes an entry.
+func (s *AddressBookStoreAdapter) Delete(_ context.Context, chainID uint64, name string) error {
+	book, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	key := chainKey(chainID)
+	if _, ok := book[key][name]; !ok {
+		return fmt.Errorf("address book entry %s on chain %d: %w", name, chainID, domain.ErrNotFound)
+	}
+	delete(book[key], name)
+	if len(book[key]) == 0 {
+		delete(book, key)
+	}
+	return s.save(book)
+}
+
+func (s *AddressBookStoreAdapter) load() (addressBookFile, error) {
+	book := addressBookFile{}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return book, nil
+		}
+		return nil, fmt.Errorf("failed to read address book: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &book); err != nil {
+		return nil, fmt.Errorf("failed to parse address book: %w", err)
+	}
+
+	for key, chainEntries := range book {
+		chainID, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain ID %q in address book", key)
+		}
+		for name, entry := range chainEntries {
+			entry.Name, entry.ChainID = name, chainID
+		}
+	}
+	return book, nil
+}
+
+func (s *AddressBookStoreAdapter) save(book addressBookFile) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(book, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal address book: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write address book: %w", err)
+	}
+	return nil
+}
+
+func chainKey(chainID uint64) string {
+	return strconv.FormatUint(chainID, 10)
+}
+
+// Ensure AddressBookStoreAdapter implements AddressBookStore
+var _ usecase.AddressBookStore = (*AddressBookStoreAdapter)(nil)
@@ -0,0 +1,58 @@
+package fs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+func TestAddressBookStore(t *testing.T) {
+	dataDir := t.TempDir()
+	store := NewAddressBookStoreAdapter(&config.RuntimeConfig{DataDir: dataDir})
+	ctx := context.Background()
+
+	entries, err := store.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	usdc := &models.AddressBookEntry{Name: "USDC", ChainID: 1, Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Artifact: "IERC20"}
+	feed := &models.AddressBookEntry{Name: "EthUsdFeed", ChainID: 1, Address: "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419", ABI: "abis/feed.json"}
+	celoUSDC := &models.AddressBookEntry{Name: "USDC", ChainID: 42220, Address: "0xcebA9300f2b948710d2653dD7B07f33A8B32118C"}
+	for _, entry := range []*models.AddressBookEntry{usdc, feed, celoUSDC} {
+		require.NoError(t, store.Save(ctx, entry))
+	}
+
+	data, err := os.ReadFile(filepath.Join(dataDir, "addressbook.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"42220": {`)
+	assert.NotContains(t, string(data), `"name"`)
+
+	entries, err = store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, []string{"EthUsdFeed", "USDC", "USDC"}, []string{entries[0].Name, entries[1].Name, entries[2].Name})
+	assert.Equal(t, uint64(42220), entries[2].ChainID)
+
+	got, err := store.Get(ctx, 1, "USDC")
+	require.NoError(t, err)
+	assert.Equal(t, usdc, got)
+
+	got, err = store.GetByAddress(ctx, 1, "0x5f4ec3df9cbd43714fe2740f5e3616155c5b8419")
+	require.NoError(t, err)
+	assert.Equal(t, "EthUsdFeed", got.Name)
+
+	_, err = store.GetByAddress(ctx, 42220, usdc.Address)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, store.Delete(ctx, 42220, "USDC"))
+	_, err = store.Get(ctx, 42220, "USDC")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.ErrorIs(t, store.Delete(ctx, 42220, "USDC"), domain.ErrNotFound)
+}
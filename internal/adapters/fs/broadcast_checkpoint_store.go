@@ -0,0 +1,80 @@
+package fs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// BroadcastCheckpointStoreAdapter implements BroadcastCheckpointStore using the file system
+type BroadcastCheckpointStoreAdapter struct {
+	checkpointDir string
+}
+
+// NewBroadcastCheckpointStoreAdapter creates a new BroadcastCheckpointStoreAdapter
+func NewBroadcastCheckpointStoreAdapter(cfg *config.RuntimeConfig) *BroadcastCheckpointStoreAdapter {
+	return &BroadcastCheckpointStoreAdapter{
+		checkpointDir: filepath.Join(cfg.DataDir, "priv", "resume"),
+	}
+}
+
+// Load reads the checkpoint for a script on a chain. Returns nil if there is none.
+func (s *BroadcastCheckpointStoreAdapter) Load(_ context.Context, broadcastDir, scriptPath string, chainID uint64) (*forge.BroadcastCheckpoint, error) {
+	data, err := os.ReadFile(s.checkpointPath(broadcastDir, scriptPath, chainID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read broadcast checkpoint: %w", err)
+	}
+
+	var checkpoint forge.BroadcastCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse broadcast checkpoint: %w", err)
+	}
+
+	return &checkpoint, nil
+}
+
+// Save writes the checkpoint to disk, creating the directory if needed.
+func (s *BroadcastCheckpointStoreAdapter) Save(_ context.Context, checkpoint *forge.BroadcastCheckpoint) error {
+	path := s.checkpointPath(checkpoint.BroadcastDir, checkpoint.Script, checkpoint.ChainID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create broadcast checkpoint directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal broadcast checkpoint: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write broadcast checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the checkpoint for a script on a chain.
+func (s *BroadcastCheckpointStoreAdapter) Delete(_ context.Context, broadcastDir, scriptPath string, chainID uint64) error {
+	err := os.Remove(s.checkpointPath(broadcastDir, scriptPath, chainID))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete broadcast checkpoint: %w", err)
+	}
+	return nil
+}
+
+// checkpointPath mirrors forge's broadcast layout, which is keyed by broadcast directory,
+// script file name and chain
+func (s *BroadcastCheckpointStoreAdapter) checkpointPath(broadcastDir, scriptPath string, chainID uint64) string {
+	return filepath.Join(s.checkpointDir, broadcastDir, fmt.Sprintf("%s-%d.json", filepath.Base(scriptPath), chainID))
+}
+
+// Ensure BroadcastCheckpointStoreAdapter implements BroadcastCheckpointStore
+var _ usecase.BroadcastCheckpointStore = (*BroadcastCheckpointStoreAdapter)(nil)
@@ -0,0 +1,87 @@
+package fs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+)
+
+func newTestBroadcastCheckpointStore(t *testing.T) *BroadcastCheckpointStoreAdapter {
+	t.Helper()
+	return NewBroadcastCheckpointStoreAdapter(&config.RuntimeConfig{DataDir: t.TempDir()})
+}
+
+func TestBroadcastCheckpointStore_LoadMissing(t *testing.T) {
+	store := newTestBroadcastCheckpointStore(t)
+
+	checkpoint, err := store.Load(context.Background(), "", "script/Deploy.s.sol", 1)
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
+
+func TestBroadcastCheckpointStore_SaveLoadDelete(t *testing.T) {
+	store := newTestBroadcastCheckpointStore(t)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	checkpoint := &forge.BroadcastCheckpoint{
+		Script:        "script/deploy/Deploy.s.sol",
+		Namespace:     "default",
+		Network:       "sepolia",
+		ChainID:       11155111,
+		BroadcastPath: "broadcast/Deploy.s.sol/11155111/run-latest.json",
+		ScriptOutput: &forge.ScriptOutput{
+			Logs:    []string{"deploying"},
+			Success: true,
+			Traces: []forge.TraceWithLabel{
+				{Label: "Execution", Trace: forge.TraceOutput{Arena: []forge.TraceNode{{Idx: 0}}}},
+			},
+		},
+		Attempts: []forge.BroadcastAttempt{
+			{Number: 1, Transactions: []forge.BroadcastAttemptTx{{Hash: "0xabc", BlockNumber: 10, Description: "CreateX.deployCreate3(bytes32,bytes)"}}},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	require.NoError(t, store.Save(ctx, checkpoint))
+
+	// Checkpoints are keyed by script file name and chain, like forge's broadcast directory
+	loaded, err := store.Load(ctx, "", "Deploy.s.sol", 11155111)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, checkpoint.BroadcastPath, loaded.BroadcastPath)
+	require.Len(t, loaded.ScriptOutput.Traces, 1)
+	assert.Equal(t, "Execution", loaded.ScriptOutput.Traces[0].Label)
+	assert.Equal(t, map[string]bool{"0xabc": true}, loaded.MinedHashes())
+
+	other, err := store.Load(ctx, "", "Deploy.s.sol", 1)
+	require.NoError(t, err)
+	assert.Nil(t, other)
+
+	// Runs with their own broadcast directory, like parallel compose steps, have their own
+	step := *checkpoint
+	step.BroadcastDir = "broadcast/compose/protocol/token"
+	step.BroadcastPath = "broadcast/compose/protocol/token/Deploy.s.sol/11155111/run-latest.json"
+	require.NoError(t, store.Save(ctx, &step))
+	loaded, err = store.Load(ctx, step.BroadcastDir, "Deploy.s.sol", 11155111)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, step.BroadcastPath, loaded.BroadcastPath)
+	loaded, err = store.Load(ctx, "", "Deploy.s.sol", 11155111)
+	require.NoError(t, err)
+	assert.Equal(t, checkpoint.BroadcastPath, loaded.BroadcastPath)
+
+	require.NoError(t, store.Delete(ctx, "", checkpoint.Script, checkpoint.ChainID))
+	loaded, err = store.Load(ctx, "", checkpoint.Script, checkpoint.ChainID)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	// Deleting again is not an error
+	require.NoError(t, store.Delete(ctx, "", checkpoint.Script, checkpoint.ChainID))
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/trebuchet-org/treb-cli/internal/domain/config"
 	"github.com/trebuchet-org/treb-cli/internal/usecase"
@@ -16,6 +17,7 @@ var registryFiles = []string{
 	"deployments.json",
 	"transactions.json",
 	"safe-txs.json",
+	"timelock-ops.json",
 	"registry.json",
 	"addressbook.json",
 }
@@ -92,6 +94,53 @@ func (m *ForkFileManagerAdapter) CleanupForkDir(_ context.Context, network strin
 	return nil
 }
 
+// ReadRegistryFiles returns the contents of the registry files in .treb/, keyed by file name.
+// Files that don't exist are left out.
+func (m *ForkFileManagerAdapter) ReadRegistryFiles(_ context.Context) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for _, name := range registryFiles {
+		data, err := os.ReadFile(filepath.Join(m.dataDir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		files[name] = data
+	}
+	return files, nil
+}
+
+// WriteRegistryFiles replaces the registry files in .treb/ with the given files.
+// Registry files that are not given are removed, unknown file names are rejected.
+func (m *ForkFileManagerAdapter) WriteRegistryFiles(_ context.Context, files map[string][]byte) error {
+	for name := range files {
+		if !slices.Contains(registryFiles, name) {
+			return fmt.Errorf("unknown registry file '%s'", name)
+		}
+	}
+
+	if err := os.MkdirAll(m.dataDir, 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	for _, name := range registryFiles {
+		path := filepath.Join(m.dataDir, name)
+		data, ok := files[name]
+		if !ok {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove %s: %w", name, err)
+			}
+			continue
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
 // copyFile copies a single file from src to dst, preserving permissions.
 func copyFile(src, dst string) error {
 	srcFile, err := os.Open(src)
@@ -264,3 +264,33 @@ func TestForkFileManager_CleanupDoesNotAffectOtherNetworks(t *testing.T) {
 	_, err = os.Stat(filepath.Join(dataDir, "priv", "fork", "mainnet"))
 	require.NoError(t, err)
 }
+
+func TestForkFileManager_ReadAndWriteRegistryFiles(t *testing.T) {
+	mgr, dataDir := newTestForkFileManager(t)
+	ctx := context.Background()
+
+	writeTestFile(t, dataDir, "deployments.json", `{"a": {}}`)
+	writeTestFile(t, dataDir, "registry.json", `{}`)
+	writeTestFile(t, dataDir, "fork-state.json", `{}`)
+
+	files, err := mgr.ReadRegistryFiles(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"deployments.json": []byte(`{"a": {}}`),
+		"registry.json":    []byte(`{}`),
+	}, files)
+
+	// Writing replaces the registry: given files are written, the others removed
+	err = mgr.WriteRegistryFiles(ctx, map[string][]byte{
+		"deployments.json":  []byte(`{"b": {}}`),
+		"transactions.json": []byte(`{}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"b": {}}`, readTestFile(t, dataDir, "deployments.json"))
+	assert.Equal(t, `{}`, readTestFile(t, dataDir, "transactions.json"))
+	assert.NoFileExists(t, filepath.Join(dataDir, "registry.json"))
+	assert.FileExists(t, filepath.Join(dataDir, "fork-state.json"))
+
+	err = mgr.WriteRegistryFiles(ctx, map[string][]byte{"../treb.toml": []byte(`x`)})
+	assert.ErrorContains(t, err, "unknown registry file '../treb.toml'")
+}
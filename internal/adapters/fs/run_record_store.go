@@ -0,0 +1,114 @@
+package fs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// RunRecordStoreAdapter implements RunRecordStore with one JSON file per run
+type RunRecordStoreAdapter struct {
+	runsDir string
+}
+
+// NewRunRecordStoreAdapter creates a new RunRecordStoreAdapter
+func NewRunRecordStoreAdapter(cfg *config.RuntimeConfig) *RunRecordStoreAdapter {
+	return &RunRecordStoreAdapter{
+		runsDir: filepath.Join(cfg.DataDir, "runs"),
+	}
+}
+
+// Save writes the run record to disk, creating the directory if needed.
+func (s *RunRecordStoreAdapter) Save(_ context.Context, record *models.RunRecord) error {
+	if err := os.MkdirAll(s.runsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create runs directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run record: %w", err)
+	}
+
+	if err := os.WriteFile(s.recordPath(record.ID), data, 0644); err != nil {
+		return fmt.Errorf("failed to write run record: %w", err)
+	}
+
+	return nil
+}
+
+// Get reads the run record with the given ID.
+func (s *RunRecordStoreAdapter) Get(_ context.Context, id string) (*models.RunRecord, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("run %s: %w", id, domain.ErrNotFound)
+	}
+
+	record, err := s.readRecord(s.recordPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("run %s: %w", id, domain.ErrNotFound)
+		}
+		return nil, err
+	}
+	return record, nil
+}
+
+// List reads every run record, most recent first.
+func (s *RunRecordStoreAdapter) List(_ context.Context) ([]*models.RunRecord, error) {
+	entries, err := os.ReadDir(s.runsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*models.RunRecord{}, nil
+		}
+		return nil, fmt.Errorf("failed to read runs directory: %w", err)
+	}
+
+	records := make([]*models.RunRecord, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		record, err := s.readRecord(filepath.Join(s.runsDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].StartedAt.Equal(records[j].StartedAt) {
+			return records[i].ID > records[j].ID
+		}
+		return records[i].StartedAt.After(records[j].StartedAt)
+	})
+
+	return records, nil
+}
+
+func (s *RunRecordStoreAdapter) readRecord(path string) (*models.RunRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var record models.RunRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse run record %s: %w", filepath.Base(path), err)
+	}
+	return &record, nil
+}
+
+func (s *RunRecordStoreAdapter) recordPath(id string) string {
+	return filepath.Join(s.runsDir, id+".json")
+}
+
+// Ensure RunRecordStoreAdapter implements RunRecordStore
+var _ usecase.RunRecordStore = (*RunRecordStoreAdapter)(nil)
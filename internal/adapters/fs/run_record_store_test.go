@@ -0,0 +1,70 @@
+package fs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+func newTestRunRecordStore(t *testing.T) *RunRecordStoreAdapter {
+	t.Helper()
+	return NewRunRecordStoreAdapter(&config.RuntimeConfig{DataDir: t.TempDir()})
+}
+
+func TestRunRecordStore_ListEmpty(t *testing.T) {
+	store := newTestRunRecordStore(t)
+
+	records, err := store.List(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestRunRecordStore_SaveGetList(t *testing.T) {
+	store := newTestRunRecordStore(t)
+	ctx := context.Background()
+	start := time.Date(2025, 1, 26, 15, 4, 5, 0, time.UTC)
+
+	older := &models.RunRecord{
+		ID:         "20250126-150405-aaaaaa",
+		Script:     "DeployCounter",
+		Parameters: map[string]string{"API_KEY": models.RedactedValue},
+		Git:        models.RunGitState{Commit: "abc", Dirty: true, DiffHash: "def"},
+		Status:     models.RunStatusCompleted,
+		Changeset:  models.RunChangeset{Deployments: []string{"default/1/Counter"}},
+		StartedAt:  start,
+	}
+	newer := &models.RunRecord{
+		ID:        "20250126-160405-bbbbbb",
+		Script:    "DeployToken",
+		Status:    models.RunStatusFailed,
+		StartedAt: start.Add(time.Hour),
+	}
+	require.NoError(t, store.Save(ctx, older))
+	require.NoError(t, store.Save(ctx, newer))
+
+	got, err := store.Get(ctx, older.ID)
+	require.NoError(t, err)
+	assert.Equal(t, older, got)
+
+	records, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, newer.ID, records[0].ID)
+	assert.Equal(t, older.ID, records[1].ID)
+}
+
+func TestRunRecordStore_GetMissing(t *testing.T) {
+	store := newTestRunRecordStore(t)
+
+	_, err := store.Get(context.Background(), "20250126-150405-aaaaaa")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = store.Get(context.Background(), "../deployments")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
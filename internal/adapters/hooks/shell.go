@@ -0,0 +1,39 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// ShellRunner runs hook commands with sh from the project root
+type ShellRunner struct {
+	projectRoot string
+}
+
+// NewShellRunner creates a new shell hook runner for the project
+func NewShellRunner(cfg *config.RuntimeConfig) *ShellRunner {
+	return &ShellRunner{projectRoot: cfg.ProjectRoot}
+}
+
+// RunHook runs a command with the environment of treb and the extra env of the hook
+func (r *ShellRunner) RunHook(ctx context.Context, command string, env map[string]string, stdin []byte) (string, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // hook commands come from the compose file
+	cmd.Dir = r.projectRoot
+	cmd.Env = os.Environ()
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		cmd.Env = append(cmd.Env, key+"="+env[key])
+	}
+	cmd.Stdin = bytes.NewReader(stdin)
+
+	output, err := cmd.CombinedOutput()
+	return string(output), err
+}
+
+var _ usecase.HookRunner = (*ShellRunner)(nil)
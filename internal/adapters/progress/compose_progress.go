@@ -3,19 +3,23 @@ package progress
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/trebuchet-org/treb-cli/internal/cli/render"
 	"github.com/trebuchet-org/treb-cli/internal/usecase"
 )
 
-// ComposeProgress handles progress events for compose/orchestrate operations
+// ComposeProgress handles progress events for compose/orchestrate operations.
+// It is safe for concurrent use by steps running in parallel.
 type ComposeProgress struct {
+	mu              sync.Mutex
 	composeRenderer *render.ComposeRenderer
 	scriptRenderer  *render.ScriptRenderer
 	spinner         *SpinnerProgressReporter
 
 	// Track state for proper rendering
 	planRendered bool
+	multiNetwork bool
 	currentStep  string
 }
 
@@ -31,12 +35,16 @@ func NewComposeProgress(composeRenderer *render.ComposeRenderer, scriptRenderer
 
 // OnProgress handles progress events for compose operations
 func (p *ComposeProgress) OnProgress(ctx context.Context, event usecase.ProgressEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	switch event.Stage {
 	case "plan_created":
 		// Render the execution plan once it's created
 		if plan, ok := event.Metadata.(*usecase.ExecutionPlan); ok && !p.planRendered {
 			p.composeRenderer.RenderExecutionPlan(plan)
 			p.planRendered = true
+			p.multiNetwork = len(plan.Networks()) > 1
 		}
 
 	case "compose_resumed":
@@ -57,8 +65,24 @@ func (p *ComposeProgress) OnProgress(ctx context.Context, event usecase.Progress
 
 				// Clear spinner and show step header
 				p.spinner.spinner.Stop()
-				fmt.Fprintf(p.composeRenderer.GetWriter(), "\n[%d/%d] Starting %s\n",
-					currentNum, totalSteps, stepName)
+				if network, _ := stepInfo["network"].(string); p.multiNetwork && network != "" {
+					fmt.Fprintf(p.composeRenderer.GetWriter(), "\n[%d/%d] Starting %s on %s\n",
+						currentNum, totalSteps, stepName, network)
+				} else {
+					fmt.Fprintf(p.composeRenderer.GetWriter(), "\n[%d/%d] Starting %s\n",
+						currentNum, totalSteps, stepName)
+				}
+			}
+		}
+
+	case "step_skipped":
+		// Show the step was skipped in place of its header
+		if stepInfo, ok := event.Metadata.(map[string]interface{}); ok {
+			p.spinner.spinner.Stop()
+			fmt.Fprintf(p.composeRenderer.GetWriter(), "\n[%d/%d] Skipping %s\n",
+				stepInfo["current"].(int), stepInfo["total"].(int), stepInfo["name"].(string))
+			if stepResult, ok := stepInfo["result"].(*usecase.StepResult); ok {
+				p.composeRenderer.RenderStepResult(stepResult)
 			}
 		}
 
@@ -93,11 +117,15 @@ func (p *ComposeProgress) OnProgress(ctx context.Context, event usecase.Progress
 
 // Info forwards info messages to the spinner
 func (p *ComposeProgress) Info(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.spinner.Info(message)
 }
 
 // Error forwards error messages to the spinner
 func (p *ComposeProgress) Error(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.spinner.Error(message)
 }
 
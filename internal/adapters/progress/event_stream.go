@@ -0,0 +1,252 @@
+package progress
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// Event types written to the event stream
+const (
+	EventTypeProgress  = "progress"
+	EventTypeInfo      = "info"
+	EventTypeError     = "error"
+	EventTypeLifecycle = "lifecycle"
+)
+
+// Lifecycle stages of a command
+const (
+	LifecycleStarted   = "started"
+	LifecycleCompleted = "completed"
+	LifecycleFailed    = "failed"
+)
+
+// Event is a single line of the NDJSON event stream
+type Event struct {
+	Time     time.Time `json:"time"`
+	Command  string    `json:"command,omitempty"`
+	Type     string    `json:"type"`
+	Stage    string    `json:"stage,omitempty"`
+	Current  int       `json:"current,omitempty"`
+	Total    int       `json:"total,omitempty"`
+	Message  string    `json:"message,omitempty"`
+	Metadata any       `json:"metadata,omitempty"`
+}
+
+// EventStream writes progress and lifecycle events as newline delimited JSON.
+// It is safe for concurrent use; every event is written with a single Write call.
+type EventStream struct {
+	mu      sync.Mutex
+	out     io.Writer
+	closer  io.Closer // file opened for ndjson=<path>, nil for stdout and stderr
+	command string
+	now     func() time.Time
+}
+
+// NewEventStream creates an event stream writing to out on behalf of command
+func NewEventStream(out io.Writer, command string) *EventStream {
+	return &EventStream{
+		out:     out,
+		command: command,
+		now:     time.Now,
+	}
+}
+
+// OpenEventStream opens the event stream configured with --events. It returns nil when
+// no event stream was requested.
+func OpenEventStream(cfg *config.RuntimeConfig, command string) (*EventStream, error) {
+	if cfg.Events == "" {
+		return nil, nil
+	}
+
+	switch cfg.EventsPath {
+	case "":
+		return NewEventStream(os.Stderr, command), nil
+	case "-":
+		return NewEventStream(os.Stdout, command), nil
+	}
+
+	file, err := os.OpenFile(cfg.EventsPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event stream %s: %w", cfg.EventsPath, err)
+	}
+	stream := NewEventStream(file, command)
+	stream.closer = file
+	return stream, nil
+}
+
+// Close closes the file the stream writes to. Streams to stdout or stderr are left open.
+func (s *EventStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closer == nil {
+		return nil
+	}
+	err := s.closer.Close()
+	s.closer = nil
+	s.out = io.Discard
+	return err
+}
+
+// OnProgress writes a progress event
+func (s *EventStream) OnProgress(ctx context.Context, event usecase.ProgressEvent) {
+	s.write(Event{
+		Type:     EventTypeProgress,
+		Stage:    event.Stage,
+		Current:  event.Current,
+		Total:    event.Total,
+		Message:  event.Message,
+		Metadata: eventMetadata(event.Metadata),
+	})
+}
+
+// Info writes an info event
+func (s *EventStream) Info(message string) {
+	s.write(Event{Type: EventTypeInfo, Message: message})
+}
+
+// Error writes an error event
+func (s *EventStream) Error(message string) {
+	s.write(Event{Type: EventTypeError, Message: message})
+}
+
+// Lifecycle writes a command lifecycle event. err is reported as the message of a failed command.
+func (s *EventStream) Lifecycle(stage string, err error) {
+	event := Event{Type: EventTypeLifecycle, Stage: stage}
+	if err != nil {
+		event.Message = err.Error()
+	}
+	s.write(event)
+}
+
+func (s *EventStream) write(event Event) {
+	event.Time = s.now().UTC()
+	event.Command = s.command
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		// Metadata that cannot be encoded must not break the stream
+		event.Metadata = fmt.Sprintf("%v", event.Metadata)
+		if line, err = json.Marshal(event); err != nil {
+			return
+		}
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.out.Write(line)
+}
+
+// eventMetadata converts progress metadata into a serializable summary. Script configs and
+// step environments carry resolved sender secrets, so only identifying fields are kept.
+func eventMetadata(metadata any) any {
+	switch m := metadata.(type) {
+	case nil:
+		return nil
+	case *usecase.RunScriptConfig:
+		summary := map[string]any{
+			"namespace": m.Namespace,
+			"dryRun":    m.DryRun,
+			"resume":    m.Resume,
+		}
+		if m.Script != nil {
+			summary["script"] = m.Script.Name
+			summary["scriptPath"] = m.Script.Path
+		}
+		if m.Network != nil {
+			summary["network"] = m.Network.Name
+			summary["chainId"] = m.Network.ChainID
+		}
+		return summary
+	case *usecase.ChangesetPreview:
+		summary := map[string]any{}
+		if m.RunResult != nil {
+			summary["transactions"] = len(m.RunResult.Transactions)
+			summary["deployments"] = len(m.RunResult.Deployments)
+			summary["safeTransactions"] = len(m.RunResult.SafeTransactions)
+			summary["governorProposals"] = len(m.RunResult.GovernorProposals)
+		}
+		if m.Changeset != nil {
+			summary["hasChanges"] = m.Changeset.HasChanges()
+		}
+		return summary
+	case *usecase.ExecutionPlan:
+		steps := make([]map[string]any, 0, len(m.Components))
+		for _, step := range m.Components {
+			steps = append(steps, stepMetadata(step))
+		}
+		return map[string]any{"group": m.Group, "steps": steps}
+	case *usecase.StepResult:
+		summary := stepMetadata(m.Step)
+		summary["success"] = m.Error == nil && (m.RunResult == nil || m.RunResult.Success)
+		if m.Error != nil {
+			summary["error"] = m.Error.Error()
+		}
+		if m.RunResult != nil && m.RunResult.Changeset != nil {
+			summary["deployments"] = len(m.RunResult.Changeset.Create.Deployments)
+		}
+		return summary
+	case error:
+		return m.Error()
+	default:
+		return m
+	}
+}
+
+func stepMetadata(step *usecase.ExecutionStep) map[string]any {
+	if step == nil {
+		return map[string]any{}
+	}
+	dependencies := step.Dependencies
+	if dependencies == nil {
+		dependencies = []string{}
+	}
+	return map[string]any{
+		"name":         step.Name,
+		"script":       step.Script,
+		"dependencies": dependencies,
+	}
+}
+
+// EventTee forwards every event to a primary sink and to the event stream
+type EventTee struct {
+	primary usecase.ProgressSink
+	events  *EventStream
+}
+
+// NewEventTee creates a sink that mirrors primary into events
+func NewEventTee(primary usecase.ProgressSink, events *EventStream) *EventTee {
+	return &EventTee{primary: primary, events: events}
+}
+
+// OnProgress forwards the event to both sinks
+func (t *EventTee) OnProgress(ctx context.Context, event usecase.ProgressEvent) {
+	t.events.OnProgress(ctx, event)
+	t.primary.OnProgress(ctx, event)
+}
+
+// Info forwards the message to both sinks
+func (t *EventTee) Info(message string) {
+	t.events.Info(message)
+	t.primary.Info(message)
+}
+
+// Error forwards the message to both sinks
+func (t *EventTee) Error(message string) {
+	t.events.Error(message)
+	t.primary.Error(message)
+}
+
+// Ensure EventStream and EventTee implement ProgressSink
+var (
+	_ usecase.ProgressSink = (*EventStream)(nil)
+	_ usecase.ProgressSink = (*EventTee)(nil)
+)
@@ -0,0 +1,191 @@
+package progress
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+func newTestEventStream(buf *bytes.Buffer) *EventStream {
+	stream := NewEventStream(buf, "treb run")
+	stream.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return stream
+}
+
+func decodeEvents(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var events []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var event map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &event), "line: %s", line)
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestEventStream_WritesOneLinePerEvent(t *testing.T) {
+	var buf bytes.Buffer
+	stream := newTestEventStream(&buf)
+
+	stream.Lifecycle(LifecycleStarted, nil)
+	stream.OnProgress(context.Background(), usecase.ProgressEvent{
+		Stage:   "verifying",
+		Current: 2,
+		Total:   5,
+		Message: "Verifying Counter",
+	})
+	stream.Info("Recovered 1 already mined transaction(s)")
+	stream.Error("forge exited with code 1")
+	stream.Lifecycle(LifecycleFailed, errors.New("script execution failed"))
+
+	events := decodeEvents(t, &buf)
+	require.Len(t, events, 5)
+
+	assert.Equal(t, map[string]any{
+		"time":    "2026-01-02T03:04:05Z",
+		"command": "treb run",
+		"type":    "lifecycle",
+		"stage":   "started",
+	}, events[0])
+	assert.Equal(t, map[string]any{
+		"time":    "2026-01-02T03:04:05Z",
+		"command": "treb run",
+		"type":    "progress",
+		"stage":   "verifying",
+		"current": float64(2),
+		"total":   float64(5),
+		"message": "Verifying Counter",
+	}, events[1])
+	assert.Equal(t, "info", events[2]["type"])
+	assert.Equal(t, "error", events[3]["type"])
+	assert.Equal(t, "failed", events[4]["stage"])
+	assert.Equal(t, "script execution failed", events[4]["message"])
+}
+
+func TestEventStream_RunScriptConfigOmitsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	stream := newTestEventStream(&buf)
+
+	stream.OnProgress(context.Background(), usecase.ProgressEvent{
+		Stage: string(usecase.StageSimulating),
+		Metadata: &usecase.RunScriptConfig{
+			Script:     &models.Contract{Name: "DeployCounter", Path: "script/DeployCounter.s.sol"},
+			Network:    &config.Network{Name: "sepolia", ChainID: 11155111},
+			Namespace:  "default",
+			Parameters: map[string]string{"DEPLOYER_PK": "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"},
+		},
+	})
+
+	assert.NotContains(t, buf.String(), "0xac0974")
+	events := decodeEvents(t, &buf)
+	assert.Equal(t, map[string]any{
+		"script":     "DeployCounter",
+		"scriptPath": "script/DeployCounter.s.sol",
+		"network":    "sepolia",
+		"chainId":    float64(11155111),
+		"namespace":  "default",
+		"dryRun":     false,
+		"resume":     false,
+	}, events[0]["metadata"])
+}
+
+func TestEventStream_StepMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	stream := newTestEventStream(&buf)
+
+	step := &usecase.ExecutionStep{
+		Name:   "Tokens",
+		Script: "DeployTokens",
+		Env:    map[string]string{"SECRET": "hunter2"},
+	}
+	stream.OnProgress(context.Background(), usecase.ProgressEvent{
+		Stage:    "step_completed",
+		Metadata: &usecase.StepResult{Step: step, Error: errors.New("boom")},
+	})
+
+	assert.NotContains(t, buf.String(), "hunter2")
+	events := decodeEvents(t, &buf)
+	assert.Equal(t, map[string]any{
+		"name":         "Tokens",
+		"script":       "DeployTokens",
+		"dependencies": []any{},
+		"success":      false,
+		"error":        "boom",
+	}, events[0]["metadata"])
+}
+
+func TestEventStream_UnencodableMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	stream := newTestEventStream(&buf)
+
+	stream.OnProgress(context.Background(), usecase.ProgressEvent{
+		Stage:    "custom",
+		Metadata: map[string]any{"fn": func() {}},
+	})
+
+	events := decodeEvents(t, &buf)
+	require.Len(t, events, 1)
+	assert.IsType(t, "", events[0]["metadata"])
+}
+
+func TestEventTee_ForwardsToBothSinks(t *testing.T) {
+	var buf bytes.Buffer
+	primary := &recordingSink{}
+	tee := NewEventTee(primary, newTestEventStream(&buf))
+
+	tee.OnProgress(context.Background(), usecase.ProgressEvent{Stage: "syncing"})
+	tee.Info("hello")
+	tee.Error("oops")
+
+	assert.Equal(t, []string{"progress:syncing", "info:hello", "error:oops"}, primary.calls)
+	assert.Len(t, decodeEvents(t, &buf), 3)
+}
+
+func TestOpenEventStream_Disabled(t *testing.T) {
+	stream, err := OpenEventStream(&config.RuntimeConfig{}, "treb run")
+	require.NoError(t, err)
+	assert.Nil(t, stream)
+}
+
+func TestOpenEventStream_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	stream, err := OpenEventStream(&config.RuntimeConfig{Events: "ndjson", EventsPath: path}, "treb run")
+	require.NoError(t, err)
+
+	stream.Info("hello")
+	require.NoError(t, stream.Close())
+	require.NoError(t, stream.Close(), "closing twice is harmless")
+	stream.Info("dropped after close")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(data), "\n"))
+
+	stdout, err := OpenEventStream(&config.RuntimeConfig{Events: "ndjson", EventsPath: "-"}, "treb run")
+	require.NoError(t, err)
+	require.NoError(t, stdout.Close())
+}
+
+type recordingSink struct {
+	calls []string
+}
+
+func (r *recordingSink) OnProgress(ctx context.Context, event usecase.ProgressEvent) {
+	r.calls = append(r.calls, "progress:"+event.Stage)
+}
+
+func (r *recordingSink) Info(message string) { r.calls = append(r.calls, "info:"+message) }
+
+func (r *recordingSink) Error(message string) { r.calls = append(r.calls, "error:"+message) }
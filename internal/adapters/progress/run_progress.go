@@ -2,12 +2,16 @@ package progress
 
 import (
 	"context"
+	"sync"
 
 	"github.com/trebuchet-org/treb-cli/internal/cli/render"
 	"github.com/trebuchet-org/treb-cli/internal/usecase"
 )
 
+// RunProgress renders script progress. It is safe for concurrent use so that compose
+// components running in parallel can share it.
 type RunProgress struct {
+	mu       sync.Mutex
 	renderer *render.ScriptRenderer
 	spinner  *SpinnerProgressReporter
 }
@@ -21,6 +25,9 @@ func NewRunProgress(renderer *render.ScriptRenderer) *RunProgress {
 
 // OnProgress does nothing with progress events
 func (n *RunProgress) OnProgress(ctx context.Context, event usecase.ProgressEvent) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
 	if event.Stage == string(usecase.StageSimulating) {
 		if config, ok := event.Metadata.(*usecase.RunScriptConfig); ok {
 			n.renderer.PrintDeploymentBanner(config)
@@ -29,16 +36,26 @@ func (n *RunProgress) OnProgress(ctx context.Context, event usecase.ProgressEven
 		}
 	}
 
+	if event.Stage == string(usecase.StagePreview) {
+		if preview, ok := event.Metadata.(*usecase.ChangesetPreview); ok {
+			n.renderer.RenderChangesetPreview(preview)
+		}
+	}
+
 	n.spinner.OnProgress(ctx, event)
 }
 
 // Info does nothing with info messages
 func (n *RunProgress) Info(message string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
 	n.spinner.Info(message)
 }
 
 // Error does nothing with error messages
 func (n *RunProgress) Error(message string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
 	n.spinner.Error(message)
 }
 
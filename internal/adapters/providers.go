@@ -1,16 +1,23 @@
 package adapters
 
 import (
+	"fmt"
+
 	"github.com/google/wire"
+	"github.com/spf13/cobra"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/abi"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/anvil"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/blockchain"
+	"github.com/trebuchet-org/treb-cli/internal/adapters/environment"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/forge"
+	"github.com/trebuchet-org/treb-cli/internal/adapters/forge/broadcast"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/fs"
+	"github.com/trebuchet-org/treb-cli/internal/adapters/hooks"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/progress"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/repository/contracts"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/repository/deployments"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/resolvers"
+	"github.com/trebuchet-org/treb-cli/internal/adapters/signer"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/template"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/verification"
 	"github.com/trebuchet-org/treb-cli/internal/cli/interactive"
@@ -30,6 +37,41 @@ func ProvideFoundryProfile(cfg *config.RuntimeConfig) contracts.FoundryProfile {
 	return contracts.FoundryProfile(cfg.Namespace)
 }
 
+// ProvideEventStream opens the event stream requested with --events, nil when disabled
+func ProvideEventStream(cfg *config.RuntimeConfig, cmd *cobra.Command) (*progress.EventStream, error) {
+	// Events on stdout would interleave with the JSON document
+	if cfg.Events != "" && cfg.EventsPath == "-" {
+		if jsonOutput, err := cmd.Flags().GetBool("json"); err == nil && jsonOutput {
+			return nil, fmt.Errorf("--events ndjson=- cannot be combined with --json, stream the events to stderr or a file instead")
+		}
+	}
+	return progress.OpenEventStream(cfg, cmd.CommandPath())
+}
+
+// ProvideProgressSink provides the spinner, mirrored into the event stream when enabled
+func ProvideProgressSink(spinner *progress.SpinnerProgressReporter, events *progress.EventStream) usecase.ProgressSink {
+	if events == nil {
+		return spinner
+	}
+	return progress.NewEventTee(spinner, events)
+}
+
+// ProvideRunProgressSink provides the run progress, mirrored into the event stream when enabled
+func ProvideRunProgressSink(runProgress *progress.RunProgress, events *progress.EventStream) usecase.RunProgressSink {
+	if events == nil {
+		return runProgress
+	}
+	return progress.NewEventTee(runProgress, events)
+}
+
+// ProvideComposeSink provides the compose progress, mirrored into the event stream when enabled
+func ProvideComposeSink(composeProgress *progress.ComposeProgress, events *progress.EventStream) usecase.ComposeSink {
+	if events == nil {
+		return composeProgress
+	}
+	return progress.NewEventTee(composeProgress, events)
+}
+
 // FSSet provides filesystem-based implementations
 var FSSet = wire.NewSet(
 	deployments.NewFileRepositoryFromConfig,
@@ -44,6 +86,15 @@ var FSSet = wire.NewSet(
 
 	fs.NewLocalConfigStoreAdapter,
 	wire.Bind(new(usecase.LocalConfigRepository), new(*fs.LocalConfigStoreAdapter)),
+
+	fs.NewBroadcastCheckpointStoreAdapter,
+	wire.Bind(new(usecase.BroadcastCheckpointStore), new(*fs.BroadcastCheckpointStoreAdapter)),
+
+	fs.NewRunRecordStoreAdapter,
+	wire.Bind(new(usecase.RunRecordStore), new(*fs.RunRecordStoreAdapter)),
+
+	fs.NewAddressBookStoreAdapter,
+	wire.Bind(new(usecase.AddressBookStore), new(*fs.AddressBookStoreAdapter)),
 )
 
 // TemplateSet provides template-based implementations
@@ -57,6 +108,8 @@ var InteractiveSet = wire.NewSet(
 	interactive.NewSelectorAdapter,
 	wire.Bind(new(usecase.ContractSelector), new(*interactive.SelectorAdapter)),
 	wire.Bind(new(usecase.DeploymentSelector), new(*interactive.SelectorAdapter)),
+	wire.Bind(new(usecase.Confirmer), new(*interactive.SelectorAdapter)),
+	wire.Bind(new(usecase.ParameterPrompter), new(*interactive.SelectorAdapter)),
 )
 
 // ProvideCastTracer provides a CastTracer from ForgeAdapter
@@ -71,6 +124,14 @@ var BlockchainSet = wire.NewSet(
 	ProvideCastTracer,
 	blockchain.NewCheckerAdapter,
 	wire.Bind(new(usecase.BlockchainChecker), new(*blockchain.CheckerAdapter)),
+	blockchain.NewTimelockAdapter,
+	wire.Bind(new(usecase.TimelockClient), new(*blockchain.TimelockAdapter)),
+	blockchain.NewAccountInspectorAdapter,
+	wire.Bind(new(usecase.AccountInspector), new(*blockchain.AccountInspectorAdapter)),
+	signer.NewTransactionSigners,
+	wire.Bind(new(usecase.TransactionSigners), new(*signer.TransactionSigners)),
+	signer.NewSafeConfirmer,
+	wire.Bind(new(usecase.SafeConfirmer), new(*signer.SafeConfirmer)),
 )
 
 // VerificationSet provides verification-based implementations
@@ -122,33 +183,44 @@ var ScriptAdapters = wire.NewSet(
 	resolvers.NewParameterResolver,
 	wire.Bind(new(usecase.ParameterResolver), new(*resolvers.ParameterResolver)),
 
-	// TODO: Add these bask
-	// parameters.NewParameterPrompterAdapter,
-	// wire.Bind(new(usecase.ParameterPrompter), new(*parameters.ParameterPrompterAdapter)),
-
 	// Script execution
 	forge.NewForgeAdapter,
 	wire.Bind(new(usecase.ForgeScriptRunner), new(*forge.ForgeAdapter)),
 
+	// Broadcast files
+	broadcast.NewParser,
+	wire.Bind(new(usecase.BroadcastReader), new(*broadcast.Parser)),
+
 	// Result hydration
 	forge.NewRunResultHydrator,
 	wire.Bind(new(usecase.RunResultHydrator), new(*forge.RunResultHydrator)),
 
 	// Registry updates - RegistryStoreAdapter also implements RegistryUpdater
 
+	// Run provenance
+	environment.NewInspector,
+	wire.Bind(new(usecase.RunEnvironmentInspector), new(*environment.Inspector)),
+
+	// Compose hooks
+	hooks.NewShellRunner,
+	wire.Bind(new(usecase.HookRunner), new(*hooks.ShellRunner)),
+
 	// Library resolution
 	resolvers.NewLibraryResolver,
 	wire.Bind(new(usecase.LibraryResolver), new(*resolvers.LibraryResolver)),
 
 	// Progress reporters
 	progress.NewRunProgress,
-	wire.Bind(new(usecase.RunProgressSink), new(*progress.RunProgress)),
+	ProvideRunProgressSink,
 
 	progress.NewComposeProgress,
-	wire.Bind(new(usecase.ComposeSink), new(*progress.ComposeProgress)),
+	ProvideComposeSink,
 
 	progress.NewSpinnerProgressReporter,
-	wire.Bind(new(usecase.ProgressSink), new(*progress.SpinnerProgressReporter)),
+	ProvideProgressSink,
+
+	// NDJSON event stream (--events)
+	ProvideEventStream,
 )
 
 // AllAdapters includes all adapter sets
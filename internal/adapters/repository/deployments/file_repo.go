@@ -24,6 +24,7 @@ const (
 	DeploymentsFile      = "deployments.json"
 	TransactionsFile     = "transactions.json"
 	SafeTransactionsFile = "safe-txs.json"
+	TimelockOpsFile      = "timelock-ops.json"
 	SolidityRegistryFile = "registry.json"
 )
 
@@ -36,6 +37,7 @@ type FileRepository struct {
 	deployments      map[string]*models.Deployment
 	transactions     map[string]*models.Transaction
 	safeTransactions map[string]*models.SafeTransaction
+	timelockOps      map[string]*models.TimelockOperation
 	solidityRegistry SolidityRegistry
 }
 
@@ -54,6 +56,7 @@ func NewFileRepository(rootDir string, log *slog.Logger) (*FileRepository, error
 		deployments:      make(map[string]*models.Deployment),
 		transactions:     make(map[string]*models.Transaction),
 		safeTransactions: make(map[string]*models.SafeTransaction),
+		timelockOps:      make(map[string]*models.TimelockOperation),
 		lookups: &LookupIndexes{
 			Version:     "1.0.0",
 			ByAddress:   make(map[uint64]map[string]string),
@@ -98,6 +101,11 @@ func (m *FileRepository) load() error {
 		return fmt.Errorf("failed to load safe transactions: %w", err)
 	}
 
+	// Load timelock operations
+	if err := m.loadFile(TimelockOpsFile, &m.timelockOps); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load timelock operations: %w", err)
+	}
+
 	// Load solidity registry
 	if err := m.loadFile(SolidityRegistryFile, &m.solidityRegistry); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to load solidity registry: %w", err)
@@ -138,6 +146,11 @@ func (m *FileRepository) save() error {
 		return fmt.Errorf("failed to save safe transactions: %w", err)
 	}
 
+	// Save timelock operations
+	if err := m.saveFile(TimelockOpsFile, m.timelockOps); err != nil {
+		return fmt.Errorf("failed to save timelock operations: %w", err)
+	}
+
 	// Save solidity registry
 	if err := m.saveFile(SolidityRegistryFile, m.solidityRegistry); err != nil {
 		return fmt.Errorf("failed to save solidity registry: %w", err)
@@ -614,6 +627,65 @@ func (m *FileRepository) UpdateSafeTransaction(ctx context.Context, tx *models.S
 	return m.save()
 }
 
+// GetTimelockOperation retrieves a timelock operation by operation ID
+func (m *FileRepository) GetTimelockOperation(ctx context.Context, operationID string) (*models.TimelockOperation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	op, exists := m.timelockOps[operationID]
+	if !exists {
+		return nil, domain.ErrNotFound
+	}
+
+	// Clone to avoid mutations
+	clone := *op
+	return &clone, nil
+}
+
+// ListTimelockOperations lists timelock operations based on filter criteria
+func (m *FileRepository) ListTimelockOperations(ctx context.Context, filter domain.TimelockOperationFilter) ([]*models.TimelockOperation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []*models.TimelockOperation
+
+	for _, op := range m.timelockOps {
+		// Apply filters
+		if filter.ChainID != 0 && op.ChainID != filter.ChainID {
+			continue
+		}
+		if filter.TimelockAddress != "" && !strings.EqualFold(op.TimelockAddress, filter.TimelockAddress) {
+			continue
+		}
+		if filter.Status != "" && op.Status != filter.Status {
+			continue
+		}
+
+		// Clone and add to result
+		clone := *op
+		result = append(result, &clone)
+	}
+
+	return result, nil
+}
+
+// UpdateTimelockOperation updates an existing timelock operation
+func (m *FileRepository) UpdateTimelockOperation(ctx context.Context, op *models.TimelockOperation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if op == nil || op.OperationID == "" {
+		return fmt.Errorf("invalid timelock operation")
+	}
+
+	if _, exists := m.timelockOps[op.OperationID]; !exists {
+		return fmt.Errorf("timelock operation %s not found", op.OperationID)
+	}
+
+	m.timelockOps[op.OperationID] = op
+	return m.save()
+}
+
 // BatchUpdate applies multiple updates to the registry in a single transaction
 type BatchUpdate struct {
 	Deployments      []*models.Deployment
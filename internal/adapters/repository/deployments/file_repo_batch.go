@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
 	"github.com/trebuchet-org/treb-cli/internal/domain/models"
 )
@@ -16,9 +17,10 @@ import (
 func (f *FileRepository) BuildChangesetFromRunResult(ctx context.Context, execution *forge.HydratedRunResult) (*models.Changeset, error) {
 	changeset := &models.Changeset{
 		Create: models.ChangesetModels{
-			Deployments:      []*models.Deployment{},
-			Transactions:     []*models.Transaction{},
-			SafeTransactions: []*models.SafeTransaction{},
+			Deployments:        []*models.Deployment{},
+			Transactions:       []*models.Transaction{},
+			SafeTransactions:   []*models.SafeTransaction{},
+			TimelockOperations: []*models.TimelockOperation{},
 		},
 	}
 
@@ -82,6 +84,20 @@ func (f *FileRepository) BuildChangesetFromRunResult(ctx context.Context, execut
 		changeset.Create.SafeTransactions = append(changeset.Create.SafeTransactions, safeTransaction)
 	}
 
+	// Process timelock operations
+	for _, op := range execution.TimelockOperations {
+		timelockOp := f.createTimelockOperationFromExecution(op, execution.ChainID, now)
+
+		// Map transaction IDs to registry IDs
+		for _, txID := range op.TransactionIds {
+			if tx := f.getTransactionByID(execution, txID); tx != nil {
+				timelockOp.TransactionIDs = append(timelockOp.TransactionIDs, f.getRegistryTransactionID(tx))
+			}
+		}
+
+		changeset.Create.TimelockOperations = append(changeset.Create.TimelockOperations, timelockOp)
+	}
+
 	// Process proxy upgrades: check if any proxy relationships reference existing proxies
 	if err := f.processProxyUpgrades(changeset, execution, now); err != nil {
 		return nil, err
@@ -113,6 +129,11 @@ func (m *FileRepository) ApplyChangeset(ctx context.Context, changeset *models.C
 		for _, tx := range changeset.Delete.SafeTransactions {
 			delete(m.safeTransactions, tx.SafeTxHash)
 		}
+
+		// Delete timelock operations
+		for _, op := range changeset.Delete.TimelockOperations {
+			delete(m.timelockOps, op.OperationID)
+		}
 	}
 
 	// Apply updates
@@ -144,6 +165,15 @@ func (m *FileRepository) ApplyChangeset(ctx context.Context, changeset *models.C
 				m.safeTransactions[tx.SafeTxHash] = tx
 			}
 		}
+
+		// Update timelock operations
+		for _, op := range changeset.Update.TimelockOperations {
+			if existing, exists := m.timelockOps[op.OperationID]; exists {
+				// Preserve original scheduling timestamp
+				op.ScheduledAt = existing.ScheduledAt
+				m.timelockOps[op.OperationID] = op
+			}
+		}
 	}
 
 	// Apply creations
@@ -181,6 +211,17 @@ func (m *FileRepository) ApplyChangeset(ctx context.Context, changeset *models.C
 			// Save transaction
 			m.safeTransactions[tx.SafeTxHash] = tx
 		}
+
+		// Create timelock operations
+		for _, op := range changeset.Create.TimelockOperations {
+			// Set timestamp
+			if op.ScheduledAt.IsZero() {
+				op.ScheduledAt = now
+			}
+
+			// Save operation
+			m.timelockOps[op.OperationID] = op
+		}
 	}
 
 	// Rebuild lookups once for all changes
@@ -333,6 +374,17 @@ func (f *FileRepository) createTransactionFromExecution(
 		transaction.SafeContext.ProposerAddress = tx.SafeTransaction.Proposer.Hex()
 	}
 
+	// Add timelock context if the transaction was scheduled on a timelock
+	if tx.TimelockOperation != nil {
+		transaction.TimelockContext = &models.TimelockContext{
+			TimelockAddress: tx.TimelockOperation.Timelock.Hex(),
+			OperationID:     tx.TimelockOperation.OperationID.Hex(),
+		}
+		if tx.TimelockBatchIdx != nil {
+			transaction.TimelockContext.BatchIndex = *tx.TimelockBatchIdx
+		}
+	}
+
 	// TODO: Build operations from transaction data
 	// This would require analyzing the transaction input data
 
@@ -376,6 +428,45 @@ func (f *FileRepository) createSafeTransactionFromExecution(
 	return safeTransaction
 }
 
+// createTimelockOperationFromExecution creates a timelock operation from execution data
+func (f *FileRepository) createTimelockOperationFromExecution(
+	op *forge.TimelockOperation,
+	chainID uint64,
+	timestamp time.Time,
+) *models.TimelockOperation {
+	timelockOp := &models.TimelockOperation{
+		OperationID:     op.OperationID.Hex(),
+		TimelockAddress: op.Timelock.Hex(),
+		ChainID:         chainID,
+		Status:          models.TransactionStatusQueued,
+		Calls:           make([]models.TimelockCall, 0, len(op.Calls)),
+		Predecessor:     op.Predecessor.Hex(),
+		Salt:            op.Salt.Hex(),
+		TransactionIDs:  []string{}, // Will be populated by caller
+		ScheduledAt:     timestamp,
+	}
+	if op.Proposer != (common.Address{}) {
+		timelockOp.ScheduledBy = op.Proposer.Hex()
+	}
+	if op.Delay != nil {
+		timelockOp.Delay = op.Delay.Uint64()
+	}
+
+	for _, call := range op.Calls {
+		value := "0"
+		if call.Value != nil {
+			value = call.Value.String()
+		}
+		timelockOp.Calls = append(timelockOp.Calls, models.TimelockCall{
+			Target: call.Target.Hex(),
+			Value:  value,
+			Data:   hexutil.Encode(call.Data),
+		})
+	}
+
+	return timelockOp
+}
+
 // processProxyUpgrades detects when a proxy relationship references an already-registered proxy
 // and updates the existing proxy's implementation address and upgrade history.
 func (f *FileRepository) processProxyUpgrades(changeset *models.Changeset, execution *forge.HydratedRunResult, now time.Time) error {
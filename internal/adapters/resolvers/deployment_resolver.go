@@ -3,6 +3,7 @@ package resolvers
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -18,6 +19,7 @@ type DeploymentResolver struct {
 	config   *config.RuntimeConfig
 	repo     usecase.DeploymentRepository
 	selector usecase.DeploymentSelector
+	networks usecase.NetworkResolver
 }
 
 // NewDeploymentResolver creates a new deployment resolver
@@ -25,16 +27,27 @@ func NewDeploymentResolver(
 	cfg *config.RuntimeConfig,
 	repo usecase.DeploymentRepository,
 	selector usecase.DeploymentSelector,
+	networks usecase.NetworkResolver,
 ) *DeploymentResolver {
 	return &DeploymentResolver{
 		config:   cfg,
 		repo:     repo,
 		selector: selector,
+		networks: networks,
 	}
 }
 
-// ResolveDeployment resolves a deployment reference with filtering
+// ResolveDeployment resolves a deployment reference with filtering. A reference ending in
+// @latest picks the most recent of several matching deployments.
 func (r *DeploymentResolver) ResolveDeployment(ctx context.Context, query domain.DeploymentQuery) (*models.Deployment, error) {
+	latest := false
+	if base, selector, found := strings.Cut(query.Reference, "@"); found {
+		if selector != "latest" {
+			return nil, fmt.Errorf("unsupported selector '@%s' in '%s', only @latest is supported", selector, query.Reference)
+		}
+		query.Reference, latest = base, true
+	}
+
 	// Try to find deployments matching the query
 	deployments, err := r.findDeployments(ctx, query)
 	if err != nil {
@@ -49,6 +62,16 @@ func (r *DeploymentResolver) ResolveDeployment(ctx context.Context, query domain
 		return deployments[0], nil
 	}
 
+	if latest {
+		newest := deployments[0]
+		for _, dep := range deployments[1:] {
+			if dep.CreatedAt.After(newest.CreatedAt) {
+				newest = dep
+			}
+		}
+		return newest, nil
+	}
+
 	// Multiple matches - use interactive selector if available
 	if r.selector != nil && !r.config.NonInteractive {
 		selected, err := r.selector.SelectDeployment(ctx, deployments, fmt.Sprintf("Multiple deployments found for '%s'. Select one:", query.Reference))
@@ -118,7 +141,16 @@ func (r *DeploymentResolver) findDeployments(ctx context.Context, query domain.D
 		}
 	}
 
-	// 3. Parse the reference to extract components
+	// 3. A <network>: prefix selects the chain by network name
+	if network, rest, found := strings.Cut(ref, ":"); found && !strings.Contains(network, "/") && r.isNetwork(ctx, network) {
+		resolved, err := r.networks.ResolveNetwork(ctx, network)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve network %s: %w", network, err)
+		}
+		chainID, ref = resolved.ChainID, rest
+	}
+
+	// 4. Parse the reference to extract components
 	contractName, label, extractedNamespace, extractedChainID := r.parseReference(ref)
 
 	// Override with extracted values if found
@@ -129,7 +161,7 @@ func (r *DeploymentResolver) findDeployments(ctx context.Context, query domain.D
 		chainID = extractedChainID
 	}
 
-	// 4. Build filter and search
+	// 5. Build filter and search
 	filter := domain.DeploymentFilter{
 		ContractName: contractName,
 		Label:        label,
@@ -145,6 +177,11 @@ func (r *DeploymentResolver) findDeployments(ctx context.Context, query domain.D
 	return deployments, nil
 }
 
+// isNetwork reports whether name is a network configured in foundry.toml
+func (r *DeploymentResolver) isNetwork(ctx context.Context, name string) bool {
+	return r.networks != nil && slices.Contains(r.networks.GetNetworks(ctx), name)
+}
+
 // parseReference parses a deployment reference and extracts components
 // Supports formats:
 // - Contract name: "Counter"
@@ -153,6 +190,7 @@ func (r *DeploymentResolver) findDeployments(ctx context.Context, query domain.D
 // - Chain/contract: "11155111/Counter"
 // - Namespace/chain/contract: "staging/11155111/Counter"
 // - Namespace/chain/contract:label: "staging/11155111/Counter:v2"
+// A leading "<network>:" (e.g. "celo:Token:v1") is handled by findDeployments.
 func (r *DeploymentResolver) parseReference(ref string) (contractName, label, namespace string, chainID uint64) {
 	// First extract label if present
 	parts := strings.Split(ref, ":")
@@ -0,0 +1,109 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// stubDeploymentRepo filters a fixed list of deployments
+type stubDeploymentRepo struct {
+	usecase.DeploymentRepository
+	deployments []*models.Deployment
+}
+
+func (s *stubDeploymentRepo) GetDeployment(_ context.Context, id string) (*models.Deployment, error) {
+	for _, dep := range s.deployments {
+		if dep.ID == id {
+			return dep, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (s *stubDeploymentRepo) ListDeployments(_ context.Context, filter domain.DeploymentFilter) ([]*models.Deployment, error) {
+	var matches []*models.Deployment
+	for _, dep := range s.deployments {
+		if (filter.ContractName == "" || dep.ContractName == filter.ContractName) &&
+			(filter.Label == "" || dep.Label == filter.Label) &&
+			(filter.ChainID == 0 || dep.ChainID == filter.ChainID) &&
+			(filter.Namespace == "" || dep.Namespace == filter.Namespace) {
+			matches = append(matches, dep)
+		}
+	}
+	return matches, nil
+}
+
+// stubNetworks resolves network names from a fixed map of chain IDs
+type stubNetworks map[string]uint64
+
+func (s stubNetworks) GetNetworks(context.Context) []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (s stubNetworks) ResolveNetwork(_ context.Context, name string) (*config.Network, error) {
+	return &config.Network{Name: name, ChainID: s[name]}, nil
+}
+
+func TestDeploymentResolver_CrossChainReferences(t *testing.T) {
+	now := time.Now()
+	deployment := func(namespace string, chainID uint64, label, address string, age time.Duration) *models.Deployment {
+		return &models.Deployment{
+			ID:           namespace + "/" + label,
+			Namespace:    namespace,
+			ChainID:      chainID,
+			ContractName: "Token",
+			Label:        label,
+			Address:      address,
+			CreatedAt:    now.Add(-age),
+		}
+	}
+	repo := &stubDeploymentRepo{deployments: []*models.Deployment{
+		deployment("default", 1, "v1", "0x01", 0),
+		deployment("production", 42220, "v1", "0x02", 2*time.Hour),
+		deployment("production", 42220, "v2", "0x03", time.Hour),
+	}}
+	resolver := NewDeploymentResolver(
+		&config.RuntimeConfig{Namespace: "production", Network: &config.Network{Name: "mainnet", ChainID: 1}, NonInteractive: true},
+		repo, nil, stubNetworks{"celo": 42220, "mainnet": 1},
+	)
+
+	tests := []struct {
+		name     string
+		ref      string
+		expected string
+		errMsg   string
+	}{
+		{name: "namespace, chain and label", ref: "production/42220/Token:v1", expected: "0x02"},
+		{name: "network prefix with label", ref: "celo:Token:v2", expected: "0x03"},
+		{name: "network prefix with latest", ref: "celo:Token@latest", expected: "0x03"},
+		{name: "namespace and chain", ref: "default/1/Token", expected: "0x01"},
+		{name: "ambiguous match fails", ref: "celo:Token", errMsg: "multiple deployments found matching 'celo:Token'"},
+		{name: "unknown selector", ref: "celo:Token@first", errMsg: "only @latest is supported"},
+		{name: "nothing in the current namespace and chain", ref: "Token", errMsg: "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dep, err := resolver.ResolveDeployment(t.Context(), domain.DeploymentQuery{Reference: tt.ref})
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, dep.Address)
+		})
+	}
+}
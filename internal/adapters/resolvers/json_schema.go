@@ -0,0 +1,191 @@
+package resolvers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// validateJSONSchema checks a document decoded with json.Decoder.UseNumber against a schema.
+// The supported keywords are type, enum, const, properties, required, additionalProperties,
+// items, minItems, maxItems, minimum, maximum, minLength, maxLength and pattern, which covers
+// the configuration files scripts take as parameters.
+func validateJSONSchema(schema map[string]any, value any, path string) error {
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		actual := jsonType(value)
+		if !slices.Contains(types, actual) && !(actual == "integer" && slices.Contains(types, "number")) {
+			return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(types, " or "), actual)
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		if !slices.ContainsFunc(enum, func(allowed any) bool { return jsonEqual(allowed, value) }) {
+			return fmt.Errorf("%s: %s is not one of the allowed values", path, jsonString(value))
+		}
+	}
+	if constant, ok := schema["const"]; ok && !jsonEqual(constant, value) {
+		return fmt.Errorf("%s: must be %s", path, jsonString(constant))
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		return validateObject(schema, v, path)
+	case []any:
+		return validateArray(schema, v, path)
+	case string:
+		if minLength, ok := schemaNumber(schema["minLength"]); ok && float64(utf8.RuneCountInString(v)) < minLength {
+			return fmt.Errorf("%s: must be at least %v characters", path, minLength)
+		}
+		if maxLength, ok := schemaNumber(schema["maxLength"]); ok && float64(utf8.RuneCountInString(v)) > maxLength {
+			return fmt.Errorf("%s: must be at most %v characters", path, maxLength)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("%s: invalid pattern %q in schema: %w", path, pattern, err)
+			}
+			if !re.MatchString(v) {
+				return fmt.Errorf("%s: %q does not match pattern %s", path, v, pattern)
+			}
+		}
+	case json.Number:
+		number, err := v.Float64()
+		if err != nil {
+			return fmt.Errorf("%s: invalid number %s", path, v)
+		}
+		if minimum, ok := schemaNumber(schema["minimum"]); ok && number < minimum {
+			return fmt.Errorf("%s: %s is less than the minimum %v", path, v, minimum)
+		}
+		if maximum, ok := schemaNumber(schema["maximum"]); ok && number > maximum {
+			return fmt.Errorf("%s: %s is greater than the maximum %v", path, v, maximum)
+		}
+	}
+
+	return nil
+}
+
+func validateObject(schema map[string]any, object map[string]any, path string) error {
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			if key, ok := name.(string); ok {
+				if _, exists := object[key]; !exists {
+					return fmt.Errorf("%s: missing required property %q", path, key)
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		propertySchema, known := properties[key].(map[string]any)
+		if !known {
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					return fmt.Errorf("%s: unexpected property %q", path, key)
+				}
+				continue
+			case map[string]any:
+				propertySchema = additional
+			default:
+				continue
+			}
+		}
+		if err := validateJSONSchema(propertySchema, object[key], path+"."+key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateArray(schema map[string]any, array []any, path string) error {
+	if minItems, ok := schemaNumber(schema["minItems"]); ok && float64(len(array)) < minItems {
+		return fmt.Errorf("%s: must have at least %v items", path, minItems)
+	}
+	if maxItems, ok := schemaNumber(schema["maxItems"]); ok && float64(len(array)) > maxItems {
+		return fmt.Errorf("%s: must have at most %v items", path, maxItems)
+	}
+	if itemSchema, ok := schema["items"].(map[string]any); ok {
+		for i, item := range array {
+			if err := validateJSONSchema(itemSchema, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schemaTypes returns the types allowed by a "type" keyword, given as a string or a list
+func schemaTypes(keyword any) []string {
+	switch t := keyword.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// jsonType returns the JSON Schema type name of a decoded value
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case json.Number:
+		if !strings.ContainsAny(v.String(), ".eE") {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// schemaNumber reads a numeric schema keyword, decoded as float64
+func schemaNumber(keyword any) (float64, bool) {
+	n, ok := keyword.(float64)
+	return n, ok
+}
+
+// jsonEqual compares a schema value (numbers decoded as float64) with a document value
+// (numbers decoded as json.Number)
+func jsonEqual(schemaValue, value any) bool {
+	if number, ok := value.(json.Number); ok {
+		f, err := number.Float64()
+		expected, isNumber := schemaValue.(float64)
+		return err == nil && isNumber && f == expected
+	}
+	return reflect.DeepEqual(schemaValue, value)
+}
+
+func jsonString(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,114 @@
+package resolvers
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+)
+
+// checkParameterConstraints checks a normalised parameter value against the constraints of
+// the parameter. List values are checked item by item. The contract constraint needs the
+// chain and is checked by the run itself.
+func checkParameterConstraints(param domain.ScriptParameter, value, projectRoot string) error {
+	constraints := param.Constraints
+	item := param
+	items := []string{value}
+	switch param.Type {
+	case domain.ParamTypeAddressArray:
+		item.Type, items = domain.ParamTypeAddress, strings.Split(value, ",")
+	case domain.ParamTypeUint256Array:
+		item.Type, items = domain.ParamTypeUint256, strings.Split(value, ",")
+	case domain.ParamTypeStringArray:
+		item.Type, items = domain.ParamTypeString, strings.Split(value, ",")
+	}
+
+	minimum, err := constraintBound(item, constraints.Min, projectRoot)
+	if err != nil {
+		return fmt.Errorf("invalid min=%s: %w", constraints.Min, err)
+	}
+	maximum, err := constraintBound(item, constraints.Max, projectRoot)
+	if err != nil {
+		return fmt.Errorf("invalid max=%s: %w", constraints.Max, err)
+	}
+	allowed, err := constraintChoices(item, constraints.Enum, projectRoot)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range items {
+		if constraints.NonZero {
+			if item.IsAddress() && common.IsHexAddress(v) && common.HexToAddress(v) == (common.Address{}) {
+				return fmt.Errorf("must not be the zero address")
+			}
+			numeric := !item.IsAddress() && item.Type != domain.ParamTypeString && item.Type != domain.ParamTypeSecret
+			if n, ok := new(big.Int).SetString(v, 10); numeric && ok && n.Sign() == 0 {
+				return fmt.Errorf("must not be zero")
+			}
+		}
+
+		if minimum != nil || maximum != nil {
+			n, ok := new(big.Int).SetString(v, 10)
+			if !ok {
+				return fmt.Errorf("min and max only apply to numbers, got %q", v)
+			}
+			if minimum != nil && n.Cmp(minimum) < 0 {
+				return fmt.Errorf("%s is less than the minimum %s", v, constraints.Min)
+			}
+			if maximum != nil && n.Cmp(maximum) > 0 {
+				return fmt.Errorf("%s is greater than the maximum %s", v, constraints.Max)
+			}
+		}
+
+		if constraints.Pattern != "" {
+			re, err := regexp.Compile(constraints.Pattern)
+			if err != nil {
+				return fmt.Errorf("invalid pattern %q: %w", constraints.Pattern, err)
+			}
+			if !re.MatchString(v) {
+				return fmt.Errorf("%q does not match pattern %s", v, constraints.Pattern)
+			}
+		}
+
+		if len(allowed) > 0 && !slices.ContainsFunc(allowed, func(choice string) bool {
+			return choice == v || (item.IsAddress() && strings.EqualFold(choice, v))
+		}) {
+			return fmt.Errorf("%q is not one of %s", v, strings.Join(constraints.Enum, ", "))
+		}
+	}
+	return nil
+}
+
+// constraintBound parses a min or max bound in the format of the parameter, so that
+// {ether} parameters can declare max=1000ether
+func constraintBound(param domain.ScriptParameter, bound, projectRoot string) (*big.Int, error) {
+	if bound == "" {
+		return nil, nil
+	}
+	normalized, err := normalizeParameterValue(param, bound, projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	n, ok := new(big.Int).SetString(normalized, 10)
+	if !ok {
+		return nil, fmt.Errorf("not a number")
+	}
+	return n, nil
+}
+
+// constraintChoices normalises enum choices in the format of the parameter
+func constraintChoices(param domain.ScriptParameter, choices []string, projectRoot string) ([]string, error) {
+	allowed := make([]string, 0, len(choices))
+	for _, choice := range choices {
+		normalized, err := normalizeParameterValue(param, choice, projectRoot)
+		if err != nil {
+			return nil, fmt.Errorf("invalid enum choice %q: %w", choice, err)
+		}
+		allowed = append(allowed, normalized)
+	}
+	return allowed, nil
+}
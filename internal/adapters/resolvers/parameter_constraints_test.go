@@ -0,0 +1,117 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+)
+
+func TestParseCustomEnvString_Constraints(t *testing.T) {
+	resolver := &ScriptResolver{}
+	params, err := resolver.parseCustomEnvString(
+		"{uint256} FEE min=0 max=10000 Fee in basis points\n{address} OWNER nonzero contract\n" +
+			"{string} LABEL pattern=^v[0-9]{2}$ Release label\n{string:optional} MODE enum=fast|safe Execution mode")
+	require.NoError(t, err)
+	require.Len(t, params, 4)
+
+	assert.Equal(t, domain.ParameterConstraints{Min: "0", Max: "10000"}, params[0].Constraints)
+	assert.Equal(t, "Fee in basis points", params[0].Description)
+	assert.Equal(t, domain.ParameterConstraints{NonZero: true, Contract: true}, params[1].Constraints)
+	assert.Empty(t, params[1].Description)
+	assert.Equal(t, "^v[0-9]{2}$", params[2].Constraints.Pattern)
+	assert.Equal(t, "Release label", params[2].Description)
+	assert.Equal(t, []string{"fast", "safe"}, params[3].Constraints.Enum)
+	assert.True(t, params[3].Optional)
+
+	_, err = resolver.parseCustomEnvString("{string} LABEL pattern=^v[ Broken")
+	assert.ErrorContains(t, err, "invalid constraint on parameter LABEL")
+}
+
+func TestParseCustomEnvString_ConcatenatedTags(t *testing.T) {
+	// solc joins repeated @custom:env tags with no separator
+	resolver := &ScriptResolver{}
+	params, err := resolver.parseCustomEnvString(
+		"{string:optional} label Label for the proxy and implementation{deployment:optional} implementation Implementation to use for the proxy" +
+			"{string} TAG pattern=^v[0-9]{2}$ Release tag{json:schemas/config.json} CONFIG Config file")
+	require.NoError(t, err)
+	require.Len(t, params, 4)
+
+	assert.Equal(t, "label", params[0].Name)
+	assert.Equal(t, domain.ParamTypeString, params[0].Type)
+	assert.Equal(t, "Label for the proxy and implementation", params[0].Description)
+	assert.True(t, params[0].Optional)
+
+	assert.Equal(t, "implementation", params[1].Name)
+	assert.Equal(t, domain.ParamTypeDeployment, params[1].Type)
+	assert.Equal(t, "Implementation to use for the proxy", params[1].Description)
+	assert.True(t, params[1].Optional)
+
+	assert.Equal(t, "TAG", params[2].Name)
+	assert.Equal(t, "^v[0-9]{2}$", params[2].Constraints.Pattern)
+	assert.Equal(t, "Release tag", params[2].Description)
+
+	assert.Equal(t, "CONFIG", params[3].Name)
+	assert.Equal(t, domain.ParamTypeJSON, params[3].Type)
+	assert.Equal(t, "schemas/config.json", params[3].Schema)
+}
+
+func TestCheckParameterConstraints(t *testing.T) {
+	tests := []struct {
+		name        string
+		paramType   domain.ParameterType
+		constraints domain.ParameterConstraints
+		value       string
+		errMsg      string
+	}{
+		{name: "within bounds", paramType: domain.ParamTypeUint256, constraints: domain.ParameterConstraints{Min: "1", Max: "10000"}, value: "500"},
+		{name: "above maximum", paramType: domain.ParamTypeUint256, constraints: domain.ParameterConstraints{Max: "10000"}, value: "10001", errMsg: "greater than the maximum 10000"},
+		{name: "below minimum", paramType: domain.ParamTypeUint256, constraints: domain.ParameterConstraints{Min: "1"}, value: "0", errMsg: "less than the minimum 1"},
+		{name: "bound in parameter units", paramType: domain.ParamTypeEther, constraints: domain.ParameterConstraints{Max: "1000ether"}, value: "1001000000000000000000", errMsg: "greater than the maximum 1000ether"},
+		{name: "zero address", paramType: domain.ParamTypeAddress, constraints: domain.ParameterConstraints{NonZero: true}, value: "0x0000000000000000000000000000000000000000", errMsg: "zero address"},
+		{name: "zero address in list", paramType: domain.ParamTypeAddressArray, constraints: domain.ParameterConstraints{NonZero: true},
+			value: "0x0000000000000000000000000000000000000001,0x0000000000000000000000000000000000000000", errMsg: "zero address"},
+		{name: "zero amount", paramType: domain.ParamTypeUint256, constraints: domain.ParameterConstraints{NonZero: true}, value: "0", errMsg: "must not be zero"},
+		{name: "zero string", paramType: domain.ParamTypeString, constraints: domain.ParameterConstraints{NonZero: true}, value: "0"},
+		{name: "pattern", paramType: domain.ParamTypeString, constraints: domain.ParameterConstraints{Pattern: "^v[0-9]+$"}, value: "v12"},
+		{name: "pattern mismatch", paramType: domain.ParamTypeString, constraints: domain.ParameterConstraints{Pattern: "^v[0-9]+$"}, value: "12", errMsg: `"12" does not match pattern ^v[0-9]+$`},
+		{name: "enum", paramType: domain.ParamTypeString, constraints: domain.ParameterConstraints{Enum: []string{"fast", "safe"}}, value: "safe"},
+		{name: "not in enum", paramType: domain.ParamTypeString, constraints: domain.ParameterConstraints{Enum: []string{"fast", "safe"}}, value: "slow", errMsg: `"slow" is not one of fast, safe`},
+		{name: "address enum ignores case", paramType: domain.ParamTypeAddress,
+			constraints: domain.ParameterConstraints{Enum: []string{"0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"}},
+			value:       "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param := domain.ScriptParameter{Name: "PARAM", Type: tt.paramType, Constraints: tt.constraints}
+			err := checkParameterConstraints(param, tt.value, t.TempDir())
+			if tt.errMsg != "" {
+				assert.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParameterResolver_ValidateParameters(t *testing.T) {
+	resolver := NewParameterResolver(&config.RuntimeConfig{ProjectRoot: t.TempDir()}, nil, nil, nil, nil)
+	params := []domain.ScriptParameter{
+		{Name: "FEE", Type: domain.ParamTypeUint256, Constraints: domain.ParameterConstraints{Max: "10000"}},
+		{Name: "MODE", Type: domain.ParamTypeString, Constraints: domain.ParameterConstraints{Enum: []string{"fast", "safe"}}},
+		{Name: "LABEL", Type: domain.ParamTypeString, Optional: true, Constraints: domain.ParameterConstraints{Pattern: "^v"}},
+	}
+
+	require.NoError(t, resolver.ValidateParameters(context.Background(), params, map[string]string{"FEE": "30", "MODE": "fast"}))
+
+	err := resolver.ValidateParameters(context.Background(), params, map[string]string{"FEE": "20000", "MODE": "slow"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "FEE: 20000 is greater than the maximum 10000")
+	assert.Contains(t, err.Error(), `MODE: "slow" is not one of fast, safe`)
+
+	err = resolver.ValidateParameters(context.Background(), params, map[string]string{"FEE": "1"})
+	assert.ErrorContains(t, err, "MODE")
+}
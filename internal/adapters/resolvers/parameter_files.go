@@ -0,0 +1,137 @@
+package resolvers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	internalconfig "github.com/trebuchet-org/treb-cli/internal/config"
+)
+
+// paramsDir holds the per-namespace parameter files, relative to the project root
+const paramsDir = "params"
+
+// parameterLayer is one set of parameter values read from a parameter file
+type parameterLayer struct {
+	location string
+	values   map[string]string
+}
+
+// loadParameterLayers reads params/<namespace>/<script>.toml for the namespace and each of
+// its parents, most specific first. Within a file the [networks.<network>] table comes
+// before the top-level values it overrides.
+func loadParameterLayers(projectRoot, namespace, network, script string) ([]parameterLayer, error) {
+	chain := internalconfig.BuildNamespaceChain(namespace)
+	slices.Reverse(chain)
+
+	var layers []parameterLayer
+	for _, ns := range chain {
+		location := filepath.ToSlash(filepath.Join(paramsDir, ns, script+".toml"))
+		path := filepath.Join(projectRoot, location)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+
+		var raw map[string]any
+		if _, err := toml.DecodeFile(path, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", location, err)
+		}
+
+		networks, err := parseNetworkTables(raw["networks"], location)
+		if err != nil {
+			return nil, err
+		}
+		delete(raw, "networks")
+
+		if overrides, ok := networks[network]; ok && network != "" {
+			values, err := parameterValues(overrides, fmt.Sprintf("%s [networks.%s]", location, network))
+			if err != nil {
+				return nil, err
+			}
+			layers = append(layers, parameterLayer{location: fmt.Sprintf("%s [networks.%s]", location, network), values: values})
+		}
+
+		values, err := parameterValues(raw, location)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, parameterLayer{location: location, values: values})
+	}
+
+	return layers, nil
+}
+
+// lookupParameter returns the value of a parameter from the first layer that sets it
+func lookupParameter(layers []parameterLayer, name string) (string, string, bool) {
+	for _, layer := range layers {
+		if value, ok := layer.values[name]; ok && value != "" {
+			return value, layer.location, true
+		}
+	}
+	return "", "", false
+}
+
+func parseNetworkTables(raw any, location string) (map[string]map[string]any, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	tables, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: networks must be a table of per-network values", location)
+	}
+
+	networks := make(map[string]map[string]any, len(tables))
+	for name, table := range tables {
+		values, ok := table.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: networks.%s must be a table", location, name)
+		}
+		networks[name] = values
+	}
+	return networks, nil
+}
+
+// parameterValues converts the TOML values of a parameter table to the strings passed to
+// the script. Arrays are joined with commas, as read by the list parameter types.
+func parameterValues(raw map[string]any, location string) (map[string]string, error) {
+	values := make(map[string]string, len(raw))
+	for name, value := range raw {
+		if items, ok := value.([]any); ok {
+			parts := make([]string, 0, len(items))
+			for _, item := range items {
+				part, err := scalarParameterValue(item)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %s: %w", location, name, err)
+				}
+				parts = append(parts, part)
+			}
+			values[name] = strings.Join(parts, ",")
+			continue
+		}
+
+		scalar, err := scalarParameterValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", location, name, err)
+		}
+		values[name] = scalar
+	}
+	return values, nil
+}
+
+func scalarParameterValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("unsupported value of type %T, expected a string, number, boolean or array", value)
+}
@@ -2,10 +2,12 @@ package resolvers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/trebuchet-org/treb-cli/internal/domain"
 	"github.com/trebuchet-org/treb-cli/internal/domain/config"
 	"github.com/trebuchet-org/treb-cli/internal/usecase"
@@ -14,62 +16,145 @@ import (
 // ParameterResolver handles parameter resolution without pkg dependencies
 type ParameterResolver struct {
 	cfg             *config.RuntimeConfig
-	deploymentRepo  usecase.DeploymentRepository
 	contractIndexer usecase.ContractRepository
+	senderAddresses usecase.SenderAddressResolver
+	deployments     usecase.DeploymentResolver
+	addressBook     usecase.AddressBookStore
 }
 
 // NewParameterResolver creates a new internal parameter resolver
 func NewParameterResolver(
 	cfg *config.RuntimeConfig,
-	deploymentRepo usecase.DeploymentRepository,
 	contractIndexer usecase.ContractRepository,
+	senderAddresses usecase.SenderAddressResolver,
+	deployments usecase.DeploymentResolver,
+	addressBook usecase.AddressBookStore,
 ) *ParameterResolver {
 	return &ParameterResolver{
 		cfg:             cfg,
-		deploymentRepo:  deploymentRepo,
 		contractIndexer: contractIndexer,
+		senderAddresses: senderAddresses,
+		deployments:     deployments,
+		addressBook:     addressBook,
 	}
 }
 
-// ResolveParameters resolves parameter values from various sources
+// ResolveParameters resolves parameter values from various sources. In order of precedence:
+// --env values, TREB_<NAME> environment variables, params/<namespace>/<script>.toml files
+// (the namespace before its parents, the network table before the top-level values), the
+// parameter type, and FOUNDRY_<NAME> or <NAME> environment variables. Required parameters
+// whose type cannot be resolved are recorded in Unresolved rather than failing.
 func (r *ParameterResolver) ResolveParameters(
 	ctx context.Context,
+	script string,
 	params []domain.ScriptParameter,
 	values map[string]string,
-) (map[string]string, error) {
-	resolved := make(map[string]string)
+) (*domain.ResolvedParameters, error) {
+	resolved := &domain.ResolvedParameters{
+		Values:     make(map[string]string),
+		Sources:    make(map[string]domain.ParameterSource),
+		Unresolved: make(map[string]error),
+	}
 
 	// Copy existing values
 	for k, v := range values {
-		resolved[k] = v
+		resolved.Values[k] = v
+		resolved.Sources[k] = domain.ParameterSource{Kind: domain.ParamSourceFlag, Location: "--env"}
+	}
+
+	network := ""
+	if r.cfg.Network != nil {
+		network = r.cfg.Network.Name
+	}
+	layers, err := loadParameterLayers(r.cfg.ProjectRoot, r.cfg.Namespace, network, script)
+	if err != nil {
+		return nil, err
 	}
 
 	// Resolve each parameter
 	for _, param := range params {
 		// Skip if already has a value
-		if resolved[param.Name] != "" {
+		if resolved.Values[param.Name] != "" {
 			continue
 		}
 
 		// Try to resolve based on type
-		value, err := r.resolveParameter(ctx, param, resolved)
+		value, source, err := r.resolveParameter(ctx, param, layers, resolved.Values)
 		if err != nil {
+			// Left to the interactive prompt, or reported by the caller through Err
 			if !param.Optional {
-				return nil, fmt.Errorf("failed to resolve parameter %s: %w", param.Name, err)
+				resolved.Unresolved[param.Name] = err
 			}
-			// Optional parameter, skip
 			continue
 		}
 
 		if value != "" {
-			resolved[param.Name] = value
+			resolved.Values[param.Name] = value
+			resolved.Sources[param.Name] = source
+		}
+	}
+
+	// Resolve @Contract:label and @account:<role> references to addresses, including the
+	// items of address lists and the values of deployment parameters. Values of other types,
+	// such as a string starting with @, are passed as is.
+	for _, param := range params {
+		switch param.Type {
+		case domain.ParamTypeAddress, domain.ParamTypeAddressArray, domain.ParamTypeDeployment:
+		default:
+			continue
+		}
+
+		value := resolved.Values[param.Name]
+		items := []string{value}
+		if param.Type == domain.ParamTypeAddressArray {
+			items = strings.Split(value, ",")
+		}
+
+		referenced := false
+		for i, item := range items {
+			ref, ok := strings.CutPrefix(strings.TrimSpace(item), "@")
+			if !ok && param.Type == domain.ParamTypeDeployment && item != "" && !common.IsHexAddress(item) {
+				// Deployment parameters take references such as production/42220/Token:v1 as is
+				ref, ok = item, true
+			}
+			if !ok {
+				continue
+			}
+			address, err := r.resolveReference(ctx, ref)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve @%s for parameter %s: %w", ref, param.Name, err)
+			}
+			items[i] = address
+			referenced = true
+		}
+		if !referenced {
+			continue
+		}
+
+		source := resolved.Sources[param.Name]
+		source.Reference = value
+		resolved.Values[param.Name] = strings.Join(items, ",")
+		resolved.Sources[param.Name] = source
+	}
+
+	// Parse rich types into the form the script reads from the environment
+	for _, param := range params {
+		value := resolved.Values[param.Name]
+		if value == "" {
+			continue
+		}
+		normalized, err := normalizeParameterValue(param, value, r.cfg.ProjectRoot)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for parameter %s (%s): %w", param.Name, param.Type, err)
 		}
+		resolved.Values[param.Name] = normalized
 	}
 
 	return resolved, nil
 }
 
-// ValidateParameters validates that all required parameters have values
+// ValidateParameters validates that all required parameters have values and that the values
+// meet their constraints
 func (r *ParameterResolver) ValidateParameters(
 	ctx context.Context,
 	params []domain.ScriptParameter,
@@ -86,6 +171,22 @@ func (r *ParameterResolver) ValidateParameters(
 		return fmt.Errorf("missing required parameters: %s", strings.Join(missing, ", "))
 	}
 
+	// Check the constraints declared in the natspec
+	var invalid []string
+	for _, param := range params {
+		value := values[param.Name]
+		if value == "" {
+			continue
+		}
+		if err := checkParameterConstraints(param, value, r.cfg.ProjectRoot); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: %v", param.Name, err))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid parameter values:\n  %s", strings.Join(invalid, "\n  "))
+	}
+
 	return nil
 }
 
@@ -93,49 +194,67 @@ func (r *ParameterResolver) ValidateParameters(
 func (r *ParameterResolver) resolveParameter(
 	ctx context.Context,
 	param domain.ScriptParameter,
+	layers []parameterLayer,
 	existingValues map[string]string,
-) (string, error) {
+) (string, domain.ParameterSource, error) {
 	// Try environment variable first
 	envVar := fmt.Sprintf("TREB_%s", strings.ToUpper(param.Name))
 	if value := os.Getenv(envVar); value != "" {
-		return value, nil
+		return value, domain.ParameterSource{Kind: domain.ParamSourceEnv, Location: envVar}, nil
+	}
+
+	// Then the parameter files of the namespace
+	if value, location, ok := lookupParameter(layers, param.Name); ok {
+		return value, domain.ParameterSource{Kind: domain.ParamSourceFile, Location: location}, nil
 	}
 
 	// Try based on parameter type
+	resolvedSource := domain.ParameterSource{Kind: domain.ParamSourceResolved, Location: string(param.Type)}
 	switch param.Type {
 	case domain.ParamTypeSender:
-		return r.resolveSender(ctx, param.Name)
+		value, err := r.resolveSender(ctx, param.Name)
+		return value, resolvedSource, err
 
 	case domain.ParamTypeDeployment:
-		return r.resolveDeployment(ctx, param.Name, existingValues)
+		value, err := r.resolveDeployment(ctx, param.Name, existingValues)
+		return value, resolvedSource, err
 
 	case domain.ParamTypeArtifact:
-		return r.resolveArtifact(ctx, param.Name)
+		value, err := r.resolveArtifact(ctx, param.Name)
+		return value, resolvedSource, err
 
 	default:
 		// For basic types, check environment or config
-		return r.resolveFromConfig(param.Name)
+		value, envVar := r.resolveFromConfig(param.Name)
+		return value, domain.ParameterSource{Kind: domain.ParamSourceEnv, Location: envVar}, nil
+	}
+}
+
+// resolveReference resolves an @ reference: account:<role> to the address of the sender
+// with that role, anything else to the address of the deployment or address book entry
+// it identifies
+func (r *ParameterResolver) resolveReference(ctx context.Context, ref string) (string, error) {
+	if role, ok := strings.CutPrefix(ref, "account:"); ok {
+		address, err := r.senderAddresses.SenderAddress(role)
+		if err != nil {
+			return "", err
+		}
+		return address.Hex(), nil
 	}
+
+	return r.resolveDeploymentAddress(ctx, ref)
 }
 
 // resolveSender resolves a sender parameter
 func (r *ParameterResolver) resolveSender(ctx context.Context, name string) (string, error) {
-	// Check if there's a sender configured with this name
+	// Check if there's a sender configured with this name, then the default sender names
 	if r.cfg.TrebConfig != nil {
-		if sender, ok := r.cfg.TrebConfig.Senders[name]; ok {
-			// Return the account address for the sender
-			if sender.Address != "" {
-				return sender.Address, nil
+		for _, senderName := range []string{name, "default", "deployer", r.cfg.Namespace} {
+			if _, ok := r.cfg.TrebConfig.Senders[senderName]; !ok {
+				continue
 			}
-			// For private key senders, we might need to derive the address
-			// This would require eth crypto utilities
-		}
-
-		// Try default sender names
-		defaultNames := []string{"default", "deployer", r.cfg.Namespace}
-		for _, defaultName := range defaultNames {
-			if sender, ok := r.cfg.TrebConfig.Senders[defaultName]; ok && sender.Address != "" {
-				return sender.Address, nil
+			if address, err := r.senderAddresses.SenderAddress(senderName); err == nil {
+				return address.Hex(), nil
 			}
 		}
 	}
@@ -151,24 +270,30 @@ func (r *ParameterResolver) resolveDeployment(ctx context.Context, name string,
 		contractName = hint
 	}
 
-	// Look up deployment in registry
-	filter := domain.DeploymentFilter{
-		Namespace:    r.cfg.Namespace,
-		ChainID:      r.cfg.Network.ChainID,
-		ContractName: contractName,
-	}
+	return r.resolveDeploymentAddress(ctx, contractName)
+}
 
-	deployments, err := r.deploymentRepo.ListDeployments(ctx, filter)
-	if err != nil {
-		return "", fmt.Errorf("failed to list deployments: %w", err)
+// resolveDeploymentAddress resolves a deployment reference through the registry, failing or
+// prompting when several deployments match, and then through the address book of the network
+func (r *ParameterResolver) resolveDeploymentAddress(ctx context.Context, ref string) (string, error) {
+	deployment, err := r.deployments.ResolveDeployment(ctx, domain.DeploymentQuery{Reference: ref})
+	if err == nil {
+		return deployment.Address, nil
 	}
-
-	if len(deployments) == 0 {
-		return "", fmt.Errorf("no deployment found for %s", contractName)
+	if !errors.Is(err, domain.ErrNotFound) {
+		return "", err
 	}
 
-	// Return the most recent deployment
-	return deployments[0].Address, nil
+	if r.cfg.Network != nil {
+		entry, bookErr := r.addressBook.Get(ctx, r.cfg.Network.ChainID, ref)
+		if bookErr == nil {
+			return entry.Address, nil
+		}
+		if !errors.Is(bookErr, domain.ErrNotFound) {
+			return "", bookErr
+		}
+	}
+	return "", fmt.Errorf("no deployment or address book entry found for %s", ref)
 }
 
 // resolveArtifact resolves an artifact parameter
@@ -196,18 +321,19 @@ func (r *ParameterResolver) resolveArtifact(ctx context.Context, name string) (s
 	return "", fmt.Errorf("artifact %s not found", name)
 }
 
-// resolveFromConfig resolves a parameter from configuration
-func (r *ParameterResolver) resolveFromConfig(name string) (string, error) {
+// resolveFromConfig resolves a parameter from configuration, returning the environment
+// variable it was read from
+func (r *ParameterResolver) resolveFromConfig(name string) (string, string) {
 	// Check environment with common prefixes
 	prefixes := []string{"TREB_", "FOUNDRY_", ""}
 	for _, prefix := range prefixes {
 		envVar := prefix + strings.ToUpper(name)
 		if value := os.Getenv(envVar); value != "" {
-			return value, nil
+			return value, envVar
 		}
 	}
 
-	return "", nil
+	return "", ""
 }
 
 var _ usecase.ParameterResolver = (*ParameterResolver)(nil)
@@ -0,0 +1,155 @@
+package resolvers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+type stubSenderAddresses map[string]common.Address
+
+func (s stubSenderAddresses) SenderAddress(sender string) (common.Address, error) {
+	if address, ok := s[sender]; ok {
+		return address, nil
+	}
+	return common.Address{}, domain.ErrNotFound
+}
+
+type stubDeploymentResolver map[string]string
+
+func (s stubDeploymentResolver) ResolveDeployment(_ context.Context, query domain.DeploymentQuery) (*models.Deployment, error) {
+	if address, ok := s[query.Reference]; ok {
+		return &models.Deployment{Address: address}, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+// stubAddressBook serves entries of chain 1 by name
+type stubAddressBook struct {
+	usecase.AddressBookStore
+	entries map[string]string
+}
+
+func (s stubAddressBook) Get(_ context.Context, chainID uint64, name string) (*models.AddressBookEntry, error) {
+	if address, ok := s.entries[name]; ok && chainID == 1 {
+		return &models.AddressBookEntry{Name: name, ChainID: chainID, Address: address}, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func writeParamsFile(t *testing.T, root, namespace, content string) {
+	t.Helper()
+	dir := filepath.Join(root, "params", namespace)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "DeployToken.toml"), []byte(content), 0644))
+}
+
+func TestParameterResolver_ParameterFiles(t *testing.T) {
+	root := t.TempDir()
+	writeParamsFile(t, root, "default", `
+NAME = "Token"
+SUPPLY = 1000
+CAP = "5 ether"
+`)
+	writeParamsFile(t, root, "production", `
+SUPPLY = 2000
+OWNERS = ["0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266", "@account:admin"]
+ADMIN = "@account:admin"
+TREASURY = "@Treasury:v1"
+QUOTE = "@USDC"
+HANDLE = "@treb"
+
+[networks.mainnet]
+SUPPLY = 3000
+`)
+
+	admin := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	resolver := NewParameterResolver(
+		&config.RuntimeConfig{ProjectRoot: root, Namespace: "production", Network: &config.Network{Name: "mainnet", ChainID: 1}},
+		nil,
+		stubSenderAddresses{"admin": admin},
+		stubDeploymentResolver{
+			"Treasury:v1":        "0x0000000000000000000000000000000000000042",
+			"celo:Bridge@latest": "0x0000000000000000000000000000000000000043",
+		},
+		stubAddressBook{entries: map[string]string{"USDC": "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"}},
+	)
+	params := []domain.ScriptParameter{
+		{Name: "NAME", Type: domain.ParamTypeString},
+		{Name: "SYMBOL", Type: domain.ParamTypeString},
+		{Name: "SUPPLY", Type: domain.ParamTypeUint256},
+		{Name: "CAP", Type: domain.ParamTypeEther},
+		{Name: "OWNERS", Type: domain.ParamTypeAddressArray},
+		{Name: "ADMIN", Type: domain.ParamTypeAddress},
+		{Name: "TREASURY", Type: domain.ParamTypeAddress},
+		{Name: "LABEL", Type: domain.ParamTypeString, Optional: true},
+		{Name: "BRIDGE", Type: domain.ParamTypeDeployment},
+		{Name: "QUOTE", Type: domain.ParamTypeAddress},
+		{Name: "HANDLE", Type: domain.ParamTypeString},
+	}
+
+	t.Setenv("TREB_NAME", "EnvToken")
+	resolved, err := resolver.ResolveParameters(t.Context(), "DeployToken", params, map[string]string{"SYMBOL": "TKN", "BRIDGE": "celo:Bridge@latest"})
+	require.NoError(t, err)
+
+	expected := map[string]struct {
+		value  string
+		source domain.ParameterSource
+	}{
+		"NAME":   {"EnvToken", domain.ParameterSource{Kind: domain.ParamSourceEnv, Location: "TREB_NAME"}},
+		"SYMBOL": {"TKN", domain.ParameterSource{Kind: domain.ParamSourceFlag, Location: "--env"}},
+		"SUPPLY": {"3000", domain.ParameterSource{Kind: domain.ParamSourceFile, Location: "params/production/DeployToken.toml [networks.mainnet]"}},
+		"CAP":    {"5000000000000000000", domain.ParameterSource{Kind: domain.ParamSourceFile, Location: "params/default/DeployToken.toml"}},
+		"OWNERS": {"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266," + admin.Hex(), domain.ParameterSource{Kind: domain.ParamSourceFile,
+			Location: "params/production/DeployToken.toml", Reference: "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266,@account:admin"}},
+		"ADMIN":    {admin.Hex(), domain.ParameterSource{Kind: domain.ParamSourceFile, Location: "params/production/DeployToken.toml", Reference: "@account:admin"}},
+		"TREASURY": {"0x0000000000000000000000000000000000000042", domain.ParameterSource{Kind: domain.ParamSourceFile, Location: "params/production/DeployToken.toml", Reference: "@Treasury:v1"}},
+		"BRIDGE":   {"0x0000000000000000000000000000000000000043", domain.ParameterSource{Kind: domain.ParamSourceFlag, Location: "--env", Reference: "celo:Bridge@latest"}},
+		"QUOTE":    {"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", domain.ParameterSource{Kind: domain.ParamSourceFile, Location: "params/production/DeployToken.toml", Reference: "@USDC"}},
+		"HANDLE":   {"@treb", domain.ParameterSource{Kind: domain.ParamSourceFile, Location: "params/production/DeployToken.toml"}},
+	}
+	for name, want := range expected {
+		assert.Equal(t, want.value, resolved.Values[name], name)
+		assert.Equal(t, want.source, resolved.Sources[name], name)
+	}
+	assert.NotContains(t, resolved.Values, "LABEL")
+
+	t.Run("network override only applies on its network", func(t *testing.T) {
+		resolver.cfg.Network = &config.Network{Name: "sepolia", ChainID: 11155111}
+		defer func() { resolver.cfg.Network = &config.Network{Name: "mainnet", ChainID: 1} }()
+
+		// The address book of mainnet does not apply either
+		_, err := resolver.ResolveParameters(t.Context(), "DeployToken", params, map[string]string{"BRIDGE": "0x0000000000000000000000000000000000000043"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no deployment or address book entry found for USDC")
+
+		resolved, err := resolver.ResolveParameters(t.Context(), "DeployToken", params, map[string]string{
+			"BRIDGE": "0x0000000000000000000000000000000000000043",
+			"QUOTE":  "0x0000000000000000000000000000000000000044",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "2000", resolved.Values["SUPPLY"])
+	})
+
+	t.Run("unknown reference fails", func(t *testing.T) {
+		_, err := resolver.ResolveParameters(t.Context(), "DeployToken", params, map[string]string{"ADMIN": "@account:nobody", "BRIDGE": "0x0000000000000000000000000000000000000043"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to resolve @account:nobody for parameter ADMIN")
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		writeParamsFile(t, root, "production", "SUPPLY = { nested = 1 }\n")
+		_, err := resolver.ResolveParameters(t.Context(), "DeployToken", params, map[string]string{"BRIDGE": "0x0000000000000000000000000000000000000043"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "params/production/DeployToken.toml: SUPPLY: unsupported value")
+	})
+}
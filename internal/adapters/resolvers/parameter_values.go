@@ -0,0 +1,213 @@
+package resolvers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+)
+
+var (
+	unitAmountPattern = regexp.MustCompile(`^([0-9][0-9_]*)(?:\.([0-9]+))?\s*([a-zA-Z]*)$`)
+	durationPattern   = regexp.MustCompile(`^(?:[0-9]+[smhdw])+$`)
+	durationPart      = regexp.MustCompile(`([0-9]+)([smhdw])`)
+)
+
+// unitDecimals maps the accepted unit suffixes of ether and gwei parameters to their decimals
+var unitDecimals = map[string]int{
+	"wei":   0,
+	"gwei":  9,
+	"ether": 18,
+	"eth":   18,
+}
+
+// durationSeconds maps the units of duration parameters to seconds
+var durationSeconds = map[string]int64{
+	"s": 1,
+	"m": 60,
+	"h": 60 * 60,
+	"d": 24 * 60 * 60,
+	"w": 7 * 24 * 60 * 60,
+}
+
+// normalizeParameterValue parses the value of a rich parameter type and returns the form
+// passed to forge. Values of other types are returned unchanged.
+func normalizeParameterValue(param domain.ScriptParameter, value, projectRoot string) (string, error) {
+	switch param.Type {
+	case domain.ParamTypeAddressArray:
+		return normalizeList(value, func(item string) (string, error) {
+			if !common.IsHexAddress(item) {
+				return "", fmt.Errorf("invalid address %q", item)
+			}
+			return common.HexToAddress(item).Hex(), nil
+		})
+	case domain.ParamTypeUint256Array:
+		return normalizeList(value, func(item string) (string, error) {
+			return parseUint(item, 256)
+		})
+	case domain.ParamTypeStringArray:
+		return normalizeList(value, func(item string) (string, error) {
+			return item, nil
+		})
+	case domain.ParamTypeEther:
+		return parseUnitAmount(value, "ether")
+	case domain.ParamTypeGwei:
+		return parseUnitAmount(value, "gwei")
+	case domain.ParamTypeDuration:
+		return parseDuration(value)
+	case domain.ParamTypeJSON:
+		return loadJSONParameter(value, param.Schema, projectRoot)
+	}
+
+	if bits, ok := param.Type.UintBits(); ok {
+		return parseUint(value, bits)
+	}
+	return value, nil
+}
+
+// normalizeList parses a comma-separated list, or a JSON array, and joins the normalised
+// items with commas as read by vm.envAddress(name, ",") and friends
+func normalizeList(value string, normalize func(string) (string, error)) (string, error) {
+	var items []string
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "[") {
+		decoder := json.NewDecoder(strings.NewReader(trimmed))
+		decoder.UseNumber()
+		var raw []any
+		if err := decoder.Decode(&raw); err != nil {
+			return "", fmt.Errorf("invalid JSON array: %w", err)
+		}
+		for _, item := range raw {
+			items = append(items, fmt.Sprint(item))
+		}
+	} else if trimmed != "" {
+		items = strings.Split(trimmed, ",")
+	}
+
+	normalized := make([]string, 0, len(items))
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if strings.Contains(item, ",") {
+			return "", fmt.Errorf("list item %q must not contain a comma", item)
+		}
+		value, err := normalize(item)
+		if err != nil {
+			return "", err
+		}
+		normalized = append(normalized, value)
+	}
+	return strings.Join(normalized, ","), nil
+}
+
+// parseUint parses a decimal or 0x-prefixed integer that fits in the given number of bits
+func parseUint(value string, bits int) (string, error) {
+	digits, base := strings.TrimSpace(value), 10
+	if hex, isHex := strings.CutPrefix(strings.ToLower(digits), "0x"); isHex {
+		digits, base = hex, 16
+	}
+	n, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return "", fmt.Errorf("invalid integer %q", value)
+	}
+	if n.Sign() < 0 {
+		return "", fmt.Errorf("%s is negative, expected an unsigned integer", value)
+	}
+	if n.BitLen() > bits {
+		return "", fmt.Errorf("%s does not fit in uint%d", value, bits)
+	}
+	return n.String(), nil
+}
+
+// parseUnitAmount parses an amount such as "1.5", "1.5 ether" or "20gwei" and returns it in wei.
+// Amounts without a unit are in defaultUnit.
+func parseUnitAmount(value, defaultUnit string) (string, error) {
+	match := unitAmountPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return "", fmt.Errorf("invalid amount %q, expected a number with an optional unit (wei, gwei, ether)", value)
+	}
+	integer, fraction, unit := strings.ReplaceAll(match[1], "_", ""), match[2], strings.ToLower(match[3])
+	if unit == "" {
+		unit = defaultUnit
+	}
+	decimals, ok := unitDecimals[unit]
+	if !ok {
+		return "", fmt.Errorf("unknown unit %q in %q, expected wei, gwei or ether", match[3], value)
+	}
+
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > decimals {
+		return "", fmt.Errorf("%q has more decimals than %s allows", value, unit)
+	}
+	wei, _ := new(big.Int).SetString(integer+fraction+strings.Repeat("0", decimals-len(fraction)), 10)
+	return wei.String(), nil
+}
+
+// parseDuration parses a duration such as "7d", "1d12h" or "90m" and returns it in seconds.
+// A plain number is taken as seconds.
+func parseDuration(value string) (string, error) {
+	trimmed := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(value)), " ", "")
+	if n, ok := new(big.Int).SetString(trimmed, 10); ok && n.Sign() >= 0 {
+		return n.String(), nil
+	}
+	if !durationPattern.MatchString(trimmed) {
+		return "", fmt.Errorf("invalid duration %q, expected a number of seconds or a duration such as 7d, 12h or 1w2d", value)
+	}
+
+	total := new(big.Int)
+	for _, part := range durationPart.FindAllStringSubmatch(trimmed, -1) {
+		amount, _ := new(big.Int).SetString(part[1], 10)
+		total.Add(total, amount.Mul(amount, big.NewInt(durationSeconds[part[2]])))
+	}
+	return total.String(), nil
+}
+
+// loadJSONParameter reads the JSON file at path, validates it against the schema when one
+// is given and returns its compact content
+func loadJSONParameter(path, schemaPath, projectRoot string) (string, error) {
+	data, err := os.ReadFile(resolveProjectPath(path, projectRoot))
+	if err != nil {
+		return "", fmt.Errorf("failed to read JSON file: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return "", fmt.Errorf("invalid JSON in %s: %w", path, err)
+	}
+
+	if schemaPath != "" {
+		schemaData, err := os.ReadFile(resolveProjectPath(schemaPath, projectRoot))
+		if err != nil {
+			return "", fmt.Errorf("failed to read JSON schema: %w", err)
+		}
+		var schema map[string]any
+		if err := json.Unmarshal(schemaData, &schema); err != nil {
+			return "", fmt.Errorf("invalid JSON schema %s: %w", schemaPath, err)
+		}
+		if err := validateJSONSchema(schema, document, "$"); err != nil {
+			return "", fmt.Errorf("%s does not match schema %s: %w", path, schemaPath, err)
+		}
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return "", fmt.Errorf("invalid JSON in %s: %w", path, err)
+	}
+	return compact.String(), nil
+}
+
+// resolveProjectPath resolves a path relative to the project root
+func resolveProjectPath(path, projectRoot string) string {
+	if filepath.IsAbs(path) || projectRoot == "" {
+		return path
+	}
+	return filepath.Join(projectRoot, path)
+}
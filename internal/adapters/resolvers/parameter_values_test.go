@@ -0,0 +1,147 @@
+package resolvers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+)
+
+func TestNormalizeParameterValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		paramType domain.ParameterType
+		value     string
+		expected  string
+		errMsg    string
+	}{
+		{name: "address list", paramType: domain.ParamTypeAddressArray,
+			value:    "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266, 0x70997970c51812dc3a010c7d01b50e0d17dc79c8",
+			expected: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266,0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+		{name: "address JSON array", paramType: domain.ParamTypeAddressArray,
+			value:    `["0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"]`,
+			expected: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+		{name: "invalid address in list", paramType: domain.ParamTypeAddressArray, value: "0x1234", errMsg: "invalid address"},
+		{name: "empty list", paramType: domain.ParamTypeAddressArray, value: " ", expected: ""},
+		{name: "uint list", paramType: domain.ParamTypeUint256Array, value: "1, 0x10,[", errMsg: "invalid integer"},
+		{name: "uint list with hex", paramType: domain.ParamTypeUint256Array, value: "1, 0x10, 010", expected: "1,16,10"},
+		{name: "uint JSON array keeps large numbers", paramType: domain.ParamTypeUint256Array, value: "[1000000000000000000000]", expected: "1000000000000000000000"},
+		{name: "string list", paramType: domain.ParamTypeStringArray, value: "alpha, beta", expected: "alpha,beta"},
+		{name: "uint8", paramType: "uint8", value: "255", expected: "255"},
+		{name: "uint8 overflow", paramType: "uint8", value: "256", errMsg: "does not fit in uint8"},
+		{name: "uint64 hex", paramType: "uint64", value: "0xff", expected: "255"},
+		{name: "negative uint", paramType: "uint32", value: "-1", errMsg: "negative"},
+		{name: "ether", paramType: domain.ParamTypeEther, value: "1.5", expected: "1500000000000000000"},
+		{name: "ether with unit", paramType: domain.ParamTypeEther, value: "20 gwei", expected: "20000000000"},
+		{name: "ether with separators", paramType: domain.ParamTypeEther, value: "1_000eth", expected: "1000000000000000000000"},
+		{name: "gwei", paramType: domain.ParamTypeGwei, value: "2.5", expected: "2500000000"},
+		{name: "too many decimals", paramType: domain.ParamTypeGwei, value: "0.0000000001", errMsg: "more decimals"},
+		{name: "unknown unit", paramType: domain.ParamTypeEther, value: "1 btc", errMsg: "unknown unit"},
+		{name: "duration days", paramType: domain.ParamTypeDuration, value: "7d", expected: "604800"},
+		{name: "duration combined", paramType: domain.ParamTypeDuration, value: "1w 2d 3h", expected: "788400"},
+		{name: "duration seconds", paramType: domain.ParamTypeDuration, value: "3600", expected: "3600"},
+		{name: "invalid duration", paramType: domain.ParamTypeDuration, value: "soon", errMsg: "invalid duration"},
+		{name: "other types unchanged", paramType: domain.ParamTypeUint256, value: "1e18", expected: "1e18"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := normalizeParameterValue(domain.ScriptParameter{Name: "P", Type: tt.paramType}, tt.value, "")
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestNormalizeParameterValue_JSON(t *testing.T) {
+	root := t.TempDir()
+	schema := `{
+		"type": "object",
+		"required": ["fee", "recipients"],
+		"additionalProperties": false,
+		"properties": {
+			"fee": {"type": "integer", "minimum": 0, "maximum": 10000},
+			"mode": {"enum": ["fixed", "dynamic"]},
+			"recipients": {"type": "array", "minItems": 1, "items": {"type": "string", "pattern": "^0x[0-9a-fA-F]{40}$"}}
+		}
+	}`
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "config"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "config", "fees.schema.json"), []byte(schema), 0644))
+
+	param := domain.ScriptParameter{Name: "FEES", Type: domain.ParamTypeJSON, Schema: "config/fees.schema.json"}
+	write := func(content string) string {
+		require.NoError(t, os.WriteFile(filepath.Join(root, "config", "fees.json"), []byte(content), 0644))
+		return "config/fees.json"
+	}
+
+	t.Run("valid file is passed as compact JSON", func(t *testing.T) {
+		path := write("{\n  \"fee\": 30,\n  \"mode\": \"fixed\",\n  \"recipients\": [\"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266\"]\n}\n")
+		value, err := normalizeParameterValue(param, path, root)
+		require.NoError(t, err)
+		assert.Equal(t, `{"fee":30,"mode":"fixed","recipients":["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"]}`, value)
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{name: "missing property", content: `{"fee": 30}`, errMsg: `$: missing required property "recipients"`},
+		{name: "wrong type", content: `{"fee": "30", "recipients": ["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"]}`, errMsg: "$.fee: expected integer, got string"},
+		{name: "fractional integer", content: `{"fee": 1.5, "recipients": ["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"]}`, errMsg: "expected integer, got number"},
+		{name: "above maximum", content: `{"fee": 20000, "recipients": ["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"]}`, errMsg: "greater than the maximum"},
+		{name: "not in enum", content: `{"fee": 1, "mode": "other", "recipients": ["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"]}`, errMsg: `"other" is not one of the allowed values`},
+		{name: "pattern mismatch", content: `{"fee": 1, "recipients": ["alice"]}`, errMsg: "$.recipients[0]"},
+		{name: "too few items", content: `{"fee": 1, "recipients": []}`, errMsg: "at least 1 items"},
+		{name: "unexpected property", content: `{"fee": 1, "recipients": ["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"], "extra": true}`, errMsg: `unexpected property "extra"`},
+		{name: "invalid JSON", content: `{"fee": `, errMsg: "invalid JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := normalizeParameterValue(param, write(tt.content), root)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := normalizeParameterValue(param, "config/missing.json", root)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read JSON file")
+	})
+
+	t.Run("without schema", func(t *testing.T) {
+		path := write(`{"anything": [1, 2]}`)
+		value, err := normalizeParameterValue(domain.ScriptParameter{Name: "FEES", Type: domain.ParamTypeJSON}, path, root)
+		require.NoError(t, err)
+		assert.Equal(t, `{"anything":[1,2]}`, value)
+	})
+}
+
+func TestParseCustomEnvString_RichTypes(t *testing.T) {
+	resolver := &ScriptResolver{}
+	params, err := resolver.parseCustomEnvString(
+		"{address[]} OWNERS Initial owners\n{uint8} DECIMALS Token decimals\n{uint7} ODD Not a sized int\n" +
+			"{ether} CAP Supply cap\n{duration:optional} DELAY Upgrade delay\n{json:config/fees.schema.json} FEES Fee config")
+	require.NoError(t, err)
+	require.Len(t, params, 6)
+
+	assert.Equal(t, domain.ParamTypeAddressArray, params[0].Type)
+	assert.Equal(t, domain.ParameterType("uint8"), params[1].Type)
+	assert.Equal(t, domain.ParamTypeString, params[2].Type)
+	assert.Equal(t, domain.ParamTypeEther, params[3].Type)
+	assert.Equal(t, domain.ParamTypeDuration, params[4].Type)
+	assert.True(t, params[4].Optional)
+	assert.Equal(t, domain.ParamTypeJSON, params[5].Type)
+	assert.Equal(t, "config/fees.schema.json", params[5].Schema)
+	assert.Equal(t, "Fee config", params[5].Description)
+}
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/trebuchet-org/treb-cli/internal/domain"
@@ -16,13 +19,15 @@ import (
 // ScriptResolver handles script resolution without pkg dependencies
 type ScriptResolver struct {
 	resolver    usecase.ContractResolver
+	contracts   usecase.ContractRepository
 	projectRoot string
 }
 
 // NewScriptResolver creates a new internal script resolver
-func NewScriptResolver(projectRoot string, resolver usecase.ContractResolver) *ScriptResolver {
+func NewScriptResolver(projectRoot string, resolver usecase.ContractResolver, contracts usecase.ContractRepository) *ScriptResolver {
 	return &ScriptResolver{
 		resolver:    resolver,
+		contracts:   contracts,
 		projectRoot: projectRoot,
 	}
 }
@@ -53,6 +58,56 @@ func (r *ScriptResolver) GetScriptParameters(ctx context.Context, script *models
 	return r.parseParametersFromArtifact(artifact)
 }
 
+// ListScripts returns the scripts under script/ that have a run() entry point, sorted by path
+func (r *ScriptResolver) ListScripts(ctx context.Context) ([]*models.Contract, error) {
+	pattern := "^script/"
+	contracts, err := r.contracts.SearchContracts(ctx, domain.ContractQuery{PathPattern: &pattern})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search scripts: %w", err)
+	}
+
+	var scripts []*models.Contract
+	for _, contract := range contracts {
+		if contract.Artifact == nil {
+			continue
+		}
+		if _, ok := contract.Artifact.MethodIdentifiers["run()"]; ok {
+			scripts = append(scripts, contract)
+		}
+	}
+	sort.Slice(scripts, func(i, j int) bool {
+		if scripts[i].Path != scripts[j].Path {
+			return scripts[i].Path < scripts[j].Path
+		}
+		return scripts[i].Name < scripts[j].Name
+	})
+	return scripts, nil
+}
+
+// lookupPattern matches registry lookups with a literal reference, e.g. lookup("Counter:v1")
+var lookupPattern = regexp.MustCompile(`\blookup\(\s*"([^"]+)"`)
+
+// GetScriptReferences returns the deployments a script looks up in the registry, in order
+// of first use. Only literal references in the script source are found.
+func (r *ScriptResolver) GetScriptReferences(ctx context.Context, script *models.Contract) ([]string, error) {
+	path := script.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(r.projectRoot, path)
+	}
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script source: %w", err)
+	}
+
+	var references []string
+	for _, match := range lookupPattern.FindAllStringSubmatch(string(source), -1) {
+		if !slices.Contains(references, match[1]) {
+			references = append(references, match[1])
+		}
+	}
+	return references, nil
+}
+
 // loadArtifact loads an artifact from disk
 func (r *ScriptResolver) loadArtifact(artifactPath string) (*models.Artifact, error) {
 	// Handle relative paths
@@ -111,22 +166,26 @@ func (r *ScriptResolver) parseParametersFromArtifact(artifact *models.Artifact)
 	return r.parseCustomEnvString(envStr)
 }
 
+// envParamPattern matches the {type} that starts each parameter of a custom:env string. solc
+// joins repeated @custom:env tags without a separator, so a type can follow the previous
+// description directly. Only known types match, so that constraint values such as
+// pattern=^[0-9]{2}$ are not taken as the start of a parameter.
+var envParamPattern = regexp.MustCompile(`\{((?:string|address|uint256|int256|bytes32|bytes|bool|sender|deployment|artifact|secret|` +
+	`address\[\]|uint256\[\]|string\[\]|ether|gwei|duration|uint[0-9]+|json(?::[^{}\s]+)?)(?::optional)?)\}`)
+
 // parseCustomEnvString parses the custom:env string into parameters
 func (r *ScriptResolver) parseCustomEnvString(envStr string) ([]domain.ScriptParameter, error) {
-	// Format: {type} name description{type2} name2 description2...
+	// Format: {type} name [constraints] description{type2} name2 description2...
 	var params []domain.ScriptParameter
 
-	// Simple parser - in production would use regex
-	parts := strings.Split(envStr, "{")
-	for _, part := range parts[1:] { // Skip first empty part
-		// Find closing brace
-		endType := strings.Index(part, "}")
-		if endType == -1 {
-			continue
+	matches := envParamPattern.FindAllStringSubmatchIndex(envStr, -1)
+	for i, match := range matches {
+		typeStr := envStr[match[2]:match[3]]
+		end := len(envStr)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
 		}
-
-		typeStr := part[:endType]
-		rest := strings.TrimSpace(part[endType+1:])
+		rest := strings.TrimSpace(envStr[match[1]:end])
 
 		// Check if optional
 		optional := false
@@ -135,36 +194,69 @@ func (r *ScriptResolver) parseCustomEnvString(envStr string) ([]domain.ScriptPar
 			typeStr = strings.TrimSuffix(typeStr, ":optional")
 		}
 
-		// Split rest into name and description
+		// Split rest into name, constraints and description
 		fields := strings.Fields(rest)
 		if len(fields) < 1 {
 			continue
 		}
 
 		name := fields[0]
-		description := ""
-		if len(fields) > 1 {
-			description = strings.Join(fields[1:], " ")
-			// Trim until next parameter
-			if nextParam := strings.Index(description, "{"); nextParam != -1 {
-				description = strings.TrimSpace(description[:nextParam])
-			}
+		constraints, consumed, err := parseParameterConstraints(fields[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid constraint on parameter %s: %w", name, err)
 		}
+		description := strings.Join(fields[1+consumed:], " ")
 
-		// Map type
+		// Map type, json parameters may name a schema as {json:<schema path>}
 		paramType := mapStringToParamType(typeStr)
+		schema := ""
+		if jsonSchema, ok := strings.CutPrefix(typeStr, "json:"); ok {
+			paramType = domain.ParamTypeJSON
+			schema = jsonSchema
+		}
 
 		params = append(params, domain.ScriptParameter{
 			Name:        name,
 			Type:        paramType,
 			Description: description,
 			Optional:    optional,
+			Schema:      schema,
+			Constraints: constraints,
 		})
 	}
 
 	return params, nil
 }
 
+// parseParameterConstraints reads the constraints at the start of the words following a
+// parameter name and returns how many words they took up
+func parseParameterConstraints(words []string) (domain.ParameterConstraints, int, error) {
+	var constraints domain.ParameterConstraints
+	for i, word := range words {
+		key, value, hasValue := strings.Cut(word, "=")
+		switch {
+		case word == "nonzero":
+			constraints.NonZero = true
+		case word == "contract":
+			constraints.Contract = true
+		case hasValue && key == "min":
+			constraints.Min = value
+		case hasValue && key == "max":
+			constraints.Max = value
+		case hasValue && key == "pattern":
+			if _, err := regexp.Compile(value); err != nil {
+				return constraints, 0, fmt.Errorf("invalid pattern %q: %w", value, err)
+			}
+			constraints.Pattern = value
+		case hasValue && key == "enum":
+			constraints.Enum = strings.Split(value, "|")
+		default:
+			return constraints, i, nil
+		}
+	}
+	return constraints, len(words), nil
+}
+
 // mapStringToParamType maps string type to domain parameter type
 func mapStringToParamType(typeStr string) domain.ParameterType {
 	switch typeStr {
@@ -188,7 +280,26 @@ func mapStringToParamType(typeStr string) domain.ParameterType {
 		return domain.ParamTypeDeployment
 	case "artifact":
 		return domain.ParamTypeArtifact
+	case "secret":
+		return domain.ParamTypeSecret
+	case "address[]":
+		return domain.ParamTypeAddressArray
+	case "uint256[]":
+		return domain.ParamTypeUint256Array
+	case "string[]":
+		return domain.ParamTypeStringArray
+	case "ether":
+		return domain.ParamTypeEther
+	case "gwei":
+		return domain.ParamTypeGwei
+	case "duration":
+		return domain.ParamTypeDuration
+	case "json":
+		return domain.ParamTypeJSON
 	default:
+		if _, ok := domain.ParameterType(typeStr).UintBits(); ok {
+			return domain.ParameterType(typeStr)
+		}
 		return domain.ParamTypeString
 	}
 }
@@ -0,0 +1,58 @@
+package resolvers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// stubContractRepository serves a fixed list of contracts
+type stubContractRepository struct {
+	usecase.ContractRepository
+	contracts []*models.Contract
+}
+
+func (s stubContractRepository) SearchContracts(context.Context, domain.ContractQuery) ([]*models.Contract, error) {
+	return s.contracts, nil
+}
+
+func TestScriptResolver_ListScripts(t *testing.T) {
+	withRun := &models.Artifact{MethodIdentifiers: map[string]string{"run()": "c0406226"}}
+	repo := stubContractRepository{contracts: []*models.Contract{
+		{Name: "UpgradeToken", Path: "script/UpgradeToken.s.sol", Artifact: withRun},
+		{Name: "Helpers", Path: "script/Helpers.sol", Artifact: &models.Artifact{}},
+		{Name: "DeployToken", Path: "script/DeployToken.s.sol", Artifact: withRun},
+	}}
+
+	scripts, err := NewScriptResolver(t.TempDir(), nil, repo).ListScripts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, scripts, 2)
+	assert.Equal(t, "DeployToken", scripts[0].Name)
+	assert.Equal(t, "UpgradeToken", scripts[1].Name)
+}
+
+func TestScriptResolver_GetScriptReferences(t *testing.T) {
+	root := t.TempDir()
+	source := `contract UpgradeToken is TrebScript {
+    function run() public broadcast {
+        address proxy = lookup("Token:v1");
+        address registry = lookup( "production/42220/Registry" );
+        address again = lookup("Token:v1");
+        address dynamic = lookup(vm.envString("NAME"));
+    }
+}`
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "script"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "script", "UpgradeToken.s.sol"), []byte(source), 0644))
+
+	resolver := NewScriptResolver(root, nil, nil)
+	references, err := resolver.GetScriptReferences(context.Background(), &models.Contract{Path: "script/UpgradeToken.s.sol"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Token:v1", "production/42220/Registry"}, references)
+}
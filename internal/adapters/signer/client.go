@@ -0,0 +1,174 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// rpcRequest is a JSON-RPC 2.0 request
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC 2.0 error object
+type rpcError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// rpcClient makes JSON-RPC calls to a single endpoint
+type rpcClient struct {
+	url        string
+	headerName string
+	header     string
+	http       *http.Client
+	nextID     atomic.Int64
+}
+
+func newRPCClient(url, authHeader string) *rpcClient {
+	c := &rpcClient{
+		url:  url,
+		http: &http.Client{Timeout: 5 * time.Minute}, // Signing services may wait for manual approval
+	}
+	if authHeader != "" {
+		c.headerName, c.header = parseAuthHeader(authHeader)
+	}
+	return c
+}
+
+// parseAuthHeader accepts either a full "Name: value" header or a bare value for Authorization
+func parseAuthHeader(authHeader string) (string, string) {
+	if name, value, ok := strings.Cut(authHeader, ":"); ok && name != "" && !strings.ContainsAny(name, " \t") {
+		return name, strings.TrimSpace(value)
+	}
+	return "Authorization", authHeader
+}
+
+// call invokes a method and returns the raw result
+func (c *rpcClient) call(ctx context.Context, method string, params ...any) (json.RawMessage, error) {
+	if params == nil {
+		params = []any{}
+	}
+	encodedParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s params: %w", method, err)
+	}
+	id, _ := json.Marshal(c.nextID.Add(1))
+
+	resp, err := c.send(ctx, rpcRequest{JSONRPC: "2.0", ID: id, Method: method, Params: encodedParams})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("%s failed: %w", method, resp.Error)
+	}
+	return resp.Result, nil
+}
+
+// send posts a single request and decodes the response
+func (c *rpcClient) send(ctx context.Context, req rpcRequest) (*rpcResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.headerName != "" {
+		httpReq.Header.Set(c.headerName, c.header)
+	}
+
+	httpResp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", req.Method, err)
+	}
+	defer httpResp.Body.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", req.Method, err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned HTTP %d: %s", req.Method, httpResp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var resp rpcResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("invalid %s response: %w", req.Method, err)
+	}
+	return &resp, nil
+}
+
+// Client signs transactions and typed data through a Clef or Web3Signer compatible endpoint
+type Client struct {
+	rpc *rpcClient
+}
+
+// NewClient creates a client for the signing endpoint. authHeader is either a full
+// "Name: value" header or the value of the Authorization header.
+func NewClient(url, authHeader string) *Client {
+	return &Client{rpc: newRPCClient(url, authHeader)}
+}
+
+// SignTransaction signs a transaction with eth_signTransaction and returns the raw signed transaction
+func (c *Client) SignTransaction(ctx context.Context, tx map[string]any) (hexutil.Bytes, error) {
+	result, err := c.rpc.call(ctx, "eth_signTransaction", tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Web3Signer returns the raw transaction, Clef returns {"raw": ..., "tx": ...}
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+	var signed struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &signed); err != nil || len(signed.Raw) == 0 {
+		return nil, fmt.Errorf("unexpected eth_signTransaction result: %s", string(result))
+	}
+	return signed.Raw, nil
+}
+
+// SignTypedData signs EIP-712 typed data with eth_signTypedData_v4
+func (c *Client) SignTypedData(ctx context.Context, address string, typedData json.RawMessage) (hexutil.Bytes, error) {
+	result, err := c.rpc.call(ctx, "eth_signTypedData_v4", address, typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	var signature hexutil.Bytes
+	if err := json.Unmarshal(result, &signature); err != nil {
+		return nil, fmt.Errorf("unexpected eth_signTypedData_v4 result: %s", string(result))
+	}
+	return signature, nil
+}
@@ -0,0 +1,256 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+)
+
+// Proxy is a local JSON-RPC endpoint that forge broadcasts through in --unlocked mode.
+// eth_sendTransaction and signing requests from remote signer accounts are signed by
+// their signing service; every other request is forwarded to the upstream node.
+type Proxy struct {
+	log      *slog.Logger
+	upstream *rpcClient
+	signers  map[common.Address]*Client
+	server   *http.Server
+	url      string
+}
+
+// NewProxy creates a signing proxy in front of the upstream RPC URL
+func NewProxy(upstreamURL string, remoteSigners []config.RemoteSignerConfig, log *slog.Logger) *Proxy {
+	signers := make(map[common.Address]*Client, len(remoteSigners))
+	for _, remoteSigner := range remoteSigners {
+		signers[remoteSigner.Address] = NewClient(remoteSigner.URL, remoteSigner.AuthHeader)
+	}
+	return &Proxy{
+		log:      log.With("component", "SigningProxy"),
+		upstream: newRPCClient(upstreamURL, ""),
+		signers:  signers,
+	}
+}
+
+// Start listens on a random local port and returns the proxy URL
+func (p *Proxy) Start() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("failed to start signing proxy: %w", err)
+	}
+
+	p.server = &http.Server{Handler: p} //nolint:gosec // local-only listener
+	p.url = "http://" + listener.Addr().String()
+	go func() {
+		if err := p.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			p.log.Error("signing proxy stopped", "error", err)
+		}
+	}()
+
+	return p.url, nil
+}
+
+// Close stops the proxy
+func (p *Proxy) Close() error {
+	if p.server == nil {
+		return nil
+	}
+	return p.server.Close()
+}
+
+// ServeHTTP handles single and batched JSON-RPC requests
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	var response any
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var requests []rpcRequest
+		if err := json.Unmarshal(trimmed, &requests); err != nil {
+			http.Error(w, "invalid JSON-RPC batch", http.StatusBadRequest)
+			return
+		}
+		responses := make([]*rpcResponse, 0, len(requests))
+		for _, req := range requests {
+			responses = append(responses, p.handle(r.Context(), req))
+		}
+		response = responses
+	} else {
+		var req rpcRequest
+		if err := json.Unmarshal(trimmed, &req); err != nil {
+			http.Error(w, "invalid JSON-RPC request", http.StatusBadRequest)
+			return
+		}
+		response = p.handle(r.Context(), req)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// handle answers one request, signing it remotely when it belongs to a remote signer
+func (p *Proxy) handle(ctx context.Context, req rpcRequest) *rpcResponse {
+	var result any
+	var handled bool
+	var err error
+
+	switch req.Method {
+	case "eth_accounts":
+		result, handled = p.accounts(), true
+	case "eth_sendTransaction":
+		result, handled, err = p.sendTransaction(ctx, req.Params)
+	case "eth_signTypedData_v4", "eth_sign":
+		result, handled, err = p.signData(ctx, req.Method, req.Params, 0)
+	case "personal_sign":
+		result, handled, err = p.signData(ctx, req.Method, req.Params, 1)
+	}
+
+	if !handled {
+		resp, err := p.upstream.send(ctx, req)
+		if err != nil {
+			return errorResponse(req.ID, err)
+		}
+		resp.ID = req.ID
+		return resp
+	}
+	if err != nil {
+		p.log.Debug("remote signing failed", "method", req.Method, "error", err)
+		return errorResponse(req.ID, err)
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(req.ID, err)
+	}
+	return &rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: encoded}
+}
+
+func (p *Proxy) accounts() []string {
+	accounts := make([]string, 0, len(p.signers))
+	for address := range p.signers {
+		accounts = append(accounts, address.Hex())
+	}
+	return accounts
+}
+
+// sendTransaction signs the transaction with the sender's signing service and submits it
+// upstream as a raw transaction. Transactions from other accounts are not handled.
+func (p *Proxy) sendTransaction(ctx context.Context, params json.RawMessage) (any, bool, error) {
+	var txs []map[string]any
+	if err := json.Unmarshal(params, &txs); err != nil || len(txs) != 1 {
+		return nil, false, nil
+	}
+	tx := txs[0]
+
+	from, _ := tx["from"].(string)
+	if !common.IsHexAddress(from) {
+		return nil, false, nil
+	}
+	client, ok := p.signers[common.HexToAddress(from)]
+	if !ok {
+		return nil, false, nil
+	}
+
+	// Signing services need the chain and nonce, forge leaves them to the node in unlocked mode
+	if _, ok := tx["chainId"]; !ok {
+		chainID, err := p.upstreamString(ctx, "eth_chainId")
+		if err != nil {
+			return nil, true, err
+		}
+		tx["chainId"] = chainID
+	}
+	if _, ok := tx["nonce"]; !ok {
+		nonce, err := p.upstreamString(ctx, "eth_getTransactionCount", from, "pending")
+		if err != nil {
+			return nil, true, err
+		}
+		tx["nonce"] = nonce
+	}
+	// Both "input" and "data" are accepted by nodes, signing services expect "data"
+	if input, ok := tx["input"]; ok {
+		if _, hasData := tx["data"]; !hasData {
+			tx["data"] = input
+		}
+		delete(tx, "input")
+	}
+
+	raw, err := client.SignTransaction(ctx, tx)
+	if err != nil {
+		return nil, true, fmt.Errorf("remote signer for %s: %w", from, err)
+	}
+
+	hash, err := p.upstreamString(ctx, "eth_sendRawTransaction", raw.String())
+	if err != nil {
+		return nil, true, err
+	}
+	return hash, true, nil
+}
+
+// signData forwards a signing request to the signing service of the account at addressIndex
+func (p *Proxy) signData(ctx context.Context, method string, params json.RawMessage, addressIndex int) (any, bool, error) {
+	var args []json.RawMessage
+	if err := json.Unmarshal(params, &args); err != nil || len(args) != 2 {
+		return nil, false, nil
+	}
+	var address string
+	if err := json.Unmarshal(args[addressIndex], &address); err != nil || !common.IsHexAddress(address) {
+		return nil, false, nil
+	}
+	client, ok := p.signers[common.HexToAddress(address)]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if method == "eth_signTypedData_v4" {
+		typedData := args[1]
+		// Typed data may be sent as a JSON string or as an object
+		var encoded string
+		if err := json.Unmarshal(typedData, &encoded); err == nil {
+			typedData = json.RawMessage(encoded)
+		}
+		signature, err := client.SignTypedData(ctx, address, typedData)
+		return signature, true, err
+	}
+
+	result, err := client.rpc.call(ctx, method, args[0], args[1])
+	if err != nil {
+		return nil, true, err
+	}
+	var signature hexutil.Bytes
+	if err := json.Unmarshal(result, &signature); err != nil {
+		return nil, true, fmt.Errorf("unexpected %s result: %s", method, strings.TrimSpace(string(result)))
+	}
+	return signature, true, nil
+}
+
+func (p *Proxy) upstreamString(ctx context.Context, method string, params ...any) (string, error) {
+	result, err := p.upstream.call(ctx, method, params...)
+	if err != nil {
+		return "", err
+	}
+	var value string
+	if err := json.Unmarshal(result, &value); err != nil {
+		return "", fmt.Errorf("unexpected %s result: %s", method, string(result))
+	}
+	return value, nil
+}
+
+func errorResponse(id json.RawMessage, err error) *rpcResponse {
+	var rpcErr *rpcError
+	if errors.As(err, &rpcErr) {
+		return &rpcResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: rpcErr.Code, Message: err.Error(), Data: rpcErr.Data}}
+	}
+	return &rpcResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: -32000, Message: err.Error()}}
+}
@@ -0,0 +1,216 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+)
+
+const testSignerKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+var testSignerAddress = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+// rpcStub is a JSON-RPC server answering with a handler per method
+type rpcStub struct {
+	*httptest.Server
+	calls   []rpcRequest
+	headers []http.Header
+}
+
+func newRPCStub(t *testing.T, handlers map[string]func(params json.RawMessage) any) *rpcStub {
+	t.Helper()
+	stub := &rpcStub{}
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req rpcRequest
+		require.NoError(t, json.Unmarshal(body, &req))
+		stub.calls = append(stub.calls, req)
+		stub.headers = append(stub.headers, r.Header.Clone())
+
+		resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+		if handler, ok := handlers[req.Method]; ok {
+			resp.Result, _ = json.Marshal(handler(req.Params))
+		} else {
+			resp.Error = &rpcError{Code: -32601, Message: "method not found"}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(stub.Close)
+	return stub
+}
+
+func (s *rpcStub) methods() []string {
+	methods := make([]string, 0, len(s.calls))
+	for _, call := range s.calls {
+		methods = append(methods, call.Method)
+	}
+	return methods
+}
+
+// newSignerStub stands in for Clef: it signs eth_signTransaction requests with the test key
+func newSignerStub(t *testing.T) *rpcStub {
+	t.Helper()
+	key, err := crypto.HexToECDSA(testSignerKey)
+	require.NoError(t, err)
+
+	return newRPCStub(t, map[string]func(json.RawMessage) any{
+		"eth_signTransaction": func(params json.RawMessage) any {
+			var txs []struct {
+				To       *common.Address `json:"to"`
+				Gas      hexutil.Uint64  `json:"gas"`
+				GasPrice *hexutil.Big    `json:"gasPrice"`
+				Value    *hexutil.Big    `json:"value"`
+				Data     hexutil.Bytes   `json:"data"`
+				Nonce    hexutil.Uint64  `json:"nonce"`
+				ChainID  *hexutil.Big    `json:"chainId"`
+			}
+			require.NoError(t, json.Unmarshal(params, &txs))
+			tx := txs[0]
+			signed, err := types.SignNewTx(key, types.NewEIP155Signer(tx.ChainID.ToInt()), &types.LegacyTx{
+				Nonce:    uint64(tx.Nonce),
+				To:       tx.To,
+				Gas:      uint64(tx.Gas),
+				GasPrice: tx.GasPrice.ToInt(),
+				Value:    tx.Value.ToInt(),
+				Data:     tx.Data,
+			})
+			require.NoError(t, err)
+			raw, err := signed.MarshalBinary()
+			require.NoError(t, err)
+			return map[string]any{"raw": hexutil.Bytes(raw), "tx": signed}
+		},
+		"eth_signTypedData_v4": func(params json.RawMessage) any {
+			return hexutil.Bytes(bytes.Repeat([]byte{0xab}, 65))
+		},
+	})
+}
+
+func startProxy(t *testing.T, upstreamURL, signerURL string) string {
+	t.Helper()
+	proxy := NewProxy(upstreamURL, []config.RemoteSignerConfig{{
+		Name:       "deployer",
+		Address:    testSignerAddress,
+		URL:        signerURL,
+		AuthHeader: "Bearer secret-token",
+	}}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	url, err := proxy.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = proxy.Close() })
+	return url
+}
+
+func TestProxy_SendTransaction(t *testing.T) {
+	signerStub := newSignerStub(t)
+	var rawTx hexutil.Bytes
+	upstream := newRPCStub(t, map[string]func(json.RawMessage) any{
+		"eth_chainId":             func(json.RawMessage) any { return "0x1" },
+		"eth_getTransactionCount": func(json.RawMessage) any { return "0x7" },
+		"eth_sendRawTransaction": func(params json.RawMessage) any {
+			var args []hexutil.Bytes
+			require.NoError(t, json.Unmarshal(params, &args))
+			rawTx = args[0]
+			return "0x" + common.Bytes2Hex(crypto.Keccak256(rawTx))
+		},
+	})
+	proxyURL := startProxy(t, upstream.URL, signerStub.URL)
+
+	client := newRPCClient(proxyURL, "")
+	result, err := client.call(t.Context(), "eth_sendTransaction", map[string]any{
+		"from":     testSignerAddress.Hex(),
+		"to":       "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+		"gas":      "0x5208",
+		"gasPrice": "0x3b9aca00",
+		"value":    "0x1",
+		"input":    "0x",
+	})
+	require.NoError(t, err)
+
+	// The signed transaction was submitted upstream, from the signer account with the node's nonce
+	require.NotEmpty(t, rawTx)
+	var tx types.Transaction
+	require.NoError(t, tx.UnmarshalBinary(rawTx))
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), &tx)
+	require.NoError(t, err)
+	assert.Equal(t, testSignerAddress, from)
+	assert.Equal(t, uint64(7), tx.Nonce())
+
+	var hash string
+	require.NoError(t, json.Unmarshal(result, &hash))
+	assert.Equal(t, tx.Hash().Hex(), hash)
+
+	assert.Equal(t, []string{"eth_chainId", "eth_getTransactionCount", "eth_sendRawTransaction"}, upstream.methods())
+	require.Len(t, signerStub.headers, 1)
+	assert.Equal(t, "Bearer secret-token", signerStub.headers[0].Get("Authorization"))
+}
+
+func TestProxy_SignTypedData(t *testing.T) {
+	signerStub := newSignerStub(t)
+	upstream := newRPCStub(t, nil)
+	proxyURL := startProxy(t, upstream.URL, signerStub.URL)
+
+	client := newRPCClient(proxyURL, "")
+	result, err := client.call(t.Context(), "eth_signTypedData_v4", testSignerAddress.Hex(), `{"primaryType":"SafeTx"}`)
+	require.NoError(t, err)
+
+	var signature hexutil.Bytes
+	require.NoError(t, json.Unmarshal(result, &signature))
+	assert.Len(t, signature, 65)
+
+	// Typed data sent as a JSON string is passed to the signer as an object
+	require.Len(t, signerStub.calls, 1)
+	var params []json.RawMessage
+	require.NoError(t, json.Unmarshal(signerStub.calls[0].Params, &params))
+	assert.JSONEq(t, `{"primaryType":"SafeTx"}`, string(params[1]))
+	assert.Empty(t, upstream.calls)
+}
+
+func TestProxy_ForwardsOtherRequests(t *testing.T) {
+	signerStub := newSignerStub(t)
+	upstream := newRPCStub(t, map[string]func(json.RawMessage) any{
+		"eth_blockNumber":     func(json.RawMessage) any { return "0x10" },
+		"eth_sendTransaction": func(json.RawMessage) any { return "0xhash" },
+	})
+	proxyURL := startProxy(t, upstream.URL, signerStub.URL)
+	client := newRPCClient(proxyURL, "")
+
+	result, err := client.call(t.Context(), "eth_blockNumber")
+	require.NoError(t, err)
+	assert.JSONEq(t, `"0x10"`, string(result))
+
+	// Transactions from accounts without a remote signer go to the node unchanged
+	result, err = client.call(t.Context(), "eth_sendTransaction", map[string]any{
+		"from": "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `"0xhash"`, string(result))
+
+	_, err = client.call(t.Context(), "eth_unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "method not found")
+
+	assert.Equal(t, []string{"eth_blockNumber", "eth_sendTransaction", "eth_unknown"}, upstream.methods())
+	assert.Empty(t, signerStub.calls)
+}
+
+func TestParseAuthHeader(t *testing.T) {
+	name, value := parseAuthHeader("Bearer abc")
+	assert.Equal(t, "Authorization", name)
+	assert.Equal(t, "Bearer abc", value)
+
+	name, value = parseAuthHeader("X-Api-Key: abc:def")
+	assert.Equal(t, "X-Api-Key", name)
+	assert.Equal(t, "abc:def", value)
+}
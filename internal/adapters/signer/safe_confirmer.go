@@ -0,0 +1,195 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+	"github.com/trebuchet-org/treb-cli/pkg/safe"
+)
+
+// SafeTransactionService reads and confirms the transactions proposed to a Safe
+type SafeTransactionService interface {
+	GetTransaction(safeTxHash common.Hash) (*safe.MultisigTransaction, error)
+	ConfirmTransaction(safeTxHash common.Hash, signature []byte) error
+}
+
+// SafeConfirmer confirms proposed Safe transactions as configured owners. Confirmations
+// are EIP-712 signatures over the SafeTx, so owners behind a remote signer can confirm
+// even though they cannot sign inside a forge script.
+type SafeConfirmer struct {
+	senders  map[string]config.SenderConfig
+	keys     SenderKeys
+	services func(chainID uint64) (SafeTransactionService, error)
+}
+
+// NewSafeConfirmer creates a Safe confirmer for the configured senders
+func NewSafeConfirmer(cfg *config.RuntimeConfig, keys SenderKeys) *SafeConfirmer {
+	var senders map[string]config.SenderConfig
+	if cfg.TrebConfig != nil {
+		senders = cfg.TrebConfig.Senders
+	}
+	return &SafeConfirmer{
+		senders: senders,
+		keys:    keys,
+		services: func(chainID uint64) (SafeTransactionService, error) {
+			return safe.NewSafeClient(chainID)
+		},
+	}
+}
+
+// ConfirmSafeTransaction signs a proposed Safe transaction as sender and submits the confirmation
+func (c *SafeConfirmer) ConfirmSafeTransaction(ctx context.Context, chainID uint64, safeTxHash common.Hash, senderKey string) (common.Address, error) {
+	service, err := c.services(chainID)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to create Safe client: %w", err)
+	}
+
+	tx, err := service.GetTransaction(safeTxHash)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get Safe transaction %s: %w", safeTxHash.Hex(), err)
+	}
+
+	typedData := safeTxTypedData(chainID, tx)
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to hash Safe transaction: %w", err)
+	}
+	// The service returns what was proposed, make sure it is what the script proposed
+	if common.BytesToHash(hash) != safeTxHash {
+		return common.Address{}, fmt.Errorf("safe transaction %s does not match its hash", safeTxHash.Hex())
+	}
+
+	address, signature, err := c.signSafeTx(ctx, senderKey, typedData, hash)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	if err := service.ConfirmTransaction(safeTxHash, signature); err != nil {
+		return common.Address{}, fmt.Errorf("failed to submit confirmation: %w", err)
+	}
+	return address, nil
+}
+
+// signSafeTx signs the SafeTx hash as sender and checks the signature recovers to its address
+func (c *SafeConfirmer) signSafeTx(ctx context.Context, senderKey string, typedData apitypes.TypedData, hash []byte) (common.Address, []byte, error) {
+	sender, exists := c.senders[senderKey]
+	if !exists {
+		return common.Address{}, nil, fmt.Errorf("sender '%s' not found in configuration", senderKey)
+	}
+
+	var address common.Address
+	var signature []byte
+	switch sender.Type {
+	case config.SenderTypePrivateKey, config.SenderTypeKeystore:
+		var key *ecdsa.PrivateKey
+		var err error
+		key, address, err = c.keys.SenderKey(senderKey)
+		if err != nil {
+			return common.Address{}, nil, err
+		}
+		if signature, err = crypto.Sign(hash, key); err != nil {
+			return common.Address{}, nil, fmt.Errorf("failed to sign Safe transaction: %w", err)
+		}
+
+	case config.SenderTypeRemoteSigner:
+		if !common.IsHexAddress(sender.Address) {
+			return common.Address{}, nil, fmt.Errorf("remote_signer sender '%s' requires an address", senderKey)
+		}
+		address = common.HexToAddress(sender.Address)
+		payload, err := json.Marshal(typedData)
+		if err != nil {
+			return common.Address{}, nil, fmt.Errorf("failed to encode Safe transaction: %w", err)
+		}
+		signature, err = NewClient(sender.URL, sender.AuthHeader).SignTypedData(ctx, address.Hex(), payload)
+		if err != nil {
+			return common.Address{}, nil, fmt.Errorf("remote signer for %s: %w", address.Hex(), err)
+		}
+
+	default:
+		return common.Address{}, nil, fmt.Errorf("sender '%s' of type %s cannot confirm Safe transactions", senderKey, sender.Type)
+	}
+
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, nil, fmt.Errorf("invalid signature length %d from sender '%s'", len(signature), senderKey)
+	}
+	// Safe expects v as 27/28, signers return either form
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(hash, signature)
+	if err != nil || crypto.PubkeyToAddress(*pubKey) != address {
+		return common.Address{}, nil, fmt.Errorf("sender '%s' did not sign the Safe transaction as %s", senderKey, address.Hex())
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return address, signature, nil
+}
+
+// safeTxTypedData builds the EIP-712 SafeTx a Safe owner signs to confirm a transaction
+func safeTxTypedData(chainID uint64, tx *safe.MultisigTransaction) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"SafeTx": {
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "SafeTx",
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           (*math.HexOrDecimal256)(new(big.Int).SetUint64(chainID)),
+			VerifyingContract: common.HexToAddress(tx.Safe).Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"to":             common.HexToAddress(tx.To).Hex(),
+			"value":          orZero(tx.Value),
+			"data":           orEmptyBytes(tx.Data),
+			"operation":      strconv.Itoa(tx.Operation),
+			"safeTxGas":      strconv.Itoa(tx.SafeTxGas),
+			"baseGas":        strconv.Itoa(tx.BaseGas),
+			"gasPrice":       orZero(tx.GasPrice),
+			"gasToken":       common.HexToAddress(tx.GasToken).Hex(),
+			"refundReceiver": common.HexToAddress(tx.RefundReceiver).Hex(),
+			"nonce":          strconv.Itoa(tx.Nonce),
+		},
+	}
+}
+
+// orZero defaults an empty decimal string from the Safe Transaction Service to zero
+func orZero(value string) string {
+	if value == "" {
+		return "0"
+	}
+	return value
+}
+
+// orEmptyBytes defaults missing calldata from the Safe Transaction Service to empty bytes
+func orEmptyBytes(data string) string {
+	if data == "" {
+		return "0x"
+	}
+	return data
+}
+
+var _ usecase.SafeConfirmer = (*SafeConfirmer)(nil)
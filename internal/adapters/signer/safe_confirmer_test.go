@@ -0,0 +1,165 @@
+package signer
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/pkg/safe"
+)
+
+// fakeSafeService serves one proposed transaction and records the confirmations it receives
+type fakeSafeService struct {
+	tx            *safe.MultisigTransaction
+	confirmations map[common.Hash][]byte
+}
+
+func (f *fakeSafeService) GetTransaction(common.Hash) (*safe.MultisigTransaction, error) {
+	return f.tx, nil
+}
+
+func (f *fakeSafeService) ConfirmTransaction(safeTxHash common.Hash, signature []byte) error {
+	f.confirmations[safeTxHash] = signature
+	return nil
+}
+
+// expectedSafeTxHash computes the hash Safe contracts (v1.3+) check signatures against
+func expectedSafeTxHash(chainID uint64, tx *safe.MultisigTransaction) common.Hash {
+	word := func(v *big.Int) []byte { return common.LeftPadBytes(v.Bytes(), 32) }
+	address := func(s string) []byte { return common.LeftPadBytes(common.HexToAddress(s).Bytes(), 32) }
+	decimal := func(s string) *big.Int { v, _ := new(big.Int).SetString(s, 10); return v }
+
+	domainSeparator := crypto.Keccak256(
+		common.FromHex("0x47e79534a245952e8b16893a336b85a3d9ea9fa8c573f3d803afb92a79469218"),
+		word(new(big.Int).SetUint64(chainID)),
+		address(tx.Safe),
+	)
+	structHash := crypto.Keccak256(
+		common.FromHex("0xbb8310d486368db6bd6f849402fdd73ad53d316b5a4b2644ad6efe0f941286d8"),
+		address(tx.To),
+		word(decimal(tx.Value)),
+		crypto.Keccak256(common.FromHex(tx.Data)),
+		word(big.NewInt(int64(tx.Operation))),
+		word(big.NewInt(int64(tx.SafeTxGas))),
+		word(big.NewInt(int64(tx.BaseGas))),
+		word(decimal(tx.GasPrice)),
+		address(tx.GasToken),
+		address(tx.RefundReceiver),
+		word(big.NewInt(int64(tx.Nonce))),
+	)
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator, structHash)
+}
+
+func newTestSafeConfirmer(t *testing.T, senders map[string]config.SenderConfig, service SafeTransactionService) *SafeConfirmer {
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	require.NoError(t, err)
+	cfg := &config.RuntimeConfig{TrebConfig: &config.TrebConfig{Senders: senders}}
+	confirmer := NewSafeConfirmer(cfg, stubSenderKeys{key: key})
+	confirmer.services = func(uint64) (SafeTransactionService, error) { return service, nil }
+	return confirmer
+}
+
+func TestSafeConfirmer_ConfirmSafeTransaction(t *testing.T) {
+	const chainID = 11155111
+	tx := &safe.MultisigTransaction{
+		Safe:      "0x3D33783D1fd1B6D849d299aD2E711f844fC16d2F",
+		To:        "0x1234567890123456789012345678901234567890",
+		Value:     "1000",
+		Data:      "0xd09de08a",
+		Operation: 1,
+		GasPrice:  "0",
+		Nonce:     7,
+	}
+	safeTxHash := expectedSafeTxHash(chainID, tx)
+
+	assertConfirmed := func(t *testing.T, service *fakeSafeService, owner common.Address) {
+		signature := service.confirmations[safeTxHash]
+		require.Len(t, signature, 65)
+		assert.Contains(t, []byte{27, 28}, signature[64])
+
+		recoverable := append([]byte{}, signature...)
+		recoverable[64] -= 27
+		pubKey, err := crypto.SigToPub(safeTxHash.Bytes(), recoverable)
+		require.NoError(t, err)
+		assert.Equal(t, owner, crypto.PubkeyToAddress(*pubKey))
+	}
+
+	t.Run("private key", func(t *testing.T) {
+		service := &fakeSafeService{tx: tx, confirmations: map[common.Hash][]byte{}}
+		confirmer := newTestSafeConfirmer(t, map[string]config.SenderConfig{
+			"owner": {Type: config.SenderTypePrivateKey, PrivateKey: "0x" + testPrivateKey},
+		}, service)
+
+		owner, err := confirmer.ConfirmSafeTransaction(t.Context(), chainID, safeTxHash, "owner")
+		require.NoError(t, err)
+		assert.Equal(t, testAddress, owner.Hex())
+		assertConfirmed(t, service, owner)
+	})
+
+	t.Run("remote signer", func(t *testing.T) {
+		key, err := crypto.HexToECDSA(testPrivateKey)
+		require.NoError(t, err)
+
+		// Stands in for a signing service, it signs the typed data it is sent with v as 0/1
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				ID     json.RawMessage   `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "eth_signTypedData_v4", req.Method)
+
+			var typedData apitypes.TypedData
+			require.NoError(t, json.Unmarshal(req.Params[1], &typedData))
+			hash, _, err := apitypes.TypedDataAndHash(typedData)
+			require.NoError(t, err)
+			signature, err := crypto.Sign(hash, key)
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": hexutil.Bytes(signature)})
+		}))
+		t.Cleanup(server.Close)
+
+		service := &fakeSafeService{tx: tx, confirmations: map[common.Hash][]byte{}}
+		confirmer := newTestSafeConfirmer(t, map[string]config.SenderConfig{
+			"ops": {Type: config.SenderTypeRemoteSigner, Address: testAddress, URL: server.URL},
+		}, service)
+
+		owner, err := confirmer.ConfirmSafeTransaction(t.Context(), chainID, safeTxHash, "ops")
+		require.NoError(t, err)
+		assertConfirmed(t, service, owner)
+	})
+
+	t.Run("transaction does not match its hash", func(t *testing.T) {
+		service := &fakeSafeService{tx: tx, confirmations: map[common.Hash][]byte{}}
+		confirmer := newTestSafeConfirmer(t, map[string]config.SenderConfig{
+			"owner": {Type: config.SenderTypePrivateKey, PrivateKey: "0x" + testPrivateKey},
+		}, service)
+
+		_, err := confirmer.ConfirmSafeTransaction(t.Context(), chainID, common.Hash{0x01}, "owner")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match its hash")
+		assert.Empty(t, service.confirmations)
+	})
+
+	t.Run("hardware wallets are not supported", func(t *testing.T) {
+		service := &fakeSafeService{tx: tx, confirmations: map[common.Hash][]byte{}}
+		confirmer := newTestSafeConfirmer(t, map[string]config.SenderConfig{
+			"ledger": {Type: config.SenderTypeLedger, Address: testAddress},
+		}, service)
+
+		_, err := confirmer.ConfirmSafeTransaction(t.Context(), chainID, safeTxHash, "ledger")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot confirm Safe transactions")
+		assert.Empty(t, service.confirmations)
+	})
+}
@@ -0,0 +1,118 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// SenderKeys gives access to the private keys of senders that hold one
+type SenderKeys interface {
+	SenderKey(senderKey string) (*ecdsa.PrivateKey, common.Address, error)
+}
+
+// TransactionSigners signs the transactions treb sends itself rather than through forge,
+// such as timelock executions. Only senders holding or delegating their key can sign.
+type TransactionSigners struct {
+	senders map[string]config.SenderConfig
+	keys    SenderKeys
+}
+
+// NewTransactionSigners creates transaction signers for the configured senders
+func NewTransactionSigners(cfg *config.RuntimeConfig, keys SenderKeys) *TransactionSigners {
+	var senders map[string]config.SenderConfig
+	if cfg.TrebConfig != nil {
+		senders = cfg.TrebConfig.Senders
+	}
+	return &TransactionSigners{senders: senders, keys: keys}
+}
+
+// TransactionSigner returns the address of a sender and a signer for its transactions
+func (s *TransactionSigners) TransactionSigner(ctx context.Context, senderKey string, chainID *big.Int) (common.Address, bind.SignerFn, error) {
+	sender, exists := s.senders[senderKey]
+	if !exists {
+		return common.Address{}, nil, fmt.Errorf("sender '%s' not found in configuration", senderKey)
+	}
+
+	switch sender.Type {
+	case config.SenderTypePrivateKey, config.SenderTypeKeystore:
+		key, address, err := s.keys.SenderKey(senderKey)
+		if err != nil {
+			return common.Address{}, nil, err
+		}
+		return address, keySigner(key, address, chainID), nil
+
+	case config.SenderTypeRemoteSigner:
+		if !common.IsHexAddress(sender.Address) {
+			return common.Address{}, nil, fmt.Errorf("remote_signer sender '%s' requires an address", senderKey)
+		}
+		address := common.HexToAddress(sender.Address)
+		client := NewClient(sender.URL, sender.AuthHeader)
+		return address, remoteSigner(ctx, client, address, chainID), nil
+
+	default:
+		return common.Address{}, nil, fmt.Errorf("sender '%s' of type %s cannot sign transactions outside of forge scripts", senderKey, sender.Type)
+	}
+}
+
+// keySigner signs transactions from address with a private key
+func keySigner(key *ecdsa.PrivateKey, address common.Address, chainID *big.Int) bind.SignerFn {
+	txSigner := types.LatestSignerForChainID(chainID)
+	return func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if from != address {
+			return nil, fmt.Errorf("not authorized to sign for %s", from.Hex())
+		}
+		return types.SignTx(tx, txSigner, key)
+	}
+}
+
+// remoteSigner signs transactions from address with eth_signTransaction on a signing service
+func remoteSigner(ctx context.Context, client *Client, address common.Address, chainID *big.Int) bind.SignerFn {
+	return func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if from != address {
+			return nil, fmt.Errorf("not authorized to sign for %s", from.Hex())
+		}
+
+		args := map[string]any{
+			"from":    address.Hex(),
+			"gas":     hexutil.Uint64(tx.Gas()),
+			"value":   (*hexutil.Big)(tx.Value()),
+			"data":    hexutil.Bytes(tx.Data()),
+			"nonce":   hexutil.Uint64(tx.Nonce()),
+			"chainId": (*hexutil.Big)(chainID),
+		}
+		if tx.To() != nil {
+			args["to"] = tx.To().Hex()
+		}
+		if tx.Type() == types.DynamicFeeTxType {
+			args["maxFeePerGas"] = (*hexutil.Big)(tx.GasFeeCap())
+			args["maxPriorityFeePerGas"] = (*hexutil.Big)(tx.GasTipCap())
+		} else {
+			args["gasPrice"] = (*hexutil.Big)(tx.GasPrice())
+		}
+
+		raw, err := client.SignTransaction(ctx, args)
+		if err != nil {
+			return nil, fmt.Errorf("remote signer for %s: %w", address.Hex(), err)
+		}
+
+		var signed types.Transaction
+		if err := signed.UnmarshalBinary(raw); err != nil {
+			return nil, fmt.Errorf("invalid signed transaction from remote signer: %w", err)
+		}
+		if signedBy, err := types.Sender(types.LatestSignerForChainID(chainID), &signed); err != nil || signedBy != address {
+			return nil, fmt.Errorf("remote signer did not sign the transaction as %s", address.Hex())
+		}
+		return &signed, nil
+	}
+}
+
+var _ usecase.TransactionSigners = (*TransactionSigners)(nil)
@@ -0,0 +1,115 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+)
+
+const (
+	testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	testAddress    = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+)
+
+// stubSenderKeys holds the key of every sender
+type stubSenderKeys struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s stubSenderKeys) SenderKey(string) (*ecdsa.PrivateKey, common.Address, error) {
+	return s.key, crypto.PubkeyToAddress(s.key.PublicKey), nil
+}
+
+func newTestSigners(t *testing.T, senders map[string]config.SenderConfig) *TransactionSigners {
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	require.NoError(t, err)
+	cfg := &config.RuntimeConfig{TrebConfig: &config.TrebConfig{Senders: senders}}
+	return NewTransactionSigners(cfg, stubSenderKeys{key: key})
+}
+
+func TestTransactionSigners_TransactionSigner(t *testing.T) {
+	chainID := big.NewInt(11155111)
+	unsigned := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     3,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &common.Address{0x01},
+		Value:     big.NewInt(5),
+	})
+
+	t.Run("private key", func(t *testing.T) {
+		signers := newTestSigners(t, map[string]config.SenderConfig{
+			"deployer": {Type: config.SenderTypePrivateKey, PrivateKey: "0x" + testPrivateKey},
+		})
+
+		address, sign, err := signers.TransactionSigner(t.Context(), "deployer", chainID)
+		require.NoError(t, err)
+		assert.Equal(t, testAddress, address.Hex())
+
+		signed, err := sign(address, unsigned)
+		require.NoError(t, err)
+		from, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		require.NoError(t, err)
+		assert.Equal(t, address, from)
+
+		_, err = sign(common.Address{0x02}, unsigned)
+		assert.Error(t, err)
+	})
+
+	t.Run("remote signer", func(t *testing.T) {
+		key, err := crypto.HexToECDSA(testPrivateKey)
+		require.NoError(t, err)
+
+		// Stands in for a signing service, it checks the request and signs the same transaction
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				ID     json.RawMessage  `json:"id"`
+				Params []map[string]any `json:"params"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "0xaa36a7", req.Params[0]["chainId"])
+			assert.Equal(t, "0x2", req.Params[0]["maxFeePerGas"])
+			assert.Equal(t, "0x3", req.Params[0]["nonce"])
+
+			signed, err := types.SignTx(unsigned, types.LatestSignerForChainID(chainID), key)
+			require.NoError(t, err)
+			raw, err := signed.MarshalBinary()
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": hexutil.Bytes(raw)})
+		}))
+		t.Cleanup(server.Close)
+
+		signers := newTestSigners(t, map[string]config.SenderConfig{
+			"ops": {Type: config.SenderTypeRemoteSigner, Address: testAddress, URL: server.URL},
+		})
+
+		address, sign, err := signers.TransactionSigner(t.Context(), "ops", chainID)
+		require.NoError(t, err)
+		signed, err := sign(address, unsigned)
+		require.NoError(t, err)
+		assert.Equal(t, unsigned.Nonce(), signed.Nonce())
+	})
+
+	t.Run("hardware wallets are not supported", func(t *testing.T) {
+		signers := newTestSigners(t, map[string]config.SenderConfig{
+			"ledger": {Type: config.SenderTypeLedger, DerivationPath: "m/44'/60'/0'/0/0"},
+		})
+
+		_, _, err := signers.TransactionSigner(t.Context(), "ledger", chainID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot sign transactions outside of forge")
+	})
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"github.com/trebuchet-org/treb-cli/internal/adapters/progress"
 	"github.com/trebuchet-org/treb-cli/internal/cli/render"
 	"github.com/trebuchet-org/treb-cli/internal/domain/config"
 	"github.com/trebuchet-org/treb-cli/internal/usecase"
@@ -32,20 +33,31 @@ type App struct {
 	RegisterDeployment       *usecase.RegisterDeployment
 	ManageAnvil              *usecase.ManageAnvil
 	InitProject              *usecase.InitProject
+	ListRuns                 *usecase.ListRuns
+	ShowRun                  *usecase.ShowRun
+	ExecuteTimelockOperation *usecase.ExecuteTimelockOperation
+	ListAccounts             *usecase.ListAccounts
+	ManageAddressBook        *usecase.ManageAddressBook
+	DescribeScript           *usecase.DescribeScript
+	PlanCompose              *usecase.PlanCompose
 
 	// Fork use cases
-	EnterFork   *usecase.EnterFork
-	ExitFork    *usecase.ExitFork
-	RevertFork  *usecase.RevertFork
-	RestartFork *usecase.RestartFork
-	ForkStatus  *usecase.ForkStatus
-	ForkHistory *usecase.ForkHistory
-	DiffFork    *usecase.DiffFork
+	EnterFork      *usecase.EnterFork
+	ExitFork       *usecase.ExitFork
+	RevertFork     *usecase.RevertFork
+	RestartFork    *usecase.RestartFork
+	ForkStatus     *usecase.ForkStatus
+	ForkHistory    *usecase.ForkHistory
+	DiffFork       *usecase.DiffFork
+	ExportFork     *usecase.ExportFork
+	ImportFork     *usecase.ImportFork
+	CheckpointFork *usecase.CheckpointFork
 
 	// Adapters (needed for special cases like log streaming)
 	AnvilManager    usecase.AnvilManager
 	NetworkResolver usecase.NetworkResolver
 	ForkStateStore  usecase.ForkStateStore
+	Events          *progress.EventStream // nil unless --events is set
 
 	// Renderers
 	GenerateRenderer render.Renderer[*usecase.GenerateScriptResult]
@@ -74,6 +86,13 @@ func NewApp(
 	registerDeployment *usecase.RegisterDeployment,
 	manageAnvil *usecase.ManageAnvil,
 	initProject *usecase.InitProject,
+	listRuns *usecase.ListRuns,
+	showRun *usecase.ShowRun,
+	executeTimelockOperation *usecase.ExecuteTimelockOperation,
+	listAccounts *usecase.ListAccounts,
+	manageAddressBook *usecase.ManageAddressBook,
+	describeScript *usecase.DescribeScript,
+	planCompose *usecase.PlanCompose,
 	enterFork *usecase.EnterFork,
 	exitFork *usecase.ExitFork,
 	revertFork *usecase.RevertFork,
@@ -81,9 +100,13 @@ func NewApp(
 	forkStatus *usecase.ForkStatus,
 	forkHistory *usecase.ForkHistory,
 	diffFork *usecase.DiffFork,
+	exportFork *usecase.ExportFork,
+	importFork *usecase.ImportFork,
+	checkpointFork *usecase.CheckpointFork,
 	anvilManager usecase.AnvilManager,
 	networkResolver usecase.NetworkResolver,
 	forkStateStore usecase.ForkStateStore,
+	events *progress.EventStream,
 	generateRenderer render.Renderer[*usecase.GenerateScriptResult],
 	scriptRenderer *render.ScriptRenderer,
 	composeRenderer *render.ComposeRenderer,
@@ -108,6 +131,13 @@ func NewApp(
 		RegisterDeployment:       registerDeployment,
 		ManageAnvil:              manageAnvil,
 		InitProject:              initProject,
+		ListRuns:                 listRuns,
+		ShowRun:                  showRun,
+		ExecuteTimelockOperation: executeTimelockOperation,
+		ListAccounts:             listAccounts,
+		ManageAddressBook:        manageAddressBook,
+		DescribeScript:           describeScript,
+		PlanCompose:              planCompose,
 		EnterFork:                enterFork,
 		ExitFork:                 exitFork,
 		RevertFork:               revertFork,
@@ -115,9 +145,13 @@ func NewApp(
 		ForkStatus:               forkStatus,
 		ForkHistory:              forkHistory,
 		DiffFork:                 diffFork,
+		ExportFork:               exportFork,
+		ImportFork:               importFork,
+		CheckpointFork:           checkpointFork,
 		AnvilManager:             anvilManager,
 		NetworkResolver:          networkResolver,
 		ForkStateStore:           forkStateStore,
+		Events:                   events,
 		GenerateRenderer:         generateRenderer,
 		ScriptRenderer:           scriptRenderer,
 		ComposeRenderer:          composeRenderer,
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/trebuchet-org/treb-cli/internal/adapters"
+	"github.com/trebuchet-org/treb-cli/internal/adapters/signer"
 	"github.com/trebuchet-org/treb-cli/internal/cli/render"
 	"github.com/trebuchet-org/treb-cli/internal/config"
 	"github.com/trebuchet-org/treb-cli/internal/logging"
@@ -24,6 +25,8 @@ func InitApp(v *viper.Viper, cmd *cobra.Command) (*App, error) {
 
 		config.NewSendersManager,
 		wire.Bind(new(usecase.SendersManager), new(*config.SendersManager)),
+		wire.Bind(new(signer.SenderKeys), new(*config.SendersManager)),
+		wire.Bind(new(usecase.SenderAddressResolver), new(*config.SendersManager)),
 
 		render.ProvideIO,
 
@@ -48,14 +51,18 @@ func InitApp(v *viper.Viper, cmd *cobra.Command) (*App, error) {
 		usecase.NewShowConfig,
 		usecase.NewSetConfig,
 		usecase.NewRemoveConfig,
+		usecase.NewRegistryLock,
 		usecase.NewRunScript,
 		usecase.NewVerifyDeployment,
+		usecase.NewComposeHooks,
 		usecase.NewComposeDeployment,
 		usecase.NewSyncRegistry,
 		usecase.NewTagDeployment,
 		usecase.NewRegisterDeployment,
 		usecase.NewManageAnvil,
 		usecase.NewInitProject,
+		usecase.NewListRuns,
+		usecase.NewShowRun,
 		usecase.NewEnterFork,
 		usecase.NewExitFork,
 		usecase.NewRevertFork,
@@ -63,6 +70,14 @@ func InitApp(v *viper.Viper, cmd *cobra.Command) (*App, error) {
 		usecase.NewForkStatus,
 		usecase.NewForkHistory,
 		usecase.NewDiffFork,
+		usecase.NewExportFork,
+		usecase.NewImportFork,
+		usecase.NewCheckpointFork,
+		usecase.NewExecuteTimelockOperation,
+		usecase.NewListAccounts,
+		usecase.NewManageAddressBook,
+		usecase.NewDescribeScript,
+		usecase.NewPlanCompose,
 
 		// App
 		NewApp,
@@ -13,12 +13,16 @@ import (
 	"github.com/trebuchet-org/treb-cli/internal/adapters/abi"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/anvil"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/blockchain"
+	"github.com/trebuchet-org/treb-cli/internal/adapters/environment"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/forge"
+	"github.com/trebuchet-org/treb-cli/internal/adapters/forge/broadcast"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/fs"
+	"github.com/trebuchet-org/treb-cli/internal/adapters/hooks"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/progress"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/repository/contracts"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/repository/deployments"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/resolvers"
+	"github.com/trebuchet-org/treb-cli/internal/adapters/signer"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/template"
 	"github.com/trebuchet-org/treb-cli/internal/adapters/verification"
 	"github.com/trebuchet-org/treb-cli/internal/cli/interactive"
@@ -48,14 +52,15 @@ func InitApp(v *viper.Viper, cmd *cobra.Command) (*App, error) {
 	networkResolver := config.ProvideNetworkResolver(runtimeConfig)
 	forkStateStoreAdapter := fs.NewForkStateStoreAdapter(runtimeConfig)
 	listDeployments := usecase.NewListDeployments(runtimeConfig, fileRepository, networkResolver, forkStateStoreAdapter)
-	deploymentResolver := resolvers.NewDeploymentResolver(runtimeConfig, fileRepository, selectorAdapter)
+	deploymentResolver := resolvers.NewDeploymentResolver(runtimeConfig, fileRepository, selectorAdapter, networkResolver)
 	showDeployment := usecase.NewShowDeployment(runtimeConfig, fileRepository, deploymentResolver, forkStateStoreAdapter)
 	string2 := adapters.ProvideProjectPath(runtimeConfig)
 	foundryProfile := adapters.ProvideFoundryProfile(runtimeConfig)
 	repository := contracts.NewRepository(string2, foundryProfile, logger)
 	contractResolver := resolvers.NewContractResolver(runtimeConfig, repository, selectorAdapter)
 	eventParser := abi.NewEventParser(string2, logger)
-	abiResolver := abi.NewABIResolver(runtimeConfig, repository, fileRepository)
+	addressBookStoreAdapter := fs.NewAddressBookStoreAdapter(runtimeConfig)
+	abiResolver := abi.NewABIResolver(runtimeConfig, repository, fileRepository, addressBookStoreAdapter)
 	scriptGeneratorAdapter, err := template.NewScriptGeneratorAdapter(runtimeConfig, eventParser, abiResolver)
 	if err != nil {
 		return nil, err
@@ -71,39 +76,64 @@ func InitApp(v *viper.Viper, cmd *cobra.Command) (*App, error) {
 	checkerAdapter := blockchain.NewCheckerAdapter(castTracer, string2)
 	pruner := deployments.NewPruner(fileRepository, checkerAdapter)
 	spinnerProgressReporter := progress.NewSpinnerProgressReporter()
-	pruneRegistry := usecase.NewPruneRegistry(networkResolver, checkerAdapter, pruner, fileRepository, spinnerProgressReporter)
+	eventStream, err := adapters.ProvideEventStream(runtimeConfig, cmd)
+	if err != nil {
+		return nil, err
+	}
+	progressSink := adapters.ProvideProgressSink(spinnerProgressReporter, eventStream)
+	pruneRegistry := usecase.NewPruneRegistry(networkResolver, checkerAdapter, pruner, fileRepository, progressSink)
 	resetRegistry := usecase.NewResetRegistry(runtimeConfig, fileRepository, fileRepository)
 	localConfigStoreAdapter := fs.NewLocalConfigStoreAdapter(runtimeConfig)
 	showConfig := usecase.NewShowConfig(localConfigStoreAdapter)
 	setConfig := usecase.NewSetConfig(localConfigStoreAdapter, networkResolver)
 	removeConfig := usecase.NewRemoveConfig(localConfigStoreAdapter)
-	scriptResolver := resolvers.NewScriptResolver(string2, contractResolver)
-	parameterResolver := resolvers.NewParameterResolver(runtimeConfig, fileRepository, repository)
+	scriptResolver := resolvers.NewScriptResolver(string2, contractResolver, repository)
 	sendersManager := config.NewSendersManager(runtimeConfig)
+	parameterResolver := resolvers.NewParameterResolver(runtimeConfig, repository, sendersManager, deploymentResolver, addressBookStoreAdapter)
 	runResultHydrator, err := forge.NewRunResultHydrator(string2, eventParser, repository, logger)
 	if err != nil {
 		return nil, err
 	}
 	libraryResolver := resolvers.NewLibraryResolver(fileRepository)
 	writer := render.ProvideIO(cmd)
-	scriptRenderer := render.NewScriptRenderer(writer, fileRepository, abiResolver, logger)
+	scriptRenderer := render.NewScriptRenderer(writer, fileRepository, addressBookStoreAdapter, abiResolver, logger)
 	runProgress := progress.NewRunProgress(scriptRenderer)
+	runProgressSink := adapters.ProvideRunProgressSink(runProgress, eventStream)
 	manager := anvil.NewManager()
 	forkFileManagerAdapter := fs.NewForkFileManagerAdapter(runtimeConfig)
-	runScript := usecase.NewRunScript(runtimeConfig, scriptResolver, parameterResolver, sendersManager, runResultHydrator, fileRepository, libraryResolver, runProgress, forgeAdapter, forkStateStoreAdapter, manager, forkFileManagerAdapter)
+	parser := broadcast.NewParser(string2)
+	broadcastCheckpointStoreAdapter := fs.NewBroadcastCheckpointStoreAdapter(runtimeConfig)
+	runRecordStoreAdapter := fs.NewRunRecordStoreAdapter(runtimeConfig)
+	inspector := environment.NewInspector(runtimeConfig)
+	safeConfirmer := signer.NewSafeConfirmer(runtimeConfig, sendersManager)
+	registryLock := usecase.NewRegistryLock()
+	runScript := usecase.NewRunScript(runtimeConfig, scriptResolver, parameterResolver, sendersManager, runResultHydrator, fileRepository, libraryResolver, runProgressSink, forgeAdapter, forkStateStoreAdapter, manager, forkFileManagerAdapter, selectorAdapter, checkerAdapter, parser, broadcastCheckpointStoreAdapter, runRecordStoreAdapter, inspector, selectorAdapter, safeConfirmer, registryLock)
 	verifier, err := verification.NewVerifier(runtimeConfig)
 	if err != nil {
 		return nil, err
 	}
-	verifyDeployment := usecase.NewVerifyDeployment(fileRepository, verifier, networkResolver, deploymentResolver, spinnerProgressReporter)
+	verifyDeployment := usecase.NewVerifyDeployment(fileRepository, verifier, networkResolver, deploymentResolver, progressSink)
+	shellRunner := hooks.NewShellRunner(runtimeConfig)
+	composeHooks := usecase.NewComposeHooks(fileRepository, shellRunner, verifyDeployment, checkerAdapter, registryLock)
 	composeRenderer := render.NewComposeRenderer(writer)
 	composeProgress := progress.NewComposeProgress(composeRenderer, scriptRenderer)
-	composeDeployment := usecase.NewComposeDeployment(runScript, composeProgress)
-	syncRegistry := usecase.NewSyncRegistry(runtimeConfig, fileRepository, spinnerProgressReporter)
-	tagDeployment := usecase.NewTagDeployment(fileRepository, deploymentResolver, spinnerProgressReporter)
+	composeSink := adapters.ProvideComposeSink(composeProgress, eventStream)
+	composeDeployment := usecase.NewComposeDeployment(runtimeConfig, networkResolver, deploymentResolver, checkerAdapter, forkStateStoreAdapter, runScript, composeHooks, composeSink)
+	syncRegistry := usecase.NewSyncRegistry(runtimeConfig, fileRepository, progressSink)
+	tagDeployment := usecase.NewTagDeployment(fileRepository, deploymentResolver, progressSink)
 	registerDeployment := usecase.NewRegisterDeployment(runtimeConfig, fileRepository, checkerAdapter, repository)
-	manageAnvil := usecase.NewManageAnvil(manager, spinnerProgressReporter)
-	initProject := usecase.NewInitProject(fileWriterAdapter, spinnerProgressReporter)
+	manageAnvil := usecase.NewManageAnvil(manager, progressSink)
+	initProject := usecase.NewInitProject(fileWriterAdapter, progressSink)
+	listRuns := usecase.NewListRuns(runRecordStoreAdapter)
+	showRun := usecase.NewShowRun(runRecordStoreAdapter, fileRepository)
+	timelockAdapter := blockchain.NewTimelockAdapter()
+	transactionSigners := signer.NewTransactionSigners(runtimeConfig, sendersManager)
+	executeTimelockOperation := usecase.NewExecuteTimelockOperation(runtimeConfig, fileRepository, timelockAdapter, transactionSigners, sendersManager, forkStateStoreAdapter, progressSink)
+	accountInspectorAdapter := blockchain.NewAccountInspectorAdapter()
+	listAccounts := usecase.NewListAccounts(runtimeConfig, sendersManager, accountInspectorAdapter, runRecordStoreAdapter, forkStateStoreAdapter)
+	manageAddressBook := usecase.NewManageAddressBook(runtimeConfig, addressBookStoreAdapter)
+	describeScript := usecase.NewDescribeScript(runtimeConfig, scriptResolver, parameterResolver, sendersManager, sendersManager, deploymentResolver, fileRepository, runScript)
+	planCompose := usecase.NewPlanCompose(runtimeConfig, composeDeployment, describeScript, scriptResolver, runScript)
 	enterFork := usecase.NewEnterFork(runtimeConfig, forkStateStoreAdapter, forkFileManagerAdapter, manager, forgeAdapter)
 	exitFork := usecase.NewExitFork(runtimeConfig, forkStateStoreAdapter, forkFileManagerAdapter, manager)
 	revertFork := usecase.NewRevertFork(runtimeConfig, forkStateStoreAdapter, forkFileManagerAdapter, manager)
@@ -111,8 +141,11 @@ func InitApp(v *viper.Viper, cmd *cobra.Command) (*App, error) {
 	forkStatus := usecase.NewForkStatus(runtimeConfig, forkStateStoreAdapter, manager)
 	forkHistory := usecase.NewForkHistory(runtimeConfig, forkStateStoreAdapter)
 	diffFork := usecase.NewDiffFork(runtimeConfig, forkStateStoreAdapter)
+	exportFork := usecase.NewExportFork(forkStateStoreAdapter, forkFileManagerAdapter, manager)
+	importFork := usecase.NewImportFork(forkStateStoreAdapter, forkFileManagerAdapter, manager, enterFork, exitFork)
+	checkpointFork := usecase.NewCheckpointFork(forkStateStoreAdapter, forkFileManagerAdapter, manager)
 	renderer := render.NewGenerateRenderer()
-	app, err := NewApp(runtimeConfig, selectorAdapter, listDeployments, showDeployment, generateDeploymentScript, listNetworks, pruneRegistry, resetRegistry, showConfig, setConfig, removeConfig, runScript, verifyDeployment, composeDeployment, syncRegistry, tagDeployment, registerDeployment, manageAnvil, initProject, enterFork, exitFork, revertFork, restartFork, forkStatus, forkHistory, diffFork, manager, networkResolver, forkStateStoreAdapter, renderer, scriptRenderer, composeRenderer)
+	app, err := NewApp(runtimeConfig, selectorAdapter, listDeployments, showDeployment, generateDeploymentScript, listNetworks, pruneRegistry, resetRegistry, showConfig, setConfig, removeConfig, runScript, verifyDeployment, composeDeployment, syncRegistry, tagDeployment, registerDeployment, manageAnvil, initProject, listRuns, showRun, executeTimelockOperation, listAccounts, manageAddressBook, describeScript, planCompose, enterFork, exitFork, revertFork, restartFork, forkStatus, forkHistory, diffFork, exportFork, importFork, checkpointFork, manager, networkResolver, forkStateStoreAdapter, eventStream, renderer, scriptRenderer, composeRenderer)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/trebuchet-org/treb-cli/internal/cli/render"
+)
+
+// NewAccountsCmd creates the accounts command
+func NewAccountsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "accounts",
+		Short: "Show what each sender role resolves to",
+		Long: `Show the sender roles of a namespace with their account, type and address.
+
+With treb.toml v2 the roles are resolved by walking the namespace hierarchy
+(default → production → production.ntt), and each role shows the account it maps
+to and the namespace section that set it.
+
+When a network is selected the live balance and nonce of each account are shown,
+together with the owners and threshold of Safes and the timelock of Governors.
+Senders whose balance does not cover the gas of their last run at the current gas
+price are flagged.`,
+		Example: `  treb accounts
+  treb accounts --namespace production --network mainnet`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			result, err := app.ListAccounts.Run(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			renderer := render.NewAccountsRenderer(cmd.OutOrStdout(), true)
+			return renderer.RenderAccounts(result)
+		},
+	}
+
+	cmd.Flags().StringP("network", "n", "", "Network to read balances and nonces from (e.g., mainnet, sepolia, local)")
+	cmd.Flags().StringP("namespace", "s", "", "Namespace to use (defaults to current context namespace)")
+
+	return cmd
+}
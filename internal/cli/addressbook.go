@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/trebuchet-org/treb-cli/internal/cli/render"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// NewAddressBookCmd creates the addressbook command group with subcommands
+func NewAddressBookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "addressbook",
+		Aliases: []string{"ab"},
+		Short:   "Manage addresses of contracts deployed outside treb",
+		Long: `Manage named addresses of third-party contracts, such as tokens, routers,
+price feeds and partner contracts, per network.
+
+Entries are stored in .treb/addressbook.json. They can be passed to deployment
+parameters and @ references by name, label the contracts in decoded transactions,
+and decode calls to them with the ABI of a project artifact (--artifact) or a
+JSON ABI file (--abi).`,
+	}
+
+	cmd.AddCommand(newAddressBookAddCmd())
+	cmd.AddCommand(newAddressBookListCmd())
+	cmd.AddCommand(newAddressBookRemoveCmd())
+
+	return cmd
+}
+
+// newAddressBookAddCmd creates the addressbook add subcommand
+func newAddressBookAddCmd() *cobra.Command {
+	var (
+		artifact string
+		abiPath  string
+		force    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <name> <address>",
+		Short: "Add a contract address to the address book of a network",
+		Example: `  treb addressbook add USDC 0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48 --network mainnet --artifact IERC20
+  treb addressbook add EthUsdFeed 0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419 --network mainnet --abi abis/AggregatorV3.json`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			entry, err := app.ManageAddressBook.Add(cmd.Context(), usecase.AddAddressBookEntryParams{
+				Name:     args[0],
+				Address:  args[1],
+				Artifact: artifact,
+				ABI:      abiPath,
+				Force:    force,
+			})
+			if err != nil {
+				return err
+			}
+
+			color.New(color.FgGreen).Fprintf(cmd.OutOrStdout(), "✓ Added %s (%s) to the address book of %s\n",
+				entry.Name, entry.Address, app.Config.Network.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("network", "n", "", "Network the contract is deployed on (e.g., mainnet, sepolia, local)")
+	cmd.Flags().StringVar(&artifact, "artifact", "", "Project contract or interface whose ABI decodes calls to the contract")
+	cmd.Flags().StringVar(&abiPath, "abi", "", "JSON ABI file, relative to the project root, that decodes calls to the contract")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing entry with the same name")
+
+	return cmd
+}
+
+// newAddressBookListCmd creates the addressbook list subcommand
+func newAddressBookListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "list",
+		Aliases:      []string{"ls"},
+		Short:        "List the address book of a network, or of all networks",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			entries, err := app.ManageAddressBook.List(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			renderer := render.NewAddressBookRenderer(cmd.OutOrStdout())
+			return renderer.RenderEntries(entries, app.Config.Network)
+		},
+	}
+
+	cmd.Flags().StringP("network", "n", "", "Only list entries of this network (e.g., mainnet, sepolia, local)")
+
+	return cmd
+}
+
+// newAddressBookRemoveCmd creates the addressbook rm subcommand
+func newAddressBookRemoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "rm <name>",
+		Aliases:      []string{"remove"},
+		Short:        "Remove a contract from the address book of a network",
+		Example:      `  treb addressbook rm USDC --network mainnet`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			entry, err := app.ManageAddressBook.Remove(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed %s (%s) from the address book of %s\n",
+				entry.Name, entry.Address, app.Config.Network.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("network", "n", "", "Network the contract is deployed on (e.g., mainnet, sepolia, local)")
+
+	return cmd
+}
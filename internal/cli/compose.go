@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/trebuchet-org/treb-cli/internal/cli/render"
@@ -21,6 +22,10 @@ func NewComposeCmd() *cobra.Command {
 		verbose        bool
 		nonInteractive bool
 		resume         bool
+		yes            bool
+		jsonOutput     bool
+		parallel       int
+		vars           []string
 	)
 
 	cmd := &cobra.Command{
@@ -51,7 +56,93 @@ Example compose file (protocol.yaml):
       deps:
         - Reserve
 
-This will execute: Broker → Tokens → Reserve → SortedOracles`,
+This will execute: Broker → Tokens → Reserve → SortedOracles
+
+Components run on the --network of the compose run unless they set network, or networks to
+run once per network. Each network of a component becomes a step named <component>@<network>,
+which depends on the step of each dependency on the same network, or on all of its steps:
+  components:
+    Core:
+      script: DeployCore
+      network: mainnet
+    Adapters:
+      script: DeployAdapter
+      networks: [arbitrum, optimism, base]
+      deps: [Core]
+    WireL1:
+      script: WireAdapters
+      network: mainnet
+      deps: [Adapters]
+
+Components can list the deployments their script creates with expects (e.g. [Token:v1, Vault:v1]).
+Before running, each expected deployment is looked up in the registry and its code checked on
+chain, and the plan marks the component:
+  [skip]     every expected deployment exists, the component does not run
+  [run]      some expected deployments are missing
+  [drifted]  the registry has deployments without code on chain, the component runs again
+Skipped components expose the expected deployments to the templates of later components.
+
+Env values can use the outputs of the components they depend on, and the runtime context:
+  ${{ components.<name>.deployments.<Contract[:label]>.address }}  (or .id)
+  ${{ components.<name>.safeTxHash }}  ${{ components.<name>.timelockOperationId }}
+  ${{ components.<name>.proposalId }}  ${{ components.<name>.runId }}
+  ${{ namespace }}  ${{ network.name }}  ${{ network.chainId }}
+A component running on several networks is referenced as <component>@<network>, or by its
+name from a step on the same network; network.* is the network of the step.
+Outputs are saved with the compose state, so --resume resolves the same values.
+
+Components are executed level by level: a level holds the components whose dependencies
+have all completed. With --parallel N, up to N components of a level run at the same time.
+Each of them writes its broadcast files to broadcast/compose/<compose-file>/<component>,
+registry updates are applied one at a time, and components sharing a sender wait for each
+other so their nonces do not collide. When components fail, the rest of their level still
+completes and --resume re-runs every failed component.
+
+Compose files can be split and reused:
+  group: Protocol
+  vars:
+    OWNER: "0x0000000000000000000000000000000000000001"
+  include:
+    - file: core.yaml        # Path relative to this file
+      prefix: core           # Components are named core/<component>
+      vars:
+        ADMIN: ${{ vars.OWNER }}
+  components:
+    Vault:
+      script: DeployVault
+      deps: [core/Token]
+      env:
+        OWNER: ${{ vars.OWNER }}
+Variables are declared in vars and used as ${{ vars.<name> }} in script, network, networks,
+expects and env. An include sets the variables of the included file, and --var KEY=VALUE
+overrides a variable in every file declaring it. For the namespace of the run, each file is
+merged with its overlay next to it, e.g. protocol.production.yaml for protocol.yaml: the
+fields an overlay component sets replace those of the base component, env and vars are merged
+by key, and new components and includes are added. Errors point at the file and line.
+
+Hooks run before and after each component, and around the whole compose run when set at the
+top level. Each hook is a shell command, or a treb action on the deployments of the component:
+  hooks:                       # Compose run: pre before the first component, post after the last
+    post: ["./scripts/notify.sh"]
+  components:
+    Token:
+      script: DeployToken
+      hooks:
+        pre: ["./scripts/preflight.sh"]
+        post:
+          - action: verify     # Verify the deployments on block explorers
+          - action: check      # Check the deployments have code on chain
+          - action: tag v1.0.0 # Tag the deployments
+          - run: forge script script/Sanity.s.sol --rpc-url "$TREB_RPC_URL"
+        on_failure: ["./scripts/alert.sh"]
+Commands run from the project root with TREB_HOOK, TREB_COMPOSE_GROUP, TREB_COMPONENT,
+TREB_NETWORK, TREB_CHAIN_ID, TREB_RPC_URL, TREB_NAMESPACE, TREB_DRY_RUN, TREB_STATUS,
+TREB_ERROR and TREB_DEPLOYMENTS (deployment IDs) set, and receive the same context with the
+deployments and outputs as JSON on stdin. A failing pre or post hook fails the component and
+on_failure hooks then run; --resume only runs the post hooks again when the script completed.
+Actions do nothing in a dry run. Hook results are recorded in the compose state.
+
+Use treb compose plan <compose-file> to review the plan without running it.`,
 		Example: `  # Execute orchestration from YAML file
   treb compose deploy.yaml
 
@@ -59,7 +150,19 @@ This will execute: Broker → Tokens → Reserve → SortedOracles`,
   treb compose deploy.yaml --network sepolia --dry-run
 
   # Execute with debug output
-  treb compose deploy.yaml --debug --verbose`,
+  treb compose deploy.yaml --debug --verbose
+
+  # Execute up to 4 independent components at the same time
+  treb compose deploy.yaml --network sepolia --parallel 4
+
+  # Override a variable of the compose files
+  treb compose deploy.yaml --network sepolia --var OWNER=0x0000000000000000000000000000000000000002
+
+  # Review the plan as a Mermaid graph
+  treb compose plan deploy.yaml --network sepolia --format mermaid
+
+  # Emit a machine-readable result for CI pipelines
+  treb compose deploy.yaml --network sepolia --json`,
 		SilenceUsage: true,
 		Args:         cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -70,6 +173,11 @@ This will execute: Broker → Tokens → Reserve → SortedOracles`,
 
 			orchestrationFile := args[0]
 
+			composeVars, err := parseComposeVars(vars)
+			if err != nil {
+				return err
+			}
+
 			// Default network and namespace resolution
 			if network == "" {
 				network = os.Getenv("DEPLOYMENT_NETWORK")
@@ -95,10 +203,45 @@ This will execute: Broker → Tokens → Reserve → SortedOracles`,
 				Verbose:        verbose,
 				NonInteractive: true, // Orchestration should always be non-interactive
 				Resume:         resume,
+				Yes:            yes,
+				Parallel:       parallel,
+				Vars:           composeVars,
 			}
 
 			ctx := cmd.Context()
 
+			if jsonOutput {
+				out := cmd.OutOrStdout()
+
+				var result *usecase.ComposeResult
+				composeErr := withStdoutToStderr(func() error {
+					var err error
+					result, err = app.ComposeDeployment.Execute(ctx, params)
+					return err
+				})
+				if result == nil {
+					result = &usecase.ComposeResult{}
+				}
+
+				doc := app.ScriptRenderer.BuildComposeJSON(result)
+				// Compose files that cannot be loaded or resumed still produce a document carrying the error
+				if composeErr != nil {
+					doc.Success = false
+					doc.Status = "failed"
+					doc.Error = composeErr.Error()
+				}
+				if err := writeJSON(out, doc); err != nil {
+					return err
+				}
+				if composeErr != nil {
+					return composeErr
+				}
+				if !result.Success {
+					return fmt.Errorf("compose failed")
+				}
+				return nil
+			}
+
 			// Execute orchestration
 			result, err := app.ComposeDeployment.Execute(ctx, params)
 			if err != nil {
@@ -113,9 +256,15 @@ This will execute: Broker → Tokens → Reserve → SortedOracles`,
 
 			// Return error if compose failed
 			if !result.Success {
+				if len(result.FailedSteps) > 1 {
+					return fmt.Errorf("compose failed: %d steps failed", len(result.FailedSteps))
+				}
 				if result.FailedStep != nil && result.FailedStep.Error != nil {
 					return fmt.Errorf("compose failed: %w", result.FailedStep.Error)
 				}
+				if result.HookError != nil {
+					return fmt.Errorf("compose failed: %w", result.HookError)
+				}
 				return fmt.Errorf("compose failed")
 			}
 
@@ -134,7 +283,26 @@ This will execute: Broker → Tokens → Reserve → SortedOracles`,
 	cmd.Flags().BoolVar(&debugJSON, "debug-json", false, "Enable JSON debug mode (shows raw JSON output)")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show extra detailed information for events and transactions")
 	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "Disable interactive prompts (always non-interactive for orchestration)")
+	cmd.Flags().StringArrayVar(&vars, "var", nil, "Override a variable of the compose files (format: KEY=VALUE, can be used multiple times)")
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "Number of independent components to execute at the same time")
 	cmd.Flags().BoolVar(&resume, "resume", false, "Resume from a previous failed or interrupted compose run")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the result as a versioned JSON document (human output goes to stderr)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Approve the confirmation gate for namespaces that require it")
+
+	cmd.AddCommand(NewComposePlanCmd())
 
 	return cmd
 }
+
+// parseComposeVars parses --var KEY=VALUE flags
+func parseComposeVars(vars []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(vars))
+	for _, v := range vars {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid var format: %s (expected key=value)", v)
+		}
+		parsed[key] = value
+	}
+	return parsed, nil
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuchet-org/treb-cli/internal/cli/render"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// NewComposePlanCmd creates the compose plan command
+func NewComposePlanCmd() *cobra.Command {
+	var simulate bool
+	var format string
+	var vars []string
+
+	cmd := &cobra.Command{
+		Use:   "plan <compose-file>",
+		Short: "Validate a compose file and show its execution plan",
+		Long: `Validate a compose file, build its dependency graph and print the levels it executes in,
+without running anything.
+
+Each component is shown with its script, the parameters the script declares and the values
+the component sets, and the sender roles of the script resolved in the namespace. With
+--simulate the components are dry-run in order to list the contracts they create.
+
+Use --format mermaid to paste the graph into a pull request description, --format dot to
+render it with Graphviz, or --format json for tooling.`,
+		Example: `  treb compose plan deploy.yaml --network sepolia
+  treb compose plan deploy.yaml --network sepolia --simulate
+  treb compose plan deploy.yaml --format mermaid
+  treb compose plan deploy.yaml --format dot | dot -Tsvg > deploy.svg`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains([]string{"text", "dot", "mermaid", "json"}, format) {
+				return fmt.Errorf("unknown format %q (use text, dot, mermaid or json)", format)
+			}
+
+			composeVars, err := parseComposeVars(vars)
+			if err != nil {
+				return err
+			}
+
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			params := usecase.PlanComposeParams{ConfigPath: args[0], Simulate: simulate, Vars: composeVars}
+
+			// Simulations print progress and forge output, which must stay out of
+			// the graph written to stdout
+			var result *usecase.ComposePlanResult
+			plan := func() error {
+				result, err = app.PlanCompose.Run(cmd.Context(), params)
+				return err
+			}
+			if format != "text" {
+				err = withStdoutToStderr(plan)
+			} else {
+				err = plan()
+			}
+			if err != nil {
+				return err
+			}
+
+			renderer := render.NewComposePlanRenderer(cmd.OutOrStdout())
+			switch format {
+			case "dot":
+				return renderer.RenderDot(result)
+			case "mermaid":
+				return renderer.RenderMermaid(result)
+			case "json":
+				return writeJSON(cmd.OutOrStdout(), render.BuildComposePlanJSON(result))
+			}
+			return renderer.RenderText(result)
+		},
+	}
+
+	cmd.Flags().BoolVar(&simulate, "simulate", false, "Dry-run the components to list the contracts they create")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, dot, mermaid, json)")
+	cmd.Flags().StringArrayVar(&vars, "var", nil, "Override a variable of the compose files (format: KEY=VALUE, can be used multiple times)")
+	cmd.Flags().StringP("network", "n", "", "Network to plan against (e.g., mainnet, sepolia, local)")
+	cmd.Flags().StringP("namespace", "s", "", "Namespace to use (defaults to current context namespace)")
+
+	return cmd
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/trebuchet-org/treb-cli/internal/cli/render"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// NewDescribeCmd creates the describe command
+func NewDescribeCmd() *cobra.Command {
+	var simulate bool
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "describe [script]",
+		Short: "Show what a script needs and what it deploys",
+		Long: `Show the parameters a script declares with @custom:env, the sender roles of its
+@custom:senders tag and what they resolve to in the namespace, and the deployments it
+depends on through lookup("...") calls and deployment parameters.
+
+With --simulate the script is dry-run on the selected network to list the contracts
+it creates.
+
+Without a script, every script under script/ is described. Use --format markdown
+to generate a deployments handbook.`,
+		Example: `  treb describe DeployCounter
+  treb describe script/deploy/DeployToken.s.sol --network sepolia --simulate
+  treb describe --format markdown > DEPLOYMENTS.md`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "markdown" {
+				return fmt.Errorf("unknown format %q (use text or markdown)", format)
+			}
+
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			params := usecase.DescribeScriptParams{Simulate: simulate}
+			if len(args) > 0 {
+				params.ScriptRef = args[0]
+			}
+
+			// Simulations print progress and forge output, which must stay out of
+			// the handbook written to stdout
+			var result *usecase.DescribeScriptResult
+			describe := func() error {
+				result, err = app.DescribeScript.Run(cmd.Context(), params)
+				return err
+			}
+			if format == "markdown" {
+				err = withStdoutToStderr(describe)
+			} else {
+				err = describe()
+			}
+			if err != nil {
+				return err
+			}
+
+			renderer := render.NewDescribeRenderer(cmd.OutOrStdout())
+			if format == "markdown" {
+				return renderer.RenderMarkdown(result)
+			}
+			return renderer.RenderText(result)
+		},
+	}
+
+	cmd.Flags().BoolVar(&simulate, "simulate", false, "Dry-run the script to list the contracts it creates")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, markdown)")
+	cmd.Flags().StringP("network", "n", "", "Network to resolve references on and simulate against (e.g., mainnet, sepolia, local)")
+	cmd.Flags().StringP("namespace", "s", "", "Namespace to use (defaults to current context namespace)")
+
+	return cmd
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/trebuchet-org/treb-cli/internal/adapters/progress"
+)
+
+// withLifecycleEvents wraps the command so that started/completed/failed events are
+// written to the --events stream around its execution
+func withLifecycleEvents(cmd *cobra.Command) *cobra.Command {
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		app, err := getApp(cmd)
+		if err != nil || app.Events == nil {
+			return runE(cmd, args)
+		}
+
+		app.Events.Lifecycle(progress.LifecycleStarted, nil)
+		if err := runE(cmd, args); err != nil {
+			app.Events.Lifecycle(progress.LifecycleFailed, err)
+			return err
+		}
+		app.Events.Lifecycle(progress.LifecycleCompleted, nil)
+		return nil
+	}
+	return cmd
+}
+
+// CloseEvents closes the --events stream of the executed command, if it opened one
+func CloseEvents(cmd *cobra.Command) {
+	if cmd == nil || cmd.Context() == nil {
+		return
+	}
+	app, err := getApp(cmd)
+	if err != nil || app.Events == nil {
+		return
+	}
+	_ = app.Events.Close()
+}
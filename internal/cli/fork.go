@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/trebuchet-org/treb-cli/internal/app"
 	"github.com/trebuchet-org/treb-cli/internal/cli/render"
 	cfg "github.com/trebuchet-org/treb-cli/internal/config"
 	"github.com/trebuchet-org/treb-cli/internal/usecase"
@@ -22,10 +23,13 @@ func NewForkCmd() *cobra.Command {
 	cmd.AddCommand(newForkEnterCmd())
 	cmd.AddCommand(newForkExitCmd())
 	cmd.AddCommand(newForkRevertCmd())
+	cmd.AddCommand(newForkCheckpointCmd())
 	cmd.AddCommand(newForkRestartCmd())
 	cmd.AddCommand(newForkStatusCmd())
 	cmd.AddCommand(newForkHistoryCmd())
 	cmd.AddCommand(newForkDiffCmd())
+	cmd.AddCommand(newForkExportCmd())
+	cmd.AddCommand(newForkImportCmd())
 
 	return cmd
 }
@@ -39,12 +43,22 @@ func newForkEnterCmd() *cobra.Command {
 and prepare the environment for fork-mode testing.
 
 The network's RPC endpoint in foundry.toml must use an environment variable
-(e.g., ${SEPOLIA_RPC_URL}) so that treb can override it for the fork.`,
+(e.g., ${SEPOLIA_RPC_URL}) so that treb can override it for the fork.
+
+By default the fork starts from the latest block. Use --block (or block in the
+[fork] section of treb.toml) to pin it to a block number or tag such as
+finalized, so that fork sessions can be reproduced. 'treb fork restart' forks
+a pinned fork from the same block again.`,
+		Example: `  treb fork enter sepolia
+  treb fork enter mainnet --block 21000000
+  treb fork enter mainnet --block finalized`,
 		Args:         cobra.ExactArgs(1),
 		SilenceUsage: true,
 		RunE:         runForkEnter,
 	}
 
+	cmd.Flags().String("block", "", "Block number or tag (latest, safe, finalized) to pin the fork to")
+
 	return cmd
 }
 
@@ -54,14 +68,32 @@ func runForkEnter(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	params, err := forkNetworkParams(cmd, app, args[0])
+	if err != nil {
+		return err
+	}
+	params.Block, _ = cmd.Flags().GetString("block")
+
+	result, err := app.EnterFork.Execute(cmd.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	// Render result
+	renderer := render.NewForkRenderer()
+	return renderer.RenderEnter(result)
+}
+
+// forkNetworkParams resolves the RPC URL, chain ID and RPC env var of a network to fork,
+// offering to migrate a hardcoded RPC endpoint in foundry.toml to an env var
+func forkNetworkParams(cmd *cobra.Command, app *app.App, network string) (usecase.EnterForkParams, error) {
 	ctx := cmd.Context()
-	network := args[0]
 	projectRoot := app.Config.ProjectRoot
 
 	// Check raw RPC endpoint format (before env var expansion)
 	rawValue, err := cfg.LoadRawRPCEndpoint(projectRoot, network)
 	if err != nil {
-		return fmt.Errorf("failed to read RPC endpoint for '%s': %w", network, err)
+		return usecase.EnterForkParams{}, fmt.Errorf("failed to read RPC endpoint for '%s': %w", network, err)
 	}
 
 	envVarName, isEnvVar := cfg.DetectEnvVar(rawValue)
@@ -73,7 +105,7 @@ func runForkEnter(cmd *cobra.Command, args []string) error {
 			// Auto-migrate in non-interactive mode
 			fmt.Fprintf(os.Stderr, "Migrating hardcoded RPC endpoint for '%s' to environment variable...\n", network)
 			if err := cfg.MigrateRPCEndpoint(projectRoot, network, rawValue); err != nil {
-				return fmt.Errorf("failed to migrate RPC endpoint: %w", err)
+				return usecase.EnterForkParams{}, fmt.Errorf("failed to migrate RPC endpoint: %w", err)
 			}
 			envVarName = cfg.GenerateEnvVarName(network)
 			fmt.Fprintf(os.Stderr, "Migrated: foundry.toml now uses ${%s}, value appended to .env\n", envVarName)
@@ -88,11 +120,11 @@ func runForkEnter(cmd *cobra.Command, args []string) error {
 
 			var answer string
 			if _, err := fmt.Scanln(&answer); err != nil || (answer != "y" && answer != "Y") {
-				return fmt.Errorf("fork mode requires environment variable RPC endpoints. Aborting")
+				return usecase.EnterForkParams{}, fmt.Errorf("fork mode requires environment variable RPC endpoints. Aborting")
 			}
 
 			if err := cfg.MigrateRPCEndpoint(projectRoot, network, rawValue); err != nil {
-				return fmt.Errorf("failed to migrate RPC endpoint: %w", err)
+				return usecase.EnterForkParams{}, fmt.Errorf("failed to migrate RPC endpoint: %w", err)
 			}
 			envVarName = cfg.GenerateEnvVarName(network)
 			fmt.Fprintf(os.Stderr, "Migrated successfully.\n\n")
@@ -102,25 +134,103 @@ func runForkEnter(cmd *cobra.Command, args []string) error {
 	// Resolve network to get RPC URL and chain ID
 	resolvedNetwork, err := app.NetworkResolver.ResolveNetwork(ctx, network)
 	if err != nil {
-		return fmt.Errorf("failed to resolve network '%s': %w", network, err)
+		return usecase.EnterForkParams{}, fmt.Errorf("failed to resolve network '%s': %w", network, err)
 	}
 
-	// Execute the use case
-	params := usecase.EnterForkParams{
+	return usecase.EnterForkParams{
 		Network:    network,
 		RPCURL:     resolvedNetwork.RPCURL,
 		ChainID:    resolvedNetwork.ChainID,
 		EnvVarName: envVarName,
+	}, nil
+}
+
+// newForkExportCmd creates the fork export subcommand
+func newForkExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <network> <file>",
+		Short: "Export the state of a fork to a file",
+		Long: `Capture the EVM state of a fork (anvil_dumpState) together with the registry
+files in .treb to a file, so that someone else can continue from the same state
+with 'treb fork import' instead of replaying every script.`,
+		Example:      `  treb fork export sepolia sepolia-fork.json`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE:         runForkExport,
+	}
+
+	return cmd
+}
+
+func runForkExport(cmd *cobra.Command, args []string) error {
+	app, err := getApp(cmd)
+	if err != nil {
+		return err
+	}
+
+	params := usecase.ExportForkParams{
+		Network: args[0],
+		File:    args[1],
 	}
 
-	result, err := app.EnterFork.Execute(ctx, params)
+	result, err := app.ExportFork.Execute(cmd.Context(), params)
 	if err != nil {
 		return err
 	}
 
-	// Render result
 	renderer := render.NewForkRenderer()
-	return renderer.RenderEnter(result)
+	return renderer.RenderExport(result)
+}
+
+// newForkImportCmd creates the fork import subcommand
+func newForkImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Start a fork from a file written by 'treb fork export'",
+		Long: `Enter fork mode for the network of an export file, at the block the exported
+fork was created from, then load its EVM state and registry files.
+
+The SetupFork script is not run, the exported state already contains it. The import
+is recorded in the fork history: 'treb fork revert' returns to the fresh fork and
+'treb fork exit' restores the registry as it was before the import.`,
+		Example:      `  treb fork import sepolia-fork.json`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE:         runForkImport,
+	}
+
+	return cmd
+}
+
+func runForkImport(cmd *cobra.Command, args []string) error {
+	app, err := getApp(cmd)
+	if err != nil {
+		return err
+	}
+
+	export, err := usecase.LoadForkExport(args[0])
+	if err != nil {
+		return err
+	}
+
+	fork, err := forkNetworkParams(cmd, app, export.Network)
+	if err != nil {
+		return err
+	}
+
+	params := usecase.ImportForkParams{
+		File:   args[0],
+		Export: export,
+		Fork:   fork,
+	}
+
+	result, err := app.ImportFork.Execute(cmd.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	renderer := render.NewForkRenderer()
+	return renderer.RenderImport(result)
 }
 
 // newForkExitCmd creates the fork exit subcommand
@@ -184,14 +294,20 @@ func newForkRevertCmd() *cobra.Command {
 		Long: `Undo the last treb run by restoring EVM state and registry files from the
 most recent snapshot.
 
-Use --all to revert all runs and restore to the initial fork state.
+Use --all to revert all runs and restore to the initial fork state, or --to to
+revert to a checkpoint created with 'treb fork checkpoint'. The checkpoint is kept,
+so you can revert to it again after trying something else.
 If no network is specified, uses the currently configured network.`,
+		Example: `  treb fork revert
+  treb fork revert --all
+  treb fork revert --to after-core`,
 		Args:         cobra.MaximumNArgs(1),
 		SilenceUsage: true,
 		RunE:         runForkRevert,
 	}
 
 	cmd.Flags().Bool("all", false, "Revert all runs to initial fork state")
+	cmd.Flags().String("to", "", "Revert to a named checkpoint")
 
 	return cmd
 }
@@ -204,6 +320,7 @@ func runForkRevert(cmd *cobra.Command, args []string) error {
 
 	ctx := cmd.Context()
 	allFlag, _ := cmd.Flags().GetBool("all")
+	to, _ := cmd.Flags().GetString("to")
 
 	network := ""
 	if len(args) > 0 {
@@ -218,6 +335,7 @@ func runForkRevert(cmd *cobra.Command, args []string) error {
 	params := usecase.RevertForkParams{
 		Network: network,
 		All:     allFlag,
+		To:      to,
 	}
 
 	result, err := app.RevertFork.Execute(ctx, params)
@@ -229,6 +347,53 @@ func runForkRevert(cmd *cobra.Command, args []string) error {
 	return renderer.RenderRevert(result)
 }
 
+// newForkCheckpointCmd creates the fork checkpoint subcommand
+func newForkCheckpointCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "checkpoint <name> [network]",
+		Short: "Create a named checkpoint on a fork",
+		Long: `Snapshot the EVM state and registry files of a fork under a name, to return to
+it later with 'treb fork revert --to <name>'. Checkpoints are listed in 'treb fork history'.
+
+If no network is specified, uses the currently configured network.`,
+		Example: `  treb fork checkpoint after-core
+  treb fork revert --to after-core`,
+		Args:         cobra.RangeArgs(1, 2),
+		SilenceUsage: true,
+		RunE:         runForkCheckpoint,
+	}
+
+	return cmd
+}
+
+func runForkCheckpoint(cmd *cobra.Command, args []string) error {
+	app, err := getApp(cmd)
+	if err != nil {
+		return err
+	}
+
+	network := ""
+	if len(args) > 1 {
+		network = args[1]
+	} else if app.Config.Network != nil {
+		// Use current configured network
+		network = app.Config.Network.Name
+	}
+
+	params := usecase.CheckpointForkParams{
+		Network: network,
+		Name:    args[0],
+	}
+
+	result, err := app.CheckpointFork.Execute(cmd.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	renderer := render.NewForkRenderer()
+	return renderer.RenderCheckpoint(result)
+}
+
 // newForkRestartCmd creates the fork restart subcommand
 func newForkRestartCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -237,7 +402,7 @@ func newForkRestartCmd() *cobra.Command {
 		Long: `Restart a fork whose Anvil process has crashed. This will:
 1. Stop the dead process (if needed)
 2. Restore registry files to the initial fork state
-3. Start a fresh fork from the original RPC
+3. Start a fresh fork from the original RPC (at the same block if the fork is pinned)
 4. Re-run SetupFork script (if configured)
 5. Take a new initial snapshot
 
@@ -287,7 +452,8 @@ func newForkStatusCmd() *cobra.Command {
 		Use:   "status",
 		Short: "Show status of all active forks",
 		Long: `Show the state of all active forks including network, chain ID, fork URL,
-anvil health, uptime, snapshot count, and fork-added deployment count.`,
+fork block and its drift from the live network head, anvil health, uptime,
+snapshot count, and fork-added deployment count.`,
 		Args:         cobra.NoArgs,
 		SilenceUsage: true,
 		RunE:         runForkStatus,
@@ -318,7 +484,8 @@ func newForkHistoryCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "history [network]",
 		Short: "Show command history for a fork",
-		Long: `Show chronological list of commands run against a fork and their snapshot points.
+		Long: `Show chronological list of commands run against a fork, their snapshot points and
+the checkpoints created with 'treb fork checkpoint'.
 
 If no network is specified, uses the currently configured network.`,
 		Args:         cobra.MaximumNArgs(1),
@@ -3,11 +3,13 @@ package interactive
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
 	"github.com/sahilm/fuzzy"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
 	"github.com/trebuchet-org/treb-cli/internal/domain/config"
 	"github.com/trebuchet-org/treb-cli/internal/domain/models"
 	"github.com/trebuchet-org/treb-cli/internal/usecase"
@@ -179,6 +181,90 @@ func formatDeploymentOptions(deployments []*models.Deployment) []string {
 	return options
 }
 
+// Confirm asks a yes/no question, defaulting to no
+func (s *SelectorAdapter) Confirm(ctx context.Context, prompt string) (bool, error) {
+	// In non-interactive mode, we can't ask
+	if s.config.NonInteractive {
+		return false, fmt.Errorf("interactive confirmation not available in non-interactive mode")
+	}
+
+	confirm := promptui.Prompt{
+		Label:     prompt,
+		IsConfirm: true,
+	}
+
+	if _, err := confirm.Run(); err != nil {
+		if err == promptui.ErrAbort {
+			return false, nil
+		}
+		return false, fmt.Errorf("confirmation cancelled: %w", err)
+	}
+
+	return true, nil
+}
+
+// PromptForParameters asks for a value for each parameter. Parameters declared with
+// enum choices are offered as a picker, secrets are masked.
+func (s *SelectorAdapter) PromptForParameters(ctx context.Context, params []domain.ScriptParameter, existing map[string]string) (map[string]string, error) {
+	// In non-interactive mode, we can't ask
+	if s.config.NonInteractive {
+		return nil, fmt.Errorf("interactive parameter prompts not available in non-interactive mode")
+	}
+
+	values := make(map[string]string, len(params))
+	for _, param := range params {
+		label := fmt.Sprintf("%s (%s)", param.Name, param.Type)
+		if param.Description != "" {
+			label = fmt.Sprintf("%s - %s", label, param.Description)
+		}
+
+		if len(param.Constraints.Enum) > 0 {
+			cursor := max(slices.Index(param.Constraints.Enum, existing[param.Name]), 0)
+			selectPrompt := promptui.Select{
+				Label:     label,
+				Items:     param.Constraints.Enum,
+				CursorPos: cursor,
+				Size:      10,
+				Templates: &promptui.SelectTemplates{
+					Label:    "{{ . }}",
+					Active:   "▸ {{ . | cyan }}",
+					Inactive: "  {{ . | faint }}",
+					Selected: "✓ {{ . | green }}",
+				},
+			}
+			_, choice, err := selectPrompt.Run()
+			if err != nil {
+				return nil, fmt.Errorf("selection cancelled: %w", err)
+			}
+			values[param.Name] = choice
+			continue
+		}
+
+		prompt := promptui.Prompt{
+			Label:   label,
+			Default: existing[param.Name],
+			Validate: func(input string) error {
+				if strings.TrimSpace(input) == "" && !param.Optional {
+					return fmt.Errorf("%s is required", param.Name)
+				}
+				return nil
+			},
+		}
+		if param.Type == domain.ParamTypeSecret {
+			prompt.Mask = '*'
+		}
+		value, err := prompt.Run()
+		if err != nil {
+			return nil, fmt.Errorf("prompt cancelled: %w", err)
+		}
+		values[param.Name] = strings.TrimSpace(value)
+	}
+
+	return values, nil
+}
+
 // Ensure the adapter implements the interfaces
 var _ usecase.ContractSelector = (*SelectorAdapter)(nil)
 var _ usecase.DeploymentSelector = (*SelectorAdapter)(nil)
+var _ usecase.Confirmer = (*SelectorAdapter)(nil)
+var _ usecase.ParameterPrompter = (*SelectorAdapter)(nil)
@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+)
+
+// withStdoutToStderr runs fn with stdout pointed at stderr, so that progress output, forge logs
+// and transaction traces cannot end up inside a JSON document written to the real stdout.
+func withStdoutToStderr(fn func() error) error {
+	stdout, colorOutput := os.Stdout, color.Output
+	os.Stdout, color.Output = os.Stderr, os.Stderr
+	defer func() {
+		os.Stdout, color.Output = stdout, colorOutput
+	}()
+
+	return fn()
+}
+
+// writeJSON writes v as an indented JSON document
+func writeJSON(out io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -264,6 +265,13 @@ func writeAccountConfig(b *strings.Builder, a domainconfig.AccountConfig) {
 	if a.Signer != "" {
 		fmt.Fprintf(b, "signer = %q\n", a.Signer)
 	}
+	if len(a.Confirmers) > 0 {
+		quoted := make([]string, len(a.Confirmers))
+		for i, confirmer := range a.Confirmers {
+			quoted[i] = fmt.Sprintf("%q", confirmer)
+		}
+		fmt.Fprintf(b, "confirmers = [%s]\n", strings.Join(quoted, ", "))
+	}
 	if a.DerivationPath != "" {
 		fmt.Fprintf(b, "derivation_path = %q\n", a.DerivationPath)
 	}
@@ -276,6 +284,21 @@ func writeAccountConfig(b *strings.Builder, a domainconfig.AccountConfig) {
 	if a.Proposer != "" {
 		fmt.Fprintf(b, "proposer = %q\n", a.Proposer)
 	}
+	if a.Keystore != "" {
+		fmt.Fprintf(b, "keystore = %q\n", a.Keystore)
+	}
+	if a.PasswordEnv != "" {
+		fmt.Fprintf(b, "password_env = %q\n", a.PasswordEnv)
+	}
+	if a.PasswordFile != "" {
+		fmt.Fprintf(b, "password_file = %q\n", a.PasswordFile)
+	}
+	if a.URL != "" {
+		fmt.Fprintf(b, "url = %q\n", a.URL)
+	}
+	if a.AuthHeader != "" {
+		fmt.Fprintf(b, "auth_header = %q\n", a.AuthHeader)
+	}
 }
 
 // tomlKey quotes a key if it contains dots to prevent TOML nested table interpretation.
@@ -435,6 +458,10 @@ func formatAccountSummary(acct domainconfig.AccountConfig) string {
 		return fmt.Sprintf("trezor (path: %s)", acct.DerivationPath)
 	case domainconfig.SenderTypeOZGovernor:
 		return fmt.Sprintf("oz_governor (%s)", acct.Governor)
+	case domainconfig.SenderTypeKeystore:
+		return fmt.Sprintf("keystore (%s)", acct.Keystore)
+	case domainconfig.SenderTypeRemoteSigner:
+		return fmt.Sprintf("remote_signer (%s)", acct.Address)
 	default:
 		return string(acct.Type)
 	}
@@ -557,7 +584,7 @@ func interactiveAccountNaming(
 		renamed[renames[oldName]] = acct
 	}
 
-	// Update signer/proposer cross-references in accounts
+	// Update signer/confirmer/proposer cross-references in accounts
 	for name, acct := range renamed {
 		updated := false
 		if acct.Signer != "" {
@@ -566,6 +593,14 @@ func interactiveAccountNaming(
 				updated = true
 			}
 		}
+		if len(acct.Confirmers) > 0 {
+			confirmers := make([]string, len(acct.Confirmers))
+			for i, confirmer := range acct.Confirmers {
+				confirmers[i] = cmp.Or(renames[confirmer], confirmer)
+			}
+			acct.Confirmers = confirmers
+			updated = true
+		}
 		if acct.Proposer != "" {
 			if newName, ok := renames[acct.Proposer]; ok {
 				acct.Proposer = newName
@@ -0,0 +1,126 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// AccountsRenderer renders the resolved sender accounts of a namespace
+type AccountsRenderer struct {
+	out   io.Writer
+	color bool
+}
+
+// NewAccountsRenderer creates a new accounts renderer
+func NewAccountsRenderer(out io.Writer, color bool) *AccountsRenderer {
+	return &AccountsRenderer{
+		out:   out,
+		color: color,
+	}
+}
+
+// RenderAccounts renders one block per sender role
+func (r *AccountsRenderer) RenderAccounts(result *usecase.ListAccountsResult) error {
+	if len(result.Accounts) == 0 {
+		fmt.Fprintf(r.out, "No senders configured for namespace %s\n", result.Namespace)
+		return nil
+	}
+
+	title := fmt.Sprintf("👤 Accounts in namespace %s", result.Namespace)
+	if result.Network != nil {
+		title += fmt.Sprintf(" on %s (%d)", result.Network.Name, result.Network.ChainID)
+	}
+	fmt.Fprintf(r.out, "%s:\n", title)
+
+	insufficient := 0
+	for _, account := range result.Accounts {
+		fmt.Fprintln(r.out)
+		r.renderAccount(account)
+		if account.InsufficientFunds {
+			insufficient++
+		}
+	}
+
+	fmt.Fprintln(r.out)
+	if result.Network == nil {
+		fmt.Fprintln(r.out, "Select a network with --network to show balances, nonces and Safe owners.")
+	} else if insufficient > 0 {
+		color.New(color.FgYellow).Fprintf(r.out, "⚠️  %d sender(s) cannot afford the gas of their last run at the current gas price\n", insufficient)
+	}
+	return nil
+}
+
+func (r *AccountsRenderer) renderAccount(account *usecase.AccountInfo) {
+	header := color.New(color.FgCyan, color.Bold).Sprint(account.Role)
+	if account.Account != "" {
+		header += fmt.Sprintf(" → %s", color.New(color.FgYellow).Sprint(account.Account))
+	}
+	if account.DefinedIn != "" {
+		header += color.New(color.Faint).Sprintf(" (set in %s)", account.DefinedIn)
+	}
+	fmt.Fprintf(r.out, "  %s\n", header)
+
+	fmt.Fprintf(r.out, "    Type:      %s\n", account.Type)
+	if account.AddressError != "" {
+		fmt.Fprintf(r.out, "    Address:   %s\n", color.New(color.FgRed).Sprint(account.AddressError))
+	} else {
+		fmt.Fprintf(r.out, "    Address:   %s\n", account.Address.Hex())
+	}
+
+	switch account.Type {
+	case config.SenderTypeSafe:
+		fmt.Fprintf(r.out, "    Signer:    %s\n", account.Signer)
+		if len(account.Confirmers) > 0 {
+			fmt.Fprintf(r.out, "    Confirms:  %s\n", strings.Join(account.Confirmers, ", "))
+		}
+		if account.Safe != nil {
+			fmt.Fprintf(r.out, "    Threshold: %d of %d\n", account.Safe.Threshold, len(account.Safe.Owners))
+			for i, owner := range account.Safe.Owners {
+				label := "Owners:"
+				if i > 0 {
+					label = ""
+				}
+				fmt.Fprintf(r.out, "    %-10s %s\n", label, owner.Hex())
+			}
+		}
+	case config.SenderTypeOZGovernor:
+		fmt.Fprintf(r.out, "    Governor:  %s\n", account.Governor.Hex())
+		if account.Timelock != (common.Address{}) {
+			fmt.Fprintf(r.out, "    Timelock:  %s\n", account.Timelock.Hex())
+		} else if account.State != nil {
+			fmt.Fprintln(r.out, "    Timelock:  none")
+		}
+		fmt.Fprintf(r.out, "    Proposer:  %s\n", account.Signer)
+	}
+
+	if account.State != nil {
+		fmt.Fprintf(r.out, "    Balance:   %s\n", formatEther(account.State.Balance))
+		fmt.Fprintf(r.out, "    Nonce:     %d\n", account.State.Nonce)
+	}
+	if account.LastRun != nil {
+		line := fmt.Sprintf("%s used %d gas", account.LastRun.RunID, account.LastRun.GasUsed)
+		if account.LastRun.GasCost != nil {
+			line += fmt.Sprintf(" (~%s at the current gas price)", formatEther(account.LastRun.GasCost))
+		}
+		fmt.Fprintf(r.out, "    Last run:  %s\n", line)
+	}
+	if account.InsufficientFunds {
+		color.New(color.FgYellow).Fprintln(r.out, "    ⚠️  Balance does not cover the gas of the last run")
+	}
+	if account.Error != "" {
+		fmt.Fprintf(r.out, "    Error:     %s\n", color.New(color.FgRed).Sprint(account.Error))
+	}
+}
+
+// formatEther formats a wei amount in ETH
+func formatEther(wei *big.Int) string {
+	ether := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e18))
+	return fmt.Sprintf("%s ETH", ether.Text('f', 6))
+}
@@ -0,0 +1,62 @@
+package render
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// AddressBookRenderer renders address book entries
+type AddressBookRenderer struct {
+	out io.Writer
+}
+
+// NewAddressBookRenderer creates a new address book renderer
+func NewAddressBookRenderer(out io.Writer) *AddressBookRenderer {
+	return &AddressBookRenderer{out: out}
+}
+
+// RenderEntries renders the entries grouped by chain
+func (r *AddressBookRenderer) RenderEntries(entries []*models.AddressBookEntry, network *config.Network) error {
+	if len(entries) == 0 {
+		if network != nil {
+			fmt.Fprintf(r.out, "No address book entries on %s\n", network.Name)
+		} else {
+			fmt.Fprintln(r.out, "No address book entries")
+		}
+		return nil
+	}
+
+	nameWidth := 0
+	for _, entry := range entries {
+		nameWidth = max(nameWidth, len(entry.Name))
+	}
+
+	var chainID uint64
+	for i, entry := range entries {
+		if i == 0 || entry.ChainID != chainID {
+			if i > 0 {
+				fmt.Fprintln(r.out)
+			}
+			chainID = entry.ChainID
+			title := fmt.Sprintf("Chain %d", chainID)
+			if network != nil && network.ChainID == chainID {
+				title = fmt.Sprintf("%s (%d)", network.Name, chainID)
+			}
+			fmt.Fprintf(r.out, "📒 %s\n", color.New(color.Bold).Sprint(title))
+		}
+
+		line := fmt.Sprintf("  %s  %s", color.New(color.FgCyan).Sprintf("%-*s", nameWidth, entry.Name), entry.Address)
+		switch {
+		case entry.Artifact != "":
+			line += color.New(color.Faint).Sprintf("  abi: %s", entry.Artifact)
+		case entry.ABI != "":
+			line += color.New(color.Faint).Sprintf("  abi: %s", entry.ABI)
+		}
+		fmt.Fprintln(r.out, line)
+	}
+	return nil
+}
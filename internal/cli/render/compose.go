@@ -1,8 +1,10 @@
 package render
 
 import (
+	"cmp"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 
 	"github.com/fatih/color"
@@ -35,8 +37,19 @@ func (r *ComposeRenderer) RenderComposeResult(result *usecase.ComposeResult) err
 
 // RenderExecutionPlan displays the execution plan
 func (r *ComposeRenderer) RenderExecutionPlan(plan *usecase.ExecutionPlan) {
+	networks := plan.Networks()
+	multiNetwork := len(networks) > 1
+	checked := slices.ContainsFunc(plan.Components, func(step *usecase.ExecutionStep) bool { return step.Check != nil })
+
 	fmt.Fprintf(r.out, "\n🎯 Orchestrating %s\n", plan.Group)
-	fmt.Fprintf(r.out, "📋 Execution plan: %d components\n\n", len(plan.Components))
+	if multiNetwork {
+		fmt.Fprintf(r.out, "📋 Execution plan: %d components on %d networks\n\n", len(plan.Components), len(networks))
+	} else {
+		fmt.Fprintf(r.out, "📋 Execution plan: %d components\n\n", len(plan.Components))
+	}
+	if checked {
+		r.renderActionCounts(plan)
+	}
 
 	color.New(color.Bold).Fprintf(r.out, "📋 Execution Plan:\n")
 	fmt.Fprintf(r.out, "%s\n", strings.Repeat("─", 50))
@@ -50,11 +63,21 @@ func (r *ComposeRenderer) RenderExecutionPlan(plan *usecase.ExecutionPlan) {
 		fmt.Fprintf(r.out, " → ")
 		color.New(color.FgGreen).Fprintf(r.out, "%s", step.Script)
 
+		// Show the network when the plan spans several
+		if multiNetwork {
+			color.New(color.FgMagenta).Fprintf(r.out, " @ %s", step.Network)
+		}
+
 		// Show dependencies if any
 		if len(step.Dependencies) > 0 {
 			color.New(color.FgHiBlack).Fprintf(r.out, " (depends on: %v)", step.Dependencies)
 		}
 
+		// Show what happens to the step when expected deployments were checked
+		if checked {
+			r.renderStepCheck(step)
+		}
+
 		// Show environment variables if any
 		if len(step.Env) > 0 {
 			fmt.Fprintln(r.out)
@@ -64,12 +87,75 @@ func (r *ComposeRenderer) RenderExecutionPlan(plan *usecase.ExecutionPlan) {
 		fmt.Fprintln(r.out)
 	}
 
+	if multiNetwork {
+		r.renderNetworks(plan, networks)
+	}
+
+	fmt.Fprintln(r.out)
+}
+
+// renderActionCounts displays how many steps run, skip or drifted
+func (r *ComposeRenderer) renderActionCounts(plan *usecase.ExecutionPlan) {
+	counts := make(map[string]int)
+	for _, step := range plan.Components {
+		counts[step.Action()]++
+	}
+	fmt.Fprintf(r.out, "📝 Plan: %s to run, %s to skip, %s drifted\n\n",
+		color.New(color.FgGreen).Sprint(counts[usecase.StepActionRun]),
+		color.New(color.FgHiBlack).Sprint(counts[usecase.StepActionSkip]),
+		color.New(color.FgYellow).Sprint(counts[usecase.StepActionDrifted]))
+}
+
+// renderStepCheck displays the action of a step and the expected deployments behind it
+func (r *ComposeRenderer) renderStepCheck(step *usecase.ExecutionStep) {
+	switch step.Action() {
+	case usecase.StepActionSkip:
+		color.New(color.FgHiBlack).Fprint(r.out, " [skip]")
+	case usecase.StepActionDrifted:
+		color.New(color.FgYellow).Fprint(r.out, " [drifted]")
+	default:
+		color.New(color.FgGreen).Fprint(r.out, " [run]")
+	}
+	check := step.Check
+	if check == nil {
+		return
+	}
+
+	for _, missing := range check.Missing {
+		fmt.Fprintln(r.out)
+		color.New(color.FgGreen).Fprintf(r.out, "   + %s", missing)
+	}
+	for _, drifted := range check.Drifted {
+		fmt.Fprintln(r.out)
+		color.New(color.FgYellow).Fprintf(r.out, "   ~ %s", drifted)
+	}
+	for _, deployment := range check.Deployments {
+		fmt.Fprintln(r.out)
+		color.New(color.FgHiBlack).Fprintf(r.out, "   = %s at %s", deployment.GetShortID(), deployment.Address)
+	}
+}
+
+// renderNetworks displays the steps of a multi-network plan per chain
+func (r *ComposeRenderer) renderNetworks(plan *usecase.ExecutionPlan, networks []string) {
 	fmt.Fprintln(r.out)
+	color.New(color.Bold).Fprintf(r.out, "🌐 Per Network:\n")
+	fmt.Fprintf(r.out, "%s\n", strings.Repeat("─", 50))
+
+	for _, network := range networks {
+		color.New(color.FgMagenta).Fprintf(r.out, "%s", network)
+		var names []string
+		for _, step := range plan.StepsOn(network) {
+			names = append(names, step.Name)
+		}
+		fmt.Fprintf(r.out, ": %s\n", strings.Join(names, " → "))
+	}
 }
 
 // RenderStepResult renders a single step result
 func (r *ComposeRenderer) RenderStepResult(stepResult *usecase.StepResult) {
-	if stepResult.Error != nil {
+	if stepResult.Skipped {
+		color.New(color.FgHiBlack).Fprintln(r.out, "⏭  Skipped: expected deployments exist")
+	} else if stepResult.Error != nil {
 		// Show error
 		color.New(color.FgRed).Fprintf(r.out, "❌ Failed: %v\n", stepResult.Error)
 	} else if stepResult.RunResult != nil && stepResult.RunResult.Success {
@@ -84,10 +170,33 @@ func (r *ComposeRenderer) RenderStepResult(stepResult *usecase.StepResult) {
 			}
 		}
 	}
+	r.renderHooks(stepResult.Hooks)
+}
+
+// renderHooks displays the outcome of hooks, with the output of those that failed
+func (r *ComposeRenderer) renderHooks(hooks []*usecase.HookResult) {
+	for _, hook := range hooks {
+		name := cmp.Or(hook.Run, hook.Action)
+		if hook.Success {
+			fmt.Fprintf(r.out, "  🪝 %s %s %s\n", hook.Hook, name, color.New(color.FgGreen).Sprint("✓"))
+			continue
+		}
+		fmt.Fprintf(r.out, "  🪝 %s %s %s\n", hook.Hook, name, color.New(color.FgRed).Sprintf("✗ %s", hook.Error))
+		for _, line := range strings.Split(hook.Output, "\n") {
+			if line != "" {
+				color.New(color.FgHiBlack).Fprintf(r.out, "     %s\n", line)
+			}
+		}
+	}
 }
 
 // renderSummary displays the final summary
 func (r *ComposeRenderer) renderSummary(result *usecase.ComposeResult) {
+	if len(result.Hooks) > 0 {
+		fmt.Fprintf(r.out, "\nHooks of %s:\n", result.Plan.Group)
+		r.renderHooks(result.Hooks)
+		fmt.Fprintln(r.out)
+	}
 	fmt.Fprintf(r.out, "%s\n", strings.Repeat("═", 70))
 
 	if result.Success {
@@ -97,12 +206,20 @@ func (r *ComposeRenderer) renderSummary(result *usecase.ComposeResult) {
 		fmt.Fprintf(r.out, "\n📊 Summary:\n")
 		fmt.Fprintf(r.out, "  • Steps executed: %d/%d\n",
 			len(result.ExecutedSteps), len(result.Plan.Components))
+		if len(result.SkippedSteps) > 0 {
+			fmt.Fprintf(r.out, "  • Steps skipped: %d\n", len(result.SkippedSteps))
+		}
 		fmt.Fprintf(r.out, "  • Total deployments: %d\n", result.TotalDeployments)
 	} else {
 		color.New(color.FgRed, color.Bold).Fprintf(r.out,
 			"❌ Orchestration failed\n")
 
-		if result.FailedStep != nil {
+		if len(result.FailedSteps) == 0 && result.HookError != nil {
+			fmt.Fprintf(r.out, "\n📊 Summary:\n")
+			fmt.Fprintf(r.out, "  • Steps completed: %d/%d\n",
+				len(result.ExecutedSteps), len(result.Plan.Components))
+			fmt.Fprintf(r.out, "  • Error: %v\n", result.HookError)
+		} else if len(result.FailedSteps) == 1 {
 			fmt.Fprintf(r.out, "\n📊 Summary:\n")
 			fmt.Fprintf(r.out, "  • Failed at step: %s\n", result.FailedStep.Step.Name)
 			fmt.Fprintf(r.out, "  • Steps completed: %d/%d\n",
@@ -111,6 +228,15 @@ func (r *ComposeRenderer) renderSummary(result *usecase.ComposeResult) {
 			if result.FailedStep.Error != nil {
 				fmt.Fprintf(r.out, "  • Error: %v\n", result.FailedStep.Error)
 			}
+		} else if len(result.FailedSteps) > 1 {
+			fmt.Fprintf(r.out, "\n📊 Summary:\n")
+			fmt.Fprintf(r.out, "  • Failed steps: %d\n", len(result.FailedSteps))
+			fmt.Fprintf(r.out, "  • Steps completed: %d/%d\n",
+				len(result.ExecutedSteps)-len(result.FailedSteps), len(result.Plan.Components))
+
+			for _, failed := range result.FailedSteps {
+				fmt.Fprintf(r.out, "  • %s: %v\n", failed.Step.Name, failed.Error)
+			}
 		}
 	}
 }
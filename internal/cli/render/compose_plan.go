@@ -0,0 +1,377 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// ComposePlanRenderer renders the execution plan of a compose file as text or as a graph
+type ComposePlanRenderer struct {
+	out io.Writer
+}
+
+// NewComposePlanRenderer creates a new compose plan renderer
+func NewComposePlanRenderer(out io.Writer) *ComposePlanRenderer {
+	return &ComposePlanRenderer{out: out}
+}
+
+// RenderText renders the steps level by level
+func (r *ComposePlanRenderer) RenderText(result *usecase.ComposePlanResult) error {
+	bold := color.New(color.Bold)
+	faint := color.New(color.Faint)
+	red := color.New(color.FgRed)
+	multiNetwork := len(result.Plan.Networks()) > 1
+
+	fmt.Fprintf(r.out, "🎯 %s %s\n", color.New(color.FgCyan, color.Bold).Sprint(result.Plan.Group), faint.Sprint(result.ConfigPath))
+	fmt.Fprintf(r.out, "📋 %d components in %d levels, namespace %s\n", len(result.Plan.Components), len(result.Levels), result.Namespace)
+
+	for i, level := range result.Levels {
+		fmt.Fprintf(r.out, "\n%s\n", bold.Sprintf("Level %d", i+1))
+		for _, step := range level {
+			description := result.Steps[step.Name]
+
+			line := fmt.Sprintf("  %s → %s", color.New(color.FgCyan).Sprint(step.Name), color.New(color.FgGreen).Sprint(step.Script))
+			if multiNetwork {
+				line += color.New(color.FgMagenta).Sprintf(" @ %s", step.Network)
+			}
+			if len(step.Dependencies) > 0 {
+				line += faint.Sprintf(" (depends on: %s)", strings.Join(step.Dependencies, ", "))
+			}
+			fmt.Fprintln(r.out, line)
+
+			if len(description.Parameters) > 0 {
+				var params []string
+				for _, param := range description.Parameters {
+					params = append(params, formatPlanParameter(step, param.Name, string(param.Type), param.Optional))
+				}
+				fmt.Fprintf(r.out, "    Parameters: %s\n", strings.Join(params, ", "))
+			}
+			for _, key := range undeclaredEnv(step, description) {
+				fmt.Fprintf(r.out, "    Env: %s=%s\n", key, step.Env[key])
+			}
+			if len(description.Senders) > 0 {
+				var senders []string
+				for _, sender := range description.Senders {
+					senders = append(senders, formatPlanSender(sender))
+				}
+				fmt.Fprintf(r.out, "    Senders: %s\n", strings.Join(senders, ", "))
+			}
+
+			for _, hook := range []string{usecase.HookPre, usecase.HookPost, usecase.HookOnFailure} {
+				if hooks := step.Hooks.Of(hook); len(hooks) > 0 {
+					var names []string
+					for _, h := range hooks {
+						names = append(names, h.String())
+					}
+					fmt.Fprintf(r.out, "    Hooks %s: %s\n", hook, strings.Join(names, ", "))
+				}
+			}
+
+			if description.Simulated {
+				switch {
+				case description.SimulationError != "":
+					fmt.Fprintf(r.out, "    %s\n", red.Sprintf("Simulation failed: %s", description.SimulationError))
+				case len(description.Creates) == 0:
+					fmt.Fprintln(r.out, "    Creates: nothing")
+				default:
+					for _, deployment := range description.Creates {
+						fmt.Fprintf(r.out, "    Creates: %s at %s\n", deployment.ContractDisplayName(), deployment.Address)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// RenderDot renders the plan as a Graphviz digraph
+func (r *ComposePlanRenderer) RenderDot(result *usecase.ComposePlanResult) error {
+	ids := planNodeIDs(result.Plan)
+
+	fmt.Fprintf(r.out, "digraph %s {\n", strconv.Quote(result.Plan.Group))
+	fmt.Fprintln(r.out, "  rankdir=TB;")
+	fmt.Fprintln(r.out, `  node [shape=box, style=rounded, fontname="Helvetica"];`)
+
+	writeNodes := func(indent string, steps []*usecase.ExecutionStep) {
+		for _, step := range steps {
+			label := strings.Join(planNodeLines(step, result.Steps[step.Name]), "\n")
+			fmt.Fprintf(r.out, "%s%s [label=%s];\n", indent, ids[step.Name], strconv.Quote(label))
+		}
+	}
+	if networks := result.Plan.Networks(); len(networks) > 1 {
+		for i, network := range networks {
+			fmt.Fprintf(r.out, "  subgraph cluster_%d {\n", i)
+			fmt.Fprintf(r.out, "    label=%s;\n", strconv.Quote(network))
+			writeNodes("    ", result.Plan.StepsOn(network))
+			fmt.Fprintln(r.out, "  }")
+		}
+	} else {
+		writeNodes("  ", result.Plan.Components)
+	}
+
+	// Steps of a level are drawn on the same rank
+	for _, level := range result.Levels {
+		if len(level) < 2 {
+			continue
+		}
+		var names []string
+		for _, step := range level {
+			names = append(names, ids[step.Name])
+		}
+		fmt.Fprintf(r.out, "  { rank=same; %s; }\n", strings.Join(names, "; "))
+	}
+
+	for _, step := range result.Plan.Components {
+		for _, dep := range step.Dependencies {
+			fmt.Fprintf(r.out, "  %s -> %s;\n", ids[dep], ids[step.Name])
+		}
+	}
+	fmt.Fprintln(r.out, "}")
+	return nil
+}
+
+// RenderMermaid renders the plan as a Mermaid flowchart, e.g. for a pull request description
+func (r *ComposePlanRenderer) RenderMermaid(result *usecase.ComposePlanResult) error {
+	ids := planNodeIDs(result.Plan)
+
+	fmt.Fprintln(r.out, "flowchart TD")
+	writeNodes := func(indent string, steps []*usecase.ExecutionStep) {
+		var lines []string
+		for _, step := range steps {
+			lines = lines[:0]
+			for i, line := range planNodeLines(step, result.Steps[step.Name]) {
+				if i == 0 {
+					line = "<b>" + mermaidText(line) + "</b>"
+				} else {
+					line = mermaidText(line)
+				}
+				lines = append(lines, line)
+			}
+			fmt.Fprintf(r.out, "%s%s[\"%s\"]\n", indent, ids[step.Name], strings.Join(lines, "<br/>"))
+		}
+	}
+	if networks := result.Plan.Networks(); len(networks) > 1 {
+		for i, network := range networks {
+			fmt.Fprintf(r.out, "  subgraph network%d[\"%s\"]\n", i, mermaidText(network))
+			writeNodes("    ", result.Plan.StepsOn(network))
+			fmt.Fprintln(r.out, "  end")
+		}
+	} else {
+		writeNodes("  ", result.Plan.Components)
+	}
+
+	for _, step := range result.Plan.Components {
+		for _, dep := range step.Dependencies {
+			fmt.Fprintf(r.out, "  %s --> %s\n", ids[dep], ids[step.Name])
+		}
+	}
+	return nil
+}
+
+// ComposePlanJSON is the JSON document of `treb compose plan --format json`
+type ComposePlanJSON struct {
+	Group     string                `json:"group"`
+	File      string                `json:"file"`
+	Namespace string                `json:"namespace"`
+	Levels    [][]string            `json:"levels"`
+	Steps     []ComposePlanStepJSON `json:"steps"`
+}
+
+// ComposePlanStepJSON is a step of the plan with the script it runs
+type ComposePlanStepJSON struct {
+	Name            string                  `json:"name"`
+	Script          string                  `json:"script"`
+	ScriptPath      string                  `json:"scriptPath"`
+	Network         string                  `json:"network,omitempty"`
+	Level           int                     `json:"level"`
+	Dependencies    []string                `json:"dependencies"`
+	Env             map[string]string       `json:"env,omitempty"`
+	Parameters      []ComposePlanParamJSON  `json:"parameters"`
+	Senders         []ComposePlanSenderJSON `json:"senders"`
+	Creates         []ComposePlanCreateJSON `json:"creates,omitempty"`
+	SimulationError string                  `json:"simulationError,omitempty"`
+}
+
+// ComposePlanParamJSON is a parameter declared by the script of a step
+type ComposePlanParamJSON struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Optional bool   `json:"optional,omitempty"`
+	Value    string `json:"value,omitempty"` // Value set in the env of the step
+}
+
+// ComposePlanSenderJSON is a sender role of the script of a step
+type ComposePlanSenderJSON struct {
+	Role    string `json:"role"`
+	Account string `json:"account,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Address string `json:"address,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ComposePlanCreateJSON is a deployment found by simulating a step
+type ComposePlanCreateJSON struct {
+	Contract string `json:"contract"`
+	Address  string `json:"address"`
+	Type     string `json:"type"`
+}
+
+// BuildComposePlanJSON converts a compose plan into its JSON document
+func BuildComposePlanJSON(result *usecase.ComposePlanResult) *ComposePlanJSON {
+	doc := &ComposePlanJSON{
+		Group:     result.Plan.Group,
+		File:      result.ConfigPath,
+		Namespace: result.Namespace,
+		Levels:    [][]string{},
+		Steps:     []ComposePlanStepJSON{},
+	}
+
+	levels := make(map[string]int)
+	for i, level := range result.Levels {
+		var names []string
+		for _, step := range level {
+			names = append(names, step.Name)
+			levels[step.Name] = i + 1
+		}
+		doc.Levels = append(doc.Levels, names)
+	}
+
+	for _, step := range result.Plan.Components {
+		description := result.Steps[step.Name]
+		entry := ComposePlanStepJSON{
+			Name:            step.Name,
+			Script:          description.Script.Name,
+			ScriptPath:      description.Script.Path,
+			Network:         step.Network,
+			Level:           levels[step.Name],
+			Dependencies:    step.Dependencies,
+			Env:             step.Env,
+			Parameters:      []ComposePlanParamJSON{},
+			Senders:         []ComposePlanSenderJSON{},
+			SimulationError: description.SimulationError,
+		}
+		if entry.Dependencies == nil {
+			entry.Dependencies = []string{}
+		}
+		for _, param := range description.Parameters {
+			entry.Parameters = append(entry.Parameters, ComposePlanParamJSON{
+				Name:     param.Name,
+				Type:     string(param.Type),
+				Optional: param.Optional,
+				Value:    step.Env[param.Name],
+			})
+		}
+		for _, sender := range description.Senders {
+			senderJSON := ComposePlanSenderJSON{
+				Role:    sender.Role,
+				Account: sender.Account,
+				Type:    string(sender.Type),
+				Error:   sender.AddressError,
+			}
+			if sender.AddressError == "" {
+				senderJSON.Address = sender.Address.Hex()
+			}
+			entry.Senders = append(entry.Senders, senderJSON)
+		}
+		for _, deployment := range description.Creates {
+			entry.Creates = append(entry.Creates, ComposePlanCreateJSON{
+				Contract: deployment.ContractDisplayName(),
+				Address:  deployment.Address,
+				Type:     string(deployment.Type),
+			})
+		}
+		doc.Steps = append(doc.Steps, entry)
+	}
+	return doc
+}
+
+// planNodeIDs assigns graph node IDs to steps; step names may contain characters such as @
+func planNodeIDs(plan *usecase.ExecutionPlan) map[string]string {
+	ids := make(map[string]string, len(plan.Components))
+	for i, step := range plan.Components {
+		ids[step.Name] = fmt.Sprintf("step%d", i+1)
+	}
+	return ids
+}
+
+// planNodeLines returns the label of a graph node, one entry per line
+func planNodeLines(step *usecase.ExecutionStep, description *usecase.ScriptDescription) []string {
+	lines := []string{step.Name, step.Script}
+	if step.Network != "" {
+		lines = append(lines, "network: "+step.Network)
+	}
+	if len(description.Parameters) > 0 {
+		var params []string
+		for _, param := range description.Parameters {
+			params = append(params, formatPlanParameter(step, param.Name, string(param.Type), param.Optional))
+		}
+		lines = append(lines, "params: "+strings.Join(params, ", "))
+	}
+	if len(description.Senders) > 0 {
+		var senders []string
+		for _, sender := range description.Senders {
+			senders = append(senders, formatPlanSender(sender))
+		}
+		lines = append(lines, "senders: "+strings.Join(senders, ", "))
+	}
+	if description.Simulated {
+		switch {
+		case description.SimulationError != "":
+			lines = append(lines, "simulation failed")
+		case len(description.Creates) > 0:
+			var creates []string
+			for _, deployment := range description.Creates {
+				creates = append(creates, deployment.ContractDisplayName())
+			}
+			lines = append(lines, "creates: "+strings.Join(creates, ", "))
+		}
+	}
+	return lines
+}
+
+// formatPlanParameter renders a script parameter with the value the step sets, if any
+func formatPlanParameter(step *usecase.ExecutionStep, name, paramType string, optional bool) string {
+	if value, ok := step.Env[name]; ok {
+		return fmt.Sprintf("%s=%s", name, value)
+	}
+	if optional {
+		return fmt.Sprintf("%s (%s, optional)", name, paramType)
+	}
+	return fmt.Sprintf("%s (%s)", name, paramType)
+}
+
+// formatPlanSender renders a sender role with the address it resolves to
+func formatPlanSender(sender *usecase.AccountInfo) string {
+	if sender.AddressError != "" {
+		return fmt.Sprintf("%s (%s)", sender.Role, sender.AddressError)
+	}
+	name := sender.Role
+	if sender.Account != "" {
+		name += " → " + sender.Account
+	}
+	return fmt.Sprintf("%s %s", name, sender.Address.Hex())
+}
+
+// undeclaredEnv returns the env keys of a step that are not parameters of its script
+func undeclaredEnv(step *usecase.ExecutionStep, description *usecase.ScriptDescription) []string {
+	var keys []string
+	for _, key := range slices.Sorted(maps.Keys(step.Env)) {
+		if !slices.ContainsFunc(description.Parameters, func(param domain.ScriptParameter) bool { return param.Name == key }) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// mermaidText escapes text for a quoted Mermaid label
+func mermaidText(text string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(text)
+}
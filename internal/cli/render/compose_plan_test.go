@@ -0,0 +1,68 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+func newTestComposePlan() *usecase.ComposePlanResult {
+	plan := &usecase.ExecutionPlan{
+		Group: "Protocol",
+		Components: []*usecase.ExecutionStep{
+			{Name: "Core", Script: "DeployCore", Network: "mainnet"},
+			{Name: "Adapter@arbitrum", Script: "DeployAdapter", Network: "arbitrum", Dependencies: []string{"Core"},
+				Env: map[string]string{"LABEL": `"fast"`}},
+		},
+	}
+	return &usecase.ComposePlanResult{
+		ConfigPath: "protocol.yaml",
+		Namespace:  "default",
+		Plan:       plan,
+		Levels:     plan.Levels(),
+		Steps: map[string]*usecase.ScriptDescription{
+			"Core": {Script: &models.Contract{Name: "DeployCore", Path: "script/DeployCore.s.sol"}},
+			"Adapter@arbitrum": {
+				Script:     &models.Contract{Name: "DeployAdapter", Path: "script/DeployAdapter.s.sol"},
+				Parameters: []domain.ScriptParameter{{Name: "LABEL", Type: domain.ParamTypeString}},
+			},
+		},
+	}
+}
+
+func TestComposePlanRenderer(t *testing.T) {
+	result := newTestComposePlan()
+
+	t.Run("mermaid", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, NewComposePlanRenderer(&out).RenderMermaid(result))
+		assert.Contains(t, out.String(), "flowchart TD\n")
+		assert.Contains(t, out.String(), `subgraph network1["arbitrum"]`)
+		assert.Contains(t, out.String(), `step2["<b>Adapter@arbitrum</b><br/>DeployAdapter<br/>network: arbitrum<br/>params: LABEL=#quot;fast#quot;"]`)
+		assert.Contains(t, out.String(), "step1 --> step2\n")
+	})
+
+	t.Run("dot", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, NewComposePlanRenderer(&out).RenderDot(result))
+		assert.Contains(t, out.String(), `digraph "Protocol" {`)
+		assert.Contains(t, out.String(), "subgraph cluster_0 {\n    label=\"mainnet\";")
+		assert.Contains(t, out.String(), `step2 [label="Adapter@arbitrum\nDeployAdapter\nnetwork: arbitrum\nparams: LABEL=\"fast\""];`)
+		assert.Contains(t, out.String(), "step1 -> step2;\n")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		doc := BuildComposePlanJSON(result)
+		assert.Equal(t, [][]string{{"Core"}, {"Adapter@arbitrum"}}, doc.Levels)
+		require.Len(t, doc.Steps, 2)
+		assert.Equal(t, 2, doc.Steps[1].Level)
+		assert.Equal(t, "script/DeployAdapter.s.sol", doc.Steps[1].ScriptPath)
+		assert.Equal(t, `"fast"`, doc.Steps[1].Parameters[0].Value)
+		assert.Equal(t, []string{}, doc.Steps[0].Dependencies)
+	})
+}
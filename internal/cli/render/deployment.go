@@ -116,6 +116,9 @@ func (r *DeploymentRenderer) RenderDeployment(deployment *models.Deployment, isF
 	if deployment.Artifact.GitCommit != "" {
 		fmt.Fprintf(r.out, "  Git Commit: %s\n", deployment.Artifact.GitCommit)
 	}
+	if deployment.RunID != "" {
+		fmt.Fprintf(r.out, "  Run: %s (treb runs show %s)\n", deployment.RunID, deployment.RunID)
+	}
 
 	// Verification Status
 	fmt.Fprintln(r.out, "\nVerification Status:")
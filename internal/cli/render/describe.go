@@ -0,0 +1,257 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// DescribeRenderer renders script descriptions as text or as a markdown handbook
+type DescribeRenderer struct {
+	out io.Writer
+}
+
+// NewDescribeRenderer creates a new describe renderer
+func NewDescribeRenderer(out io.Writer) *DescribeRenderer {
+	return &DescribeRenderer{out: out}
+}
+
+// RenderText renders one block per script
+func (r *DescribeRenderer) RenderText(result *usecase.DescribeScriptResult) error {
+	if len(result.Scripts) == 0 {
+		fmt.Fprintln(r.out, "No scripts found under script/")
+		return nil
+	}
+
+	for i, description := range result.Scripts {
+		if i > 0 {
+			fmt.Fprintln(r.out)
+		}
+		r.renderScript(result, description)
+	}
+	return nil
+}
+
+func (r *DescribeRenderer) renderScript(result *usecase.DescribeScriptResult, description *usecase.ScriptDescription) {
+	bold := color.New(color.Bold)
+	faint := color.New(color.Faint)
+	red := color.New(color.FgRed)
+
+	fmt.Fprintf(r.out, "📜 %s %s\n", color.New(color.FgCyan, color.Bold).Sprint(description.Script.Name), faint.Sprint(description.Script.Path))
+
+	fmt.Fprintf(r.out, "\n  %s\n", bold.Sprint("Parameters:"))
+	if len(description.Parameters) == 0 {
+		fmt.Fprintln(r.out, "    none")
+	}
+	nameWidth := 0
+	for _, param := range description.Parameters {
+		nameWidth = max(nameWidth, len(param.Name))
+	}
+	for _, param := range description.Parameters {
+		line := fmt.Sprintf("    %s  %s", color.New(color.FgYellow).Sprintf("%-*s", nameWidth, param.Name), param.Type)
+		if param.Optional {
+			line += faint.Sprint(" (optional)")
+		}
+		if constraints := formatConstraints(param.Constraints); constraints != "" {
+			line += " " + color.New(color.FgMagenta).Sprint(constraints)
+		}
+		if param.Description != "" {
+			line += faint.Sprintf("  %s", param.Description)
+		}
+		fmt.Fprintln(r.out, line)
+	}
+
+	fmt.Fprintf(r.out, "\n  %s\n", bold.Sprintf("Senders (namespace %s):", result.Namespace))
+	if len(description.Senders) == 0 {
+		fmt.Fprintln(r.out, "    none declared with @custom:senders")
+	}
+	for _, sender := range description.Senders {
+		line := fmt.Sprintf("    %s", color.New(color.FgCyan).Sprint(sender.Role))
+		if sender.Account != "" {
+			line += fmt.Sprintf(" → %s", sender.Account)
+		}
+		if sender.Type != "" {
+			line += faint.Sprintf(" (%s)", sender.Type)
+		}
+		if sender.AddressError != "" {
+			line += "  " + red.Sprint(sender.AddressError)
+		} else {
+			line += "  " + sender.Address.Hex()
+		}
+		fmt.Fprintln(r.out, line)
+	}
+
+	fmt.Fprintf(r.out, "\n  %s\n", bold.Sprint("References:"))
+	if len(description.References) == 0 {
+		fmt.Fprintln(r.out, "    none")
+	}
+	for _, reference := range description.References {
+		line := "    " + formatReferenceName(reference)
+		switch {
+		case reference.Error != "":
+			line += "  " + red.Sprint(reference.Error)
+		case reference.Deployment != nil:
+			line += fmt.Sprintf("  %s %s", reference.Deployment.Address, faint.Sprintf("(%s)", reference.Deployment.ID))
+		case reference.Reference == "":
+			line += faint.Sprint("  not set")
+		case result.Network == nil:
+			line += faint.Sprint("  select a network with --network to resolve")
+		}
+		fmt.Fprintln(r.out, line)
+	}
+
+	if description.Simulated {
+		fmt.Fprintf(r.out, "\n  %s\n", bold.Sprint("Creates (simulated):"))
+		switch {
+		case description.SimulationError != "":
+			fmt.Fprintf(r.out, "    %s\n", red.Sprintf("simulation failed: %s", description.SimulationError))
+		case len(description.Creates) == 0:
+			fmt.Fprintln(r.out, "    no deployments")
+		}
+		for _, deployment := range description.Creates {
+			fmt.Fprintf(r.out, "    %s  %s %s\n",
+				color.New(color.FgGreen).Sprint(deployment.ContractDisplayName()),
+				deployment.Address,
+				faint.Sprintf("(%s)", deployment.Type))
+		}
+	}
+}
+
+// RenderMarkdown renders the scripts as a deployments handbook
+func (r *DescribeRenderer) RenderMarkdown(result *usecase.DescribeScriptResult) error {
+	fmt.Fprintln(r.out, "# Deployment Scripts")
+	fmt.Fprintln(r.out)
+	context := fmt.Sprintf("Namespace: `%s`", result.Namespace)
+	if result.Network != nil {
+		context += fmt.Sprintf(", network: `%s` (%d)", result.Network.Name, result.Network.ChainID)
+	}
+	fmt.Fprintln(r.out, context)
+
+	for _, description := range result.Scripts {
+		fmt.Fprintf(r.out, "\n## %s\n\n", description.Script.Name)
+		fmt.Fprintf(r.out, "Source: `%s`\n", description.Script.Path)
+
+		fmt.Fprint(r.out, "\n### Parameters\n\n")
+		if len(description.Parameters) == 0 {
+			fmt.Fprintln(r.out, "None.")
+		} else {
+			fmt.Fprintln(r.out, "| Name | Type | Required | Constraints | Description |")
+			fmt.Fprintln(r.out, "|------|------|----------|-------------|-------------|")
+			for _, param := range description.Parameters {
+				required := "yes"
+				if param.Optional {
+					required = "no"
+				}
+				fmt.Fprintf(r.out, "| `%s` | `%s` | %s | %s | %s |\n", param.Name, param.Type, required,
+					markdownCode(formatConstraints(param.Constraints)), markdownCell(param.Description))
+			}
+		}
+
+		fmt.Fprint(r.out, "\n### Senders\n\n")
+		if len(description.Senders) == 0 {
+			fmt.Fprintln(r.out, "None declared with `@custom:senders`.")
+		} else {
+			fmt.Fprintln(r.out, "| Role | Account | Type | Address |")
+			fmt.Fprintln(r.out, "|------|---------|------|---------|")
+			for _, sender := range description.Senders {
+				address := markdownCode(sender.Address.Hex())
+				if sender.AddressError != "" {
+					address = markdownCell(sender.AddressError)
+				}
+				fmt.Fprintf(r.out, "| `%s` | %s | %s | %s |\n", sender.Role, markdownCell(sender.Account), markdownCell(string(sender.Type)), address)
+			}
+		}
+
+		fmt.Fprint(r.out, "\n### Referenced Deployments\n\n")
+		if len(description.References) == 0 {
+			fmt.Fprintln(r.out, "None.")
+		} else {
+			fmt.Fprintln(r.out, "| Reference | Via | Address |")
+			fmt.Fprintln(r.out, "|-----------|-----|---------|")
+			for _, reference := range description.References {
+				via := "`lookup`"
+				if reference.Parameter != "" {
+					via = fmt.Sprintf("parameter `%s`", reference.Parameter)
+				}
+				address := ""
+				switch {
+				case reference.Error != "":
+					address = markdownCell(reference.Error)
+				case reference.Deployment != nil:
+					address = markdownCode(reference.Deployment.Address)
+				}
+				fmt.Fprintf(r.out, "| %s | %s | %s |\n", markdownCode(reference.Reference), via, address)
+			}
+		}
+
+		if description.Simulated {
+			fmt.Fprint(r.out, "\n### Creates\n\n")
+			switch {
+			case description.SimulationError != "":
+				fmt.Fprintf(r.out, "Simulation failed: %s\n", markdownCell(description.SimulationError))
+			case len(description.Creates) == 0:
+				fmt.Fprintln(r.out, "No deployments.")
+			default:
+				fmt.Fprintln(r.out, "| Contract | Type | Address |")
+				fmt.Fprintln(r.out, "|----------|------|---------|")
+				for _, deployment := range description.Creates {
+					fmt.Fprintf(r.out, "| %s | %s | `%s` |\n", markdownCell(deployment.ContractDisplayName()), deployment.Type, deployment.Address)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// formatConstraints renders constraints the way they are declared in the natspec
+func formatConstraints(constraints domain.ParameterConstraints) string {
+	var parts []string
+	if constraints.NonZero {
+		parts = append(parts, "nonzero")
+	}
+	if constraints.Contract {
+		parts = append(parts, "contract")
+	}
+	if constraints.Min != "" {
+		parts = append(parts, "min="+constraints.Min)
+	}
+	if constraints.Max != "" {
+		parts = append(parts, "max="+constraints.Max)
+	}
+	if constraints.Pattern != "" {
+		parts = append(parts, "pattern="+constraints.Pattern)
+	}
+	if len(constraints.Enum) > 0 {
+		parts = append(parts, "enum="+strings.Join(constraints.Enum, "|"))
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatReferenceName(reference *usecase.ScriptReference) string {
+	name := color.New(color.FgYellow).Sprint(reference.Reference)
+	if reference.Parameter == "" {
+		return name + color.New(color.Faint).Sprint(" (lookup)")
+	}
+	if reference.Reference == "" {
+		return color.New(color.Faint).Sprintf("parameter %s", reference.Parameter)
+	}
+	return name + color.New(color.Faint).Sprintf(" (parameter %s)", reference.Parameter)
+}
+
+// markdownCell escapes a value for a markdown table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// markdownCode renders a non-empty value as inline code in a markdown table cell
+func markdownCode(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "`" + markdownCell(value) + "`"
+}
@@ -24,6 +24,9 @@ func (r *ForkRenderer) RenderEnter(result *usecase.EnterForkResult) error {
 	fmt.Printf("  Network:      %s\n", entry.Network)
 	fmt.Printf("  Chain ID:     %d\n", entry.ChainID)
 	fmt.Printf("  Fork URL:     %s\n", entry.ForkURL)
+	if entry.ForkBlock > 0 {
+		fmt.Printf("  Fork Block:   %s\n", formatForkBlock(entry.ForkBlock, entry.BlockPinned))
+	}
 	fmt.Printf("  Anvil PID:    %d\n", entry.AnvilPID)
 	fmt.Printf("  Env Override: %s=%s\n", entry.EnvVarName, entry.ForkURL)
 	fmt.Printf("  Logs:         %s\n", entry.LogFile)
@@ -66,6 +69,12 @@ func (r *ForkRenderer) RenderStatus(result *usecase.ForkStatusResult) error {
 		fmt.Printf("  %s%s\n", e.Network, currentMarker)
 		fmt.Printf("    Chain ID:     %d\n", e.ChainID)
 		fmt.Printf("    Fork URL:     %s\n", e.ForkURL)
+		if e.ForkBlock > 0 {
+			fmt.Printf("    Fork Block:   %s\n", formatForkBlock(e.ForkBlock, e.BlockPinned))
+			if drift := e.Drift(); drift >= 0 {
+				fmt.Printf("    Drift:        %d blocks behind head (%d)\n", drift, e.HeadBlock)
+			}
+		}
 		fmt.Printf("    Anvil PID:    %d\n", e.AnvilPID)
 		fmt.Printf("    Status:       %s\n", e.HealthDetail)
 		fmt.Printf("    Uptime:       %s\n", formatDuration(e.Uptime))
@@ -80,6 +89,14 @@ func (r *ForkRenderer) RenderStatus(result *usecase.ForkStatusResult) error {
 	return nil
 }
 
+// formatForkBlock formats the block a fork was created from
+func formatForkBlock(block uint64, pinned bool) string {
+	if pinned {
+		return fmt.Sprintf("%d (pinned)", block)
+	}
+	return fmt.Sprintf("%d", block)
+}
+
 // formatDuration formats a duration in a human-readable way
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
@@ -98,11 +115,21 @@ func (r *ForkRenderer) RenderRevert(result *usecase.RevertForkResult) error {
 	if result.RevertedCommand != "" {
 		fmt.Printf("  Reverted:   %s\n", result.RevertedCommand)
 	}
-	fmt.Printf("  Reverted:   %d snapshot(s)\n", result.RevertedCount)
+	fmt.Printf("  Reverted:   %d run(s)\n", result.RevertedCount)
 	fmt.Printf("  Remaining:  %d snapshot(s)\n", result.RemainingSnapshots)
 	return nil
 }
 
+// RenderCheckpoint renders the result of fork checkpoint
+func (r *ForkRenderer) RenderCheckpoint(result *usecase.CheckpointForkResult) error {
+	fmt.Println(result.Message)
+	fmt.Println()
+	fmt.Printf("  Snapshot:   [%d]\n", result.Snapshot.Index)
+	fmt.Println()
+	fmt.Printf("Run 'treb fork revert --to %s' to return to this checkpoint\n", result.Snapshot.Name)
+	return nil
+}
+
 // RenderRestart renders the result of fork restart
 func (r *ForkRenderer) RenderRestart(result *usecase.RestartForkResult) error {
 	entry := result.ForkEntry
@@ -112,6 +139,9 @@ func (r *ForkRenderer) RenderRestart(result *usecase.RestartForkResult) error {
 	fmt.Printf("  Network:      %s\n", entry.Network)
 	fmt.Printf("  Chain ID:     %d\n", entry.ChainID)
 	fmt.Printf("  Fork URL:     %s\n", entry.ForkURL)
+	if entry.ForkBlock > 0 {
+		fmt.Printf("  Fork Block:   %s\n", formatForkBlock(entry.ForkBlock, entry.BlockPinned))
+	}
 	fmt.Printf("  Anvil PID:    %d\n", entry.AnvilPID)
 	fmt.Printf("  Env Override: %s=%s\n", entry.EnvVarName, entry.ForkURL)
 	fmt.Printf("  Logs:         %s\n", entry.LogFile)
@@ -138,6 +168,8 @@ func (r *ForkRenderer) RenderHistory(result *usecase.ForkHistoryResult) error {
 		label := ""
 		if e.IsInitial {
 			label = "initial"
+		} else if e.Checkpoint != "" {
+			label = fmt.Sprintf("checkpoint '%s'", e.Checkpoint)
 		} else {
 			label = e.Command
 		}
@@ -149,6 +181,47 @@ func (r *ForkRenderer) RenderHistory(result *usecase.ForkHistoryResult) error {
 	return nil
 }
 
+// RenderExport renders the result of fork export
+func (r *ForkRenderer) RenderExport(result *usecase.ExportForkResult) error {
+	export := result.Export
+
+	fmt.Println(result.Message)
+	fmt.Println()
+	fmt.Printf("  Network:      %s\n", export.Network)
+	fmt.Printf("  Chain ID:     %d\n", export.ChainID)
+	if export.ForkBlock > 0 {
+		fmt.Printf("  Fork Block:   %d\n", export.ForkBlock)
+	}
+	fmt.Printf("  Registry:     %d file(s)\n", len(export.Registry))
+	fmt.Println()
+	fmt.Printf("Run 'treb fork import %s' to start a fork from this state\n", result.File)
+
+	return nil
+}
+
+// RenderImport renders the result of fork import
+func (r *ForkRenderer) RenderImport(result *usecase.ImportForkResult) error {
+	entry := result.ForkEntry
+
+	fmt.Println(result.Message)
+	fmt.Println()
+	fmt.Printf("  Network:      %s\n", entry.Network)
+	fmt.Printf("  Chain ID:     %d\n", entry.ChainID)
+	fmt.Printf("  Fork URL:     %s\n", entry.ForkURL)
+	if entry.ForkBlock > 0 {
+		fmt.Printf("  Fork Block:   %s\n", formatForkBlock(entry.ForkBlock, entry.BlockPinned))
+	}
+	fmt.Printf("  Anvil PID:    %d\n", entry.AnvilPID)
+	fmt.Printf("  Env Override: %s=%s\n", entry.EnvVarName, entry.ForkURL)
+	fmt.Printf("  Exported At:  %s\n", result.Export.ExportedAt.Format(time.RFC3339))
+	fmt.Printf("  Logs:         %s\n", entry.LogFile)
+	fmt.Println()
+	fmt.Println("Run 'treb fork revert' to undo the import")
+	fmt.Println("Run 'treb fork exit' to stop fork and restore original state")
+
+	return nil
+}
+
 // RenderDiff renders the result of fork diff
 func (r *ForkRenderer) RenderDiff(result *usecase.ForkDiffResult) error {
 	fmt.Printf("Fork Diff: %s\n", result.Network)
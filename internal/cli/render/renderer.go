@@ -11,6 +11,9 @@ type Renderer[T any] interface {
 }
 
 func ProvideIO(cmd *cobra.Command) io.Writer {
+	// With --json, stdout carries the machine-readable document and human output moves to stderr
+	if flag := cmd.Flags().Lookup("json"); flag != nil && flag.Value.String() == "true" {
+		return cmd.ErrOrStderr()
+	}
 	return cmd.OutOrStdout()
-
 }
@@ -0,0 +1,187 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// RunsRenderer renders run records
+type RunsRenderer struct {
+	out   io.Writer
+	color bool
+}
+
+// NewRunsRenderer creates a new runs renderer
+func NewRunsRenderer(out io.Writer, color bool) *RunsRenderer {
+	return &RunsRenderer{
+		out:   out,
+		color: color,
+	}
+}
+
+// RenderRunsList renders one line per run, most recent first
+func (r *RunsRenderer) RenderRunsList(result *usecase.ListRunsResult) error {
+	if len(result.Runs) == 0 {
+		fmt.Fprintln(r.out, "No runs recorded")
+		return nil
+	}
+
+	fmt.Fprintf(r.out, "📜 Runs (%d):\n\n", len(result.Runs))
+	for _, run := range result.Runs {
+		script := run.Script
+		if run.Compose != nil {
+			script = fmt.Sprintf("%s [%s/%s]", run.Script, run.Compose.Group, run.Compose.Step)
+		}
+		network := fmt.Sprintf("%s/%s", run.Namespace, run.Network)
+		if run.Fork {
+			network += " (fork)"
+		}
+
+		fmt.Fprintf(r.out, "  %s %s  %s  %s  %s  %s\n",
+			runStatusIcon(run.Status),
+			color.New(color.FgCyan).Sprint(run.ID),
+			color.New(color.FgYellow).Sprint(script),
+			network,
+			timestampStyle.Sprint(run.StartedAt.Local().Format("2006-01-02 15:04:05")),
+			runChangesSummary(run.Changeset),
+		)
+	}
+
+	return nil
+}
+
+// RenderRun renders the full record of a run
+func (r *RunsRenderer) RenderRun(result *usecase.ShowRunResult) error {
+	run := result.Run
+
+	color.New(color.FgCyan, color.Bold).Fprintf(r.out, "Run: %s", run.ID)
+	fmt.Fprintln(r.out)
+	fmt.Fprintln(r.out, strings.Repeat("=", 80))
+
+	fmt.Fprintln(r.out, "\nExecution:")
+	fmt.Fprintf(r.out, "  Status: %s %s\n", runStatusIcon(run.Status), run.Status)
+	if run.Error != "" {
+		fmt.Fprintf(r.out, "  Error: %s\n", color.New(color.FgRed).Sprint(run.Error))
+	}
+	fmt.Fprintf(r.out, "  Script: %s (%s)\n", color.New(color.FgYellow).Sprint(run.Script), run.ScriptPath)
+	if run.Compose != nil {
+		fmt.Fprintf(r.out, "  Compose: %s, step %s (%s)\n", run.Compose.Group, run.Compose.Step, run.Compose.File)
+	}
+	fmt.Fprintf(r.out, "  Network: %s (%d)\n", run.Network, run.ChainID)
+	if run.Fork {
+		fmt.Fprintln(r.out, "  Fork: yes")
+	}
+	fmt.Fprintf(r.out, "  Namespace: %s\n", run.Namespace)
+	fmt.Fprintf(r.out, "  Foundry Profile: %s\n", run.FoundryProfile)
+	if run.Resumed {
+		fmt.Fprintln(r.out, "  Resumed: yes")
+	}
+	fmt.Fprintf(r.out, "  Started: %s\n", run.StartedAt.Local().Format("2006-01-02 15:04:05"))
+	if !run.CompletedAt.IsZero() {
+		fmt.Fprintf(r.out, "  Duration: %s\n", run.CompletedAt.Sub(run.StartedAt).Round(100*time.Millisecond))
+	}
+
+	fmt.Fprintln(r.out, "\nProvenance:")
+	fmt.Fprintf(r.out, "  Operator: %s\n", valueOrUnknown(run.Operator))
+	gitCommit := valueOrUnknown(run.Git.Commit)
+	if run.Git.Dirty {
+		gitCommit += " " + pendingStyle.Sprint("(dirty)")
+	}
+	fmt.Fprintf(r.out, "  Git Commit: %s\n", gitCommit)
+	if run.Git.DiffHash != "" {
+		fmt.Fprintf(r.out, "  Diff Hash: %s\n", run.Git.DiffHash)
+	}
+	fmt.Fprintf(r.out, "  treb: %s\n", valueOrUnknown(run.TrebVersion))
+	fmt.Fprintf(r.out, "  forge: %s\n", valueOrUnknown(run.ForgeVersion))
+
+	if len(run.Parameters) > 0 {
+		fmt.Fprintln(r.out, "\nParameters:")
+		names := make([]string, 0, len(run.Parameters))
+		for name := range run.Parameters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(r.out, "  %s: %s\n", name, run.Parameters[name])
+		}
+	}
+
+	if len(run.Senders) > 0 {
+		fmt.Fprintln(r.out, "\nSenders:")
+		for _, sender := range run.Senders {
+			fmt.Fprintf(r.out, "  %s (%s): %s\n", sender.Name, sender.Type, sender.Address)
+			for _, detail := range [][2]string{
+				{"Safe", sender.Safe},
+				{"Signer", sender.Signer},
+				{"Derivation Path", sender.DerivationPath},
+				{"Governor", sender.Governor},
+				{"Timelock", sender.Timelock},
+				{"Proposer", sender.Proposer},
+				{"Keystore", sender.Keystore},
+			} {
+				if detail[1] != "" {
+					fmt.Fprintf(r.out, "    %s: %s\n", detail[0], detail[1])
+				}
+			}
+		}
+	}
+
+	fmt.Fprintln(r.out, "\nChangeset:")
+	if len(run.Changeset.Deployments)+len(run.Changeset.Transactions)+len(run.Changeset.SafeTransactions)+len(run.Changeset.TimelockOperations) == 0 {
+		fmt.Fprintln(r.out, "  No registry changes")
+	}
+	deployments := make(map[string]*models.Deployment, len(result.Deployments))
+	for _, dep := range result.Deployments {
+		deployments[dep.ID] = dep
+	}
+	for _, id := range run.Changeset.Deployments {
+		if dep, ok := deployments[id]; ok {
+			fmt.Fprintf(r.out, "  Deployment: %s at %s\n", id, addressStyle.Sprint(dep.Address))
+		} else {
+			fmt.Fprintf(r.out, "  Deployment: %s %s\n", id, timestampStyle.Sprint("(no longer in registry)"))
+		}
+	}
+	for _, id := range run.Changeset.Transactions {
+		fmt.Fprintf(r.out, "  Transaction: %s\n", id)
+	}
+	for _, hash := range run.Changeset.SafeTransactions {
+		fmt.Fprintf(r.out, "  Safe Transaction: %s\n", hash)
+	}
+	for _, id := range run.Changeset.TimelockOperations {
+		fmt.Fprintf(r.out, "  Timelock Operation: %s\n", id)
+	}
+
+	return nil
+}
+
+func runStatusIcon(status models.RunStatus) string {
+	if status == models.RunStatusCompleted {
+		return "✅"
+	}
+	return "❌"
+}
+
+func runChangesSummary(changeset models.RunChangeset) string {
+	switch n := len(changeset.Deployments); n {
+	case 0:
+		return fmt.Sprintf("%d tx", len(changeset.Transactions))
+	case 1:
+		return "1 deployment"
+	default:
+		return fmt.Sprintf("%d deployments", n)
+	}
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
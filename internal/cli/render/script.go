@@ -1,15 +1,21 @@
 package render
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"log/slog"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/fatih/color"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/bindings"
 	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
 	"github.com/trebuchet-org/treb-cli/internal/usecase"
 )
 
@@ -31,7 +37,7 @@ type ScriptRenderer struct {
 }
 
 // NewScriptRenderer creates a new script renderer
-func NewScriptRenderer(out io.Writer, deploymentsRepo usecase.DeploymentRepository, abiResolver usecase.ABIResolver, log *slog.Logger) *ScriptRenderer {
+func NewScriptRenderer(out io.Writer, deploymentsRepo usecase.DeploymentRepository, addressBook usecase.AddressBookStore, abiResolver usecase.ABIResolver, log *slog.Logger) *ScriptRenderer {
 	return &ScriptRenderer{
 		out:             out,
 		deploymentsRepo: deploymentsRepo,
@@ -39,6 +45,7 @@ func NewScriptRenderer(out io.Writer, deploymentsRepo usecase.DeploymentReposito
 		txRenderer: NewTransactionRenderer(
 			abiResolver,
 			deploymentsRepo,
+			addressBook,
 			log,
 		),
 	}
@@ -67,11 +74,17 @@ func (r *ScriptRenderer) RenderExecution(result *usecase.RunScriptResult) error
 		return err
 	}
 
+	// Render operations scheduled on timelocks
+	r.renderTimelockOperations(exec)
+
 	// Render script logs
 	if err := r.renderLogs(exec); err != nil {
 		return err
 	}
 
+	// Render which attempt sent which transaction when a broadcast was resumed
+	r.renderBroadcastAttempts(result.Attempts)
+
 	// Registry update summary
 	if result.Success && !result.RunResult.DryRun {
 		if len(exec.Deployments) > 0 {
@@ -143,7 +156,8 @@ func (r *ScriptRenderer) renderDeploymentSummary(exec *forge.HydratedRunResult)
 		fmt.Fprintf(r.out, "\n%s\n", yellow.Sprint("⚠️ Deployment Collisions Detected:"))
 		fmt.Fprintf(r.out, "%s\n", gray.Sprint(strings.Repeat("─", 50)))
 
-		for address, collision := range exec.Collisions {
+		for _, address := range sortedCollisionAddresses(exec.Collisions) {
+			collision := exec.Collisions[address]
 			contractName := extractContractName(collision.DeploymentDetails.Artifact)
 			fmt.Fprintf(r.out, "%s already deployed at %s\n",
 				cyan.Sprint(contractName),
@@ -196,6 +210,30 @@ func (r *ScriptRenderer) renderDeploymentSummary(exec *forge.HydratedRunResult)
 	return nil
 }
 
+// renderTimelockOperations lists the scheduled timelock operations and how to execute them
+func (r *ScriptRenderer) renderTimelockOperations(exec *forge.HydratedRunResult) {
+	if len(exec.TimelockOperations) == 0 {
+		return
+	}
+
+	fmt.Fprintf(r.out, "\n%s\n", bold.Sprint("⏳ Timelock Operations:"))
+	fmt.Fprintf(r.out, "%s\n", gray.Sprint(strings.Repeat("─", 50)))
+
+	for _, op := range exec.TimelockOperations {
+		delay := time.Duration(0)
+		if op.Delay != nil {
+			delay = time.Duration(op.Delay.Int64()) * time.Second
+		}
+		fmt.Fprintf(r.out, "%s on %s: %d call(s), delay %s\n",
+			cyan.Sprint(op.OperationID.Hex()), yellow.Sprint(op.Timelock.Hex()), len(op.Calls), delay)
+	}
+
+	if !exec.DryRun {
+		fmt.Fprintf(r.out, "%s\n", gray.Sprint("Run `treb timelock execute <operation-id>` once the delay has passed."))
+	}
+	fmt.Fprintln(r.out)
+}
+
 // renderLogs displays console.log output from the script
 func (r *ScriptRenderer) renderLogs(exec *forge.HydratedRunResult) error {
 	if exec.ParsedOutput == nil {
@@ -217,6 +255,32 @@ func (r *ScriptRenderer) renderLogs(exec *forge.HydratedRunResult) error {
 	return nil
 }
 
+// renderBroadcastAttempts displays the transactions landed by each broadcast attempt
+func (r *ScriptRenderer) renderBroadcastAttempts(attempts []forge.BroadcastAttempt) {
+	if len(attempts) == 0 {
+		return
+	}
+
+	fmt.Fprintf(r.out, "\n%s\n", bold.Sprint("🔁 Broadcast Attempts:"))
+	fmt.Fprintf(r.out, "%s\n", gray.Sprint(strings.Repeat("─", 50)))
+
+	for _, attempt := range attempts {
+		kind := "interrupted"
+		if attempt.Resumed {
+			kind = "resumed"
+		}
+		fmt.Fprintf(r.out, "Attempt %d %s: %d transaction(s)\n",
+			attempt.Number, gray.Sprintf("(%s)", kind), len(attempt.Transactions))
+		for _, tx := range attempt.Transactions {
+			fmt.Fprintf(r.out, "  %s %s %s\n",
+				green.Sprint("✓"), cyan.Sprint(tx.Description),
+				gray.Sprintf("%s (block %d)", tx.Hash, tx.BlockNumber))
+		}
+	}
+
+	fmt.Fprintln(r.out)
+}
+
 // PrintDeploymentBanner prints the deployment banner (called before execution)
 func (r *ScriptRenderer) PrintDeploymentBanner(config *usecase.RunScriptConfig) {
 	bold := color.New(color.Bold)
@@ -236,6 +300,8 @@ func (r *ScriptRenderer) PrintDeploymentBanner(config *usecase.RunScriptConfig)
 
 	if config.DryRun {
 		fmt.Fprintf(r.out, "  Mode:      %s\n", yellow.Sprint("DRY_RUN"))
+	} else if config.Resume {
+		fmt.Fprintf(r.out, "  Mode:      %s\n", yellow.Sprint("RESUME"))
 	} else if len(config.ForkEnvOverrides) > 0 {
 		fmt.Fprintf(r.out, "  Mode:      %s\n", purple.Sprint("FORK"))
 	} else {
@@ -262,6 +328,134 @@ func (r *ScriptRenderer) PrintDeploymentBanner(config *usecase.RunScriptConfig)
 	fmt.Fprintf(r.out, "%s\n", gray.Sprint(strings.Repeat("─", 50)))
 }
 
+// RenderParameterSources lists the resolved script parameters with where each value came from
+func (r *ScriptRenderer) RenderParameterSources(result *usecase.RunScriptResult) {
+	if len(result.ScriptParams) == 0 {
+		fmt.Fprintln(r.out, "Script declares no parameters")
+		return
+	}
+
+	fmt.Fprintf(r.out, "%s\n", bold.Sprint("Script parameters:"))
+	for _, param := range result.ScriptParams {
+		value := result.Parameters.Values[param.Name]
+		source, ok := result.Parameters.Sources[param.Name]
+
+		fmt.Fprintf(r.out, "  %s %s\n", yellow.Sprint(param.Name), gray.Sprintf("(%s)", param.Type))
+		switch {
+		case value == "" && param.Optional:
+			fmt.Fprintf(r.out, "    %s\n", gray.Sprint("not set (optional)"))
+			continue
+		case value == "":
+			fmt.Fprintf(r.out, "    %s\n", red.Sprint("missing"))
+			continue
+		case param.Type == domain.ParamTypeSecret:
+			value = models.RedactedValue
+		}
+
+		fmt.Fprintf(r.out, "    %s\n", green.Sprint(value))
+		if ok {
+			from := string(source.Kind)
+			if source.Location != "" {
+				from += " " + source.Location
+			}
+			if source.Reference != "" {
+				from += fmt.Sprintf(" → %s", source.Reference)
+			}
+			fmt.Fprintf(r.out, "    %s\n", gray.Sprintf("from %s", from))
+		}
+	}
+}
+
+// RenderChangesetPreview displays the registry changes a simulated run would make
+func (r *ScriptRenderer) RenderChangesetPreview(preview *usecase.ChangesetPreview) {
+	exec := preview.RunResult
+	changeset := preview.Changeset
+
+	fmt.Fprintf(r.out, "\n%s\n", bold.Sprint("📋 Changeset Preview:"))
+	fmt.Fprintf(r.out, "%s\n", gray.Sprint(strings.Repeat("─", 50)))
+
+	if len(changeset.Create.Deployments) > 0 {
+		fmt.Fprintf(r.out, "New deployments (%d):\n", len(changeset.Create.Deployments))
+		for _, dep := range changeset.Create.Deployments {
+			typeStr := ""
+			if dep.Type != models.SingletonDeployment {
+				typeStr = yellow.Sprintf(" [%s]", dep.Type)
+			}
+			fmt.Fprintf(r.out, "  %s %s at %s%s\n",
+				green.Sprint("+"), cyan.Sprint(dep.GetDisplayName()), green.Sprint(dep.Address), typeStr)
+		}
+	}
+
+	if len(changeset.Update.Deployments) > 0 {
+		fmt.Fprintf(r.out, "Proxy upgrades (%d):\n", len(changeset.Update.Deployments))
+		for _, dep := range changeset.Update.Deployments {
+			if dep.ProxyInfo == nil {
+				continue
+			}
+			previous := ""
+			if n := len(dep.ProxyInfo.History); n > 0 {
+				previous = gray.Sprintf(" (was %s)", dep.ProxyInfo.History[n-1].ImplementationID)
+			}
+			fmt.Fprintf(r.out, "  %s %s → %s%s\n",
+				yellow.Sprint("~"), cyan.Sprint(dep.GetDisplayName()), green.Sprint(dep.ProxyInfo.Implementation), previous)
+		}
+	}
+
+	if len(exec.SafeTransactions) > 0 {
+		fmt.Fprintf(r.out, "Safe batches (%d):\n", len(exec.SafeTransactions))
+		for _, safeTx := range exec.SafeTransactions {
+			fmt.Fprintf(r.out, "  %s Safe %s: %d transaction(s) %s\n",
+				cyan.Sprint("•"), safeTx.Safe.Hex(), len(safeTx.TransactionIds),
+				gray.Sprintf("(safeTxHash 0x%x)", safeTx.SafeTxHash))
+		}
+	}
+
+	if len(exec.GovernorProposals) > 0 {
+		fmt.Fprintf(r.out, "Governor proposals (%d):\n", len(exec.GovernorProposals))
+		for _, proposal := range exec.GovernorProposals {
+			fmt.Fprintf(r.out, "  %s Governor %s: %d transaction(s)\n",
+				cyan.Sprint("•"), proposal.Governor.Hex(), len(proposal.TransactionIds))
+		}
+	}
+
+	if len(exec.TimelockOperations) > 0 {
+		fmt.Fprintf(r.out, "Timelock operations (%d):\n", len(exec.TimelockOperations))
+		for _, op := range exec.TimelockOperations {
+			fmt.Fprintf(r.out, "  %s Timelock %s: %d call(s) %s\n",
+				cyan.Sprint("•"), op.Timelock.Hex(), len(op.Calls),
+				gray.Sprintf("(operation %s)", op.OperationID.Hex()))
+		}
+	}
+
+	if len(exec.Collisions) > 0 {
+		fmt.Fprintf(r.out, "Collisions (%d):\n", len(exec.Collisions))
+		for _, address := range sortedCollisionAddresses(exec.Collisions) {
+			collision := exec.Collisions[address]
+			fmt.Fprintf(r.out, "  %s %s already deployed at %s\n",
+				yellow.Sprint("!"), cyan.Sprint(extractContractName(collision.DeploymentDetails.Artifact)), yellow.Sprint(address.Hex()))
+		}
+	}
+
+	if !changeset.HasChanges() && len(exec.Collisions) == 0 {
+		fmt.Fprintf(r.out, "%s\n", gray.Sprint("No registry changes"))
+	}
+
+	fmt.Fprintf(r.out, "Transactions to broadcast: %d\n", len(exec.Transactions))
+	fmt.Fprintf(r.out, "%s\n", gray.Sprint(strings.Repeat("─", 50)))
+}
+
+// sortedCollisionAddresses returns the addresses of collisions in a stable order
+func sortedCollisionAddresses(collisions map[common.Address]*bindings.TrebDeploymentCollision) []common.Address {
+	addresses := make([]common.Address, 0, len(collisions))
+	for address := range collisions {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+	return addresses
+}
+
 // extractContractName extracts just the contract name from an artifact path
 func extractContractName(artifact string) string {
 	// First check if it has a colon separator (Foundry format)
@@ -0,0 +1,509 @@
+package render
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// RunJSONSchemaVersion is the version of the `treb run --json` and `treb compose --json` documents.
+// Bump it whenever a field is renamed, removed or changes meaning; adding fields does not need a bump.
+// The schema is documented in docs/JSON_OUTPUT.md.
+const RunJSONSchemaVersion = 1
+
+// RunJSON is the machine-readable result of `treb run --json`
+type RunJSON struct {
+	SchemaVersion      int                      `json:"schemaVersion"`
+	Success            bool                     `json:"success"`
+	Error              string                   `json:"error,omitempty"`
+	Aborted            bool                     `json:"aborted,omitempty"`
+	Script             string                   `json:"script,omitempty"`
+	Network            string                   `json:"network,omitempty"`
+	ChainID            uint64                   `json:"chainId,omitempty"`
+	Namespace          string                   `json:"namespace,omitempty"`
+	DryRun             bool                     `json:"dryRun"`
+	Transactions       []TransactionJSON        `json:"transactions"`
+	Deployments        []DeploymentJSON         `json:"deployments"`
+	SafeTransactions   []SafeTransactionJSON    `json:"safeTransactions"`
+	GovernorProposals  []GovernorProposalJSON   `json:"governorProposals"`
+	TimelockOperations []TimelockOperationJSON  `json:"timelockOperations"`
+	Collisions         []CollisionJSON          `json:"collisions"`
+	Changeset          *ChangesetJSON           `json:"changeset"`
+	Attempts           []forge.BroadcastAttempt `json:"attempts,omitempty"`
+	RunID              string                   `json:"runId,omitempty"`
+}
+
+// TransactionJSON is a transaction of a run
+type TransactionJSON struct {
+	ID          string    `json:"id"`
+	Status      string    `json:"status"`
+	Sender      string    `json:"sender"`
+	SenderName  string    `json:"senderName,omitempty"`
+	To          string    `json:"to"`
+	Value       string    `json:"value"`
+	Data        string    `json:"data"`
+	Call        *CallJSON `json:"call,omitempty"`
+	Hash        string    `json:"hash,omitempty"`
+	BlockNumber *uint64   `json:"blockNumber,omitempty"`
+	GasUsed     *uint64   `json:"gasUsed,omitempty"`
+	SafeTxHash  string    `json:"safeTxHash,omitempty"`
+	OperationID string    `json:"timelockOperationId,omitempty"`
+}
+
+// CallJSON is the decoded call of a transaction
+type CallJSON struct {
+	Target string        `json:"target"`
+	Method string        `json:"method"`
+	Args   []CallArgJSON `json:"args"`
+}
+
+// CallArgJSON is a decoded call argument
+type CallArgJSON struct {
+	Name  string `json:"name,omitempty"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+// DeploymentJSON is a contract deployed by a run
+type DeploymentJSON struct {
+	Contract       string `json:"contract"`
+	Artifact       string `json:"artifact"`
+	Address        string `json:"address"`
+	Label          string `json:"label,omitempty"`
+	Entropy        string `json:"entropy,omitempty"`
+	Strategy       string `json:"strategy"`
+	Salt           string `json:"salt"`
+	Deployer       string `json:"deployer"`
+	TransactionID  string `json:"transactionId"`
+	Implementation string `json:"implementation,omitempty"`
+	ProxyType      string `json:"proxyType,omitempty"`
+}
+
+// SafeTransactionJSON is a Safe batch proposed or executed by a run
+type SafeTransactionJSON struct {
+	SafeTxHash      string   `json:"safeTxHash"`
+	Safe            string   `json:"safe"`
+	Proposer        string   `json:"proposer"`
+	Executed        bool     `json:"executed"`
+	ExecutionTxHash string   `json:"executionTxHash,omitempty"`
+	TransactionIDs  []string `json:"transactionIds"`
+}
+
+// GovernorProposalJSON is a Governor proposal created by a run
+type GovernorProposalJSON struct {
+	ProposalID     string   `json:"proposalId"`
+	Governor       string   `json:"governor"`
+	Timelock       string   `json:"timelock,omitempty"`
+	Proposer       string   `json:"proposer"`
+	TransactionIDs []string `json:"transactionIds"`
+}
+
+// TimelockOperationJSON is an operation scheduled on a TimelockController by a run
+type TimelockOperationJSON struct {
+	OperationID    string   `json:"operationId"`
+	Timelock       string   `json:"timelock"`
+	Proposer       string   `json:"proposer,omitempty"`
+	Delay          string   `json:"delay"`
+	Calls          int      `json:"calls"`
+	TransactionIDs []string `json:"transactionIds"`
+}
+
+// CollisionJSON is a deployment skipped because the contract already exists
+type CollisionJSON struct {
+	Contract string `json:"contract"`
+	Artifact string `json:"artifact"`
+	Address  string `json:"address"`
+	Label    string `json:"label,omitempty"`
+}
+
+// ChangesetJSON lists the registry entries written by a run
+type ChangesetJSON struct {
+	Create ChangesetModelsJSON `json:"create"`
+	Update ChangesetModelsJSON `json:"update"`
+	Delete ChangesetModelsJSON `json:"delete"`
+}
+
+// ChangesetModelsJSON lists registry IDs per entity type
+type ChangesetModelsJSON struct {
+	Deployments        []string `json:"deployments"`
+	Transactions       []string `json:"transactions"`
+	SafeTransactions   []string `json:"safeTransactions"`
+	TimelockOperations []string `json:"timelockOperations"`
+}
+
+// ComposeJSON is the machine-readable result of `treb compose --json`
+type ComposeJSON struct {
+	SchemaVersion    int               `json:"schemaVersion"`
+	Group            string            `json:"group"`
+	Success          bool              `json:"success"`
+	Status           string            `json:"status"`
+	Error            string            `json:"error,omitempty"`
+	TotalDeployments int               `json:"totalDeployments"`
+	Steps            []ComposeStepJSON `json:"steps"`
+	Hooks            []HookJSON        `json:"hooks,omitempty"` // Hooks of the compose run
+}
+
+// ComposeStepJSON is a step of the compose plan together with its outcome
+type ComposeStepJSON struct {
+	Name         string            `json:"name"`
+	Script       string            `json:"script"`
+	Network      string            `json:"network,omitempty"`
+	Dependencies []string          `json:"dependencies"`
+	Env          map[string]string `json:"env,omitempty"`
+	Status       string            `json:"status"`
+	Error        string            `json:"error,omitempty"`
+	Run          *RunJSON          `json:"run,omitempty"`
+	Hooks        []HookJSON        `json:"hooks,omitempty"`
+}
+
+// HookJSON is the outcome of a compose hook
+type HookJSON struct {
+	Hook    string `json:"hook"`
+	Run     string `json:"run,omitempty"`
+	Action  string `json:"action,omitempty"`
+	Success bool   `json:"success"`
+	Output  string `json:"output,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BuildRunJSON converts a run result into the versioned JSON document
+func (r *ScriptRenderer) BuildRunJSON(result *usecase.RunScriptResult) *RunJSON {
+	doc := &RunJSON{
+		SchemaVersion:      RunJSONSchemaVersion,
+		Success:            result.Success,
+		Transactions:       []TransactionJSON{},
+		Deployments:        []DeploymentJSON{},
+		SafeTransactions:   []SafeTransactionJSON{},
+		GovernorProposals:  []GovernorProposalJSON{},
+		TimelockOperations: []TimelockOperationJSON{},
+		Collisions:         []CollisionJSON{},
+		Aborted:            result.Aborted,
+		Attempts:           result.Attempts,
+		RunID:              result.RunID,
+	}
+	if result.Error != nil {
+		doc.Error = result.Error.Error()
+	}
+	if result.Changeset != nil {
+		doc.Changeset = buildChangesetJSON(result.Changeset)
+	}
+
+	exec := result.RunResult
+	if exec == nil || exec.RunResult == nil {
+		return doc
+	}
+
+	if exec.Script != nil {
+		doc.Script = exec.Script.Name
+	}
+	doc.Network = exec.Network
+	doc.ChainID = exec.ChainID
+	doc.Namespace = exec.Namespace
+	doc.DryRun = exec.DryRun
+
+	if len(exec.Transactions) > 0 {
+		txRenderer := r.txRenderer.WithExecution(exec)
+		for _, tx := range exec.Transactions {
+			doc.Transactions = append(doc.Transactions, txRenderer.buildTransactionJSON(tx))
+		}
+	}
+
+	for _, dep := range exec.Deployments {
+		entry := DeploymentJSON{
+			Address:       dep.Address.Hex(),
+			Deployer:      dep.Deployer.Hex(),
+			TransactionID: hexBytes(dep.TransactionID[:]),
+		}
+		if dep.Event != nil {
+			entry.Contract = extractContractName(dep.Event.Artifact)
+			entry.Artifact = dep.Event.Artifact
+			entry.Label = dep.Event.Label
+			entry.Entropy = dep.Event.Entropy
+			entry.Strategy = dep.Event.CreateStrategy
+			entry.Salt = hexBytes(dep.Event.Salt[:])
+		}
+		if dep.Contract != nil && dep.Contract.Name != "" {
+			entry.Contract = dep.Contract.Name
+		}
+		if rel, ok := exec.ProxyRelationships[dep.Address]; ok {
+			entry.Implementation = rel.ImplementationAddress.Hex()
+			entry.ProxyType = string(rel.ProxyType)
+		}
+		doc.Deployments = append(doc.Deployments, entry)
+	}
+
+	for _, safeTx := range exec.SafeTransactions {
+		entry := SafeTransactionJSON{
+			SafeTxHash:     hexBytes(safeTx.SafeTxHash[:]),
+			Safe:           safeTx.Safe.Hex(),
+			Proposer:       safeTx.Proposer.Hex(),
+			Executed:       safeTx.Executed,
+			TransactionIDs: transactionIDs(safeTx.TransactionIds),
+		}
+		if safeTx.ExecutionTxHash != nil {
+			entry.ExecutionTxHash = safeTx.ExecutionTxHash.Hex()
+		}
+		doc.SafeTransactions = append(doc.SafeTransactions, entry)
+	}
+
+	for _, proposal := range exec.GovernorProposals {
+		entry := GovernorProposalJSON{
+			Governor:       proposal.Governor.Hex(),
+			Proposer:       proposal.Proposer.Hex(),
+			TransactionIDs: transactionIDs(proposal.TransactionIds),
+		}
+		if proposal.ProposalId != nil {
+			entry.ProposalID = proposal.ProposalId.String()
+		}
+		if proposal.Timelock != (common.Address{}) {
+			entry.Timelock = proposal.Timelock.Hex()
+		}
+		doc.GovernorProposals = append(doc.GovernorProposals, entry)
+	}
+
+	for _, op := range exec.TimelockOperations {
+		entry := TimelockOperationJSON{
+			OperationID:    op.OperationID.Hex(),
+			Timelock:       op.Timelock.Hex(),
+			Delay:          "0",
+			Calls:          len(op.Calls),
+			TransactionIDs: transactionIDs(op.TransactionIds),
+		}
+		if op.Proposer != (common.Address{}) {
+			entry.Proposer = op.Proposer.Hex()
+		}
+		if op.Delay != nil {
+			entry.Delay = op.Delay.String()
+		}
+		doc.TimelockOperations = append(doc.TimelockOperations, entry)
+	}
+
+	for address, collision := range exec.Collisions {
+		doc.Collisions = append(doc.Collisions, CollisionJSON{
+			Contract: extractContractName(collision.DeploymentDetails.Artifact),
+			Artifact: collision.DeploymentDetails.Artifact,
+			Address:  address.Hex(),
+			Label:    collision.DeploymentDetails.Label,
+		})
+	}
+	// Collisions come from a map, keep the document stable
+	sort.Slice(doc.Collisions, func(i, j int) bool {
+		return doc.Collisions[i].Address < doc.Collisions[j].Address
+	})
+
+	return doc
+}
+
+// BuildComposeJSON converts a compose result into the versioned JSON document
+func (r *ScriptRenderer) BuildComposeJSON(result *usecase.ComposeResult) *ComposeJSON {
+	doc := &ComposeJSON{
+		SchemaVersion:    RunJSONSchemaVersion,
+		Success:          result.Success,
+		Status:           "completed",
+		TotalDeployments: result.TotalDeployments,
+		Steps:            []ComposeStepJSON{},
+	}
+	if !result.Success {
+		doc.Status = "failed"
+	}
+	if result.FailedStep != nil && result.FailedStep.Error != nil {
+		doc.Error = result.FailedStep.Error.Error()
+	} else if result.HookError != nil {
+		doc.Error = result.HookError.Error()
+	}
+	doc.Hooks = buildHooksJSON(result.Hooks)
+	if result.Plan == nil {
+		return doc
+	}
+	doc.Group = result.Plan.Group
+
+	executed := make(map[string]*usecase.StepResult, len(result.ExecutedSteps)+len(result.SkippedSteps))
+	for _, stepResult := range slices.Concat(result.ExecutedSteps, result.SkippedSteps) {
+		if stepResult.Step != nil {
+			executed[stepResult.Step.Name] = stepResult
+		}
+	}
+
+	for _, step := range result.Plan.Components {
+		entry := ComposeStepJSON{
+			Name:         step.Name,
+			Script:       step.Script,
+			Network:      step.Network,
+			Dependencies: step.Dependencies,
+			Env:          step.Env,
+			Status:       "pending",
+		}
+		if entry.Dependencies == nil {
+			entry.Dependencies = []string{}
+		}
+
+		if stepResult, ok := executed[step.Name]; ok {
+			switch {
+			case stepResult.Skipped:
+				entry.Status = "skipped"
+			case stepResult.Error != nil:
+				entry.Status = "failed"
+				entry.Error = stepResult.Error.Error()
+			case stepResult.RunResult != nil && !stepResult.RunResult.Success:
+				entry.Status = "failed"
+			default:
+				entry.Status = "completed"
+			}
+			// Steps restored from a previous compose run carry no run data
+			if stepResult.RunResult != nil && stepResult.RunResult.RunResult != nil {
+				entry.Run = r.BuildRunJSON(stepResult.RunResult)
+			}
+			entry.Hooks = buildHooksJSON(stepResult.Hooks)
+		}
+
+		doc.Steps = append(doc.Steps, entry)
+	}
+
+	return doc
+}
+
+// buildHooksJSON converts the outcome of compose hooks
+func buildHooksJSON(hooks []*usecase.HookResult) []HookJSON {
+	var entries []HookJSON
+	for _, hook := range hooks {
+		entries = append(entries, HookJSON{
+			Hook:    hook.Hook,
+			Run:     hook.Run,
+			Action:  hook.Action,
+			Success: hook.Success,
+			Output:  hook.Output,
+			Error:   hook.Error,
+		})
+	}
+	return entries
+}
+
+// buildTransactionJSON converts a transaction, decoding its call when the ABI is known
+func (tr *TransactionRenderer) buildTransactionJSON(tx *forge.Transaction) TransactionJSON {
+	entry := TransactionJSON{
+		ID:          hexBytes(tx.TransactionId[:]),
+		Status:      string(tx.Status),
+		Sender:      tx.Sender.Hex(),
+		SenderName:  tr.senderName(tx.Sender),
+		To:          tx.Transaction.To.Hex(),
+		Value:       "0",
+		Data:        hexBytes(tx.Transaction.Data),
+		BlockNumber: tx.BlockNumber,
+		GasUsed:     tx.GasUsed,
+	}
+	if entry.SenderName == "<unknown sender>" {
+		entry.SenderName = ""
+	}
+	if tx.Transaction.Value != nil {
+		entry.Value = tx.Transaction.Value.String()
+	}
+	if tx.TxHash != nil {
+		entry.Hash = tx.TxHash.Hex()
+	}
+	if tx.SafeTransaction != nil {
+		entry.SafeTxHash = hexBytes(tx.SafeTransaction.SafeTxHash[:])
+	}
+	if tx.TimelockOperation != nil {
+		entry.OperationID = tx.TimelockOperation.OperationID.Hex()
+	}
+
+	decoded := tr.txDecoder.DecodeTransaction(tx.Transaction.To, tx.Transaction.Data, tx.Transaction.Value, tx.ReturnData)
+	if decoded != nil && decoded.Method != "" {
+		call := &CallJSON{
+			Target: tr.txDecoder.GetLabel(decoded.To),
+			Method: decoded.Method,
+			Args:   []CallArgJSON{},
+		}
+		for _, input := range decoded.Inputs {
+			call.Args = append(call.Args, CallArgJSON{
+				Name:  input.Name,
+				Type:  input.Type,
+				Value: jsonArgValue(input.Value),
+			})
+		}
+		entry.Call = call
+	}
+
+	return entry
+}
+
+// buildChangesetJSON lists the registry IDs touched by a changeset
+func buildChangesetJSON(changeset *models.Changeset) *ChangesetJSON {
+	return &ChangesetJSON{
+		Create: buildChangesetModelsJSON(&changeset.Create),
+		Update: buildChangesetModelsJSON(&changeset.Update),
+		Delete: buildChangesetModelsJSON(&changeset.Delete),
+	}
+}
+
+func buildChangesetModelsJSON(changes *models.ChangesetModels) ChangesetModelsJSON {
+	out := ChangesetModelsJSON{
+		Deployments:        []string{},
+		Transactions:       []string{},
+		SafeTransactions:   []string{},
+		TimelockOperations: []string{},
+	}
+	for _, dep := range changes.Deployments {
+		out.Deployments = append(out.Deployments, dep.ID)
+	}
+	for _, tx := range changes.Transactions {
+		out.Transactions = append(out.Transactions, tx.ID)
+	}
+	for _, safeTx := range changes.SafeTransactions {
+		out.SafeTransactions = append(out.SafeTransactions, safeTx.SafeTxHash)
+	}
+	for _, op := range changes.TimelockOperations {
+		out.TimelockOperations = append(out.TimelockOperations, op.OperationID)
+	}
+	return out
+}
+
+// jsonArgValue converts decoded ABI values into JSON friendly representations
+func jsonArgValue(value any) any {
+	switch v := value.(type) {
+	case []byte:
+		return hexBytes(v)
+	case [32]byte:
+		return hexBytes(v[:])
+	case common.Hash:
+		return v.Hex()
+	case common.Address:
+		return v.Hex()
+	case *common.Address:
+		if v == nil {
+			return nil
+		}
+		return v.Hex()
+	case *big.Int:
+		if v == nil {
+			return nil
+		}
+		return v.String()
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = jsonArgValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func transactionIDs(ids [][32]byte) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, hexBytes(id[:]))
+	}
+	return out
+}
+
+func hexBytes(b []byte) string {
+	return fmt.Sprintf("0x%x", b)
+}
@@ -0,0 +1,111 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain/bindings"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+func newTestScriptRenderer() *ScriptRenderer {
+	return NewScriptRenderer(io.Discard, nil, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestBuildRunJSON(t *testing.T) {
+	proxy := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	impl := common.HexToAddress("0x2000000000000000000000000000000000000002")
+
+	result := &usecase.RunScriptResult{
+		Success: true,
+		RunResult: &forge.HydratedRunResult{
+			RunResult: &forge.RunResult{
+				Script:    &models.Contract{Name: "DeployCounter"},
+				Network:   "sepolia",
+				ChainID:   11155111,
+				Namespace: "default",
+			},
+			Deployments: []*forge.Deployment{{
+				Address: proxy,
+				Event: &bindings.ITrebEventsDeploymentDetails{
+					Artifact:       "src/Counter.sol:Counter",
+					Label:          "v1",
+					CreateStrategy: "CREATE3",
+				},
+			}},
+			ProxyRelationships: map[common.Address]*forge.ProxyRelationship{
+				proxy: {ProxyAddress: proxy, ImplementationAddress: impl, ProxyType: forge.ProxyTypeUUPS},
+			},
+		},
+		Changeset: &models.Changeset{
+			Create: models.ChangesetModels{Deployments: []*models.Deployment{{ID: "default/11155111/Counter:v1"}}},
+		},
+	}
+
+	doc := newTestScriptRenderer().BuildRunJSON(result)
+
+	assert.Equal(t, RunJSONSchemaVersion, doc.SchemaVersion)
+	assert.Equal(t, "DeployCounter", doc.Script)
+	require.Len(t, doc.Deployments, 1)
+	assert.Equal(t, "Counter", doc.Deployments[0].Contract)
+	assert.Equal(t, "CREATE3", doc.Deployments[0].Strategy)
+	assert.Equal(t, impl.Hex(), doc.Deployments[0].Implementation)
+	assert.Equal(t, []string{"default/11155111/Counter:v1"}, doc.Changeset.Create.Deployments)
+
+	// Empty collections serialize as arrays so consumers never need null checks
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.Equal(t, []any{}, raw["transactions"])
+	assert.Equal(t, []any{}, raw["safeTransactions"])
+
+	// Runs that fail before forge starts have nothing but the error
+	doc = newTestScriptRenderer().BuildRunJSON(&usecase.RunScriptResult{Error: fmt.Errorf("failed to resolve script: not found")})
+	assert.False(t, doc.Success)
+	assert.Equal(t, "failed to resolve script: not found", doc.Error)
+	assert.Empty(t, doc.Deployments)
+	assert.NotNil(t, doc.Deployments)
+
+	doc = newTestScriptRenderer().BuildRunJSON(&usecase.RunScriptResult{Aborted: true, Error: fmt.Errorf("broadcast cancelled by user")})
+	assert.True(t, doc.Aborted)
+}
+
+func TestBuildComposeJSON(t *testing.T) {
+	plan := &usecase.ExecutionPlan{
+		Group: "Protocol",
+		Components: []*usecase.ExecutionStep{
+			{Name: "Token", Script: "DeployToken"},
+			{Name: "Vault", Script: "DeployVault", Dependencies: []string{"Token"}},
+			{Name: "Router", Script: "DeployRouter", Dependencies: []string{"Vault"}},
+		},
+	}
+	failed := &usecase.StepResult{Step: plan.Components[1], Error: fmt.Errorf("revert")}
+	result := &usecase.ComposeResult{
+		Plan: plan,
+		ExecutedSteps: []*usecase.StepResult{
+			{Step: plan.Components[0], RunResult: &usecase.RunScriptResult{Success: true}},
+			failed,
+		},
+		FailedStep: failed,
+	}
+
+	doc := newTestScriptRenderer().BuildComposeJSON(result)
+
+	assert.Equal(t, "failed", doc.Status)
+	assert.Equal(t, "revert", doc.Error)
+	require.Len(t, doc.Steps, 3)
+	assert.Equal(t, "completed", doc.Steps[0].Status)
+	assert.Nil(t, doc.Steps[0].Run, "steps without run data carry no run document")
+	assert.Equal(t, "failed", doc.Steps[1].Status)
+	assert.Equal(t, "pending", doc.Steps[2].Status)
+	assert.Equal(t, []string{}, doc.Steps[0].Dependencies)
+}
@@ -0,0 +1,45 @@
+package render
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/trebuchet-org/treb-cli/internal/domain/bindings"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+func TestRenderChangesetPreview_SortsCollisions(t *testing.T) {
+	collisions := make(map[common.Address]*bindings.TrebDeploymentCollision)
+	for i, name := range []string{"Counter", "Token", "Vault", "Oracle", "Router"} {
+		collisions[common.Address{byte(0xf0 - i*0x10)}] = &bindings.TrebDeploymentCollision{
+			DeploymentDetails: bindings.ITrebEventsDeploymentDetails{Artifact: "src/" + name + ".sol:" + name},
+		}
+	}
+	preview := &usecase.ChangesetPreview{
+		RunResult: &forge.HydratedRunResult{Collisions: collisions},
+		Changeset: &models.Changeset{},
+	}
+
+	var first string
+	for range 5 {
+		var out bytes.Buffer
+		r := NewScriptRenderer(&out, nil, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		r.RenderChangesetPreview(preview)
+
+		if first == "" {
+			first = out.String()
+			continue
+		}
+		assert.Equal(t, first, out.String())
+	}
+
+	// Router has the lowest address and Counter the highest
+	assert.Less(t, strings.Index(first, "Router"), strings.Index(first, "Counter"))
+}
@@ -20,20 +20,23 @@ type TransactionRenderer struct {
 	txDecoder    *abi.TransactionDecoder
 	eventDecoder *abi.EventDecoder
 
-	repo      usecase.DeploymentRepository
-	log       *slog.Logger
-	tx        *forge.Transaction
-	execution *forge.HydratedRunResult
+	repo        usecase.DeploymentRepository
+	addressBook usecase.AddressBookStore
+	log         *slog.Logger
+	tx          *forge.Transaction
+	execution   *forge.HydratedRunResult
 }
 
 // NewTransactionRenderer creates a new transaction display handler
 func NewTransactionRenderer(
 	abiResolver usecase.ABIResolver,
 	repo usecase.DeploymentRepository,
+	addressBook usecase.AddressBookStore,
 	log *slog.Logger,
 ) *TransactionRenderer {
 	return &TransactionRenderer{
 		repo:        repo,
+		addressBook: addressBook,
 		abiResolver: abiResolver,
 		log:         log.With("component", "TxRenderer"),
 	}
@@ -46,6 +49,7 @@ func (tr *TransactionRenderer) WithExecution(execution *forge.HydratedRunResult)
 	scopedTr.txDecoder = abi.NewTransactionDecoder(
 		tr.abiResolver,
 		tr.repo,
+		tr.addressBook,
 		execution,
 		tr.log,
 	)
@@ -454,6 +458,10 @@ func (tr *TransactionRenderer) displayTransactionFooter() {
 		details = append(details, fmt.Sprintf("Proposal: %s", tx.GovernorProposal.ProposalId.String()))
 	}
 
+	if tx.TimelockOperation != nil {
+		details = append(details, fmt.Sprintf("Timelock Op: %s", tx.TimelockOperation.OperationID.Hex()))
+	}
+
 	if tx.BlockNumber != nil {
 		details = append(details, fmt.Sprintf("Block: %d", *tx.BlockNumber))
 	}
@@ -63,6 +63,9 @@ The namespace and network are determined from the current configuration context
 			if len(del.SafeTransactions) > 0 {
 				fmt.Fprintf(cmd.OutOrStdout(), "  Safe Transactions:  %d\n", len(del.SafeTransactions))
 			}
+			if len(del.TimelockOperations) > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "  Timelock Ops:       %d\n", len(del.TimelockOperations))
+			}
 			fmt.Fprintln(cmd.OutOrStdout())
 
 			// Handle confirmation
@@ -76,6 +76,7 @@ smart contract deployments using CreateX factory contracts.`,
 
 	// Global flags
 	rootCmd.PersistentFlags().Bool("non-interactive", false, "Disable interactive prompts")
+	rootCmd.PersistentFlags().String("events", "", "Stream progress events as NDJSON: ndjson (stderr), ndjson=- (stdout) or ndjson=<path>")
 
 	// Add command groups
 	rootCmd.AddGroup(&cobra.Group{
@@ -104,19 +105,23 @@ smart contract deployments using CreateX factory contracts.`,
 	generateCmd.GroupID = "main"
 	rootCmd.AddCommand(generateCmd)
 
-	runCmd := NewRunCmd()
+	runCmd := withLifecycleEvents(NewRunCmd())
 	runCmd.GroupID = "main"
 	rootCmd.AddCommand(runCmd)
 
-	verifyCmd := NewVerifyCmd()
+	describeCmd := NewDescribeCmd()
+	describeCmd.GroupID = "main"
+	rootCmd.AddCommand(describeCmd)
+
+	verifyCmd := withLifecycleEvents(NewVerifyCmd())
 	verifyCmd.GroupID = "main"
 	rootCmd.AddCommand(verifyCmd)
 
-	composeCmd := NewComposeCmd()
+	composeCmd := withLifecycleEvents(NewComposeCmd())
 	composeCmd.GroupID = "main"
 	rootCmd.AddCommand(composeCmd)
 
-	syncCmd := NewSyncCmd()
+	syncCmd := withLifecycleEvents(NewSyncCmd())
 	syncCmd.GroupID = "management"
 	rootCmd.AddCommand(syncCmd)
 
@@ -141,6 +146,14 @@ smart contract deployments using CreateX factory contracts.`,
 	networksCmd.GroupID = "management"
 	rootCmd.AddCommand(networksCmd)
 
+	accountsCmd := NewAccountsCmd()
+	accountsCmd.GroupID = "management"
+	rootCmd.AddCommand(accountsCmd)
+
+	addressBookCmd := NewAddressBookCmd()
+	addressBookCmd.GroupID = "management"
+	rootCmd.AddCommand(addressBookCmd)
+
 	pruneCmd := NewPruneCmd()
 	pruneCmd.GroupID = "management"
 	rootCmd.AddCommand(pruneCmd)
@@ -157,6 +170,14 @@ smart contract deployments using CreateX factory contracts.`,
 	migrateCmd.GroupID = "management"
 	rootCmd.AddCommand(migrateCmd)
 
+	runsCmd := NewRunsCmd()
+	runsCmd.GroupID = "management"
+	rootCmd.AddCommand(runsCmd)
+
+	timelockCmd := NewTimelockCmd()
+	timelockCmd.GroupID = "management"
+	rootCmd.AddCommand(timelockCmd)
+
 	// Version command
 	versionCmd := NewVersionCmd()
 	rootCmd.AddCommand(versionCmd)
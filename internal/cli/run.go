@@ -5,17 +5,23 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/trebuchet-org/treb-cli/internal/app"
 	"github.com/trebuchet-org/treb-cli/internal/usecase"
 )
 
 // NewRunCmd creates the run command using the new architecture
 func NewRunCmd() *cobra.Command {
 	var (
-		envVars   []string
-		dryRun    bool
-		debug     bool
-		debugJSON bool
-		dumpCmd   bool
+		envVars    []string
+		dryRun     bool
+		debug      bool
+		debugJSON  bool
+		dumpCmd    bool
+		showParams bool
+		confirm    bool
+		yes        bool
+		resume     bool
+		jsonOutput bool
 	)
 
 	cmd := &cobra.Command{
@@ -43,6 +49,23 @@ Supported parameter types:
 - Base types: string, address, uint256, int256, bytes32, bytes
 - Meta types: sender (sender ID), deployment (contract reference), artifact (contract name)
 
+Constraints follow the parameter name and are checked before forge runs:
+  @custom:env {uint256} fee min=0 max=10000 Fee in basis points
+  @custom:env {address} owner nonzero contract Owner contract
+  @custom:env {string} mode enum=fast|safe Execution mode
+pattern=<regexp> is supported too. Missing values are prompted for unless
+--non-interactive is set, with enum choices offered as a picker.
+
+Deployment parameters take references from any namespace or chain, such as
+production/42220/Token:v1 or celo:Token@latest, and are passed as addresses.
+
+Parameter Files:
+Values can be kept in params/<namespace>/<script>.toml, with [networks.<name>]
+tables overriding them per network. Files of parent namespaces (and params/default)
+apply too. --env and TREB_<NAME> variables take precedence over files. Values like
+"@Token:v1" resolve to a deployment address, "@account:admin" to a sender address.
+Use --show-params to see the resolved values and where each came from.
+
 Examples:
   # Run a deployment script
   treb run script/deploy/DeployCounter.s.sol
@@ -53,6 +76,9 @@ Examples:
   # Run with deployment reference
   treb run script/deploy/DeployProxy.s.sol --env implementation=Counter:v1
 
+  # Show the resolved parameters and their sources without running
+  treb run script/deploy/DeployCounter.s.sol --network mainnet --show-params
+
   # Run with dry-run to see what would happen
   treb run script/deploy/DeployCounter.s.sol --dry-run
 
@@ -60,7 +86,28 @@ Examples:
   treb run script/deploy/DeployCounter.s.sol --debug
 
   # Run with specific network and profile
-  treb run script/deploy/DeployCounter.s.sol --network sepolia --profile production`,
+  treb run script/deploy/DeployCounter.s.sol --network sepolia --profile production
+
+  # Resume a broadcast that was interrupted halfway
+  treb run script/deploy/DeployCounter.s.sol --network sepolia --resume
+
+  # Emit a machine-readable result for CI pipelines
+  treb run script/deploy/DeployCounter.s.sol --network sepolia --json
+
+  # Review the simulated changeset and confirm before broadcasting
+  treb run script/deploy/DeployCounter.s.sol --network mainnet --confirm
+
+Confirmation Gate:
+With --confirm, or require_confirmation = true on the namespace in treb.toml,
+the script is simulated first and the resulting registry changes (new deployments,
+proxy upgrades, Safe batches, collisions) are shown before asking to broadcast.
+In --non-interactive mode the gate fails closed unless --yes is given.
+
+Resuming Broadcasts:
+When a broadcast fails partway (RPC timeout, gas spike, unplugged hardware wallet),
+treb keeps a checkpoint of the run. --resume checks the broadcast file against the
+chain, records every transaction that already landed in the registry, and then lets
+forge send the remaining ones. The output lists the transactions landed per attempt.`,
 		Args:         cobra.ExactArgs(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -92,7 +139,15 @@ Examples:
 				Debug:       debug,
 				DebugJSON:   debugJSON,
 				DumpCommand: dumpCmd,
+				ShowParams:  showParams,
+				Resume:      resume,
+				Confirm:     confirm,
+				Yes:         yes,
 			}
+			if jsonOutput && !dumpCmd && !showParams {
+				return runScriptJSON(cmd, app, params)
+			}
+
 			result, err := app.RunScript.Run(cmd.Context(), params)
 			if err != nil {
 				return err
@@ -101,6 +156,14 @@ Examples:
 				fmt.Print(result.DumpedCommand)
 				return nil
 			}
+			if params.ShowParams {
+				app.ScriptRenderer.RenderParameterSources(result)
+				return nil
+			}
+			if result.Aborted {
+				fmt.Println("❌ Broadcast cancelled.")
+				return nil
+			}
 			if result.Error != nil {
 				return result.Error
 			}
@@ -125,8 +188,49 @@ Examples:
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Perform a dry run without broadcasting transactions")
 	cmd.Flags().BoolVar(&debug, "debug", false, "Enable debug mode (shows forge output and saves to file)")
 	cmd.Flags().BoolVar(&debugJSON, "debug-json", false, "Enable JSON debug mode (shows raw JSON output)")
+	cmd.Flags().BoolVar(&resume, "resume", false, "Resume an interrupted broadcast, recording already mined transactions first")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Show the simulated changeset and ask for confirmation before broadcasting")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Approve the confirmation gate without prompting")
 	cmd.Flags().BoolVar(&dumpCmd, "dump-command", false, "Print the underlying forge command (with injected env vars) without executing")
+	cmd.Flags().BoolVar(&showParams, "show-params", false, "Print the resolved script parameters and where each value came from, without executing")
 	cmd.Flags().BoolP("verbose", "v", false, "Show extra detailed information for events and transactions")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the result as a versioned JSON document (human output goes to stderr)")
 
 	return cmd
 }
+
+// runScriptJSON runs the script and writes the result as a JSON document on stdout
+func runScriptJSON(cmd *cobra.Command, app *app.App, params usecase.RunScriptParams) error {
+	out := cmd.OutOrStdout()
+
+	var result *usecase.RunScriptResult
+	runErr := withStdoutToStderr(func() error {
+		var err error
+		result, err = app.RunScript.Run(cmd.Context(), params)
+		return err
+	})
+	// Runs that fail before forge starts still produce a document carrying the error
+	if runErr != nil {
+		if result == nil {
+			result = &usecase.RunScriptResult{}
+		}
+		result.Success = false
+		result.Error = runErr
+	}
+
+	if err := writeJSON(out, app.ScriptRenderer.BuildRunJSON(result)); err != nil {
+		return err
+	}
+
+	// A declined broadcast is not a failure, the document reports it with "aborted"
+	if result.Aborted {
+		return nil
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if !result.Success {
+		return fmt.Errorf("script execution failed")
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/trebuchet-org/treb-cli/internal/cli/render"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// NewRunsCmd creates the runs command group with subcommands
+func NewRunsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "runs",
+		Short: "Browse recorded script runs",
+		Long: `Every broadcast made with 'treb run' or a 'treb compose' step is recorded in .treb/runs/.
+
+A run record holds the script, resolved parameters (values of {secret} parameters are
+redacted), sender configuration, network, namespace, foundry profile, treb and forge
+versions, git commit and dirty state, the operator and the registry entries it produced.
+
+The operator is taken from TREB_OPERATOR, then the git user, then the OS user.`,
+	}
+
+	cmd.AddCommand(newRunsListCmd())
+	cmd.AddCommand(newRunsShowCmd())
+
+	return cmd
+}
+
+// newRunsListCmd creates the runs list subcommand
+func newRunsListCmd() *cobra.Command {
+	var (
+		script     string
+		limit      int
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recorded runs, most recent first",
+		Example: `  treb runs list
+  treb runs list --network sepolia --namespace production
+  treb runs list --script DeployCounter --limit 5`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			params := usecase.ListRunsParams{
+				Script: script,
+				Limit:  limit,
+			}
+			if cmd.Flags().Changed("namespace") {
+				params.Namespace = app.Config.Namespace
+			}
+			if cmd.Flags().Changed("network") && app.Config.Network != nil {
+				params.ChainID = app.Config.Network.ChainID
+			}
+
+			result, err := app.ListRuns.Run(cmd.Context(), params)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), result.Runs)
+			}
+
+			renderer := render.NewRunsRenderer(cmd.OutOrStdout(), true)
+			return renderer.RenderRunsList(result)
+		},
+	}
+
+	cmd.Flags().String("network", "", "Only show runs on this network")
+	cmd.Flags().String("namespace", "", "Only show runs in this namespace")
+	cmd.Flags().StringVar(&script, "script", "", "Only show runs of this script (name or path)")
+	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum number of runs to show (0 for all)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+// newRunsShowCmd creates the runs show subcommand
+func newRunsShowCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "show <run-id|latest>",
+		Short: "Show a recorded run",
+		Long: `Show the full record of a run. The run can be given by its ID, a unique prefix
+of the ID, or 'latest' for the most recent run.`,
+		Example: `  treb runs show latest
+  treb runs show 20250126-150405`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			result, err := app.ShowRun.Run(cmd.Context(), usecase.ShowRunParams{RunRef: args[0]})
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), result.Run)
+			}
+
+			renderer := render.NewRunsRenderer(cmd.OutOrStdout(), true)
+			return renderer.RenderRun(result)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/trebuchet-org/treb-cli/internal/usecase"
+)
+
+// NewTimelockCmd creates the timelock command group with subcommands
+func NewTimelockCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "timelock",
+		Short: "Manage operations scheduled on timelocks",
+		Long: `Manage operations scheduled on OpenZeppelin TimelockController contracts.
+
+Operations that scripts schedule on a timelock are recorded in
+.treb/timelock-ops.json and can be executed once their delay has passed.`,
+	}
+
+	cmd.AddCommand(newTimelockExecuteCmd())
+
+	return cmd
+}
+
+// newTimelockExecuteCmd creates the timelock execute subcommand
+func newTimelockExecuteCmd() *cobra.Command {
+	var sender string
+
+	cmd := &cobra.Command{
+		Use:   "execute <operation-id>",
+		Short: "Execute a scheduled timelock operation once its delay has passed",
+		Long: `Execute a scheduled timelock operation once its delay has passed.
+
+The operation ID may be shortened to a unique prefix. The execution is sent by the
+sender that scheduled the operation unless another account is chosen with --sender.
+The account must hold the executor role, unless the role is open, and be a
+private_key, keystore or remote_signer account.`,
+		Example: `  treb timelock execute 0x3f2a --network sepolia
+  treb timelock execute 0x3f2a9c... --network mainnet --sender executor`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			if app.Config.Network == nil {
+				return fmt.Errorf("network must be set (use 'treb config set network <name>' or --network flag)")
+			}
+
+			result, err := app.ExecuteTimelockOperation.Run(cmd.Context(), usecase.ExecuteTimelockOperationParams{
+				OperationID: args[0],
+				Sender:      sender,
+			})
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if result.AlreadyExecuted {
+				fmt.Fprintf(out, "Operation %s was already executed on-chain, registry updated.\n", result.Operation.OperationID)
+				return nil
+			}
+
+			color.New(color.FgGreen).Fprintf(out, "✓ Executed timelock operation %s\n", result.Operation.OperationID)
+			fmt.Fprintf(out, "  Timelock: %s\n", result.Operation.TimelockAddress)
+			fmt.Fprintf(out, "  Calls:    %d\n", len(result.Operation.Calls))
+			fmt.Fprintf(out, "  Sender:   %s\n", result.Sender)
+			fmt.Fprintf(out, "  Tx:       %s (block %d)\n", result.TxHash, result.BlockNumber)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("network", "n", "", "Network to run on (e.g., mainnet, sepolia, local)")
+	cmd.Flags().StringP("namespace", "s", "", "Namespace to use (defaults to current context namespace) [also sets foundry profile]")
+	cmd.Flags().StringVar(&sender, "sender", "", "Account that sends the execution (defaults to the sender that scheduled it)")
+
+	return cmd
+}
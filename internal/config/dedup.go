@@ -1,7 +1,9 @@
 package config
 
 import (
+	"cmp"
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -52,7 +54,7 @@ func DeduplicateSenders(
 		}
 	}
 
-	// Phase 2: Update signer/proposer cross-references to use new account names.
+	// Phase 2: Update signer/confirmer/proposer cross-references to use new account names.
 	// In the old format these reference sender names within the same profile/namespace;
 	// in the new format they must reference account names.
 	for accountName, acct := range accounts {
@@ -66,6 +68,14 @@ func DeduplicateSenders(
 				updated = true
 			}
 		}
+		if len(acct.Confirmers) > 0 {
+			confirmers := make([]string, len(acct.Confirmers))
+			for i, confirmer := range acct.Confirmers {
+				confirmers[i] = cmp.Or(nsMappings[confirmer], confirmer)
+			}
+			acct.Confirmers = confirmers
+			updated = true
+		}
 		if acct.Proposer != "" {
 			if newName, exists := nsMappings[acct.Proposer]; exists {
 				acct.Proposer = newName
@@ -88,13 +98,17 @@ func senderFingerprint(s config.SenderConfig) string {
 	case config.SenderTypePrivateKey:
 		return fmt.Sprintf("private_key:%s", s.PrivateKey)
 	case config.SenderTypeSafe:
-		return fmt.Sprintf("safe:%s:%s", s.Safe, s.Signer)
+		return fmt.Sprintf("safe:%s:%s:%s", s.Safe, s.Signer, strings.Join(s.Confirmers, ","))
 	case config.SenderTypeOZGovernor:
 		return fmt.Sprintf("oz_governor:%s:%s:%s", s.Governor, s.Timelock, s.Proposer)
 	case config.SenderTypeLedger:
 		return fmt.Sprintf("ledger:%s:%s", s.Address, s.DerivationPath)
 	case config.SenderTypeTrezor:
 		return fmt.Sprintf("trezor:%s:%s", s.Address, s.DerivationPath)
+	case config.SenderTypeKeystore:
+		return fmt.Sprintf("keystore:%s", s.Keystore)
+	case config.SenderTypeRemoteSigner:
+		return fmt.Sprintf("remote_signer:%s:%s", s.Address, s.URL)
 	default:
 		return fmt.Sprintf("%s:%s:%s:%s:%s:%s:%s:%s:%s",
 			s.Type, s.PrivateKey, s.Safe, s.Signer, s.Address, s.DerivationPath,
@@ -133,6 +147,18 @@ func generateAccountName(s config.SenderConfig, usedNames map[string]bool) strin
 		} else {
 			base = "trezor"
 		}
+	case config.SenderTypeKeystore:
+		if name := strings.TrimSuffix(filepath.Base(s.Keystore), filepath.Ext(s.Keystore)); name != "" && name != "." {
+			base = "keystore-" + strings.ToLower(name)
+		} else {
+			base = "keystore"
+		}
+	case config.SenderTypeRemoteSigner:
+		if prefix := hexPrefix(s.Address, 6); prefix != "" {
+			base = "signer-" + prefix
+		} else {
+			base = "signer"
+		}
 	default:
 		base = "account"
 	}
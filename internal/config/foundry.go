@@ -59,6 +59,10 @@ func loadFoundryConfig(projectRoot string) (*config.FoundryConfig, error) {
 				senderConfig.Governor = os.ExpandEnv(senderConfig.Governor)
 				senderConfig.Timelock = os.ExpandEnv(senderConfig.Timelock)
 				senderConfig.Proposer = os.ExpandEnv(senderConfig.Proposer)
+				senderConfig.Keystore = os.ExpandEnv(senderConfig.Keystore)
+				senderConfig.PasswordFile = os.ExpandEnv(senderConfig.PasswordFile)
+				senderConfig.URL = os.ExpandEnv(senderConfig.URL)
+				senderConfig.AuthHeader = os.ExpandEnv(senderConfig.AuthHeader)
 
 				// Update the map with the expanded config
 				profile.Treb.Senders[senderName] = senderConfig
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/manifoldco/promptui"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+)
+
+// passwordPrompter asks the user for the password of a keystore
+type passwordPrompter func(label string) (string, error)
+
+// resolveKeystoreSenders makes keystore and password file paths absolute and fills in the
+// address of keystore senders from the keystore file, so the address is known without
+// decrypting the key. Keystores that record no address are decrypted by SenderAddress, and
+// a keystore that cannot be read is left to fail when the sender is used.
+func resolveKeystoreSenders(senders map[string]config.SenderConfig, projectRoot string) error {
+	for name, sender := range senders {
+		if sender.Type != config.SenderTypeKeystore {
+			continue
+		}
+		if sender.Keystore == "" {
+			return fmt.Errorf("keystore sender '%s' requires a keystore path", name)
+		}
+
+		sender.Keystore = resolveKeystorePath(sender.Keystore, projectRoot)
+		if sender.PasswordFile != "" {
+			sender.PasswordFile = resolveKeystorePath(sender.PasswordFile, projectRoot)
+		}
+
+		address, err := readKeystoreAddress(sender.Keystore)
+		if err == nil && address != (common.Address{}) {
+			if sender.Address != "" && common.HexToAddress(sender.Address) != address {
+				return fmt.Errorf("keystore sender '%s': configured address %s does not match keystore address %s", name, sender.Address, address.Hex())
+			}
+			sender.Address = address.Hex()
+		}
+
+		senders[name] = sender
+	}
+	return nil
+}
+
+// resolveKeystorePath expands a leading ~ and makes relative paths relative to the project root
+func resolveKeystorePath(path, projectRoot string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	if !filepath.IsAbs(path) && projectRoot != "" {
+		path = filepath.Join(projectRoot, path)
+	}
+	return path
+}
+
+// readKeystoreAddress reads the unencrypted address recorded in a keystore file.
+// Keystores written by geth always record it, Foundry keystores may not.
+func readKeystoreAddress(path string) (common.Address, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	var keyFile struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(data, &keyFile); err != nil {
+		return common.Address{}, fmt.Errorf("invalid keystore %s: %w", path, err)
+	}
+	if keyFile.Address == "" {
+		return common.Address{}, nil
+	}
+	if !common.IsHexAddress(keyFile.Address) {
+		return common.Address{}, fmt.Errorf("invalid address in keystore %s: %s", path, keyFile.Address)
+	}
+	return common.HexToAddress(keyFile.Address), nil
+}
+
+// decryptKeystore decrypts the key of a keystore sender and checks it against the configured address
+func (m *SendersManager) decryptKeystore(senderKey string, sender config.SenderConfig) (*ecdsa.PrivateKey, common.Address, error) {
+	if sender.Keystore == "" {
+		return nil, common.Address{}, fmt.Errorf("keystore sender requires a keystore path")
+	}
+
+	// Held while decrypting so that concurrent runs ask for a password only once
+	m.keystoreMu.Lock()
+	defer m.keystoreMu.Unlock()
+	if key, ok := m.keystoreKeys[sender.Keystore]; ok {
+		return key.PrivateKey, key.Address, nil
+	}
+
+	keyJSON, err := os.ReadFile(sender.Keystore)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to read keystore: %w", err)
+	}
+
+	password, err := m.keystorePassword(senderKey, sender)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to decrypt keystore %s: %w", sender.Keystore, err)
+	}
+
+	if sender.Address != "" && common.HexToAddress(sender.Address) != key.Address {
+		return nil, common.Address{}, fmt.Errorf("keystore %s holds %s, not the configured address %s", sender.Keystore, key.Address.Hex(), sender.Address)
+	}
+
+	m.keystoreKeys[sender.Keystore] = key
+	return key.PrivateKey, key.Address, nil
+}
+
+// keystorePassword returns the keystore password from password_env, then password_file,
+// then an interactive prompt
+func (m *SendersManager) keystorePassword(senderKey string, sender config.SenderConfig) (string, error) {
+	if sender.PasswordEnv != "" {
+		password, ok := os.LookupEnv(sender.PasswordEnv)
+		if !ok {
+			return "", fmt.Errorf("keystore password environment variable %s is not set", sender.PasswordEnv)
+		}
+		return password, nil
+	}
+
+	if sender.PasswordFile != "" {
+		data, err := os.ReadFile(sender.PasswordFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read keystore password file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	if m.nonInteractive || m.promptPassword == nil {
+		return "", fmt.Errorf("keystore sender '%s' has no password_env or password_file and prompting is disabled in non-interactive mode", senderKey)
+	}
+
+	password, err := m.promptPassword(fmt.Sprintf("Password for keystore sender '%s'", senderKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to read keystore password: %w", err)
+	}
+	return password, nil
+}
+
+// promptKeystorePassword reads a password from the terminal without echoing it
+func promptKeystorePassword(label string) (string, error) {
+	prompt := promptui.Prompt{
+		Label: label,
+		Mask:  '*',
+	}
+	return prompt.Run()
+}
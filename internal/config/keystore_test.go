@@ -0,0 +1,300 @@
+package config
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+const (
+	testKeystorePrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	testKeystoreAddress    = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	testKeystorePassword   = "correct horse battery staple"
+)
+
+// writeTestKeystore encrypts the test key into a keystore file with light scrypt parameters
+func writeTestKeystore(t *testing.T) string {
+	t.Helper()
+	key, err := crypto.HexToECDSA(testKeystorePrivateKey)
+	require.NoError(t, err)
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(key, testKeystorePassword)
+	require.NoError(t, err)
+	return account.URL.Path
+}
+
+// writeKeystoreWithoutAddress copies a keystore without its address field, as Foundry may write it
+func writeKeystoreWithoutAddress(t *testing.T, keystorePath string) string {
+	t.Helper()
+	data, err := os.ReadFile(keystorePath)
+	require.NoError(t, err)
+	var keyFile map[string]any
+	require.NoError(t, json.Unmarshal(data, &keyFile))
+	delete(keyFile, "address")
+	data, err = json.Marshal(keyFile)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "deployer")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func newKeystoreManager(senders map[string]config.SenderConfig) *SendersManager {
+	return NewSendersManager(&config.RuntimeConfig{
+		NonInteractive: true,
+		TrebConfig:     &config.TrebConfig{Senders: senders},
+	})
+}
+
+func TestResolveKeystoreSenders(t *testing.T) {
+	keystorePath := writeTestKeystore(t)
+	projectRoot := filepath.Dir(keystorePath)
+
+	t.Run("fills address and resolves relative paths", func(t *testing.T) {
+		senders := map[string]config.SenderConfig{
+			"deployer": {
+				Type:         config.SenderTypeKeystore,
+				Keystore:     filepath.Base(keystorePath),
+				PasswordFile: "password.txt",
+			},
+			"other": {Type: config.SenderTypeLedger, Address: "0x1"},
+		}
+
+		require.NoError(t, resolveKeystoreSenders(senders, projectRoot))
+		assert.Equal(t, keystorePath, senders["deployer"].Keystore)
+		assert.Equal(t, filepath.Join(projectRoot, "password.txt"), senders["deployer"].PasswordFile)
+		assert.Equal(t, testKeystoreAddress, senders["deployer"].Address)
+		assert.Equal(t, "0x1", senders["other"].Address)
+	})
+
+	t.Run("rejects mismatched address", func(t *testing.T) {
+		senders := map[string]config.SenderConfig{
+			"deployer": {
+				Type:     config.SenderTypeKeystore,
+				Keystore: keystorePath,
+				Address:  "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+			},
+		}
+
+		err := resolveKeystoreSenders(senders, projectRoot)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match keystore address")
+	})
+
+	t.Run("requires keystore path", func(t *testing.T) {
+		senders := map[string]config.SenderConfig{
+			"deployer": {Type: config.SenderTypeKeystore},
+		}
+
+		err := resolveKeystoreSenders(senders, projectRoot)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires a keystore path")
+	})
+
+	t.Run("leaves missing keystore for use time", func(t *testing.T) {
+		senders := map[string]config.SenderConfig{
+			"deployer": {Type: config.SenderTypeKeystore, Keystore: "missing.json"},
+		}
+
+		require.NoError(t, resolveKeystoreSenders(senders, projectRoot))
+		assert.Empty(t, senders["deployer"].Address)
+	})
+}
+
+func TestSendersManager_KeystoreSender(t *testing.T) {
+	keystorePath := writeTestKeystore(t)
+
+	t.Run("password from env", func(t *testing.T) {
+		t.Setenv("TEST_KEYSTORE_PASSWORD", testKeystorePassword)
+		manager := newKeystoreManager(map[string]config.SenderConfig{
+			"deployer": {
+				Type:        config.SenderTypeKeystore,
+				Keystore:    keystorePath,
+				PasswordEnv: "TEST_KEYSTORE_PASSWORD",
+			},
+		})
+
+		initConfig, err := manager.buildSenderInitConfig("deployer")
+		require.NoError(t, err)
+		assert.Equal(t, testKeystoreAddress, initConfig.Account.Hex())
+		assert.Equal(t, SENDER_TYPE_IN_MEMORY, initConfig.SenderType)
+		assert.True(t, initConfig.CanBroadcast)
+		assert.Equal(t, config.SenderTypeKeystore, initConfig.BaseConfig.Type)
+
+		// Config is the private key encoded as uint256, same as private_key senders
+		uint256Type, _ := abi.NewType("uint256", "", nil)
+		values, err := abi.Arguments{{Type: uint256Type}}.Unpack(initConfig.Config)
+		require.NoError(t, err)
+		expected, _ := new(big.Int).SetString(testKeystorePrivateKey, 16)
+		assert.Equal(t, 0, expected.Cmp(values[0].(*big.Int)))
+	})
+
+	t.Run("password from file", func(t *testing.T) {
+		passwordFile := filepath.Join(t.TempDir(), "password.txt")
+		require.NoError(t, os.WriteFile(passwordFile, []byte(testKeystorePassword+"\n"), 0600))
+		manager := newKeystoreManager(map[string]config.SenderConfig{
+			"deployer": {
+				Type:         config.SenderTypeKeystore,
+				Keystore:     keystorePath,
+				PasswordFile: passwordFile,
+			},
+		})
+
+		initConfig, err := manager.buildSenderInitConfig("deployer")
+		require.NoError(t, err)
+		assert.Equal(t, testKeystoreAddress, initConfig.Account.Hex())
+	})
+
+	t.Run("password from prompt is asked once", func(t *testing.T) {
+		manager := newKeystoreManager(map[string]config.SenderConfig{
+			"deployer": {Type: config.SenderTypeKeystore, Keystore: keystorePath},
+			"proposer": {Type: config.SenderTypeKeystore, Keystore: keystorePath},
+		})
+		manager.nonInteractive = false
+		prompts := 0
+		manager.promptPassword = func(label string) (string, error) {
+			prompts++
+			assert.Contains(t, label, "deployer")
+			return testKeystorePassword, nil
+		}
+
+		configs, err := manager.buildSenderInitConfigs([]string{"deployer", "proposer"})
+		require.NoError(t, err)
+		require.Len(t, configs, 2)
+		assert.Equal(t, 1, prompts)
+	})
+
+	t.Run("parallel scripts decrypt the keystore once", func(t *testing.T) {
+		manager := newKeystoreManager(map[string]config.SenderConfig{
+			"deployer": {Type: config.SenderTypeKeystore, Keystore: keystorePath},
+		})
+		manager.nonInteractive = false
+		var prompts atomic.Int32
+		manager.promptPassword = func(string) (string, error) {
+			prompts.Add(1)
+			return testKeystorePassword, nil
+		}
+		artifact := &models.Artifact{}
+		artifact.Metadata.Output.DevDoc = json.RawMessage(`{"methods":{"run()":{"custom:senders":"deployer"}}}`)
+
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				scriptConfig, err := manager.BuildSenderScriptConfig(artifact)
+				assert.NoError(t, err)
+				assert.Len(t, scriptConfig.SenderInitConfigs, 1)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), prompts.Load())
+	})
+
+	t.Run("no prompt in non-interactive mode", func(t *testing.T) {
+		manager := newKeystoreManager(map[string]config.SenderConfig{
+			"deployer": {Type: config.SenderTypeKeystore, Keystore: keystorePath},
+		})
+
+		_, err := manager.buildSenderInitConfig("deployer")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "non-interactive")
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		t.Setenv("TEST_KEYSTORE_PASSWORD", "wrong")
+		manager := newKeystoreManager(map[string]config.SenderConfig{
+			"deployer": {
+				Type:        config.SenderTypeKeystore,
+				Keystore:    keystorePath,
+				PasswordEnv: "TEST_KEYSTORE_PASSWORD",
+			},
+		})
+
+		_, err := manager.buildSenderInitConfig("deployer")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to decrypt keystore")
+	})
+
+	t.Run("keystore without address field", func(t *testing.T) {
+		// Foundry keystores may omit the address, it is then derived on decryption
+		foundryKeystore := writeKeystoreWithoutAddress(t, keystorePath)
+
+		t.Setenv("TEST_KEYSTORE_PASSWORD", testKeystorePassword)
+		manager := newKeystoreManager(map[string]config.SenderConfig{
+			"deployer": {
+				Type:        config.SenderTypeKeystore,
+				Keystore:    foundryKeystore,
+				PasswordEnv: "TEST_KEYSTORE_PASSWORD",
+			},
+		})
+
+		initConfig, err := manager.buildSenderInitConfig("deployer")
+		require.NoError(t, err)
+		assert.Equal(t, testKeystoreAddress, initConfig.Account.Hex())
+	})
+}
+
+func TestSendersManager_KeystoreSenderAddress(t *testing.T) {
+	keystorePath := writeTestKeystore(t)
+
+	t.Run("address from keystore file", func(t *testing.T) {
+		senders := map[string]config.SenderConfig{
+			"deployer": {Type: config.SenderTypeKeystore, Keystore: keystorePath},
+		}
+		require.NoError(t, resolveKeystoreSenders(senders, ""))
+		manager := newKeystoreManager(senders)
+
+		// No password is configured, the address must come without decrypting
+		address, err := manager.SenderAddress("deployer")
+		require.NoError(t, err)
+		assert.Equal(t, testKeystoreAddress, address.Hex())
+	})
+
+	t.Run("keystore without address field is decrypted once", func(t *testing.T) {
+		senders := map[string]config.SenderConfig{
+			"deployer": {
+				Type:        config.SenderTypeKeystore,
+				Keystore:    writeKeystoreWithoutAddress(t, keystorePath),
+				PasswordEnv: "TEST_KEYSTORE_PASSWORD",
+			},
+		}
+		require.NoError(t, resolveKeystoreSenders(senders, ""))
+		assert.Empty(t, senders["deployer"].Address)
+
+		t.Setenv("TEST_KEYSTORE_PASSWORD", testKeystorePassword)
+		manager := newKeystoreManager(senders)
+		address, err := manager.SenderAddress("deployer")
+		require.NoError(t, err)
+		assert.Equal(t, testKeystoreAddress, address.Hex())
+
+		// The decrypted key is reused, the password is not read again
+		t.Setenv("TEST_KEYSTORE_PASSWORD", "wrong")
+		initConfig, err := manager.buildSenderInitConfig("deployer")
+		require.NoError(t, err)
+		assert.Equal(t, testKeystoreAddress, initConfig.Account.Hex())
+	})
+
+	t.Run("keystore without address field and no password", func(t *testing.T) {
+		manager := newKeystoreManager(map[string]config.SenderConfig{
+			"deployer": {Type: config.SenderTypeKeystore, Keystore: writeKeystoreWithoutAddress(t, keystorePath)},
+		})
+
+		_, err := manager.SenderAddress("deployer")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "records no address")
+		assert.Contains(t, err.Error(), "non-interactive")
+	})
+}
@@ -37,6 +37,13 @@ func Provider(v *viper.Viper) (*config.RuntimeConfig, error) {
 		DryRun:         v.GetBool("dry_run"),
 	}
 
+	events, eventsPath, err := ParseEventsFlag(v.GetString("events"))
+	if err != nil {
+		return nil, err
+	}
+	cfg.Events = events
+	cfg.EventsPath = eventsPath
+
 	// Load foundry config (always needed for network resolution etc.)
 	foundryConfig, err := loadFoundryConfig(projectRoot)
 	if err != nil {
@@ -66,11 +73,14 @@ func Provider(v *viper.Viper) (*config.RuntimeConfig, error) {
 		}
 		cfg.ConfigSource = "treb.toml (v2)"
 		cfg.TrebConfig = trebConfig
+		cfg.ResolvedNamespace = resolved
 		cfg.FoundryProfile = resolved.Profile
 		if cfg.FoundryProfile == "" {
 			cfg.FoundryProfile = cfg.Namespace
 		}
 		cfg.ForkSetup = v2Config.Fork.Setup
+		cfg.ForkBlock = string(v2Config.Fork.Block)
+		cfg.RequireConfirmation = resolved.RequireConfirmation
 
 	case TrebConfigFormatV1:
 		trebFileConfig, err := loadTrebConfig(projectRoot)
@@ -96,6 +106,12 @@ func Provider(v *viper.Viper) (*config.RuntimeConfig, error) {
 		cfg.ForkSetup = v.GetString("forksetup")
 	}
 
+	if cfg.TrebConfig != nil {
+		if err := resolveKeystoreSenders(cfg.TrebConfig.Senders, projectRoot); err != nil {
+			return nil, err
+		}
+	}
+
 	// Resolve slow mode: default to true if not configured
 	cfg.Slow = true
 	if cfg.TrebConfig != nil && cfg.TrebConfig.Slow != nil {
@@ -127,6 +143,21 @@ func Provider(v *viper.Viper) (*config.RuntimeConfig, error) {
 	return cfg, nil
 }
 
+// ParseEventsFlag splits an --events value of the form "ndjson" or "ndjson=<path>" into
+// the stream format and its destination
+func ParseEventsFlag(value string) (format string, path string, err error) {
+	if value == "" {
+		return "", "", nil
+	}
+
+	format, path, _ = strings.Cut(value, "=")
+	if format != "ndjson" {
+		return "", "", fmt.Errorf("unsupported --events format %q (expected ndjson or ndjson=<path>)", format)
+	}
+
+	return format, path, nil
+}
+
 // FindProjectRoot walks up from current directory to find foundry.toml
 func FindProjectRoot() (string, error) {
 	dir, err := os.Getwd()
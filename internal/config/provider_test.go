@@ -410,6 +410,74 @@ setup = "script/SetupFork.s.sol"
 		assert.Equal(t, "script/SetupFork.s.sol", cfg.ForkSetup)
 	})
 
+	t.Run("v2 format reads fork.block into ForkBlock", func(t *testing.T) {
+		dir := t.TempDir()
+
+		foundryToml := `[profile.default]
+src = "src"
+`
+		err := os.WriteFile(filepath.Join(dir, "foundry.toml"), []byte(foundryToml), 0644)
+		require.NoError(t, err)
+
+		trebToml := `
+[accounts.deployer]
+type = "private_key"
+private_key = "0x1234"
+
+[namespace.default.senders]
+deployer = "deployer"
+
+[fork]
+block = "21000000"
+`
+		err = os.WriteFile(filepath.Join(dir, "treb.toml"), []byte(trebToml), 0644)
+		require.NoError(t, err)
+
+		v := viper.New()
+		v.Set("project_root", dir)
+		v.Set("namespace", "default")
+
+		cfg, err := Provider(v)
+		require.NoError(t, err)
+
+		assert.Equal(t, "treb.toml (v2)", cfg.ConfigSource)
+		assert.Equal(t, "21000000", cfg.ForkBlock)
+		assert.Empty(t, cfg.ForkSetup)
+	})
+
+	t.Run("v2 format reads an unquoted fork.block", func(t *testing.T) {
+		dir := t.TempDir()
+
+		foundryToml := `[profile.default]
+src = "src"
+`
+		err := os.WriteFile(filepath.Join(dir, "foundry.toml"), []byte(foundryToml), 0644)
+		require.NoError(t, err)
+
+		trebToml := `
+[accounts.deployer]
+type = "private_key"
+private_key = "0x1234"
+
+[namespace.default.senders]
+deployer = "deployer"
+
+[fork]
+block = 21000000
+`
+		err = os.WriteFile(filepath.Join(dir, "treb.toml"), []byte(trebToml), 0644)
+		require.NoError(t, err)
+
+		v := viper.New()
+		v.Set("project_root", dir)
+		v.Set("namespace", "default")
+
+		cfg, err := Provider(v)
+		require.NoError(t, err)
+
+		assert.Equal(t, "21000000", cfg.ForkBlock)
+	})
+
 	t.Run("v2 format defaults FoundryProfile to namespace when profile not set", func(t *testing.T) {
 		dir := t.TempDir()
 
@@ -473,6 +541,37 @@ private_key = "0x1234"
 		assert.Equal(t, config.SenderType("private_key"), cfg.TrebConfig.Senders["deployer"].Type)
 	})
 
+	t.Run("v1 treb.toml resolves keystore senders", func(t *testing.T) {
+		dir := t.TempDir()
+
+		foundryToml := `[profile.default]
+src = "src"
+`
+		err := os.WriteFile(filepath.Join(dir, "foundry.toml"), []byte(foundryToml), 0644)
+		require.NoError(t, err)
+
+		trebToml := `
+[ns.default.senders.deployer]
+type = "keystore"
+keystore = "keys/deployer.json"
+password_file = "keys/password.txt"
+`
+		err = os.WriteFile(filepath.Join(dir, "treb.toml"), []byte(trebToml), 0644)
+		require.NoError(t, err)
+
+		v := viper.New()
+		v.Set("project_root", dir)
+		v.Set("namespace", "default")
+
+		cfg, err := Provider(v)
+		require.NoError(t, err)
+
+		require.NotNil(t, cfg.TrebConfig)
+		sender := cfg.TrebConfig.Senders["deployer"]
+		assert.Equal(t, filepath.Join(dir, "keys", "deployer.json"), sender.Keystore)
+		assert.Equal(t, filepath.Join(dir, "keys", "password.txt"), sender.PasswordFile)
+	})
+
 	t.Run("v1 format reads forkSetup from viper for backwards compat", func(t *testing.T) {
 		dir := t.TempDir()
 
@@ -567,3 +666,32 @@ deployer = "safe-wallet"
 		assert.Equal(t, config.SenderType("safe"), cfg.TrebConfig.Senders["deployer"].Type, "deployer should be overridden by production.ntt")
 	})
 }
+
+func TestParseEventsFlag(t *testing.T) {
+	tests := []struct {
+		value      string
+		wantFormat string
+		wantPath   string
+		wantErr    bool
+	}{
+		{value: "", wantFormat: "", wantPath: ""},
+		{value: "ndjson", wantFormat: "ndjson", wantPath: ""},
+		{value: "ndjson=-", wantFormat: "ndjson", wantPath: "-"},
+		{value: "ndjson=out/events.jsonl", wantFormat: "ndjson", wantPath: "out/events.jsonl"},
+		{value: "json", wantErr: true},
+		{value: "=events.jsonl", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			format, path, err := ParseEventsFlag(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFormat, format)
+			assert.Equal(t, tt.wantPath, path)
+		})
+	}
+}
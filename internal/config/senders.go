@@ -1,6 +1,7 @@
 package config
 
 import (
+	"cmp"
 	"crypto/ecdsa"
 	"encoding/hex"
 	"encoding/json"
@@ -9,8 +10,10 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/trebuchet-org/treb-cli/internal/domain/config"
@@ -20,7 +23,11 @@ import (
 // SenderInitConfig represents a single sender configuration for the new Senders library
 // This matches the Solidity SenderInitConfig struct in Senders.sol
 type SendersManager struct {
-	config *config.TrebConfig
+	config         *config.TrebConfig
+	nonInteractive bool
+	promptPassword passwordPrompter
+	keystoreMu     sync.Mutex               // Guards keystoreKeys, parallel runs build sender configs concurrently
+	keystoreKeys   map[string]*keystore.Key // Decrypted keys by keystore path
 }
 
 // Magic constants for sender types - these match the constants in Senders.sol
@@ -38,11 +45,18 @@ var (
 	SENDER_TYPE_LEDGER      = bitwiseOr(calculateBytes8("ledger"), SENDER_TYPE_HARDWARE_WALLET)
 	SENDER_TYPE_TREZOR      = bitwiseOr(calculateBytes8("trezor"), SENDER_TYPE_HARDWARE_WALLET)
 	SENDER_TYPE_OZ_GOVERNOR = bitwiseOr(SENDER_TYPE_GOVERNANCE, calculateBytes8("oz-governor"))
+
+	// Remote signers carry the hardware wallet bits, so treb-sol broadcasts from their account
+	// like from a hardware wallet, and their own bits to tell them apart from Ledger and Trezor
+	SENDER_TYPE_REMOTE_SIGNER = bitwiseOr(calculateBytes8("remote-signer"), SENDER_TYPE_HARDWARE_WALLET)
 )
 
 func NewSendersManager(config *config.RuntimeConfig) *SendersManager {
 	return &SendersManager{
-		config: config.TrebConfig,
+		config:         config.TrebConfig,
+		nonInteractive: config.NonInteractive,
+		promptPassword: promptKeystorePassword,
+		keystoreKeys:   make(map[string]*keystore.Key),
 	}
 }
 
@@ -51,7 +65,7 @@ func (m *SendersManager) BuildSenderScriptConfig(
 ) (*config.SenderScriptConfig, error) {
 	var err error
 	var senders []string
-	if senders, err = m.getSendersFromScript(script); err != nil {
+	if senders, err = m.GetScriptSenders(script); err != nil {
 		return nil, err
 
 	}
@@ -86,6 +100,10 @@ func (m *SendersManager) BuildSenderScriptConfig(
 	sort.Strings(senders)
 	allSenders := append(slices.Clone(safeSigners), senders...)
 
+	if err := m.checkRemoteSignerMix(allSenders); err != nil {
+		return nil, err
+	}
+
 	var senderInitConfigs []config.SenderInitConfig
 	if senderInitConfigs, err = m.buildSenderInitConfigs(allSenders); err != nil {
 		return nil, err
@@ -102,10 +120,44 @@ func (m *SendersManager) BuildSenderScriptConfig(
 		EncodedConfig:     encodedSenderInitConfigs,
 		SenderInitConfigs: senderInitConfigs,
 		Senders:           senders,
+		RemoteSigners:     remoteSigners(senderInitConfigs),
 	}, nil
 }
 
-func (m *SendersManager) getSendersFromScript(script *models.Artifact) ([]string, error) {
+// checkRemoteSignerMix rejects scripts that use remote signers together with senders holding a
+// local key or hardware wallet. Remote signers make forge send every transaction unsigned with
+// eth_sendTransaction, which only the remote signers' signing service can sign.
+func (m *SendersManager) checkRemoteSignerMix(senders []string) error {
+	if m.config == nil || m.config.Senders == nil {
+		return nil
+	}
+
+	// Governor proposals are sent by the proposer
+	broadcasters := slices.Clone(senders)
+	for _, senderKey := range senders {
+		if sender, exists := m.config.Senders[senderKey]; exists && sender.Type == config.SenderTypeOZGovernor {
+			broadcasters = append(broadcasters, sender.Proposer)
+		}
+	}
+
+	var remoteSigner, localSigner string
+	for _, senderKey := range broadcasters {
+		switch m.config.Senders[senderKey].Type {
+		case config.SenderTypeRemoteSigner:
+			remoteSigner = cmp.Or(remoteSigner, senderKey)
+		case config.SenderTypePrivateKey, config.SenderTypeKeystore, config.SenderTypeLedger, config.SenderTypeTrezor:
+			localSigner = cmp.Or(localSigner, senderKey)
+		}
+	}
+	if remoteSigner != "" && localSigner != "" {
+		return fmt.Errorf("remote_signer sender '%s' cannot be used together with %s sender '%s' in one script, configure @custom:senders",
+			remoteSigner, m.config.Senders[localSigner].Type, localSigner)
+	}
+	return nil
+}
+
+// GetScriptSenders returns the sender roles declared by the @custom:senders tag of run()
+func (m *SendersManager) GetScriptSenders(script *models.Artifact) ([]string, error) {
 	// Extract devdoc from metadata
 	var devdoc struct {
 		Methods map[string]map[string]any `json:"methods"`
@@ -231,6 +283,29 @@ func (m *SendersManager) buildSenderInitConfig(senderKey string) (*config.Sender
 			BaseConfig:   sender,
 		}, nil
 
+	case "keystore":
+		// Decrypt the keystore and use the key like an in-memory private key sender
+		privateKey, address, err := m.decryptKeystore(senderKey, sender)
+		if err != nil {
+			return nil, err
+		}
+
+		uint256Type, _ := abi.NewType("uint256", "", nil)
+		args := abi.Arguments{{Type: uint256Type}}
+		configData, err := args.Pack(privateKey.D)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode keystore config: %w", err)
+		}
+
+		return &config.SenderInitConfig{
+			Name:         senderKey,
+			Account:      address,
+			SenderType:   SENDER_TYPE_IN_MEMORY,
+			CanBroadcast: true,
+			Config:       configData,
+			BaseConfig:   sender,
+		}, nil
+
 	case "safe":
 		// Parse Safe address
 		safe := common.HexToAddress(sender.Safe)
@@ -244,10 +319,27 @@ func (m *SendersManager) buildSenderInitConfig(senderKey string) (*config.Sender
 		}
 
 		// Validate that the signer exists in the sender configs
-		_, exists := m.config.Senders[sender.Signer]
+		signer, exists := m.config.Senders[sender.Signer]
 		if !exists {
 			return nil, fmt.Errorf("safe signer '%s' not found in sender configurations", sender.Signer)
 		}
+		// Safe transactions are signed inside the script, where forge has no access to a remote key
+		if signer.Type == config.SenderTypeRemoteSigner {
+			return nil, fmt.Errorf("safe signer '%s' is a remote_signer account, which cannot sign Safe transactions", sender.Signer)
+		}
+
+		// Confirmers sign after the broadcast, outside of forge, so they need a key treb can use
+		for _, confirmerKey := range sender.Confirmers {
+			confirmer, exists := m.config.Senders[confirmerKey]
+			if !exists {
+				return nil, fmt.Errorf("safe confirmer '%s' not found in sender configurations", confirmerKey)
+			}
+			switch confirmer.Type {
+			case config.SenderTypePrivateKey, config.SenderTypeKeystore, config.SenderTypeRemoteSigner:
+			default:
+				return nil, fmt.Errorf("safe confirmer '%s' is a %s account, which cannot confirm Safe transactions", confirmerKey, confirmer.Type)
+			}
+		}
 
 		// For Safe senders, config contains the proposer name as string
 		stringType, _ := abi.NewType("string", "", nil)
@@ -314,6 +406,37 @@ func (m *SendersManager) buildSenderInitConfig(senderKey string) (*config.Sender
 			Config:       configData,
 		}, nil
 
+	case "remote_signer":
+		// Validate address and endpoint are provided
+		if sender.Address == "" {
+			return nil, fmt.Errorf("remote_signer sender requires an address to be specified")
+		}
+		if !common.IsHexAddress(sender.Address) {
+			return nil, fmt.Errorf("invalid remote_signer address: %s", sender.Address)
+		}
+		if sender.URL == "" {
+			return nil, fmt.Errorf("remote_signer sender requires a url to be specified")
+		}
+
+		// Remote signers are broadcast by address like hardware wallets, forge sends their
+		// transactions unsigned and treb's signing proxy has them signed by the service.
+		// No derivation path applies, so the config carries an empty one.
+		stringType, _ := abi.NewType("string", "", nil)
+		args := abi.Arguments{{Type: stringType}}
+		configData, err := args.Pack("")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode remote signer config: %w", err)
+		}
+
+		return &config.SenderInitConfig{
+			Name:         senderKey,
+			Account:      common.HexToAddress(sender.Address),
+			SenderType:   SENDER_TYPE_REMOTE_SIGNER,
+			CanBroadcast: true,
+			Config:       configData,
+			BaseConfig:   sender,
+		}, nil
+
 	case "oz_governor":
 		// Parse Governor address
 		governor := common.HexToAddress(sender.Governor)
@@ -370,6 +493,74 @@ func (m *SendersManager) buildSenderInitConfig(senderKey string) (*config.Sender
 	}
 }
 
+// SenderAddress returns the address a sender acts as, without contacting devices. Private keys
+// are converted to their address and contract senders use their contract address. Keystores use
+// the address read from the keystore file, a keystore that records none is decrypted once.
+func (m *SendersManager) SenderAddress(senderKey string) (common.Address, error) {
+	if m.config == nil || m.config.Senders == nil {
+		return common.Address{}, fmt.Errorf("no sender configuration available")
+	}
+
+	sender, exists := m.config.Senders[senderKey]
+	if !exists {
+		return common.Address{}, fmt.Errorf("sender '%s' not found in configuration", senderKey)
+	}
+
+	var address string
+	switch sender.Type {
+	case config.SenderTypePrivateKey:
+		key, err := parsePrivateKey(sender.PrivateKey)
+		if err != nil {
+			return common.Address{}, fmt.Errorf("invalid private key: %w", err)
+		}
+		return key.Address, nil
+	case config.SenderTypeSafe:
+		address = sender.Safe
+	case config.SenderTypeOZGovernor:
+		// Governor senders act as their timelock when one is configured
+		address = sender.Governor
+		if sender.Timelock != "" {
+			address = sender.Timelock
+		}
+	case config.SenderTypeKeystore:
+		if sender.Address == "" {
+			_, keyAddress, err := m.decryptKeystore(senderKey, sender)
+			if err != nil {
+				return common.Address{}, fmt.Errorf("keystore %s records no address and could not be decrypted to derive it: %w", sender.Keystore, err)
+			}
+			return keyAddress, nil
+		}
+		address = sender.Address
+	default:
+		address = sender.Address
+	}
+
+	if address == "" {
+		return common.Address{}, fmt.Errorf("%s sender '%s' has no address configured", sender.Type, senderKey)
+	}
+	if !common.IsHexAddress(address) {
+		return common.Address{}, fmt.Errorf("invalid address for sender '%s': %s", senderKey, address)
+	}
+	return common.HexToAddress(address), nil
+}
+
+// remoteSigners lists the remote signer senders among the sender init configs
+func remoteSigners(senderInitConfigs []config.SenderInitConfig) []config.RemoteSignerConfig {
+	var signers []config.RemoteSignerConfig
+	for _, initConfig := range senderInitConfigs {
+		if initConfig.BaseConfig.Type != config.SenderTypeRemoteSigner {
+			continue
+		}
+		signers = append(signers, config.RemoteSignerConfig{
+			Name:       initConfig.Name,
+			Address:    initConfig.Account,
+			URL:        initConfig.BaseConfig.URL,
+			AuthHeader: initConfig.BaseConfig.AuthHeader,
+		})
+	}
+	return signers
+}
+
 func (m *SendersManager) encodeSenderInitConfigs(senderInitConfigs []config.SenderInitConfig) (string, error) {
 	// Use standard ABI encoding for array of structs - this is much more reliable than manual encoding
 	tupleType, err := abi.NewType("tuple[]", "", []abi.ArgumentMarshaling{
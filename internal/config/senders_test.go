@@ -124,6 +124,64 @@ func TestSendersManager_BuildSenderInitConfigs(t *testing.T) {
 			senders:       []string{"signer0"},
 			expectedError: "invalid private key",
 		},
+		{
+			name: "remote signer sender",
+			trebConfig: &config.TrebConfig{
+				Senders: map[string]config.SenderConfig{
+					"signer0": {
+						Type:       "remote_signer",
+						Address:    "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+						URL:        "http://127.0.0.1:8550",
+						AuthHeader: "Bearer token",
+					},
+				},
+			},
+			senders: []string{"signer0"},
+			validateConfig: func(t *testing.T, configs []config.SenderInitConfig) {
+				require.Len(t, configs, 1)
+				assert.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), configs[0].Account)
+				assert.Equal(t, SENDER_TYPE_REMOTE_SIGNER, configs[0].SenderType)
+				assert.NotEqual(t, SENDER_TYPE_LEDGER, configs[0].SenderType)
+				assert.True(t, configs[0].CanBroadcast)
+
+				signers := remoteSigners(configs)
+				require.Len(t, signers, 1)
+				assert.Equal(t, "http://127.0.0.1:8550", signers[0].URL)
+				assert.Equal(t, "Bearer token", signers[0].AuthHeader)
+			},
+		},
+		{
+			name: "safe sender signed by a remote signer",
+			trebConfig: &config.TrebConfig{
+				Senders: map[string]config.SenderConfig{
+					"signer0": {
+						Type:    "remote_signer",
+						Address: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+						URL:     "http://127.0.0.1:8550",
+					},
+					"safe0": {
+						Type:   "safe",
+						Safe:   "0x1234567890123456789012345678901234567890",
+						Signer: "signer0",
+					},
+				},
+			},
+			senders:       []string{"safe0"},
+			expectedError: "safe signer 'signer0' is a remote_signer account, which cannot sign Safe transactions",
+		},
+		{
+			name: "remote signer sender without url",
+			trebConfig: &config.TrebConfig{
+				Senders: map[string]config.SenderConfig{
+					"signer0": {
+						Type:    "remote_signer",
+						Address: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+					},
+				},
+			},
+			senders:       []string{"signer0"},
+			expectedError: "requires a url",
+		},
 	}
 
 	for _, tt := range tests {
@@ -276,6 +334,104 @@ func TestSendersManager_BuildSenderScriptConfig(t *testing.T) {
 	assert.True(t, foundSigner, "Signer not found")
 }
 
+func TestSendersManager_RemoteSignerMix(t *testing.T) {
+	senders := map[string]config.SenderConfig{
+		"ops": {
+			Type:    "remote_signer",
+			Address: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+			URL:     "http://127.0.0.1:8550",
+		},
+		"ops2": {
+			Type:    "remote_signer",
+			Address: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+			URL:     "http://127.0.0.1:8550",
+		},
+		"deployer": {
+			Type:       "private_key",
+			PrivateKey: "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+		},
+		"hw": {
+			Type:    "ledger",
+			Address: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+		},
+		"safe0": {
+			Type:   "safe",
+			Safe:   "0x3D33783D1fd1B6D849d299aD2E711f844fC16d2F",
+			Signer: "deployer",
+		},
+		"safe1": {
+			Type:       "safe",
+			Safe:       "0x3D33783D1fd1B6D849d299aD2E711f844fC16d2F",
+			Signer:     "deployer",
+			Confirmers: []string{"ops", "ops2"},
+		},
+		"safe2": {
+			Type:       "safe",
+			Safe:       "0x3D33783D1fd1B6D849d299aD2E711f844fC16d2F",
+			Signer:     "deployer",
+			Confirmers: []string{"hw"},
+		},
+		"governor": {
+			Type:     "oz_governor",
+			Governor: "0x1234567890123456789012345678901234567890",
+			Proposer: "deployer",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		senders       string
+		expectedError string
+	}{
+		{name: "remote signers only", senders: "ops, ops2"},
+		{name: "local senders only", senders: "deployer, hw"},
+		{
+			name:          "remote signer with private key",
+			senders:       "ops, deployer",
+			expectedError: "remote_signer sender 'ops' cannot be used together with private_key sender 'deployer' in one script",
+		},
+		{
+			name:          "remote signer with ledger",
+			senders:       "hw, ops",
+			expectedError: "remote_signer sender 'ops' cannot be used together with ledger sender 'hw' in one script",
+		},
+		{
+			name:          "remote signer with a Safe signed by a private key",
+			senders:       "ops, safe0",
+			expectedError: "private_key sender 'deployer'",
+		},
+		{
+			name:          "remote signer with a governor proposed by a private key",
+			senders:       "ops, governor",
+			expectedError: "private_key sender 'deployer'",
+		},
+		// Confirmers sign after the broadcast, so they do not join the senders forge unlocks
+		{name: "Safe confirmed by remote signers", senders: "safe1"},
+		{
+			name:          "Safe confirmed by a ledger",
+			senders:       "safe2",
+			expectedError: "safe confirmer 'hw' is a ledger account, which cannot confirm Safe transactions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := NewSendersManager(&config.RuntimeConfig{TrebConfig: &config.TrebConfig{Senders: senders}})
+			artifact := &models.Artifact{}
+			artifact.Metadata.Output.DevDoc = json.RawMessage(`{"methods":{"run()":{"custom:senders":"` + tt.senders + `"}}}`)
+
+			scriptConfig, err := manager.BuildSenderScriptConfig(artifact)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, scriptConfig.SenderInitConfigs)
+		})
+	}
+}
+
 func TestParsePrivateKey(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -325,3 +481,52 @@ func TestParsePrivateKey(t *testing.T) {
 		})
 	}
 }
+
+func TestSendersManager_SenderAddress(t *testing.T) {
+	manager := NewSendersManager(&config.RuntimeConfig{
+		TrebConfig: &config.TrebConfig{
+			Senders: map[string]config.SenderConfig{
+				"deployer": {Type: config.SenderTypePrivateKey, PrivateKey: "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"},
+				"safe":     {Type: config.SenderTypeSafe, Safe: "0x2222222222222222222222222222222222222222", Signer: "deployer"},
+				"governor": {Type: config.SenderTypeOZGovernor, Governor: "0x3333333333333333333333333333333333333333", Proposer: "deployer"},
+				"governor-timelock": {
+					Type:     config.SenderTypeOZGovernor,
+					Governor: "0x3333333333333333333333333333333333333333",
+					Timelock: "0x4444444444444444444444444444444444444444",
+					Proposer: "deployer",
+				},
+				"ledger":         {Type: config.SenderTypeLedger, Address: "0x6666666666666666666666666666666666666666"},
+				"ledger-no-addr": {Type: config.SenderTypeLedger, DerivationPath: "m/44'/60'/0'/0/0"},
+				"bad-address":    {Type: config.SenderTypeRemoteSigner, Address: "0x1234"},
+			},
+		},
+	})
+
+	tests := []struct {
+		sender   string
+		expected string
+		errMsg   string
+	}{
+		{sender: "deployer", expected: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+		{sender: "safe", expected: "0x2222222222222222222222222222222222222222"},
+		{sender: "governor", expected: "0x3333333333333333333333333333333333333333"},
+		{sender: "governor-timelock", expected: "0x4444444444444444444444444444444444444444"},
+		{sender: "ledger", expected: "0x6666666666666666666666666666666666666666"},
+		{sender: "ledger-no-addr", errMsg: "has no address configured"},
+		{sender: "bad-address", errMsg: "invalid address"},
+		{sender: "missing", errMsg: "not found in configuration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sender, func(t *testing.T) {
+			address, err := manager.SenderAddress(tt.sender)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, address.Hex())
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+)
+
+// SenderKey returns the private key and address of a sender that holds its key, decrypting
+// keystores on first use. It is used to sign transactions treb sends itself, such as
+// timelock executions.
+func (m *SendersManager) SenderKey(senderKey string) (*ecdsa.PrivateKey, common.Address, error) {
+	if m.config == nil || m.config.Senders == nil {
+		return nil, common.Address{}, fmt.Errorf("no sender configuration available")
+	}
+
+	sender, exists := m.config.Senders[senderKey]
+	if !exists {
+		return nil, common.Address{}, fmt.Errorf("sender '%s' not found in configuration", senderKey)
+	}
+
+	switch sender.Type {
+	case config.SenderTypePrivateKey:
+		key, err := parsePrivateKey(sender.PrivateKey)
+		if err != nil {
+			return nil, common.Address{}, fmt.Errorf("invalid private key: %w", err)
+		}
+		return key.PrivateKey, key.Address, nil
+
+	case config.SenderTypeKeystore:
+		return m.decryptKeystore(senderKey, sender)
+
+	default:
+		return nil, common.Address{}, fmt.Errorf("sender '%s' of type %s does not hold a private key", senderKey, sender.Type)
+	}
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+)
+
+func TestSendersManager_SenderKey(t *testing.T) {
+	manager := newKeystoreManager(map[string]config.SenderConfig{
+		"deployer": {Type: config.SenderTypePrivateKey, PrivateKey: "0x" + testKeystorePrivateKey},
+		"ledger":   {Type: config.SenderTypeLedger, DerivationPath: "m/44'/60'/0'/0/0"},
+	})
+
+	key, address, err := manager.SenderKey("deployer")
+	require.NoError(t, err)
+	require.NotNil(t, key)
+	assert.Equal(t, testKeystoreAddress, address.Hex())
+
+	_, _, err = manager.SenderKey("ledger")
+	assert.EqualError(t, err, "sender 'ledger' of type ledger does not hold a private key")
+
+	_, _, err = manager.SenderKey("missing")
+	assert.EqualError(t, err, "sender 'missing' not found in configuration")
+}
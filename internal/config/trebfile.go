@@ -42,6 +42,10 @@ func loadTrebConfig(projectRoot string) (*config.TrebFileConfig, error) {
 			sender.Governor = os.ExpandEnv(sender.Governor)
 			sender.Timelock = os.ExpandEnv(sender.Timelock)
 			sender.Proposer = os.ExpandEnv(sender.Proposer)
+			sender.Keystore = os.ExpandEnv(sender.Keystore)
+			sender.PasswordFile = os.ExpandEnv(sender.PasswordFile)
+			sender.URL = os.ExpandEnv(sender.URL)
+			sender.AuthHeader = os.ExpandEnv(sender.AuthHeader)
 			nsCfg.Senders[senderName] = sender
 		}
 		cfg.Ns[nsName] = nsCfg
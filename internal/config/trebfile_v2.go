@@ -78,7 +78,7 @@ func loadTrebConfigV2(projectRoot string) (*config.TrebFileConfigV2, error) {
 	}
 
 	// If no accounts, no namespace, and no fork config, this isn't v2 format
-	if len(raw.Accounts) == 0 && len(raw.Namespace) == 0 && raw.Fork.Setup == "" {
+	if len(raw.Accounts) == 0 && len(raw.Namespace) == 0 && raw.Fork == (config.ForkConfig{}) {
 		return nil, nil
 	}
 
@@ -106,6 +106,10 @@ func loadTrebConfigV2(projectRoot string) (*config.TrebFileConfigV2, error) {
 		acct.Governor = os.ExpandEnv(acct.Governor)
 		acct.Timelock = os.ExpandEnv(acct.Timelock)
 		acct.Proposer = os.ExpandEnv(acct.Proposer)
+		acct.Keystore = os.ExpandEnv(acct.Keystore)
+		acct.PasswordFile = os.ExpandEnv(acct.PasswordFile)
+		acct.URL = os.ExpandEnv(acct.URL)
+		acct.AuthHeader = os.ExpandEnv(acct.AuthHeader)
 		cfg.Accounts[name] = acct
 	}
 
@@ -122,11 +126,12 @@ func ResolveNamespace(cfg *config.TrebFileConfigV2, namespaceName string, warnWr
 	w := resolveWarnWriter(warnWriter)
 
 	// Build the ancestry chain: always start with "default", then each prefix segment
-	chain := buildNamespaceChain(namespaceName)
+	chain := BuildNamespaceChain(namespaceName)
 
-	// Accumulate profile and roles by walking the chain
+	// Accumulate profile, confirmation gate and roles by walking the chain
 	profile := ""
-	roles := make(map[string]string)
+	requireConfirmation := false
+	roles := make(map[string]config.RoleBinding)
 
 	for _, ancestor := range chain {
 		ns, exists := cfg.Namespace[ancestor]
@@ -136,24 +141,30 @@ func ResolveNamespace(cfg *config.TrebFileConfigV2, namespaceName string, warnWr
 		if ns.Profile != "" {
 			profile = ns.Profile
 		}
+		if ns.RequireConfirmation != nil {
+			requireConfirmation = *ns.RequireConfirmation
+		}
 		for role, account := range ns.Senders {
-			roles[role] = account
+			roles[role] = config.RoleBinding{Account: account, Namespace: ancestor}
 		}
 	}
 
 	// Build resolved accounts map, skipping roles that reference unknown accounts
 	accounts := make(map[string]config.AccountConfig, len(roles))
-	for role, accountName := range roles {
-		if _, exists := cfg.Accounts[accountName]; !exists {
-			fmt.Fprintf(w, "Warning: namespace %q role %q references unknown account %q — skipping\n", namespaceName, role, accountName)
+	for role, binding := range roles {
+		if _, exists := cfg.Accounts[binding.Account]; !exists {
+			fmt.Fprintf(w, "Warning: namespace %q role %q references unknown account %q — skipping\n", namespaceName, role, binding.Account)
+			delete(roles, role)
 			continue
 		}
-		accounts[role] = cfg.Accounts[accountName]
+		accounts[role] = cfg.Accounts[binding.Account]
 	}
 
 	return &config.ResolvedNamespace{
-		Profile:  profile,
-		Accounts: accounts,
+		Profile:             profile,
+		RequireConfirmation: requireConfirmation,
+		Accounts:            accounts,
+		Roles:               roles,
 	}, nil
 }
 
@@ -199,11 +210,11 @@ func resolveWarnWriter(writers []io.Writer) io.Writer {
 	return os.Stderr
 }
 
-// buildNamespaceChain returns the ordered list of namespace names to resolve,
+// BuildNamespaceChain returns the ordered list of namespace names to resolve,
 // starting from "default" and adding each dot-separated prefix.
 // For "production.ntt.v2" it returns: ["default", "production", "production.ntt", "production.ntt.v2"]
 // For "default" it returns just: ["default"]
-func buildNamespaceChain(namespaceName string) []string {
+func BuildNamespaceChain(namespaceName string) []string {
 	if namespaceName == "default" {
 		return []string{"default"}
 	}
@@ -271,6 +271,37 @@ setup = "script/ForkSetup.s.sol"
 		assert.Equal(t, "script/ForkSetup.s.sol", cfg.Fork.Setup)
 	})
 
+	t.Run("parses fork block as number or tag", func(t *testing.T) {
+		tests := []struct {
+			value string
+			want  config.ForkBlock
+		}{
+			{"21000000", "21000000"},
+			{`"21000000"`, "21000000"},
+			{`"finalized"`, "finalized"},
+		}
+		for _, tt := range tests {
+			dir := t.TempDir()
+			content := "[fork]\nblock = " + tt.value + "\n"
+			err := os.WriteFile(filepath.Join(dir, "treb.toml"), []byte(content), 0644)
+			require.NoError(t, err)
+
+			cfg, err := loadTrebConfigV2(dir)
+			require.NoError(t, err, tt.value)
+			require.NotNil(t, cfg)
+			assert.Equal(t, tt.want, cfg.Fork.Block, tt.value)
+		}
+	})
+
+	t.Run("rejects a negative fork block", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "treb.toml"), []byte("[fork]\nblock = -1\n"), 0644)
+		require.NoError(t, err)
+
+		_, err = loadTrebConfigV2(dir)
+		assert.Error(t, err)
+	})
+
 	t.Run("fork-only config loads correctly", func(t *testing.T) {
 		dir := t.TempDir()
 		content := `
@@ -381,6 +412,7 @@ func TestResolveNamespace(t *testing.T) {
 		// Deployer overridden at production.ntt level
 		assert.Equal(t, config.SenderType("private_key"), resolved.Accounts["deployer"].Type)
 		assert.Equal(t, "0xntt", resolved.Accounts["deployer"].PrivateKey)
+		assert.Equal(t, config.RoleBinding{Account: "ntt-deployer", Namespace: "production.ntt"}, resolved.Roles["deployer"])
 	})
 
 	t.Run("profile inheritance without override", func(t *testing.T) {
@@ -408,6 +440,7 @@ func TestResolveNamespace(t *testing.T) {
 		assert.Equal(t, "mainnet", resolved.Profile)
 		// deployer inherited from default
 		assert.Equal(t, "0x1234", resolved.Accounts["deployer"].PrivateKey)
+		assert.Equal(t, config.RoleBinding{Account: "deployer", Namespace: "default"}, resolved.Roles["deployer"])
 	})
 
 	t.Run("profile override at child level", func(t *testing.T) {
@@ -475,6 +508,7 @@ func TestResolveNamespace(t *testing.T) {
 		resolved, err := ResolveNamespace(cfg, "default", &buf)
 		require.NoError(t, err)
 		assert.Empty(t, resolved.Accounts)
+		assert.Empty(t, resolved.Roles)
 		assert.Contains(t, buf.String(), `namespace "default" role "deployer" references unknown account "nonexistent"`)
 	})
 
@@ -586,6 +620,38 @@ func TestResolveNamespace(t *testing.T) {
 		assert.Equal(t, "0xv2", resolved.Accounts["deployer"].PrivateKey) // overridden at deepest level
 		assert.Equal(t, "0xdev", resolved.Accounts["monitor"].PrivateKey) // inherited from default
 	})
+
+	t.Run("require_confirmation inheritance and override", func(t *testing.T) {
+		enabled, disabled := true, false
+		cfg := &config.TrebFileConfigV2{
+			Accounts: map[string]config.AccountConfig{
+				"deployer": {Type: "private_key", PrivateKey: "0x1234"},
+			},
+			Namespace: map[string]config.NamespaceRoles{
+				"default": {
+					Senders: map[string]string{"deployer": "deployer"},
+				},
+				"production": {
+					RequireConfirmation: &enabled,
+				},
+				"production.sandbox": {
+					RequireConfirmation: &disabled,
+				},
+			},
+		}
+
+		resolved, err := ResolveNamespace(cfg, "default")
+		require.NoError(t, err)
+		assert.False(t, resolved.RequireConfirmation)
+
+		resolved, err = ResolveNamespace(cfg, "production.ntt")
+		require.NoError(t, err)
+		assert.True(t, resolved.RequireConfirmation) // inherited from production
+
+		resolved, err = ResolveNamespace(cfg, "production.sandbox")
+		require.NoError(t, err)
+		assert.False(t, resolved.RequireConfirmation) // explicitly disabled at child level
+	})
 }
 
 func TestResolvedNamespaceToTrebConfig(t *testing.T) {
@@ -806,7 +872,7 @@ func TestBuildNamespaceChain(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.expected, buildNamespaceChain(tt.input))
+			assert.Equal(t, tt.expected, BuildNamespaceChain(tt.input))
 		})
 	}
 }
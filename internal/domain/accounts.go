@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountState is the on-chain state of an account
+type AccountState struct {
+	Balance *big.Int // Wei
+	Nonce   uint64
+}
+
+// SafeInfo is the on-chain signing configuration of a Safe
+type SafeInfo struct {
+	Owners    []common.Address
+	Threshold uint64
+}
@@ -2,12 +2,13 @@ package domain
 
 // AnvilInstance represents a local anvil node instance
 type AnvilInstance struct {
-	Name    string `json:"name"`
-	Port    string `json:"port"`
-	ChainID string `json:"chainId,omitempty"`
-	ForkURL string `json:"forkUrl,omitempty"`
-	PidFile string `json:"pidFile"`
-	LogFile string `json:"logFile"`
+	Name      string `json:"name"`
+	Port      string `json:"port"`
+	ChainID   string `json:"chainId,omitempty"`
+	ForkURL   string `json:"forkUrl,omitempty"`
+	ForkBlock uint64 `json:"forkBlock,omitempty"` // block number to fork at, 0 forks the latest block
+	PidFile   string `json:"pidFile"`
+	LogFile   string `json:"logFile"`
 }
 
 // AnvilStatus represents the status of an anvil instance
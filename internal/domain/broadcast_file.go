@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // BroadcastFile represents a Foundry broadcast file
 type BroadcastFile struct {
 	Chain        uint64                 `json:"chain"`
@@ -7,6 +9,7 @@ type BroadcastFile struct {
 	Receipts     []BroadcastReceipt     `json:"receipts"`
 	Timestamp    uint64                 `json:"timestamp"`
 	Commit       string                 `json:"commit"`
+	UpdatedAt    time.Time              `json:"-"` // Modification time of the file on disk
 }
 
 // BroadcastTransaction represents a transaction in a broadcast file
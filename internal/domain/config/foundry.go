@@ -39,11 +39,13 @@ type TrebConfig struct {
 type SenderType string
 
 var (
-	SenderTypeLedger     SenderType = "ledger"
-	SenderTypeTrezor     SenderType = "trezor"
-	SenderTypeSafe       SenderType = "safe"
-	SenderTypePrivateKey SenderType = "private_key"
-	SenderTypeOZGovernor SenderType = "oz_governor"
+	SenderTypeLedger       SenderType = "ledger"
+	SenderTypeTrezor       SenderType = "trezor"
+	SenderTypeSafe         SenderType = "safe"
+	SenderTypePrivateKey   SenderType = "private_key"
+	SenderTypeOZGovernor   SenderType = "oz_governor"
+	SenderTypeKeystore     SenderType = "keystore"
+	SenderTypeRemoteSigner SenderType = "remote_signer"
 )
 
 // SenderConfig represents a sender configuration
@@ -53,8 +55,14 @@ type SenderConfig struct {
 	PrivateKey     string     `toml:"private_key,omitempty"` //nolint:gosec // holds env var reference, not a literal secret
 	Safe           string     `toml:"safe,omitempty"`
 	Signer         string     `toml:"signer,omitempty"`          // For Safe senders
+	Confirmers     []string   `toml:"confirmers,omitempty"`      // For Safe senders: owners confirming proposed transactions
 	DerivationPath string     `toml:"derivation_path,omitempty"` // For Ledger senders
 	Governor       string     `toml:"governor,omitempty"`        // For OZ Governor senders
 	Timelock       string     `toml:"timelock,omitempty"`        // For OZ Governor senders (optional)
 	Proposer       string     `toml:"proposer,omitempty"`        // For OZ Governor senders
+	Keystore       string     `toml:"keystore,omitempty"`        // For keystore senders: encrypted JSON keystore path
+	PasswordEnv    string     `toml:"password_env,omitempty"`    // For keystore senders (optional)
+	PasswordFile   string     `toml:"password_file,omitempty"`   // For keystore senders (optional)
+	URL            string     `toml:"url,omitempty"`             // For remote signer senders: JSON-RPC endpoint
+	AuthHeader     string     `toml:"auth_header,omitempty"`     // For remote signer senders (optional)
 }
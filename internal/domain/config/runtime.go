@@ -20,10 +20,13 @@ type RuntimeConfig struct {
 	NonInteractive bool
 	JSON           bool // Output in JSON format
 	Timeout        time.Duration
+	Events         string // Progress event stream format ("ndjson"), empty when disabled
+	EventsPath     string // Destination of the event stream, empty for stderr and "-" for stdout
 
 	// Command-specific settings (only populated for relevant commands)
-	DryRun bool
-	Slow   bool
+	DryRun              bool
+	Slow                bool
+	RequireConfirmation bool // Broadcasts must be confirmed after a changeset preview (from treb.toml v2)
 
 	// Config source tracking
 	FoundryProfile string // Foundry profile name to use (from treb.toml or namespace)
@@ -31,10 +34,12 @@ type RuntimeConfig struct {
 
 	// Project configuration from treb.toml
 	ForkSetup string // Fork setup script path (from [fork] section in treb.toml v2)
+	ForkBlock string // Block number or tag forks are pinned to (from [fork] section in treb.toml v2)
 
 	// Resolved configurations
-	FoundryConfig *FoundryConfig
-	TrebConfig    *TrebConfig // Profile-specific treb config
+	FoundryConfig     *FoundryConfig
+	TrebConfig        *TrebConfig        // Profile-specific treb config
+	ResolvedNamespace *ResolvedNamespace // Role → account resolution of the namespace, nil unless treb.toml v2
 }
 
 // Network represents network configuration
@@ -9,6 +9,15 @@ type SenderScriptConfig struct {
 	EncodedConfig     string
 	SenderInitConfigs []SenderInitConfig
 	Senders           []string
+	RemoteSigners     []RemoteSignerConfig
+}
+
+// RemoteSignerConfig is a sender whose transactions are signed by a JSON-RPC signing service
+type RemoteSignerConfig struct {
+	Name       string
+	Address    common.Address
+	URL        string
+	AuthHeader string
 }
 
 type SenderInitConfig struct {
@@ -1,5 +1,10 @@
 package config
 
+import (
+	"fmt"
+	"strconv"
+)
+
 // AccountConfig represents a named signing entity in [accounts.*] sections.
 // It has the same fields as SenderConfig but is defined at the top level,
 // decoupled from any specific namespace.
@@ -9,17 +14,25 @@ type AccountConfig struct {
 	PrivateKey     string     `toml:"private_key,omitempty"` //nolint:gosec // holds env var reference, not a literal secret
 	Safe           string     `toml:"safe,omitempty"`
 	Signer         string     `toml:"signer,omitempty"`          // For Safe accounts: references another account name
+	Confirmers     []string   `toml:"confirmers,omitempty"`      // For Safe accounts: account names confirming proposed transactions
 	DerivationPath string     `toml:"derivation_path,omitempty"` // For Ledger/Trezor accounts
 	Governor       string     `toml:"governor,omitempty"`        // For OZ Governor accounts
 	Timelock       string     `toml:"timelock,omitempty"`        // For OZ Governor accounts (optional)
 	Proposer       string     `toml:"proposer,omitempty"`        // For OZ Governor accounts: references another account name
+	Keystore       string     `toml:"keystore,omitempty"`        // For keystore accounts: encrypted JSON keystore path
+	PasswordEnv    string     `toml:"password_env,omitempty"`    // For keystore accounts: env var holding the password (optional)
+	PasswordFile   string     `toml:"password_file,omitempty"`   // For keystore accounts: file holding the password (optional)
+	URL            string     `toml:"url,omitempty"`             // For remote signer accounts: JSON-RPC signing endpoint
+	AuthHeader     string     `toml:"auth_header,omitempty"`     // For remote signer accounts: auth header sent to the endpoint (optional)
 }
 
 // NamespaceRoles represents a [namespace.*] section in treb.toml v2.
 // Profile maps to a foundry.toml profile, and Senders maps role names to account names.
+// RequireConfirmation gates broadcasts behind a reviewed changeset preview.
 type NamespaceRoles struct {
-	Profile string            `toml:"profile,omitempty"`
-	Senders map[string]string `toml:"senders"`
+	Profile             string            `toml:"profile,omitempty"`
+	RequireConfirmation *bool             `toml:"require_confirmation,omitempty"`
+	Senders             map[string]string `toml:"senders"`
 }
 
 // TrebFileConfigV2 represents the new treb.toml format with separate accounts and namespaces.
@@ -31,12 +44,41 @@ type TrebFileConfigV2 struct {
 
 // ForkConfig represents the [fork] section in treb.toml v2.
 type ForkConfig struct {
-	Setup string `toml:"setup,omitempty"`
+	Setup string    `toml:"setup,omitempty"`
+	Block ForkBlock `toml:"block,omitempty"` // block number or tag (latest, safe, finalized) forks are pinned to
+}
+
+// ForkBlock is the block forks are pinned to. In treb.toml it may be written as
+// a number (block = 21000000) or as a string (block = "21000000", block = "finalized").
+type ForkBlock string
+
+// UnmarshalTOML accepts both integer and string values
+func (b *ForkBlock) UnmarshalTOML(value any) error {
+	switch v := value.(type) {
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("fork block cannot be negative: %d", v)
+		}
+		*b = ForkBlock(strconv.FormatInt(v, 10))
+	case string:
+		*b = ForkBlock(v)
+	default:
+		return fmt.Errorf("fork block must be a block number or tag, got %T", value)
+	}
+	return nil
 }
 
 // ResolvedNamespace holds the fully-resolved configuration for a namespace
 // after walking the dot-based hierarchy and resolving role→account mappings.
 type ResolvedNamespace struct {
-	Profile  string                   // Resolved foundry profile name
-	Accounts map[string]AccountConfig // role name → resolved AccountConfig
+	Profile             string                   // Resolved foundry profile name
+	RequireConfirmation bool                     // Whether broadcasts need an explicit confirmation
+	Accounts            map[string]AccountConfig // role name → resolved AccountConfig
+	Roles               map[string]RoleBinding   // role name → account name and the namespace that set it
+}
+
+// RoleBinding records which account a role resolved to and where in the hierarchy it was set.
+type RoleBinding struct {
+	Account   string // Account name in [accounts.*]
+	Namespace string // Namespace section that set the role, e.g. "production"
 }
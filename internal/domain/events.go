@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -9,10 +10,12 @@ import (
 type EventType string
 
 const (
-	// Proxy events only - Treb events now come from generated bindings
+	// Proxy and TimelockController events only - Treb events now come from generated bindings
 	EventTypeAdminChanged   EventType = "AdminChanged"
 	EventTypeBeaconUpgraded EventType = "BeaconUpgraded"
 	EventTypeUpgraded       EventType = "Upgraded"
+	EventTypeCallScheduled  EventType = "CallScheduled"
+	EventTypeCallSalt       EventType = "CallSalt"
 	EventTypeUnknown        EventType = "Unknown"
 )
 
@@ -81,6 +84,50 @@ func (e *UpgradedEvent) String() string {
 	)
 }
 
+// CallScheduledEvent represents a call scheduled on an OpenZeppelin TimelockController
+type CallScheduledEvent struct {
+	Timelock    common.Address
+	OperationID common.Hash
+	Index       *big.Int
+	Target      common.Address
+	Value       *big.Int
+	Data        []byte
+	Predecessor common.Hash
+	Delay       *big.Int
+}
+
+func (CallScheduledEvent) ContractEventName() string {
+	return string(EventTypeCallScheduled)
+}
+
+func (e *CallScheduledEvent) String() string {
+	return fmt.Sprintf("%s: timelock=%s, id=%s, index=%s",
+		e.ContractEventName(),
+		e.Timelock.Hex()[:10]+"...",
+		e.OperationID.Hex()[:10]+"...",
+		e.Index,
+	)
+}
+
+// CallSaltEvent represents the salt of an operation scheduled on a TimelockController
+type CallSaltEvent struct {
+	Timelock    common.Address
+	OperationID common.Hash
+	Salt        common.Hash
+}
+
+func (CallSaltEvent) ContractEventName() string {
+	return string(EventTypeCallSalt)
+}
+
+func (e *CallSaltEvent) String() string {
+	return fmt.Sprintf("%s: timelock=%s, id=%s",
+		e.ContractEventName(),
+		e.Timelock.Hex()[:10]+"...",
+		e.OperationID.Hex()[:10]+"...",
+	)
+}
+
 // UnknownEvent represents an unknown event type
 type UnknownEvent struct {
 	Address       common.Address
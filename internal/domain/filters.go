@@ -20,6 +20,13 @@ type DeploymentFilter struct {
 	Type         models.DeploymentType
 }
 
+// TimelockOperationFilter defines filtering options for timelock operations
+type TimelockOperationFilter struct {
+	ChainID         uint64
+	Status          models.TransactionStatus
+	TimelockAddress string
+}
+
 // SafeTransactionFilter defines filtering options for Safe transactions
 type SafeTransactionFilter struct {
 	ChainID     uint64
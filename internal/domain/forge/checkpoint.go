@@ -0,0 +1,45 @@
+package forge
+
+import "time"
+
+// BroadcastCheckpoint records an interrupted broadcast so it can be picked up again with `treb run --resume`.
+// Forge does not re-execute the script when resuming, so the script output of the original
+// run is kept here to hydrate the transactions that land on chain.
+type BroadcastCheckpoint struct {
+	Script        string             `json:"script"`
+	BroadcastDir  string             `json:"broadcastDir,omitempty"` // Broadcast directory of the run, empty for forge's default
+	Namespace     string             `json:"namespace"`
+	Network       string             `json:"network"`
+	ChainID       uint64             `json:"chainId"`
+	BroadcastPath string             `json:"broadcastPath"`
+	ScriptOutput  *ScriptOutput      `json:"scriptOutput"`
+	TraceOutputs  []TraceOutput      `json:"traceOutputs,omitempty"`
+	Attempts      []BroadcastAttempt `json:"attempts"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	UpdatedAt     time.Time          `json:"updatedAt"`
+}
+
+// BroadcastAttempt lists the transactions that landed on chain during one broadcast attempt
+type BroadcastAttempt struct {
+	Number       int                  `json:"number"`
+	Resumed      bool                 `json:"resumed"`
+	Transactions []BroadcastAttemptTx `json:"transactions"`
+}
+
+// BroadcastAttemptTx is a mined transaction from a broadcast file
+type BroadcastAttemptTx struct {
+	Hash        string `json:"hash"`
+	BlockNumber uint64 `json:"blockNumber"`
+	Description string `json:"description"`
+}
+
+// MinedHashes returns the hashes of every transaction recorded across all attempts
+func (c *BroadcastCheckpoint) MinedHashes() map[string]bool {
+	hashes := make(map[string]bool)
+	for _, attempt := range c.Attempts {
+		for _, tx := range attempt.Transactions {
+			hashes[tx.Hash] = true
+		}
+	}
+	return hashes
+}
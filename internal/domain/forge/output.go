@@ -159,3 +159,7 @@ func (traceWithLabel *TraceWithLabel) UnmarshalJSON(data []byte) error {
 
 	return json.Unmarshal(raw[1], &traceWithLabel.Trace)
 }
+
+func (traceWithLabel TraceWithLabel) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{traceWithLabel.Label, traceWithLabel.Trace})
+}
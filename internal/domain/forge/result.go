@@ -33,6 +33,7 @@ type HydratedRunResult struct {
 	Transactions       []*Transaction                                       // All transactions in execution order
 	SafeTransactions   []*SafeTransaction                                   // Safe transaction batches
 	GovernorProposals  []*GovernorProposal                                  // Governor proposal batches
+	TimelockOperations []*TimelockOperation                                 // TimelockController operations scheduled
 	Deployments        []*Deployment                                        // Contract deployments
 	ProxyRelationships map[common.Address]*ProxyRelationship                // Proxy relationships
 	Collisions         map[common.Address]*bindings.TrebDeploymentCollision // Deployment collisions (contracts already deployed)
@@ -71,17 +72,19 @@ type ProxyRelationship struct {
 
 type Transaction struct {
 	bindings.SimulatedTransaction
-	Status           models.TransactionStatus
-	TxHash           *common.Hash
-	BlockNumber      *uint64
-	GasUsed          *uint64
-	SafeTransaction  *SafeTransaction
-	SafeBatchIdx     *int
-	GovernorProposal *GovernorProposal
-	GovernorBatchIdx *int
-	Deployments      []DeploymentInfo
-	TraceData        *TraceOutput
-	ReceiptData      *Receipt
+	Status            models.TransactionStatus
+	TxHash            *common.Hash
+	BlockNumber       *uint64
+	GasUsed           *uint64
+	SafeTransaction   *SafeTransaction
+	SafeBatchIdx      *int
+	GovernorProposal  *GovernorProposal
+	GovernorBatchIdx  *int
+	TimelockOperation *TimelockOperation
+	TimelockBatchIdx  *int
+	Deployments       []DeploymentInfo
+	TraceData         *TraceOutput
+	ReceiptData       *Receipt
 }
 
 // DeploymentInfo contains deployment details for a transaction
@@ -124,3 +127,22 @@ type GovernorProposal struct {
 	ExecutionTxHash   *common.Hash
 	ExecutionBlockNum *uint64
 }
+
+// TimelockOperation represents an operation scheduled on a TimelockController (runtime type)
+type TimelockOperation struct {
+	OperationID    common.Hash
+	Timelock       common.Address
+	Proposer       common.Address
+	Calls          []TimelockCall
+	Predecessor    common.Hash
+	Salt           common.Hash
+	Delay          *big.Int
+	TransactionIds [][32]byte
+}
+
+// TimelockCall is a single call of a TimelockController operation
+type TimelockCall struct {
+	Target common.Address
+	Value  *big.Int
+	Data   []byte
+}
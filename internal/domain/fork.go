@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // ForkState represents the state of all active forks
 type ForkState struct {
@@ -14,6 +17,8 @@ type ForkEntry struct {
 	EnvVarName  string          `json:"envVarName"`
 	OriginalRPC string          `json:"originalRpc"`
 	ForkURL     string          `json:"forkUrl"`
+	ForkBlock   uint64          `json:"forkBlock,omitempty"`   // block the fork was created from
+	BlockPinned bool            `json:"blockPinned,omitempty"` // restarts fork ForkBlock instead of the latest block
 	AnvilPID    int             `json:"anvilPid"`
 	PidFile     string          `json:"pidFile"`
 	LogFile     string          `json:"logFile"`
@@ -26,9 +31,35 @@ type SnapshotEntry struct {
 	Index      int       `json:"index"`
 	SnapshotID string    `json:"snapshotId"`
 	Command    string    `json:"command"`
+	Name       string    `json:"name,omitempty"` // name of a checkpoint created with 'treb fork checkpoint'
 	Timestamp  time.Time `json:"timestamp"`
 }
 
+// GetCheckpoint returns the position in the snapshot stack of the checkpoint with the given name, or -1
+func (e *ForkEntry) GetCheckpoint(name string) int {
+	for i, snap := range e.Snapshots {
+		if snap.Name != "" && snap.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// ForkExportVersion is the version of the fork export file format
+const ForkExportVersion = 1
+
+// ForkExport is a fork captured to a file by 'treb fork export': the anvil state
+// together with the registry files of .treb at the time of the export
+type ForkExport struct {
+	Version    int                        `json:"version"`
+	Network    string                     `json:"network"`
+	ChainID    uint64                     `json:"chainId"`
+	ForkBlock  uint64                     `json:"forkBlock,omitempty"`
+	ExportedAt time.Time                  `json:"exportedAt"`
+	AnvilState string                     `json:"anvilState"` // hex-encoded anvil_dumpState output
+	Registry   map[string]json.RawMessage `json:"registry"`   // registry files keyed by file name
+}
+
 // NewForkState creates a new empty ForkState
 func NewForkState() *ForkState {
 	return &ForkState{
@@ -52,3 +83,26 @@ func (s *ForkState) GetActiveFork(network string) *ForkEntry {
 	}
 	return s.Forks[network]
 }
+
+// LastRun returns the position in the snapshot stack of the most recent run, skipping
+// checkpoints, or -1 if nothing has run since the fork was entered
+func (e *ForkEntry) LastRun() int {
+	for i := len(e.Snapshots) - 1; i >= 1; i-- {
+		if e.Snapshots[i].Name == "" {
+			return i
+		}
+	}
+	return -1
+}
+
+// RunsAfter returns the number of runs in the snapshot stack after the given position,
+// not counting checkpoints
+func (e *ForkEntry) RunsAfter(position int) int {
+	count := 0
+	for i := position + 1; i < len(e.Snapshots); i++ {
+		if e.Snapshots[i].Name == "" {
+			count++
+		}
+	}
+	return count
+}
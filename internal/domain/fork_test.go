@@ -179,3 +179,86 @@ func TestForkState_GetActiveFork(t *testing.T) {
 		assert.Nil(t, got)
 	})
 }
+
+func TestForkEntry_GetCheckpoint(t *testing.T) {
+	entry := &ForkEntry{
+		Network: "sepolia",
+		Snapshots: []SnapshotEntry{
+			{Index: 0, SnapshotID: "0x1", Command: "fork enter"},
+			{Index: 1, SnapshotID: "0x2", Command: "fork checkpoint", Name: "after-core"},
+			{Index: 2, SnapshotID: "0x3", Command: "DeployConfig"},
+			{Index: 3, SnapshotID: "0x4", Command: "fork checkpoint", Name: "after-config"},
+		},
+	}
+
+	assert.Equal(t, 1, entry.GetCheckpoint("after-core"))
+	assert.Equal(t, 3, entry.GetCheckpoint("after-config"))
+	assert.Equal(t, -1, entry.GetCheckpoint("missing"))
+	assert.Equal(t, -1, entry.GetCheckpoint(""))
+}
+
+func TestForkEntry_LastRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		snapshots []SnapshotEntry
+		want      int
+	}{
+		{
+			name:      "only the initial snapshot",
+			snapshots: []SnapshotEntry{{Index: 0, Command: "fork enter"}},
+			want:      -1,
+		},
+		{
+			name: "run on top",
+			snapshots: []SnapshotEntry{
+				{Index: 0, Command: "fork enter"},
+				{Index: 1, Command: "fork checkpoint", Name: "after-core"},
+				{Index: 2, Command: "DeployConfig"},
+			},
+			want: 2,
+		},
+		{
+			name: "checkpoints on top",
+			snapshots: []SnapshotEntry{
+				{Index: 0, Command: "fork enter"},
+				{Index: 1, Command: "DeployCore"},
+				{Index: 2, Command: "fork checkpoint", Name: "after-core"},
+				{Index: 3, Command: "fork checkpoint", Name: "again"},
+			},
+			want: 1,
+		},
+		{
+			name: "only checkpoints",
+			snapshots: []SnapshotEntry{
+				{Index: 0, Command: "fork enter"},
+				{Index: 1, Command: "fork checkpoint", Name: "start"},
+			},
+			want: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &ForkEntry{Snapshots: tt.snapshots}
+			assert.Equal(t, tt.want, entry.LastRun())
+		})
+	}
+}
+
+func TestForkEntry_RunsAfter(t *testing.T) {
+	entry := &ForkEntry{
+		Snapshots: []SnapshotEntry{
+			{Index: 0, Command: "fork enter"},
+			{Index: 1, Command: "DeployCore"},
+			{Index: 2, Command: "fork checkpoint", Name: "after-core"},
+			{Index: 3, Command: "DeployConfig"},
+			{Index: 4, Command: "fork checkpoint", Name: "after-config"},
+			{Index: 5, Command: "DeployPeriphery"},
+		},
+	}
+
+	assert.Equal(t, 3, entry.RunsAfter(0))
+	assert.Equal(t, 2, entry.RunsAfter(2))
+	assert.Equal(t, 1, entry.RunsAfter(4))
+	assert.Equal(t, 0, entry.RunsAfter(5))
+}
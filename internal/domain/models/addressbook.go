@@ -0,0 +1,14 @@
+package models
+
+// AddressBookEntry names a contract that treb did not deploy, such as a token, router or
+// price feed, on one chain
+type AddressBookEntry struct {
+	Name    string `json:"-"`
+	ChainID uint64 `json:"-"`
+	Address string `json:"address"`
+
+	// ABI source used to decode calls to the contract, either a project artifact or
+	// a JSON ABI file relative to the project root
+	Artifact string `json:"artifact,omitempty"`
+	ABI      string `json:"abi,omitempty"`
+}
@@ -5,10 +5,11 @@ type ChangesetMetadata struct {
 }
 
 type ChangesetModels struct {
-	Deployments      []*Deployment
-	Transactions     []*Transaction
-	SafeTransactions []*SafeTransaction
-	Metadata         ChangesetMetadata
+	Deployments        []*Deployment
+	Transactions       []*Transaction
+	SafeTransactions   []*SafeTransaction
+	TimelockOperations []*TimelockOperation
+	Metadata           ChangesetMetadata
 }
 
 func (cm *ChangesetModels) HasChanges() bool {
@@ -16,7 +17,7 @@ func (cm *ChangesetModels) HasChanges() bool {
 }
 
 func (cm *ChangesetModels) Count() int {
-	return len(cm.Deployments) + len(cm.Transactions) + len(cm.SafeTransactions)
+	return len(cm.Deployments) + len(cm.Transactions) + len(cm.SafeTransactions) + len(cm.TimelockOperations)
 }
 
 type Changeset struct {
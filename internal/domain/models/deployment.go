@@ -59,7 +59,8 @@ type Deployment struct {
 	Verification VerificationInfo `json:"verification"`
 
 	// Metadata
-	Tags      []string  `json:"tags"` // User-defined tags
+	Tags      []string  `json:"tags"`            // User-defined tags
+	RunID     string    `json:"runId,omitempty"` // Run record that created the deployment
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 
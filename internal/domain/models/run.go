@@ -0,0 +1,89 @@
+package models
+
+import "time"
+
+// RunStatus represents the outcome of a recorded run
+type RunStatus string
+
+const (
+	RunStatusCompleted RunStatus = "COMPLETED"
+	RunStatusFailed    RunStatus = "FAILED"
+)
+
+// RedactedValue replaces secret parameter values in run records
+const RedactedValue = "<redacted>"
+
+// RunRecord describes how a broadcast was produced: its inputs, environment and resulting registry changes
+type RunRecord struct {
+	ID             string            `json:"id"`         // e.g., "20250126-150405-a1b2c3"
+	Script         string            `json:"script"`     // e.g., "DeployCounter"
+	ScriptPath     string            `json:"scriptPath"` // e.g., "script/deploy/DeployCounter.s.sol"
+	Namespace      string            `json:"namespace"`
+	Network        string            `json:"network"`
+	ChainID        uint64            `json:"chainId"`
+	FoundryProfile string            `json:"foundryProfile"`
+	Parameters     map[string]string `json:"parameters"` // Resolved values, secret-typed values redacted
+	Senders        []RunSender       `json:"senders"`
+	Compose        *RunComposeStep   `json:"compose,omitempty"` // Set when the run was a compose step
+	Resumed        bool              `json:"resumed,omitempty"`
+	Fork           bool              `json:"fork,omitempty"` // Set when the run went to a fork started with 'treb fork enter'
+
+	// Environment
+	TrebVersion  string      `json:"trebVersion"`
+	ForgeVersion string      `json:"forgeVersion"`
+	Git          RunGitState `json:"git"`
+	Operator     string      `json:"operator"`
+
+	// Outcome
+	Status    RunStatus    `json:"status"`
+	Error     string       `json:"error,omitempty"`
+	Changeset RunChangeset `json:"changeset"`
+
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+// RunSender is the configuration of a sender used by a run, without key material
+type RunSender struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Address        string `json:"address"`
+	Safe           string `json:"safe,omitempty"`
+	Signer         string `json:"signer,omitempty"`
+	DerivationPath string `json:"derivationPath,omitempty"`
+	Governor       string `json:"governor,omitempty"`
+	Timelock       string `json:"timelock,omitempty"`
+	Proposer       string `json:"proposer,omitempty"`
+	Keystore       string `json:"keystore,omitempty"`
+	GasUsed        uint64 `json:"gasUsed,omitempty"` // Gas of the transactions the sender broadcast
+}
+
+// RunComposeStep identifies the compose step a run belongs to
+type RunComposeStep struct {
+	File  string `json:"file"`
+	Group string `json:"group"`
+	Step  string `json:"step"`
+}
+
+// RunGitState captures the state of the working tree at run time
+type RunGitState struct {
+	Commit   string `json:"commit"`
+	Dirty    bool   `json:"dirty"`
+	DiffHash string `json:"diffHash,omitempty"` // sha256 of `git diff HEAD`, set when dirty
+}
+
+// RunChangeset lists the registry IDs a run created or updated
+type RunChangeset struct {
+	Deployments        []string `json:"deployments"`
+	Transactions       []string `json:"transactions"`
+	SafeTransactions   []string `json:"safeTransactions"`
+	TimelockOperations []string `json:"timelockOperations,omitempty"`
+}
+
+// RunEnvironment is the tooling, source and operator state captured before a run
+type RunEnvironment struct {
+	TrebVersion  string
+	ForgeVersion string
+	Git          RunGitState
+	Operator     string
+}
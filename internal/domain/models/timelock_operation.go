@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// TimelockOperation represents an operation scheduled on an OpenZeppelin TimelockController
+type TimelockOperation struct {
+	// Identification
+	OperationID     string            `json:"operationId"`
+	TimelockAddress string            `json:"timelockAddress"`
+	ChainID         uint64            `json:"chainId"`
+	Status          TransactionStatus `json:"status"`
+
+	// Calls in the operation, more than one when scheduled with scheduleBatch
+	Calls       []TimelockCall `json:"calls"`
+	Predecessor string         `json:"predecessor"`
+	Salt        string         `json:"salt"`
+	Delay       uint64         `json:"delay"` // Seconds between scheduling and execution
+
+	// References to the transactions routed through the timelock
+	TransactionIDs []string `json:"transactionIds"`
+
+	// Scheduling details
+	ScheduledBy string    `json:"scheduledBy"`
+	ScheduledAt time.Time `json:"scheduledAt"`
+
+	// Execution details (when executed)
+	ExecutedAt      *time.Time `json:"executedAt,omitempty"`
+	ExecutionTxHash string     `json:"executionTxHash,omitempty"`
+}
+
+// TimelockCall represents a single call in a timelock operation
+type TimelockCall struct {
+	Target string `json:"target"`
+	Value  string `json:"value"`
+	Data   string `json:"data"`
+}
+
+// ReadyAt returns the earliest time the operation can be executed
+func (o *TimelockOperation) ReadyAt() time.Time {
+	return o.ScheduledAt.Add(time.Duration(o.Delay) * time.Second)
+}
@@ -34,6 +34,9 @@ type Transaction struct {
 	// Safe context (if applicable)
 	SafeContext *SafeContext `json:"safeContext,omitempty"`
 
+	// Timelock context (if applicable)
+	TimelockContext *TimelockContext `json:"timelockContext,omitempty"`
+
 	// Metadata
 	Environment string    `json:"environment"` // Which environment/namespace
 	CreatedAt   time.Time `json:"createdAt"`
@@ -54,3 +57,10 @@ type SafeContext struct {
 	BatchIndex      int    `json:"batchIndex"` // Index within the batch
 	ProposerAddress string `json:"proposerAddress"`
 }
+
+// TimelockContext contains timelock-specific transaction information
+type TimelockContext struct {
+	TimelockAddress string `json:"timelockAddress"`
+	OperationID     string `json:"operationId"`
+	BatchIndex      int    `json:"batchIndex"` // Index within the operation
+}
@@ -1,5 +1,14 @@
 package domain
 
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
 // ParameterType represents the type of a script parameter
 type ParameterType string
 
@@ -14,14 +23,55 @@ const (
 	ParamTypeSender     ParameterType = "sender"
 	ParamTypeDeployment ParameterType = "deployment"
 	ParamTypeArtifact   ParameterType = "artifact"
+	ParamTypeSecret     ParameterType = "secret" // A string that is redacted from run records
+
+	// Rich types, normalised before they are passed to forge
+	ParamTypeAddressArray ParameterType = "address[]" // Comma-separated addresses
+	ParamTypeUint256Array ParameterType = "uint256[]" // Comma-separated integers
+	ParamTypeStringArray  ParameterType = "string[]"  // Comma-separated strings
+	ParamTypeEther        ParameterType = "ether"     // Amount in ether or with a unit, passed as wei
+	ParamTypeGwei         ParameterType = "gwei"      // Amount in gwei or with a unit, passed as wei
+	ParamTypeDuration     ParameterType = "duration"  // Human duration such as "7d", passed as seconds
+	ParamTypeJSON         ParameterType = "json"      // Path to a JSON file, passed as its compact content
 )
 
+// UintBits returns the size of sized unsigned integer types uint8 to uint248
+func (t ParameterType) UintBits() (int, bool) {
+	bits, err := strconv.Atoi(strings.TrimPrefix(string(t), "uint"))
+	if !strings.HasPrefix(string(t), "uint") || err != nil || bits < 8 || bits > 248 || bits%8 != 0 {
+		return 0, false
+	}
+	return bits, true
+}
+
 // ScriptParameter represents a parameter expected by a script
 type ScriptParameter struct {
 	Name        string
 	Type        ParameterType
 	Description string
 	Optional    bool
+	Schema      string // JSON schema path of json parameters, relative to the project root (optional)
+	Constraints ParameterConstraints
+}
+
+// ParameterConstraints are the checks declared between a parameter name and its description,
+// e.g. "{uint256} FEE min=0 max=10000 Fee in basis points" or "{address} OWNER nonzero contract"
+type ParameterConstraints struct {
+	Min      string   // Lower bound of numeric parameters, in the format of the parameter type
+	Max      string   // Upper bound of numeric parameters, in the format of the parameter type
+	Pattern  string   // Regular expression the value must match
+	Enum     []string // Allowed values, declared as enum=a|b|c
+	NonZero  bool     // Rejects the zero address and zero amounts
+	Contract bool     // Requires code at the address on the target chain
+}
+
+// IsAddress reports whether values of the parameter are addresses
+func (p ScriptParameter) IsAddress() bool {
+	switch p.Type {
+	case ParamTypeAddress, ParamTypeAddressArray, ParamTypeSender, ParamTypeDeployment:
+		return true
+	}
+	return false
 }
 
 // ScriptParameterValue represents a resolved parameter value
@@ -30,3 +80,37 @@ type ScriptParameterValue struct {
 	Type  ParameterType
 	Value string
 }
+
+// ParameterSourceKind identifies where a parameter value came from
+type ParameterSourceKind string
+
+const (
+	ParamSourceFlag     ParameterSourceKind = "flag"     // --env on the command line
+	ParamSourceEnv      ParameterSourceKind = "env"      // TREB_<NAME>, FOUNDRY_<NAME> or <NAME> environment variable
+	ParamSourceFile     ParameterSourceKind = "file"     // params/<namespace>/<script>.toml
+	ParamSourceResolved ParameterSourceKind = "resolved" // Derived from the parameter type (sender, deployment, artifact)
+	ParamSourcePrompt   ParameterSourceKind = "prompt"   // Entered at the interactive prompt
+)
+
+// ParameterSource describes where a resolved parameter value came from
+type ParameterSource struct {
+	Kind      ParameterSourceKind
+	Location  string // Flag, environment variable or file (with the network table) the value was read from
+	Reference string // The @ reference the value was resolved from, if any
+}
+
+// ResolvedParameters holds the resolved parameter values of a script with their sources
+type ResolvedParameters struct {
+	Values     map[string]string
+	Sources    map[string]ParameterSource
+	Unresolved map[string]error // Why each required parameter without a value could not be resolved
+}
+
+// Err returns an error for the required parameters that could not be resolved
+func (r *ResolvedParameters) Err() error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(r.Unresolved)) {
+		errs = append(errs, fmt.Errorf("failed to resolve parameter %s: %w", name, r.Unresolved[name]))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// TimelockOperationState is the on-chain state of a TimelockController operation
+type TimelockOperationState struct {
+	Scheduled bool      // Scheduled and not canceled
+	Ready     bool      // The delay has passed and the operation can be executed
+	Done      bool      // Already executed
+	ReadyAt   time.Time // When the operation becomes executable, zero unless scheduled
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+)
+
+// CheckpointFork handles creating named checkpoints on a fork
+type CheckpointFork struct {
+	forkState    ForkStateStore
+	forkFiles    ForkFileManager
+	anvilManager AnvilManager
+}
+
+// NewCheckpointFork creates a new CheckpointFork use case
+func NewCheckpointFork(
+	forkState ForkStateStore,
+	forkFiles ForkFileManager,
+	anvilManager AnvilManager,
+) *CheckpointFork {
+	return &CheckpointFork{
+		forkState:    forkState,
+		forkFiles:    forkFiles,
+		anvilManager: anvilManager,
+	}
+}
+
+// CheckpointForkParams contains parameters for creating a checkpoint
+type CheckpointForkParams struct {
+	Network string // network name (empty = use current configured network)
+	Name    string // name of the checkpoint
+}
+
+// CheckpointForkResult contains the result of creating a checkpoint
+type CheckpointForkResult struct {
+	Snapshot domain.SnapshotEntry
+	Message  string
+}
+
+// Execute takes an EVM snapshot and a registry backup of the fork and records them
+// under a name that 'treb fork revert --to' can return to
+func (uc *CheckpointFork) Execute(ctx context.Context, params CheckpointForkParams) (*CheckpointForkResult, error) {
+	if params.Network == "" {
+		return nil, fmt.Errorf("no network specified. Use 'treb fork checkpoint <name> --network <network>' or configure a network")
+	}
+	if strings.TrimSpace(params.Name) == "" {
+		return nil, fmt.Errorf("checkpoint name cannot be empty")
+	}
+
+	state, err := uc.forkState.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fork state: %w", err)
+	}
+
+	entry := state.GetActiveFork(params.Network)
+	if entry == nil {
+		return nil, fmt.Errorf("no active fork for network '%s'", params.Network)
+	}
+	if entry.GetCheckpoint(params.Name) >= 0 {
+		return nil, fmt.Errorf("checkpoint '%s' already exists on fork '%s'", params.Name, params.Network)
+	}
+
+	instance := &domain.AnvilInstance{
+		Name:    fmt.Sprintf("fork-%s", entry.Network),
+		Port:    portFromURL(entry.ForkURL),
+		ChainID: fmt.Sprintf("%d", entry.ChainID),
+		PidFile: entry.PidFile,
+		LogFile: entry.LogFile,
+	}
+
+	nextIndex := len(entry.Snapshots)
+	snapshotID, err := uc.anvilManager.TakeSnapshot(ctx, instance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to take EVM snapshot: %w", err)
+	}
+
+	if err := uc.forkFiles.BackupFiles(ctx, entry.Network, nextIndex); err != nil {
+		return nil, fmt.Errorf("failed to backup registry files: %w", err)
+	}
+
+	snapshot := domain.SnapshotEntry{
+		Index:      nextIndex,
+		SnapshotID: snapshotID,
+		Command:    "fork checkpoint",
+		Name:       params.Name,
+		Timestamp:  time.Now(),
+	}
+	entry.Snapshots = append(entry.Snapshots, snapshot)
+
+	if err := uc.forkState.Save(ctx, state); err != nil {
+		return nil, fmt.Errorf("failed to save fork state: %w", err)
+	}
+
+	return &CheckpointForkResult{
+		Snapshot: snapshot,
+		Message:  fmt.Sprintf("Checkpoint '%s' created on fork '%s'", params.Name, entry.Network),
+	}, nil
+}
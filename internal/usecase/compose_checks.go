@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// Actions planned for a step from its expected deployments
+const (
+	StepActionRun     = "run"     // Some expected deployments are missing
+	StepActionSkip    = "skip"    // Every expected deployment exists with code on chain
+	StepActionDrifted = "drifted" // The registry has expected deployments without code on chain, the step runs again
+)
+
+// StepCheck is the outcome of checking the expected deployments of a step before running it
+type StepCheck struct {
+	Action      string
+	Deployments []*models.Deployment // Expected deployments found in the registry
+	Missing     []string             // Expected deployments missing from the registry
+	Drifted     []string             // Expected deployments without code on chain, with the reason
+}
+
+// Action returns the planned action of a step, steps without expected deployments run
+func (s *ExecutionStep) Action() string {
+	if s.Check == nil {
+		return StepActionRun
+	}
+	return s.Check.Action
+}
+
+// checkSteps checks the expected deployments of the steps that declare them against the
+// registry and the chain they run on
+func (o *ComposeDeployment) checkSteps(ctx context.Context, steps []*ExecutionStep, networks map[string]*config.Network) error {
+	connected := ""
+	for _, step := range steps {
+		network := networks[step.Network]
+		if len(step.Expects) == 0 || network == nil {
+			continue
+		}
+
+		if connected != network.Name {
+			if err := o.blockchainChecker.Connect(ctx, networkRPCURL(ctx, o.forkStateStore, network), network.ChainID); err != nil {
+				return fmt.Errorf("failed to connect to %s to check expected deployments: %w", network.Name, err)
+			}
+			connected = network.Name
+		}
+
+		check, err := o.checkStep(ctx, step, network)
+		if err != nil {
+			return fmt.Errorf("failed to check expected deployments of '%s': %w", step.Name, err)
+		}
+		step.Check = check
+	}
+	return nil
+}
+
+// checkStep looks up the expected deployments of a step and checks their code
+func (o *ComposeDeployment) checkStep(ctx context.Context, step *ExecutionStep, network *config.Network) (*StepCheck, error) {
+	check := &StepCheck{}
+	for _, expected := range step.Expects {
+		deployment, err := o.deployments.ResolveDeployment(ctx, domain.DeploymentQuery{
+			Reference: expected,
+			ChainID:   network.ChainID,
+			Namespace: o.config.Namespace,
+		})
+		if errors.Is(err, domain.ErrNotFound) {
+			check.Missing = append(check.Missing, expected)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		exists, reason, err := o.blockchainChecker.CheckDeploymentExists(ctx, deployment.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check code at %s: %w", deployment.Address, err)
+		}
+		if !exists {
+			check.Drifted = append(check.Drifted, fmt.Sprintf("%s at %s: %s", expected, deployment.Address, reason))
+			continue
+		}
+		check.Deployments = append(check.Deployments, deployment)
+	}
+
+	switch {
+	case len(check.Drifted) > 0:
+		check.Action = StepActionDrifted
+	case len(check.Missing) > 0:
+		check.Action = StepActionRun
+	default:
+		check.Action = StepActionSkip
+	}
+	return check, nil
+}
+
+// skipOutputs returns the outputs of a skipped step, taken from its expected deployments
+func skipOutputs(check *StepCheck) map[string]string {
+	changeset := &models.Changeset{}
+	changeset.Create.Deployments = check.Deployments
+	return stepOutputs(&RunScriptResult{Changeset: changeset})
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// stubCodeChecker reports code at the addresses of a set
+type stubCodeChecker struct {
+	BlockchainChecker
+	code      map[string]bool
+	connected string
+}
+
+func (s *stubCodeChecker) Connect(_ context.Context, rpcURL string, _ uint64) error {
+	s.connected = rpcURL
+	return nil
+}
+
+func (s *stubCodeChecker) CheckDeploymentExists(_ context.Context, address string) (bool, string, error) {
+	if s.code[address] {
+		return true, "", nil
+	}
+	return false, "no code at address", nil
+}
+
+func TestComposeDeployment_ExpectedDeployments(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("protocol.yaml", []byte(`group: Protocol
+components:
+  tokens:
+    script: DeployTokens
+    expects: [Token:v1, Vault:v1]
+  oracle:
+    script: DeployOracle
+    expects: [Oracle]
+  reserve:
+    script: DeployReserve
+    expects: [Reserve]
+    deps: [tokens, oracle]
+    env:
+      TOKEN: ${{ components.tokens.deployments.Token.address }}
+  broker:
+    script: DeployBroker
+    deps: [reserve]
+`), 0644))
+
+	registry := map[string]*models.Deployment{
+		"Token:v1": {ID: "default/31337/Token:v1", ContractName: "Token", Label: "v1", Address: "0x01"},
+		"Vault:v1": {ID: "default/31337/Vault:v1", ContractName: "Vault", Label: "v1", Address: "0x02"},
+		"Oracle":   {ID: "default/31337/Oracle", ContractName: "Oracle", Address: "0x03"},
+	}
+	deployments := &mockDeploymentResolver{resolveFunc: func(_ context.Context, query domain.DeploymentQuery) (*models.Deployment, error) {
+		assert.Equal(t, uint64(31337), query.ChainID)
+		assert.Equal(t, "default", query.Namespace)
+		if deployment, ok := registry[query.Reference]; ok {
+			return deployment, nil
+		}
+		return nil, domain.ErrNotFound
+	}}
+	checker := &stubCodeChecker{code: map[string]bool{"0x01": true, "0x02": true}}
+	runner := &fakeScriptRunner{broadcastDirs: map[string]string{}, parameters: map[string]map[string]string{}}
+	compose := &ComposeDeployment{
+		config:            &config.RuntimeConfig{Namespace: "default", Network: &config.Network{Name: "anvil", ChainID: 31337, RPCURL: "http://localhost:8545"}},
+		deployments:       deployments,
+		blockchainChecker: checker,
+		forkStateStore:    &mockForkState{},
+		runScript:         runner,
+		progress:          nopComposeSink{},
+	}
+
+	result, err := compose.Execute(context.Background(), ComposeParams{ConfigPath: "protocol.yaml"})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "http://localhost:8545", checker.connected)
+
+	actions := make(map[string]string)
+	for _, step := range result.Plan.Components {
+		actions[step.Name] = step.Action()
+	}
+	assert.Equal(t, map[string]string{
+		"tokens":  StepActionSkip,
+		"oracle":  StepActionDrifted,
+		"reserve": StepActionRun,
+		"broker":  StepActionRun,
+	}, actions)
+	assert.Equal(t, []string{"Oracle at 0x03: no code at address"}, result.Plan.Components[0].Check.Drifted)
+
+	// Skipped steps do not run but still expose their deployments to later steps
+	assert.Equal(t, []string{"oracle", "reserve", "broker"}, runner.ran)
+	require.Len(t, result.SkippedSteps, 1)
+	assert.Equal(t, "tokens", result.SkippedSteps[0].Step.Name)
+	assert.Equal(t, "0x01", runner.parameters["reserve"]["TOKEN"])
+
+	state, err := compose.loadState("protocol.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, StepStatusSkipped, state.ExecutedSteps["tokens"].Status)
+	assert.Equal(t, "0x02", state.ExecutedSteps["tokens"].Outputs["deployments.Vault:v1.address"])
+}
@@ -1,31 +1,62 @@
 package usecase
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
-	"gopkg.in/yaml.v3"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
 )
 
 // ComposeDeployment handles orchestrated deployments from YAML configuration
 type ComposeDeployment struct {
-	runScript *RunScript
-	progress  ComposeSink
+	config            *config.RuntimeConfig
+	networks          NetworkResolver
+	deployments       DeploymentResolver
+	blockchainChecker BlockchainChecker
+	forkStateStore    ForkStateStore
+	runScript         scriptRunner
+	hooks             *ComposeHooks
+	progress          ComposeSink
+}
+
+// scriptRunner runs the script of a compose step, implemented by RunScript
+type scriptRunner interface {
+	Run(ctx context.Context, params RunScriptParams) (*RunScriptResult, error)
 }
 
 // NewComposeDeployment creates a new orchestrate deployment use case
 func NewComposeDeployment(
+	cfg *config.RuntimeConfig,
+	networks NetworkResolver,
+	deployments DeploymentResolver,
+	blockchainChecker BlockchainChecker,
+	forkStateStore ForkStateStore,
 	runScript *RunScript,
+	hooks *ComposeHooks,
 	progress ComposeSink,
 ) *ComposeDeployment {
 	return &ComposeDeployment{
-		runScript: runScript,
-		progress:  progress,
+		config:            cfg,
+		networks:          networks,
+		deployments:       deployments,
+		blockchainChecker: blockchainChecker,
+		forkStateStore:    forkStateStore,
+		runScript:         runScript,
+		hooks:             hooks,
+		progress:          progress,
 	}
 }
 
@@ -40,14 +71,21 @@ type ComposeParams struct {
 	DebugJSON      bool
 	Verbose        bool
 	NonInteractive bool
-	Resume         bool // Resume from previous execution
+	Resume         bool              // Resume from previous execution
+	Yes            bool              // Approve confirmation gates without prompting
+	Parallel       int               // Independent steps executed at the same time, one at a time when unset
+	Vars           map[string]string // Values of the variables declared by the compose files
 }
 
 // ComposeResult contains the result of orchestration
 type ComposeResult struct {
 	Plan             *ExecutionPlan
 	ExecutedSteps    []*StepResult
-	FailedStep       *StepResult
+	FailedStep       *StepResult   // First failed step in plan order
+	FailedSteps      []*StepResult // Every step that failed, several when steps ran in parallel
+	SkippedSteps     []*StepResult // Steps whose expected deployments already exist
+	Hooks            []*HookResult // Hooks of the compose run
+	HookError        error         // Failure of a pre or post hook of the compose run
 	Success          bool
 	TotalDeployments int
 }
@@ -57,17 +95,38 @@ type StepResult struct {
 	Step      *ExecutionStep
 	RunResult *RunScriptResult
 	Error     error
+	Skipped   bool // The expected deployments of the step already exist, nothing ran
+	Hooks     []*HookResult
 }
 
+// Compose and step statuses recorded in the compose state
+const (
+	ComposeStatusRunning   = "running"
+	ComposeStatusFailed    = "failed"
+	ComposeStatusCompleted = "completed"
+
+	StepStatusRunning   = "running"
+	StepStatusFailed    = "failed"
+	StepStatusCompleted = "completed"
+	StepStatusSkipped   = "skipped"
+	// The script completed but a post hook failed, resuming only runs the post hooks again
+	StepStatusPostHookFailed = "post_hook_failed"
+)
+
 // StepStateInfo is a lightweight version of StepResult for state storage
 type StepStateInfo struct {
 	Step        *ExecutionStep `json:"step"`
+	Status      string         `json:"status,omitempty"` // "running", "failed", "completed", "skipped"; a running step was interrupted
 	Success     bool           `json:"success"`
 	Error       string         `json:"error,omitempty"`
 	Deployments int            `json:"deployments,omitempty"`
+	// Values exposed to the ${{ components.<name>.<output> }} templates of later steps
+	Outputs map[string]string `json:"outputs,omitempty"`
+	Hooks   []*HookResult     `json:"hooks,omitempty"`
 }
 
-// ComposeState represents the state of a compose execution
+// ComposeState represents the state of a compose execution. Steps are tracked individually,
+// resuming runs every step that did not complete.
 type ComposeState struct {
 	StartedAt        time.Time                 `json:"started_at"`
 	UpdatedAt        time.Time                 `json:"updated_at"`
@@ -76,16 +135,15 @@ type ComposeState struct {
 	Namespace        string                    `json:"namespace"`
 	Plan             *ExecutionPlan            `json:"plan"`
 	ExecutedSteps    map[string]*StepStateInfo `json:"executed_steps"`
-	CurrentStepIndex int                       `json:"current_step_index"`
 	Status           string                    `json:"status"` // "running", "failed", "completed"
 	TotalDeployments int                       `json:"total_deployments"`
+	Hooks            []*HookResult             `json:"hooks,omitempty"` // Hooks of the compose run, of every attempt
 }
 
 // Execute runs the orchestration
 func (o *ComposeDeployment) Execute(ctx context.Context, params ComposeParams) (*ComposeResult, error) {
 	var state *ComposeState
 	var plan *ExecutionPlan
-	startIndex := 0
 
 	// Handle resume mode
 	if params.Resume {
@@ -99,54 +157,39 @@ func (o *ComposeDeployment) Execute(ctx context.Context, params ComposeParams) (
 		if prevState.ConfigPath != params.ConfigPath {
 			return nil, fmt.Errorf("cannot resume: config path changed (was %s, now %s)", prevState.ConfigPath, params.ConfigPath)
 		}
+		if prevState.Namespace != params.Namespace {
+			return nil, fmt.Errorf("cannot resume: namespace changed (was %s, now %s)", prevState.Namespace, params.Namespace)
+		}
+		if prevState.Network != params.Network {
+			return nil, fmt.Errorf("cannot resume: network changed (was %s, now %s)", prevState.Network, params.Network)
+		}
 
-		if prevState.Status == "completed" {
+		if prevState.Status == ComposeStatusCompleted {
 			return nil, fmt.Errorf("previous run already completed successfully")
 		}
 
-		// Use the existing plan and start from where we left off
+		// Use the existing plan, steps that completed are not run again
 		plan = prevState.Plan
 		state = prevState
-		startIndex = prevState.CurrentStepIndex
-
-		// Emit resume event
-		o.progress.OnProgress(ctx, ProgressEvent{
-			Stage: "compose_resumed",
-			Metadata: map[string]interface{}{
-				"from_step": startIndex,
-				"total":     len(plan.Components),
-			},
-		})
-	} else {
-		// Parse orchestration file
-		config, err := o.parseComposeFile(params.ConfigPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse orchestration file: %w", err)
-		}
-
-		// Validate configuration
-		if err := config.Validate(); err != nil {
-			return nil, fmt.Errorf("invalid orchestration configuration: %w", err)
+		if state.ExecutedSteps == nil {
+			state.ExecutedSteps = make(map[string]*StepStateInfo)
 		}
-
-		// Create execution plan
-		plan, err = o.createExecutionPlan(config)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create execution plan: %w", err)
+	} else {
+		var err error
+		if plan, err = o.loadPlan(params.ConfigPath, params.Vars); err != nil {
+			return nil, err
 		}
 
 		// Initialize new state
 		state = &ComposeState{
-			StartedAt:        time.Now(),
-			UpdatedAt:        time.Now(),
-			ConfigPath:       params.ConfigPath,
-			Network:          params.Network,
-			Namespace:        params.Namespace,
-			Plan:             plan,
-			ExecutedSteps:    make(map[string]*StepStateInfo),
-			CurrentStepIndex: 0,
-			Status:           "running",
-			TotalDeployments: 0,
+			StartedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+			ConfigPath:    params.ConfigPath,
+			Network:       params.Network,
+			Namespace:     params.Namespace,
+			Plan:          plan,
+			ExecutedSteps: make(map[string]*StepStateInfo),
+			Status:        ComposeStatusRunning,
 		}
 
 		// Save initial state
@@ -156,130 +199,186 @@ func (o *ComposeDeployment) Execute(ctx context.Context, params ComposeParams) (
 		}
 	}
 
-	// Emit plan created event so it can be rendered
-	o.progress.OnProgress(ctx, ProgressEvent{
-		Stage:    "plan_created",
-		Metadata: plan,
-	})
+	networks, err := o.resolveNetworks(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	// Steps switch the network of the runtime configuration, restore it once done
+	if o.config != nil {
+		defer func(network *config.Network) { o.config.Network = network }(o.config.Network)
+	}
 
 	// Build result from state
 	result := &ComposeResult{
-		Plan:             plan,
-		ExecutedSteps:    make([]*StepResult, 0),
-		Success:          true,
-		TotalDeployments: state.TotalDeployments,
-	}
-
-	// Add previously executed steps to result - we only have StepStateInfo, not full StepResult
-	// This is fine since we're resuming and these steps were already completed
-	for i := 0; i < startIndex; i++ {
-		if stateInfo, exists := state.ExecutedSteps[plan.Components[i].Name]; exists {
-			// Create a minimal StepResult for display purposes
-			stepResult := &StepResult{
-				Step: stateInfo.Step,
-				RunResult: &RunScriptResult{
-					Success: stateInfo.Success,
-				},
-				Error: nil,
-			}
-			if stateInfo.Error != "" {
-				stepResult.Error = fmt.Errorf("%s", stateInfo.Error)
-			}
-			result.ExecutedSteps = append(result.ExecutedSteps, stepResult)
+		Plan:          plan,
+		ExecutedSteps: make([]*StepResult, 0),
+		Success:       true,
+	}
 
-			// Add the deployment count from the saved state
-			if stateInfo.Deployments > 0 {
-				// We can't recreate the full changeset, but we can track the count
-				result.TotalDeployments += stateInfo.Deployments
-			}
+	// Add previously completed steps to result - we only have StepStateInfo, not full StepResult.
+	// Steps that failed or were interrupted are executed again.
+	completed := make(map[string]bool)
+	state.TotalDeployments = 0
+	for _, step := range plan.Components {
+		stateInfo, exists := state.ExecutedSteps[step.Name]
+		if !exists || !stateInfo.Success {
+			continue
 		}
+		completed[step.Name] = true
+		if stateInfo.Status == StepStatusSkipped {
+			result.SkippedSteps = append(result.SkippedSteps, &StepResult{Step: stateInfo.Step, Skipped: true})
+			continue
+		}
+		result.ExecutedSteps = append(result.ExecutedSteps, &StepResult{
+			Step:      stateInfo.Step,
+			RunResult: &RunScriptResult{Success: true},
+		})
+		// We can't recreate the full changeset, but we can track the count
+		state.TotalDeployments += stateInfo.Deployments
 	}
+	result.TotalDeployments = state.TotalDeployments
 
-	// Execute remaining steps
-	for i := startIndex; i < len(plan.Components); i++ {
-		step := plan.Components[i]
-
-		// Update current step in state
-		state.CurrentStepIndex = i
-		state.Status = "running"
-		if err := o.saveState(state); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to save state: %v\n", err)
+	// Steps whose expected deployments already exist are skipped, the plan shows why
+	var remaining []*ExecutionStep
+	for _, step := range plan.Components {
+		if !completed[step.Name] {
+			remaining = append(remaining, step)
 		}
+	}
+	if err := o.checkSteps(ctx, remaining, networks); err != nil {
+		return nil, err
+	}
 
-		// Emit step starting event
+	if params.Resume {
+		// Emit resume event
 		o.progress.OnProgress(ctx, ProgressEvent{
-			Stage: "step_starting",
-			Metadata: map[string]any{
-				"name":    step.Name,
-				"script":  step.Script,
-				"current": i + 1,
-				"total":   len(plan.Components),
+			Stage: "compose_resumed",
+			Metadata: map[string]interface{}{
+				"from_step": len(completed),
+				"total":     len(plan.Components),
 			},
 		})
+	}
 
-		// Execute the step
-		stepResult := o.executeStep(ctx, step, params)
-		result.ExecutedSteps = append(result.ExecutedSteps, stepResult)
+	// Emit plan created event so it can be rendered
+	o.progress.OnProgress(ctx, ProgressEvent{
+		Stage:    "plan_created",
+		Metadata: plan,
+	})
 
-		// Convert to lightweight state info for storage
-		stateInfo := &StepStateInfo{
-			Step:    step,
-			Success: stepResult.Error == nil && (stepResult.RunResult == nil || stepResult.RunResult.Success),
-			Error:   "",
+	rpcURLs := make(map[uint64]string, len(networks))
+	for _, network := range networks {
+		if network != nil {
+			rpcURLs[network.ChainID] = networkRPCURL(ctx, o.forkStateStore, network)
 		}
-		if stepResult.Error != nil {
-			stateInfo.Error = stepResult.Error.Error()
+	}
+	groupHooks := func(hook string) *hookContext {
+		hc := &hookContext{
+			hook:      hook,
+			group:     plan.Group,
+			network:   networks[o.defaultNetwork()],
+			rpcURLs:   rpcURLs,
+			namespace: o.namespace(),
+			dryRun:    params.DryRun,
+			status:    state.Status,
 		}
-		if stepResult.RunResult != nil && stepResult.RunResult.Changeset != nil {
-			stateInfo.Deployments = len(stepResult.RunResult.Changeset.Create.Deployments)
+		switch {
+		case result.HookError != nil:
+			hc.err = result.HookError.Error()
+		case len(result.FailedSteps) > 0:
+			hc.err = result.FailedSteps[0].Error.Error()
 		}
-		state.ExecutedSteps[step.Name] = stateInfo
-
-		// Emit step completed event
-		o.progress.OnProgress(ctx, ProgressEvent{
-			Stage:    "step_completed",
-			Metadata: stepResult,
-		})
+		if hook != HookPre {
+			var outputs []map[string]string
+			for _, step := range plan.Components {
+				if info := state.ExecutedSteps[step.Name]; info != nil {
+					outputs = append(outputs, info.Outputs)
+				}
+			}
+			hc.deployments = o.hooks.outputDeployments(ctx, outputs...)
+		}
+		return hc
+	}
+	runGroupHooks := func(hook string) error {
+		results, err := o.hooks.run(ctx, plan.Hooks.Of(hook), groupHooks(hook))
+		result.Hooks = append(result.Hooks, results...)
+		state.Hooks = append(state.Hooks, results...)
+		return err
+	}
 
-		// Check for failure - either error or unsuccessful result
-		if stepResult.Error != nil || (stepResult.RunResult != nil && !stepResult.RunResult.Success) {
-			result.FailedStep = stepResult
-			result.Success = false
-			state.Status = "failed"
+	// Execute the remaining steps level by level: the steps of a level only depend on
+	// earlier levels, so they can run at the same time
+	run := &composeRun{
+		compose:  o,
+		params:   params,
+		plan:     plan,
+		state:    state,
+		networks: networks,
+		rpcURLs:  rpcURLs,
+		started:  len(completed),
+		parallel: max(params.Parallel, 1),
+	}
+	levels := plan.Levels()
+	if err := runGroupHooks(HookPre); err != nil {
+		// Nothing runs when a pre hook of the compose run fails
+		result.HookError, result.Success = err, false
+		levels = nil
+	}
+	for _, level := range levels {
+		var pending []*ExecutionStep
+		for _, step := range level {
+			if !completed[step.Name] {
+				pending = append(pending, step)
+			}
+		}
 
-			// If there's no error but success is false, create an error
-			if stepResult.Error == nil {
-				stepResult.Error = fmt.Errorf("step '%s' failed", step.Name)
+		for _, stepResult := range run.executeLevel(ctx, pending) {
+			if stepResult.Skipped {
+				result.SkippedSteps = append(result.SkippedSteps, stepResult)
+				continue
 			}
+			result.ExecutedSteps = append(result.ExecutedSteps, stepResult)
 
-			// Save failed state
-			if err := o.saveState(state); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to save failed state: %v\n", err)
+			// Check for failure - either error or unsuccessful result
+			if stepResult.Error != nil || (stepResult.RunResult != nil && !stepResult.RunResult.Success) {
+				// If there's no error but success is false, create an error
+				if stepResult.Error == nil {
+					stepResult.Error = fmt.Errorf("step '%s' failed", stepResult.Step.Name)
+				}
+				result.FailedSteps = append(result.FailedSteps, stepResult)
+				result.Success = false
+				continue
 			}
 
-			break
+			// Count deployments
+			if stepResult.RunResult != nil && stepResult.RunResult.Changeset != nil {
+				result.TotalDeployments += len(stepResult.RunResult.Changeset.Create.Deployments)
+			}
 		}
 
-		// Count deployments
-		if stepResult.RunResult != nil && stepResult.RunResult.Changeset != nil {
-			deploymentCount := len(stepResult.RunResult.Changeset.Create.Deployments)
-			result.TotalDeployments += deploymentCount
-			state.TotalDeployments += deploymentCount
+		// Later levels depend on this one, so nothing more runs once a step failed
+		if !result.Success {
+			break
 		}
+	}
 
-		// Save successful step state
-		if err := o.saveState(state); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to save state after step: %v\n", err)
+	if result.Success {
+		state.Status = ComposeStatusCompleted
+		if err := runGroupHooks(HookPost); err != nil {
+			result.HookError, result.Success = err, false
 		}
 	}
 
 	// Update final state
-	if result.Success {
-		state.Status = "completed"
-		state.CurrentStepIndex = len(plan.Components)
-		if err := o.saveState(state); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to save final state: %v\n", err)
+	if !result.Success {
+		if len(result.FailedSteps) > 0 {
+			result.FailedStep = result.FailedSteps[0]
 		}
+		state.Status = ComposeStatusFailed
+		_ = runGroupHooks(HookOnFailure)
+	}
+	if err := o.saveState(state); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save final state: %v\n", err)
 	}
 
 	// Emit compose completed event
@@ -290,6 +389,285 @@ func (o *ComposeDeployment) Execute(ctx context.Context, params ComposeParams) (
 	return result, nil
 }
 
+// composeRun executes the steps of a plan, keeping the compose state of steps that
+// run in parallel consistent
+type composeRun struct {
+	compose  *ComposeDeployment
+	params   ComposeParams
+	plan     *ExecutionPlan
+	state    *ComposeState
+	networks map[string]*config.Network
+	rpcURLs  map[uint64]string // RPC URL of each chain of the plan, for hooks
+	parallel int
+
+	mu      sync.Mutex // Guards state, started and progress events
+	started int
+}
+
+// executeLevel executes the steps of one DAG level, at most parallel at a time, and
+// returns their results in plan order. The runtime configuration holds a single network,
+// so the steps of a level run network by network.
+func (r *composeRun) executeLevel(ctx context.Context, steps []*ExecutionStep) []*StepResult {
+	results := make([]*StepResult, len(steps))
+	for _, network := range stepNetworks(steps) {
+		if r.compose.config != nil && r.networks[network] != nil {
+			r.compose.config.Network = r.networks[network]
+		}
+
+		slots := make(chan struct{}, r.parallel)
+		var wg sync.WaitGroup
+		for i, step := range steps {
+			if step.Network != network {
+				continue
+			}
+			wg.Add(1)
+			slots <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-slots }()
+				results[i] = r.executeStep(ctx, step)
+			}()
+		}
+		wg.Wait()
+	}
+	return results
+}
+
+// executeStep executes a single step, recording its state before and after
+func (r *composeRun) executeStep(ctx context.Context, step *ExecutionStep) *StepResult {
+	r.mu.Lock()
+	previous := r.state.ExecutedSteps[step.Name]
+	r.mu.Unlock()
+	if previous != nil && previous.Status == StepStatusPostHookFailed {
+		return r.resumePostHooks(ctx, step, previous)
+	}
+	if step.Check != nil && step.Check.Action == StepActionSkip {
+		return r.skipStep(ctx, step)
+	}
+
+	r.mu.Lock()
+	// Resolve the templates of the env from the outputs of the completed steps, which
+	// are persisted so a resumed run resolves the same values
+	env, err := r.templates(step).resolveEnv(step.Env)
+
+	r.started++
+	r.state.ExecutedSteps[step.Name] = &StepStateInfo{Step: step, Status: StepStatusRunning}
+	r.saveState("Warning: failed to save state: %v\n")
+
+	// Emit step starting event
+	r.compose.progress.OnProgress(ctx, ProgressEvent{
+		Stage: "step_starting",
+		Metadata: map[string]any{
+			"name":    step.Name,
+			"script":  step.Script,
+			"network": step.Network,
+			"current": r.started,
+			"total":   len(r.plan.Components),
+		},
+	})
+	r.mu.Unlock()
+
+	// Parallel steps may run the same script, so each gets its own broadcast files
+	broadcastDir := ""
+	if r.parallel > 1 {
+		broadcastDir = filepath.Join("broadcast", "compose", r.compose.runName(r.params.ConfigPath), step.Name)
+	}
+
+	// Execute the step between its pre and post hooks
+	var hooks []*HookResult
+	if err == nil {
+		hooks, err = r.compose.hooks.run(ctx, step.Hooks.Of(HookPre), r.hookContext(HookPre, step, nil))
+	}
+	var stepResult *StepResult
+	if err != nil {
+		stepResult = &StepResult{Step: step, Error: err}
+	} else {
+		stepResult = r.compose.executeStep(ctx, r.plan.Group, step, env, r.params, broadcastDir)
+	}
+
+	postHookFailed := false
+	if stepResult.Error == nil && stepResult.RunResult != nil && stepResult.RunResult.Success {
+		results, err := r.compose.hooks.run(ctx, step.Hooks.Of(HookPost), r.hookContext(HookPost, step, stepResult))
+		hooks = append(hooks, results...)
+		if err != nil {
+			stepResult.Error = err
+			postHookFailed = true
+		}
+	}
+	if stepResult.Error != nil || (stepResult.RunResult != nil && !stepResult.RunResult.Success) {
+		results, _ := r.compose.hooks.run(ctx, step.Hooks.Of(HookOnFailure), r.hookContext(HookOnFailure, step, stepResult))
+		hooks = append(hooks, results...)
+	}
+	stepResult.Hooks = hooks
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Convert to lightweight state info for storage
+	stateInfo := &StepStateInfo{
+		Step:    step,
+		Status:  StepStatusCompleted,
+		Success: stepResult.Error == nil && (stepResult.RunResult == nil || stepResult.RunResult.Success),
+	}
+	if !stateInfo.Success {
+		stateInfo.Status = StepStatusFailed
+	}
+	if stepResult.Error != nil {
+		stateInfo.Error = stepResult.Error.Error()
+	}
+	if stepResult.RunResult != nil && stepResult.RunResult.Changeset != nil {
+		stateInfo.Deployments = len(stepResult.RunResult.Changeset.Create.Deployments)
+	}
+	// The script of a step whose post hook failed completed, its outputs are kept so
+	// resuming only runs the post hooks
+	if stateInfo.Success || postHookFailed {
+		stateInfo.Outputs = stepOutputs(stepResult.RunResult)
+	}
+	if postHookFailed {
+		stateInfo.Status = StepStatusPostHookFailed
+	}
+	stateInfo.Hooks = stepResult.Hooks
+	r.state.ExecutedSteps[step.Name] = stateInfo
+	if stateInfo.Success {
+		r.state.TotalDeployments += stateInfo.Deployments
+	} else {
+		r.state.Status = ComposeStatusFailed
+	}
+	r.saveState("Warning: failed to save state after step: %v\n")
+
+	// Emit step completed event
+	r.compose.progress.OnProgress(ctx, ProgressEvent{
+		Stage:    "step_completed",
+		Metadata: stepResult,
+	})
+
+	return stepResult
+}
+
+// resumePostHooks runs the post hooks of a step whose script completed in an earlier run
+func (r *composeRun) resumePostHooks(ctx context.Context, step *ExecutionStep, previous *StepStateInfo) *StepResult {
+	r.mu.Lock()
+	r.started++
+	r.compose.progress.OnProgress(ctx, ProgressEvent{
+		Stage: "step_starting",
+		Metadata: map[string]any{
+			"name":    step.Name,
+			"script":  step.Script,
+			"network": step.Network,
+			"current": r.started,
+			"total":   len(r.plan.Components),
+		},
+	})
+	r.mu.Unlock()
+
+	changeset := &models.Changeset{}
+	changeset.Create.Deployments = r.compose.hooks.outputDeployments(ctx, previous.Outputs)
+	stepResult := &StepResult{Step: step, RunResult: &RunScriptResult{Success: true, Changeset: changeset}}
+
+	hooks, err := r.compose.hooks.run(ctx, step.Hooks.Of(HookPost), r.hookContext(HookPost, step, stepResult))
+	if err != nil {
+		stepResult.Error = err
+		results, _ := r.compose.hooks.run(ctx, step.Hooks.Of(HookOnFailure), r.hookContext(HookOnFailure, step, stepResult))
+		hooks = append(hooks, results...)
+	}
+	stepResult.Hooks = hooks
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stateInfo := *previous
+	stateInfo.Hooks = append(slices.Clone(previous.Hooks), hooks...)
+	if err != nil {
+		stateInfo.Error = err.Error()
+		r.state.Status = ComposeStatusFailed
+	} else {
+		stateInfo.Status, stateInfo.Success, stateInfo.Error = StepStatusCompleted, true, ""
+		r.state.TotalDeployments += stateInfo.Deployments
+	}
+	r.state.ExecutedSteps[step.Name] = &stateInfo
+	r.saveState("Warning: failed to save state after step: %v\n")
+
+	r.compose.progress.OnProgress(ctx, ProgressEvent{
+		Stage:    "step_completed",
+		Metadata: stepResult,
+	})
+	return stepResult
+}
+
+// hookContext returns the context of the hooks of a step, with the result of its script
+// for post and on_failure hooks
+func (r *composeRun) hookContext(hook string, step *ExecutionStep, stepResult *StepResult) *hookContext {
+	hc := &hookContext{
+		hook:      hook,
+		group:     r.plan.Group,
+		component: step.Name,
+		network:   r.networks[step.Network],
+		rpcURLs:   r.rpcURLs,
+		namespace: r.compose.namespace(),
+		dryRun:    r.params.DryRun,
+		status:    StepStatusRunning,
+	}
+	if stepResult == nil {
+		return hc
+	}
+
+	hc.status = StepStatusCompleted
+	if stepResult.Error != nil || (stepResult.RunResult != nil && !stepResult.RunResult.Success) {
+		hc.status = StepStatusFailed
+	}
+	if stepResult.Error != nil {
+		hc.err = stepResult.Error.Error()
+	}
+	if stepResult.RunResult != nil {
+		hc.outputs = stepOutputs(stepResult.RunResult)
+		hc.deployments = runDeployments(stepResult.RunResult)
+	}
+	return hc
+}
+
+// skipStep records a step whose expected deployments exist, exposing them as its outputs
+func (r *composeRun) skipStep(ctx context.Context, step *ExecutionStep) *StepResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.started++
+	r.state.ExecutedSteps[step.Name] = &StepStateInfo{
+		Step:    step,
+		Status:  StepStatusSkipped,
+		Success: true,
+		Outputs: skipOutputs(step.Check),
+	}
+	r.saveState("Warning: failed to save state after step: %v\n")
+
+	stepResult := &StepResult{Step: step, Skipped: true}
+	r.compose.progress.OnProgress(ctx, ProgressEvent{
+		Stage: "step_skipped",
+		Metadata: map[string]any{
+			"name":    step.Name,
+			"current": r.started,
+			"total":   len(r.plan.Components),
+			"result":  stepResult,
+		},
+	})
+	return stepResult
+}
+
+// templates returns the context resolving the env templates of a step
+func (r *composeRun) templates(step *ExecutionStep) *composeTemplateContext {
+	templates := &composeTemplateContext{steps: r.state.ExecutedSteps, network: r.networks[step.Network]}
+	if cfg := r.compose.config; cfg != nil {
+		templates.namespace = cfg.Namespace
+	}
+	return templates
+}
+
+// saveState saves the compose state, warning when it cannot be written
+func (r *composeRun) saveState(warning string) {
+	if err := r.compose.saveState(r.state); err != nil {
+		fmt.Fprintf(os.Stderr, warning, err)
+	}
+}
+
 // getStateFilePath returns the path to the compose state file
 func (o *ComposeDeployment) getStateFilePath(configPath string) (string, error) {
 	// Get the working directory
@@ -304,16 +682,84 @@ func (o *ComposeDeployment) getStateFilePath(configPath string) (string, error)
 		return "", err
 	}
 
-	// Get the base name of the config file without extension
+	// Create state file name like "compose-<config-name>-<namespace>-<network>.json"
+	stateFileName := fmt.Sprintf("compose-%s.json", o.runName(configPath))
+	return filepath.Join(stateDir, stateFileName), nil
+}
+
+// namespace returns the namespace of the compose run, which selects the overlays of compose files
+func (o *ComposeDeployment) namespace() string {
+	if o.config != nil {
+		return o.config.Namespace
+	}
+	return ""
+}
+
+// defaultNetwork returns the network of steps that do not set one
+func (o *ComposeDeployment) defaultNetwork() string {
+	if o.config != nil && o.config.Network != nil {
+		return o.config.Network.Name
+	}
+	return ""
+}
+
+// resolveNetworks resolves the networks the steps of the plan run on
+func (o *ComposeDeployment) resolveNetworks(ctx context.Context, plan *ExecutionPlan) (map[string]*config.Network, error) {
+	networks := make(map[string]*config.Network)
+	for _, name := range plan.Networks() {
+		if name == o.defaultNetwork() {
+			networks[name] = o.config.Network
+			continue
+		}
+		network, err := o.networks.ResolveNetwork(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve network %s: %w", name, err)
+		}
+		networks[name] = network
+	}
+	return networks, nil
+}
+
+// configName returns the base name of a compose file without its extension
+func configName(configPath string) string {
 	configBase := filepath.Base(configPath)
-	configName := configBase
-	if ext := filepath.Ext(configBase); ext != "" {
-		configName = configBase[:len(configBase)-len(ext)]
+	return strings.TrimSuffix(configBase, filepath.Ext(configBase))
+}
+
+// runName names the state file and broadcast directories of a compose run. Runs of a compose
+// file in another namespace or on another network are separate runs, and compose files of the
+// same name in different directories are told apart by a hash of their directory.
+func (o *ComposeDeployment) runName(configPath string) string {
+	parts := []string{configName(configPath)}
+	if dir := composeDir(configPath); dir != "" {
+		sum := sha256.Sum256([]byte(dir))
+		parts = append(parts, hex.EncodeToString(sum[:4]))
+	}
+	if namespace := o.namespace(); namespace != "" {
+		parts = append(parts, namespace)
+	}
+	if network := o.defaultNetwork(); network != "" {
+		parts = append(parts, network)
 	}
+	return strings.Join(parts, "-")
+}
 
-	// Create state file name like "compose-<config-name>.json"
-	stateFileName := fmt.Sprintf("compose-%s.json", configName)
-	return filepath.Join(stateDir, stateFileName), nil
+// composeDir returns the directory of a compose file relative to the working directory, or
+// empty when it is in the working directory
+func composeDir(configPath string) string {
+	dir, err := filepath.Abs(filepath.Dir(configPath))
+	if err != nil {
+		return filepath.ToSlash(filepath.Dir(configPath))
+	}
+	if workDir, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(workDir, dir); err == nil {
+			dir = rel
+		}
+	}
+	if dir == "." {
+		return ""
+	}
+	return filepath.ToSlash(dir)
 }
 
 // saveState saves the current compose state to disk
@@ -360,46 +806,109 @@ func (o *ComposeDeployment) loadState(configPath string) (*ComposeState, error)
 	return &state, nil
 }
 
-// parseComposeFile parses the YAML orchestration file
-func (o *ComposeDeployment) parseComposeFile(path string) (*ComposeConfig, error) {
-	data, err := os.ReadFile(path)
+// loadPlan parses and validates a compose file and creates its execution plan
+func (o *ComposeDeployment) loadPlan(configPath string, vars map[string]string) (*ExecutionPlan, error) {
+	// Parse orchestration file with its overlay and includes
+	config, err := loadComposeFile(configPath, o.namespace(), vars)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, fmt.Errorf("failed to parse orchestration file: %w", err)
 	}
 
-	var config ComposeConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	// Validate configuration
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid orchestration configuration: %w", err)
 	}
 
-	return &config, nil
+	// Create execution plan
+	plan, err := o.createExecutionPlan(config, o.defaultNetwork())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create execution plan: %w", err)
+	}
+	if err := validateTemplates(plan); err != nil {
+		return nil, fmt.Errorf("invalid orchestration configuration: %w", err)
+	}
+	return plan, nil
 }
 
-// createExecutionPlan creates a linearized execution plan from the configuration
-func (o *ComposeDeployment) createExecutionPlan(config *ComposeConfig) (*ExecutionPlan, error) {
+// createExecutionPlan creates a linearized execution plan from the configuration.
+// Components with several networks are expanded into one step per network, named
+// <component>@<network>.
+func (o *ComposeDeployment) createExecutionPlan(config *ComposeConfig, defaultNetwork string) (*ExecutionPlan, error) {
 	graph := NewDependencyGraph(config)
-	steps, err := graph.TopologicalSort()
+	components, err := graph.TopologicalSort()
 	if err != nil {
 		return nil, err
 	}
 
+	expanded := make(map[string][]*ExecutionStep, len(components))
+	var steps []*ExecutionStep
+	for _, component := range components {
+		matrix := config.Components[component.Name].Networks
+		networks := matrix
+		if len(networks) == 0 {
+			networks = []string{cmp.Or(component.Network, defaultNetwork)}
+		}
+
+		for _, network := range networks {
+			step := &ExecutionStep{
+				Name:    component.Name,
+				Script:  component.Script,
+				Network: network,
+				Env:     component.Env,
+				Expects: component.Expects,
+				Hooks:   config.Components[component.Name].Hooks,
+				source:  config.Components[component.Name].source("env"),
+			}
+			if len(matrix) > 0 {
+				step.Name = component.Name + "@" + network
+			}
+			for _, dep := range component.Dependencies {
+				step.Dependencies = append(step.Dependencies, dependencySteps(expanded[dep], network)...)
+			}
+			expanded[component.Name] = append(expanded[component.Name], step)
+			steps = append(steps, step)
+		}
+	}
+
 	return &ExecutionPlan{
 		Group:      config.Group,
 		Components: steps,
+		Hooks:      config.Hooks,
 	}, nil
 }
 
+// dependencySteps returns the steps a step on network depends on for one dependency: the
+// step of the dependency on the same network, or every step of the dependency when it does
+// not run there
+func dependencySteps(steps []*ExecutionStep, network string) []string {
+	var names []string
+	for _, step := range steps {
+		if len(steps) > 1 && step.Network == network {
+			return []string{step.Name}
+		}
+		names = append(names, step.Name)
+	}
+	return names
+}
+
 // executeStep executes a single orchestration step
-func (o *ComposeDeployment) executeStep(ctx context.Context, step *ExecutionStep, params ComposeParams) *StepResult {
+func (o *ComposeDeployment) executeStep(ctx context.Context, group string, step *ExecutionStep, env map[string]string, params ComposeParams, broadcastDir string) *StepResult {
 	// Prepare parameters for run script
 	scriptParams := RunScriptParams{
 		ScriptRef:      step.Script,
-		Parameters:     step.Env,
+		Parameters:     env,
 		DryRun:         params.DryRun,
 		Debug:          params.Debug,
 		DebugJSON:      params.DebugJSON,
 		Verbose:        params.Verbose,
 		NonInteractive: true, // Always non-interactive for orchestration
+		Yes:            params.Yes,
+		Compose: &models.RunComposeStep{
+			File:  params.ConfigPath,
+			Group: group,
+			Step:  step.Name,
+		},
+		BroadcastDir: broadcastDir,
 	}
 
 	// Execute the script
@@ -417,28 +926,100 @@ func (o *ComposeDeployment) executeStep(ctx context.Context, step *ExecutionStep
 // ComposeConfig represents the top-level configuration for orchestrated deployments
 type ComposeConfig struct {
 	Group      string                      `yaml:"group"`
+	Include    []*IncludeConfig            `yaml:"include,omitempty"` // Compose files whose components are added, resolved when loading
+	Vars       map[string]string           `yaml:"vars,omitempty"`    // Variables used as ${{ vars.<name> }}, overridden with --var
+	Hooks      *HooksConfig                `yaml:"hooks,omitempty"`   // Hooks of the compose run
 	Components map[string]*ComponentConfig `yaml:"components"`
+
+	hooksSource composeSource // Position of the hooks of the compose run
 }
 
 // ComponentConfig represents a single component in the orchestration
 type ComponentConfig struct {
-	Script string            `yaml:"script"`
-	Deps   []string          `yaml:"deps,omitempty"`
-	Env    map[string]string `yaml:"env,omitempty"`
+	Script   string            `yaml:"script"`
+	Deps     []string          `yaml:"deps,omitempty"`
+	Env      map[string]string `yaml:"env,omitempty"`
+	Network  string            `yaml:"network,omitempty"`  // Network to run on, the network of the compose run by default
+	Networks []string          `yaml:"networks,omitempty"` // Networks to run on, once per network
+	Expects  []string          `yaml:"expects,omitempty"`  // Deployments the script creates, the component is skipped when they exist
+	Hooks    *HooksConfig      `yaml:"hooks,omitempty"`    // Hooks run before and after the component
+
+	sources map[string]composeSource // Position of the component and of its fields, by YAML key
 }
 
 // ExecutionPlan represents the linearized execution plan
 type ExecutionPlan struct {
 	Group      string
 	Components []*ExecutionStep
+	Hooks      *HooksConfig `json:",omitempty"`
+}
+
+// Levels groups the steps by depth in the dependency graph. Every step comes after all of
+// its dependencies, and steps of the same level are independent of each other.
+func (p *ExecutionPlan) Levels() [][]*ExecutionStep {
+	depth := make(map[string]int, len(p.Components))
+	var levels [][]*ExecutionStep
+	// Components are topologically sorted, so dependencies have a depth already
+	for _, step := range p.Components {
+		level := 0
+		for _, dep := range step.Dependencies {
+			level = max(level, depth[dep]+1)
+		}
+		depth[step.Name] = level
+		for len(levels) <= level {
+			levels = append(levels, nil)
+		}
+		levels[level] = append(levels[level], step)
+	}
+	return levels
+}
+
+// Networks returns the networks of the plan in the order their first step runs
+func (p *ExecutionPlan) Networks() []string {
+	var networks []string
+	for _, network := range stepNetworks(p.Components) {
+		if network != "" {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}
+
+// StepsOn returns the steps of the plan that run on a network
+func (p *ExecutionPlan) StepsOn(network string) []*ExecutionStep {
+	var steps []*ExecutionStep
+	for _, step := range p.Components {
+		if step.Network == network {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// stepNetworks returns the distinct networks of steps in order of appearance
+func stepNetworks(steps []*ExecutionStep) []string {
+	var networks []string
+	for _, step := range steps {
+		if !slices.Contains(networks, step.Network) {
+			networks = append(networks, step.Network)
+		}
+	}
+	return networks
 }
 
 // ExecutionStep represents a single step in the execution plan
 type ExecutionStep struct {
 	Name         string
 	Script       string
+	Network      string `json:",omitempty"`
 	Env          map[string]string
-	Dependencies []string // For reference/debugging
+	Dependencies []string     // For reference/debugging
+	Expects      []string     `json:",omitempty"`
+	Hooks        *HooksConfig `json:",omitempty"`
+
+	Check *StepCheck `json:"-"` // Set before running when the step has expected deployments
+
+	source composeSource // Position of the env of the component in the compose files
 }
 
 // Validate checks the orchestration configuration for errors
@@ -450,20 +1031,42 @@ func (config *ComposeConfig) Validate() error {
 	if len(config.Components) == 0 {
 		return fmt.Errorf("at least one component is required")
 	}
+	if err := config.Hooks.validate(); err != nil {
+		return config.hooksSource.errorf("hooks: %w", err)
+	}
 
 	// Check for self-dependencies and non-existent dependencies
-	for name, component := range config.Components {
+	for _, name := range slices.Sorted(maps.Keys(config.Components)) {
+		component := config.Components[name]
 		if component.Script == "" {
-			return fmt.Errorf("component '%s' must specify a script", name)
+			return component.source("script").errorf("component '%s' must specify a script", name)
+		}
+
+		if strings.Contains(name, "@") {
+			return component.source("").errorf("component name '%s' cannot contain '@'", name)
+		}
+		if component.Network != "" && len(component.Networks) > 0 {
+			return component.source("networks").errorf("component '%s' cannot set both network and networks", name)
+		}
+		if slices.Contains(component.Expects, "") {
+			return component.source("expects").errorf("component '%s' has an empty entry in expects", name)
+		}
+		if err := component.Hooks.validate(); err != nil {
+			return component.source("hooks").errorf("component '%s' hooks: %w", name, err)
+		}
+		for i, network := range component.Networks {
+			if network == "" || slices.Contains(component.Networks[:i], network) {
+				return component.source("networks").errorf("component '%s' has an empty or duplicate network in networks", name)
+			}
 		}
 
 		for _, dep := range component.Deps {
 			if dep == name {
-				return fmt.Errorf("component '%s' cannot depend on itself", name)
+				return component.source("deps").errorf("component '%s' cannot depend on itself", name)
 			}
 
 			if _, exists := config.Components[dep]; !exists {
-				return fmt.Errorf("component '%s' depends on non-existent component '%s'", name, dep)
+				return component.source("deps").errorf("component '%s' depends on non-existent component '%s'", name, dep)
 			}
 		}
 	}
@@ -543,7 +1146,9 @@ func (g *DependencyGraph) TopologicalSort() ([]*ExecutionStep, error) {
 		step := &ExecutionStep{
 			Name:         current,
 			Script:       component.Script,
+			Network:      component.Network,
 			Env:          component.Env,
+			Expects:      component.Expects,
 			Dependencies: component.Deps,
 		}
 		result = append(result, step)
@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// fakeScriptRunner records the steps it runs and how many ran at the same time
+type fakeScriptRunner struct {
+	mu            sync.Mutex
+	failing       map[string]bool
+	running       int
+	maxRunning    int
+	ran           []string
+	broadcastDirs map[string]string
+	parameters    map[string]map[string]string
+	config        *config.RuntimeConfig // Records the network each step ran on when set
+	networks      map[string]string
+}
+
+func (f *fakeScriptRunner) Run(_ context.Context, params RunScriptParams) (*RunScriptResult, error) {
+	f.mu.Lock()
+	f.running++
+	f.maxRunning = max(f.maxRunning, f.running)
+	f.ran = append(f.ran, params.Compose.Step)
+	f.broadcastDirs[params.Compose.Step] = params.BroadcastDir
+	if f.parameters != nil {
+		f.parameters[params.Compose.Step] = params.Parameters
+	}
+	if f.config != nil {
+		f.networks[params.Compose.Step] = f.config.Network.Name
+	}
+	failing := f.failing[params.Compose.Step]
+	f.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	f.mu.Lock()
+	f.running--
+	f.mu.Unlock()
+
+	// Like RunScript, dry runs only have the simulated deployments and no changeset
+	if params.DryRun {
+		return &RunScriptResult{Success: !failing, RunResult: &forge.HydratedRunResult{
+			RunResult: &forge.RunResult{Namespace: "default", ChainID: 31337},
+			Deployments: []*forge.Deployment{{
+				Address:  common.BytesToAddress([]byte(params.Compose.Step)),
+				Contract: &models.Contract{Name: params.ScriptRef},
+			}},
+		}}, nil
+	}
+
+	changeset := &models.Changeset{}
+	changeset.Create.Deployments = []*models.Deployment{{
+		ID:           "default/31337/" + params.ScriptRef,
+		ContractName: params.ScriptRef,
+		Address:      "0x" + params.Compose.Step,
+	}}
+	return &RunScriptResult{Success: !failing, Changeset: changeset}, nil
+}
+
+// nopComposeSink discards compose progress
+type nopComposeSink struct{}
+
+func (nopComposeSink) OnProgress(context.Context, ProgressEvent) {}
+func (nopComposeSink) Info(string)                               {}
+func (nopComposeSink) Error(string)                              {}
+
+func TestExecutionPlanLevels(t *testing.T) {
+	plan, err := (&ComposeDeployment{}).createExecutionPlan(&ComposeConfig{
+		Group: "Protocol",
+		Components: map[string]*ComponentConfig{
+			"Tokens":  {Script: "DeployTokens"},
+			"Oracle":  {Script: "DeployOracle"},
+			"Broker":  {Script: "DeployBroker", Deps: []string{"Tokens"}},
+			"Reserve": {Script: "DeployReserve", Deps: []string{"Broker", "Oracle"}},
+		},
+	}, "")
+	require.NoError(t, err)
+
+	var names [][]string
+	for _, level := range plan.Levels() {
+		var levelNames []string
+		for _, step := range level {
+			levelNames = append(levelNames, step.Name)
+		}
+		names = append(names, levelNames)
+	}
+	assert.Equal(t, [][]string{{"Oracle", "Tokens"}, {"Broker"}, {"Reserve"}}, names)
+}
+
+func TestComposeDeployment_Parallel(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("protocol.yaml", []byte(`group: Protocol
+components:
+  A:
+    script: DeployA
+  B:
+    script: DeployB
+  C:
+    script: DeployC
+  D:
+    script: DeployD
+    deps: [A, B, C]
+`), 0644))
+
+	t.Run("independent steps run together", func(t *testing.T) {
+		runner := &fakeScriptRunner{broadcastDirs: map[string]string{}}
+		compose := &ComposeDeployment{runScript: runner, progress: nopComposeSink{}}
+
+		result, err := compose.Execute(context.Background(), ComposeParams{ConfigPath: "protocol.yaml", Parallel: 2})
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, 4, result.TotalDeployments)
+		assert.Equal(t, 2, runner.maxRunning)
+		assert.Equal(t, "D", runner.ran[3])
+		assert.Equal(t, "broadcast/compose/protocol/A", runner.broadcastDirs["A"])
+	})
+
+	t.Run("sequential by default", func(t *testing.T) {
+		runner := &fakeScriptRunner{broadcastDirs: map[string]string{}}
+		compose := &ComposeDeployment{runScript: runner, progress: nopComposeSink{}}
+
+		_, err := compose.Execute(context.Background(), ComposeParams{ConfigPath: "protocol.yaml"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, runner.maxRunning)
+		assert.Equal(t, []string{"A", "B", "C", "D"}, runner.ran)
+		assert.Empty(t, runner.broadcastDirs["A"])
+	})
+
+	t.Run("resume reruns every failed step", func(t *testing.T) {
+		runner := &fakeScriptRunner{broadcastDirs: map[string]string{}, failing: map[string]bool{"A": true, "C": true}}
+		compose := &ComposeDeployment{runScript: runner, progress: nopComposeSink{}}
+
+		result, err := compose.Execute(context.Background(), ComposeParams{ConfigPath: "protocol.yaml", Parallel: 3})
+		require.NoError(t, err)
+		assert.False(t, result.Success)
+		require.Len(t, result.FailedSteps, 2)
+		assert.Equal(t, "A", result.FailedStep.Step.Name)
+		assert.Equal(t, "C", result.FailedSteps[1].Step.Name)
+		assert.NotContains(t, runner.ran, "D")
+
+		state, err := compose.loadState("protocol.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, ComposeStatusFailed, state.Status)
+		assert.Equal(t, StepStatusFailed, state.ExecutedSteps["A"].Status)
+		assert.Equal(t, StepStatusCompleted, state.ExecutedSteps["B"].Status)
+		assert.Equal(t, StepStatusFailed, state.ExecutedSteps["C"].Status)
+		assert.Equal(t, 1, state.TotalDeployments)
+
+		runner = &fakeScriptRunner{broadcastDirs: map[string]string{}}
+		compose.runScript = runner
+		result, err = compose.Execute(context.Background(), ComposeParams{ConfigPath: "protocol.yaml", Parallel: 3, Resume: true})
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.ElementsMatch(t, []string{"A", "C", "D"}, runner.ran)
+		assert.Equal(t, 4, result.TotalDeployments)
+
+		state, err = compose.loadState("protocol.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, ComposeStatusCompleted, state.Status)
+		assert.Equal(t, 4, state.TotalDeployments)
+	})
+}
+
+func TestComposeDeployment_RunState(t *testing.T) {
+	t.Chdir(t.TempDir())
+	protocol := []byte(`group: Protocol
+components:
+  A:
+    script: DeployA
+`)
+	require.NoError(t, os.MkdirAll("core", 0755))
+	require.NoError(t, os.MkdirAll("periphery", 0755))
+	require.NoError(t, os.WriteFile("core/protocol.yaml", protocol, 0644))
+	require.NoError(t, os.WriteFile("periphery/protocol.yaml", protocol, 0644))
+
+	newCompose := func(namespace, network string, failing bool) (*ComposeDeployment, *fakeScriptRunner) {
+		runner := &fakeScriptRunner{broadcastDirs: map[string]string{}, failing: map[string]bool{"A": failing}}
+		cfg := &config.RuntimeConfig{Namespace: namespace, Network: &config.Network{Name: network, ChainID: 31337}}
+		return &ComposeDeployment{config: cfg, runScript: runner, progress: nopComposeSink{}}, runner
+	}
+
+	// A failed run on anvil in the default namespace
+	compose, runner := newCompose("default", "anvil", true)
+	result, err := compose.Execute(context.Background(), ComposeParams{ConfigPath: "core/protocol.yaml", Namespace: "default", Network: "anvil", Parallel: 2})
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	coreDir := runner.broadcastDirs["A"]
+
+	t.Run("another network or namespace is another run", func(t *testing.T) {
+		compose, _ := newCompose("default", "sepolia", false)
+		_, err := compose.Execute(context.Background(), ComposeParams{ConfigPath: "core/protocol.yaml", Resume: true})
+		assert.EqualError(t, err, "failed to resume: no previous compose run found for protocol.yaml")
+
+		compose, _ = newCompose("production", "anvil", false)
+		_, err = compose.Execute(context.Background(), ComposeParams{ConfigPath: "core/protocol.yaml", Resume: true})
+		assert.EqualError(t, err, "failed to resume: no previous compose run found for protocol.yaml")
+	})
+
+	t.Run("a compose file of the same name in another directory is another run", func(t *testing.T) {
+		compose, runner := newCompose("default", "anvil", false)
+		result, err := compose.Execute(context.Background(), ComposeParams{ConfigPath: "periphery/protocol.yaml", Parallel: 2})
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.NotEqual(t, coreDir, runner.broadcastDirs["A"])
+	})
+
+	t.Run("a run is not resumed on another namespace or network", func(t *testing.T) {
+		compose, runner := newCompose("default", "anvil", false)
+		_, err := compose.Execute(context.Background(), ComposeParams{ConfigPath: "core/protocol.yaml", Namespace: "production", Network: "anvil", Resume: true})
+		assert.EqualError(t, err, "cannot resume: namespace changed (was default, now production)")
+
+		_, err = compose.Execute(context.Background(), ComposeParams{ConfigPath: "core/protocol.yaml", Namespace: "default", Network: "sepolia", Resume: true})
+		assert.EqualError(t, err, "cannot resume: network changed (was anvil, now sepolia)")
+		assert.Empty(t, runner.ran)
+	})
+
+	t.Run("the failed run resumes", func(t *testing.T) {
+		compose, runner := newCompose("default", "anvil", false)
+		result, err := compose.Execute(context.Background(), ComposeParams{ConfigPath: "core/protocol.yaml", Namespace: "default", Network: "anvil", Parallel: 2, Resume: true})
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, []string{"A"}, runner.ran)
+		assert.Equal(t, coreDir, runner.broadcastDirs["A"])
+	})
+}
+
+// stubNetworkResolver resolves every network to a chain ID from a map
+type stubNetworkResolver map[string]uint64
+
+func (s stubNetworkResolver) GetNetworks(context.Context) []string { return nil }
+
+func (s stubNetworkResolver) ResolveNetwork(_ context.Context, name string) (*config.Network, error) {
+	chainID, ok := s[name]
+	if !ok {
+		return nil, fmt.Errorf("network '%s' not found in foundry.toml [rpc_endpoints]", name)
+	}
+	return &config.Network{Name: name, ChainID: chainID}, nil
+}
+
+func TestCreateExecutionPlan_Networks(t *testing.T) {
+	plan, err := (&ComposeDeployment{}).createExecutionPlan(&ComposeConfig{
+		Group: "Bridge",
+		Components: map[string]*ComponentConfig{
+			"Core":     {Script: "DeployCore"},
+			"Registry": {Script: "DeployRegistry", Networks: []string{"arbitrum", "base"}},
+			"Adapter":  {Script: "DeployAdapter", Networks: []string{"arbitrum", "base"}, Deps: []string{"Core", "Registry"}},
+			"Wire":     {Script: "WireL1", Network: "mainnet", Deps: []string{"Adapter"}},
+		},
+	}, "mainnet")
+	require.NoError(t, err)
+
+	var steps []string
+	for _, step := range plan.Components {
+		steps = append(steps, fmt.Sprintf("%s on %s after %v", step.Name, step.Network, step.Dependencies))
+	}
+	assert.Equal(t, []string{
+		"Core on mainnet after []",
+		"Registry@arbitrum on arbitrum after []",
+		"Registry@base on base after []",
+		"Adapter@arbitrum on arbitrum after [Core Registry@arbitrum]",
+		"Adapter@base on base after [Core Registry@base]",
+		"Wire on mainnet after [Adapter@arbitrum Adapter@base]",
+	}, steps)
+	assert.Equal(t, []string{"mainnet", "arbitrum", "base"}, plan.Networks())
+	assert.Len(t, plan.StepsOn("arbitrum"), 2)
+}
+
+func TestComposeDeployment_Networks(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("bridge.yaml", []byte(`group: Bridge
+components:
+  core:
+    script: DeployCore
+  adapter:
+    script: DeployAdapter
+    networks: [arbitrum, base]
+    deps: [core]
+    env:
+      CORE: ${{ components.core.deployments.DeployCore.address }}
+      CHAIN: ${{ network.chainId }}
+  wire:
+    script: WireAdapters
+    deps: [adapter]
+    env:
+      ARBITRUM: ${{ components.adapter@arbitrum.deployments.DeployAdapter.address }}
+`), 0644))
+
+	mainnet := &config.Network{Name: "mainnet", ChainID: 1}
+	cfg := &config.RuntimeConfig{Namespace: "default", Network: mainnet}
+	runner := &fakeScriptRunner{
+		broadcastDirs: map[string]string{},
+		parameters:    map[string]map[string]string{},
+		config:        cfg,
+		networks:      map[string]string{},
+	}
+	compose := &ComposeDeployment{
+		config:    cfg,
+		networks:  stubNetworkResolver{"arbitrum": 42161, "base": 8453},
+		runScript: runner,
+		progress:  nopComposeSink{},
+	}
+
+	result, err := compose.Execute(context.Background(), ComposeParams{ConfigPath: "bridge.yaml", Parallel: 4})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, map[string]string{
+		"core":             "mainnet",
+		"adapter@arbitrum": "arbitrum",
+		"adapter@base":     "base",
+		"wire":             "mainnet",
+	}, runner.networks)
+	assert.Equal(t, map[string]string{"CORE": "0xcore", "CHAIN": "8453"}, runner.parameters["adapter@base"])
+	assert.Equal(t, "0xadapter@arbitrum", runner.parameters["wire"]["ARBITRUM"])
+	assert.Same(t, mainnet, cfg.Network, "the network of the runtime configuration is restored")
+
+	state, err := compose.loadState("bridge.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "base", state.ExecutedSteps["adapter@base"].Step.Network)
+
+	t.Run("unknown network", func(t *testing.T) {
+		compose.networks = stubNetworkResolver{"arbitrum": 42161}
+		_, err := compose.Execute(context.Background(), ComposeParams{ConfigPath: "bridge.yaml"})
+		assert.ErrorContains(t, err, "failed to resolve network base")
+	})
+}
@@ -0,0 +1,406 @@
+package usecase
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IncludeConfig is a compose file included by another one
+type IncludeConfig struct {
+	File   string            `yaml:"file"`             // Path relative to the including file
+	Prefix string            `yaml:"prefix,omitempty"` // Components of the file are named <prefix>/<component>
+	Vars   map[string]string `yaml:"vars,omitempty"`   // Values of variables declared by the file
+
+	source composeSource
+}
+
+// composeSource is the position of a value in a compose file
+type composeSource struct {
+	File string
+	Line int
+}
+
+// errorf returns an error prefixed with the position, when it is known
+func (s composeSource) errorf(format string, args ...any) error {
+	err := fmt.Errorf(format, args...)
+	if s.File == "" {
+		return err
+	}
+	return fmt.Errorf("%s:%d: %w", s.File, s.Line, err)
+}
+
+// source returns the position of a field of the component, or of the component itself
+// when the field is not set
+func (c *ComponentConfig) source(field string) composeSource {
+	if source, ok := c.sources[field]; ok {
+		return source
+	}
+	return c.sources[""]
+}
+
+// composeLoader loads a compose file with the files it includes
+type composeLoader struct {
+	namespace string            // Selects the overlay of each file, compose.<namespace>.yaml
+	overrides map[string]string // --var values, applied to every file declaring the variable
+	declared  map[string]bool   // Variables declared by the loaded files
+	loading   []string          // Files being loaded, to detect include cycles
+}
+
+// loadComposeFile reads a compose file merged with its namespace overlay, substitutes its
+// variables and adds the components of the files it includes
+func loadComposeFile(path, namespace string, overrides map[string]string) (*ComposeConfig, error) {
+	loader := &composeLoader{namespace: namespace, overrides: overrides, declared: make(map[string]bool)}
+	config, err := loader.load(path, nil, composeSource{})
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		if !loader.declared[name] {
+			return nil, fmt.Errorf("variable '%s' is not declared in the vars of %s or the files it includes", name, path)
+		}
+	}
+	return config, nil
+}
+
+// load loads a file with the values its includer sets for its variables
+func (l *composeLoader) load(path string, vars map[string]string, from composeSource) (*ComposeConfig, error) {
+	if slices.Contains(l.loading, path) {
+		return nil, from.errorf("include cycle: %s", strings.Join(append(l.loading, path), " → "))
+	}
+	l.loading = append(l.loading, path)
+	defer func() { l.loading = l.loading[:len(l.loading)-1] }()
+
+	config, err := parseComposeYAML(path)
+	if err != nil {
+		return nil, err
+	}
+	if l.namespace != "" {
+		overlayPath := namespaceOverlayPath(path, l.namespace)
+		if _, err := os.Stat(overlayPath); err == nil {
+			overlay, err := parseComposeYAML(overlayPath)
+			if err != nil {
+				return nil, err
+			}
+			config.merge(overlay)
+		}
+	}
+
+	// Declared values, replaced by those of the includer, replaced by --var
+	values := make(map[string]string, len(config.Vars))
+	maps.Copy(values, config.Vars)
+	for _, name := range slices.Sorted(maps.Keys(vars)) {
+		if _, ok := config.Vars[name]; !ok {
+			return nil, from.errorf("variable '%s' is not declared in the vars of %s", name, path)
+		}
+		values[name] = vars[name]
+	}
+	for name := range config.Vars {
+		l.declared[name] = true
+		if value, ok := l.overrides[name]; ok {
+			values[name] = value
+		}
+	}
+	if err := config.substituteVars(values); err != nil {
+		return nil, err
+	}
+
+	for _, include := range config.Include {
+		if include == nil {
+			continue
+		}
+		if err := l.include(config, path, include, values); err != nil {
+			return nil, err
+		}
+	}
+	config.Include = nil
+	return config, nil
+}
+
+// include adds the components of an included file to the configuration of its includer
+func (l *composeLoader) include(config *ComposeConfig, path string, include *IncludeConfig, values map[string]string) error {
+	if include.File == "" {
+		return include.source.errorf("include must specify a file")
+	}
+	if strings.ContainsAny(include.Prefix, "@.") {
+		return include.source.errorf("include prefix '%s' cannot contain '@' or '.'", include.Prefix)
+	}
+
+	file := include.File
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(filepath.Dir(path), file)
+	}
+	if _, err := os.Stat(file); err != nil {
+		return include.source.errorf("failed to include %s: %w", include.File, err)
+	}
+
+	// Values passed to the included file can use the variables of the includer
+	vars := make(map[string]string, len(include.Vars))
+	for name, value := range include.Vars {
+		resolved, err := substituteVars(value, values)
+		if err != nil {
+			return include.source.errorf("include %s: var %s: %w", include.File, name, err)
+		}
+		vars[name] = resolved
+	}
+
+	included, err := l.load(file, vars, include.source)
+	if err != nil {
+		return err
+	}
+	if included.Hooks != nil {
+		return included.hooksSource.errorf("hooks of the compose run cannot be set in included file %s, set them on its components", include.File)
+	}
+	if config.Components == nil {
+		config.Components = make(map[string]*ComponentConfig)
+	}
+	for name, component := range included.prefixed(include.Prefix) {
+		if _, exists := config.Components[name]; exists {
+			return include.source.errorf("component '%s' included from %s is already defined", name, include.File)
+		}
+		config.Components[name] = component
+	}
+	return nil
+}
+
+// parseComposeYAML parses a compose file and records the position of its components
+func parseComposeYAML(path string) (*ComposeConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML in %s: %w", path, err)
+	}
+	config := &ComposeConfig{}
+	if len(document.Content) == 0 {
+		return config, nil
+	}
+	root := document.Content[0]
+	if err := root.Decode(config); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML in %s: %w", path, err)
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		switch key.Value {
+		case "hooks":
+			config.hooksSource = composeSource{File: path, Line: key.Line}
+		case "include":
+			for j, item := range value.Content {
+				if j < len(config.Include) && config.Include[j] != nil {
+					config.Include[j].source = composeSource{File: path, Line: item.Line}
+				}
+			}
+		case "components":
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				name, fields := value.Content[j], value.Content[j+1]
+				component := config.Components[name.Value]
+				if component == nil {
+					component = &ComponentConfig{}
+					config.Components[name.Value] = component
+				}
+				component.sources = map[string]composeSource{"": {File: path, Line: name.Line}}
+				for k := 0; k+1 < len(fields.Content); k += 2 {
+					component.sources[fields.Content[k].Value] = composeSource{File: path, Line: fields.Content[k].Line}
+				}
+			}
+		}
+	}
+	return config, nil
+}
+
+// namespaceOverlayPath returns the overlay of a compose file for a namespace, e.g.
+// compose.production.yaml for compose.yaml
+func namespaceOverlayPath(path, namespace string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + namespace + ext
+}
+
+// merge applies an overlay: the fields it sets replace those of the base component, env
+// and vars are merged by key, and includes and new components are added
+func (config *ComposeConfig) merge(overlay *ComposeConfig) {
+	if overlay.Group != "" {
+		config.Group = overlay.Group
+	}
+	if overlay.Hooks != nil {
+		config.Hooks, config.hooksSource = overlay.Hooks, overlay.hooksSource
+	}
+	if len(overlay.Vars) > 0 && config.Vars == nil {
+		config.Vars = make(map[string]string, len(overlay.Vars))
+	}
+	maps.Copy(config.Vars, overlay.Vars)
+	config.Include = append(config.Include, overlay.Include...)
+
+	if len(overlay.Components) > 0 && config.Components == nil {
+		config.Components = make(map[string]*ComponentConfig, len(overlay.Components))
+	}
+	for name, override := range overlay.Components {
+		component, exists := config.Components[name]
+		if !exists {
+			config.Components[name] = override
+			continue
+		}
+
+		for field, source := range override.sources {
+			switch field {
+			case "script":
+				component.Script = override.Script
+			case "deps":
+				component.Deps = override.Deps
+			case "expects":
+				component.Expects = override.Expects
+			case "hooks":
+				component.Hooks = override.Hooks
+			case "env":
+				if component.Env == nil {
+					component.Env = make(map[string]string, len(override.Env))
+				}
+				maps.Copy(component.Env, override.Env)
+			case "network":
+				// Where the component runs is replaced as a whole
+				component.Network = override.Network
+				if _, ok := override.sources["networks"]; !ok {
+					component.Networks = nil
+					delete(component.sources, "networks")
+				}
+			case "networks":
+				component.Networks = override.Networks
+				if _, ok := override.sources["network"]; !ok {
+					component.Network = ""
+					delete(component.sources, "network")
+				}
+			default:
+				continue
+			}
+			component.sources[field] = source
+		}
+	}
+}
+
+// substituteVars replaces ${{ vars.<name> }} in the fields of the components and in hooks
+func (config *ComposeConfig) substituteVars(values map[string]string) error {
+	if err := config.Hooks.substituteVars(values); err != nil {
+		return config.hooksSource.errorf("hooks: %w", err)
+	}
+	for _, name := range slices.Sorted(maps.Keys(config.Components)) {
+		component := config.Components[name]
+		substitute := func(field string, value *string) error {
+			resolved, err := substituteVars(*value, values)
+			if err != nil {
+				return component.source(field).errorf("component '%s': %w", name, err)
+			}
+			*value = resolved
+			return nil
+		}
+
+		if err := substitute("script", &component.Script); err != nil {
+			return err
+		}
+		if err := substitute("network", &component.Network); err != nil {
+			return err
+		}
+		for i := range component.Networks {
+			if err := substitute("networks", &component.Networks[i]); err != nil {
+				return err
+			}
+		}
+		for i := range component.Expects {
+			if err := substitute("expects", &component.Expects[i]); err != nil {
+				return err
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(component.Env)) {
+			value := component.Env[key]
+			if err := substitute("env", &value); err != nil {
+				return err
+			}
+			component.Env[key] = value
+		}
+		if err := component.Hooks.substituteVars(values); err != nil {
+			return component.source("hooks").errorf("component '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// substituteVars replaces ${{ vars.<name> }} in the commands and actions of hooks
+func (c *HooksConfig) substituteVars(values map[string]string) error {
+	if c == nil {
+		return nil
+	}
+	for _, hook := range c.all() {
+		for _, value := range []*string{&hook.Run, &hook.Action} {
+			resolved, err := substituteVars(*value, values)
+			if err != nil {
+				return err
+			}
+			*value = resolved
+		}
+	}
+	return nil
+}
+
+// substituteVars replaces ${{ vars.<name> }} in a value, other expressions are resolved
+// when the step runs
+func substituteVars(value string, values map[string]string) (string, error) {
+	var substituteErr error
+	resolved := composeTemplatePattern.ReplaceAllStringFunc(value, func(match string) string {
+		name, ok := strings.CutPrefix(composeTemplatePattern.FindStringSubmatch(match)[1], "vars.")
+		if !ok {
+			return match
+		}
+		resolved, ok := values[name]
+		if !ok && substituteErr == nil {
+			substituteErr = fmt.Errorf("undefined variable '%s'", name)
+		}
+		return resolved
+	})
+	return resolved, substituteErr
+}
+
+// prefixed returns the components named <prefix>/<component>, with their dependencies and
+// template references to each other renamed
+func (config *ComposeConfig) prefixed(prefix string) map[string]*ComponentConfig {
+	if prefix == "" {
+		return config.Components
+	}
+	rename := func(name string) string {
+		base, _, _ := strings.Cut(name, "@")
+		if _, ok := config.Components[base]; ok {
+			return prefix + "/" + name
+		}
+		return name
+	}
+
+	components := make(map[string]*ComponentConfig, len(config.Components))
+	for name, component := range config.Components {
+		renamed := *component
+		renamed.Deps = nil
+		for _, dep := range component.Deps {
+			renamed.Deps = append(renamed.Deps, rename(dep))
+		}
+		if component.Env != nil {
+			renamed.Env = make(map[string]string, len(component.Env))
+		}
+		for key, value := range component.Env {
+			renamed.Env[key] = composeTemplatePattern.ReplaceAllStringFunc(value, func(match string) string {
+				parts := strings.SplitN(composeTemplatePattern.FindStringSubmatch(match)[1], ".", 3)
+				if parts[0] != "components" || len(parts) < 2 {
+					return match
+				}
+				parts[1] = rename(parts[1])
+				return "${{ " + strings.Join(parts, ".") + " }}"
+			})
+		}
+		components[prefix+"/"+name] = &renamed
+	}
+	return components
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeComposeFiles(t *testing.T, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestLoadComposeFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeComposeFiles(t, map[string]string{
+		"protocol.yaml": `group: Protocol
+vars:
+  OWNER: "0x01"
+  TIER: standard
+include:
+  - file: modules/core.yaml
+    prefix: core
+    vars:
+      ADMIN: ${{ vars.OWNER }}
+components:
+  Vault:
+    script: DeployVault
+    deps: [core/Token]
+    env:
+      OWNER: ${{ vars.OWNER }}
+      TOKEN: ${{ components.core/Token.deployments.Token.address }}
+      TIER: ${{ vars.TIER }}
+`,
+		"protocol.production.yaml": `vars:
+  TIER: premium
+components:
+  Vault:
+    networks: [mainnet, base]
+    env:
+      EXTRA: "1"
+  Monitor:
+    script: DeployMonitor
+`,
+		"modules/core.yaml": `group: Core
+vars:
+  ADMIN: "0x00"
+components:
+  Token:
+    script: DeployToken
+    env:
+      ADMIN: ${{ vars.ADMIN }}
+  Minter:
+    script: DeployMinter
+    deps: [Token]
+    env:
+      TOKEN: ${{ components.Token.deployments.Token.address }}
+`,
+	})
+
+	t.Run("includes are prefixed", func(t *testing.T) {
+		config, err := loadComposeFile("protocol.yaml", "default", nil)
+		require.NoError(t, err)
+		require.NoError(t, config.Validate())
+
+		assert.Equal(t, "Protocol", config.Group)
+		assert.ElementsMatch(t, []string{"Vault", "core/Token", "core/Minter"}, slices.Collect(maps.Keys(config.Components)))
+		assert.Equal(t, []string{"core/Token"}, config.Components["core/Minter"].Deps)
+		assert.Equal(t, "${{ components.core/Token.deployments.Token.address }}", config.Components["core/Minter"].Env["TOKEN"])
+		assert.Equal(t, "0x01", config.Components["core/Token"].Env["ADMIN"])
+		assert.Equal(t, "0x01", config.Components["Vault"].Env["OWNER"])
+		assert.Equal(t, "standard", config.Components["Vault"].Env["TIER"])
+	})
+
+	t.Run("namespace overlay", func(t *testing.T) {
+		config, err := loadComposeFile("protocol.yaml", "production", nil)
+		require.NoError(t, err)
+
+		vault := config.Components["Vault"]
+		assert.Equal(t, "DeployVault", vault.Script)
+		assert.Equal(t, []string{"mainnet", "base"}, vault.Networks)
+		assert.Equal(t, "premium", vault.Env["TIER"])
+		assert.Equal(t, "1", vault.Env["EXTRA"])
+		assert.Equal(t, "0x01", vault.Env["OWNER"])
+		assert.Contains(t, config.Components, "Monitor")
+	})
+
+	t.Run("var overrides", func(t *testing.T) {
+		config, err := loadComposeFile("protocol.yaml", "default", map[string]string{"OWNER": "0x02", "ADMIN": "0x03"})
+		require.NoError(t, err)
+		assert.Equal(t, "0x02", config.Components["Vault"].Env["OWNER"])
+		assert.Equal(t, "0x03", config.Components["core/Token"].Env["ADMIN"])
+
+		_, err = loadComposeFile("protocol.yaml", "default", map[string]string{"OWNR": "0x02"})
+		assert.ErrorContains(t, err, "variable 'OWNR' is not declared")
+	})
+}
+
+func TestLoadComposeFile_Errors(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name: "undefined variable",
+			files: map[string]string{"compose.yaml": `group: G
+components:
+  A:
+    script: DeployA
+    env:
+      OWNER: ${{ vars.OWNER }}
+`},
+			wantErr: "compose.yaml:5: component 'A': undefined variable 'OWNER'",
+		},
+		{
+			name: "missing dependency in included file",
+			files: map[string]string{
+				"compose.yaml": `group: G
+include:
+  - file: lib/core.yaml
+    prefix: core
+`,
+				"lib/core.yaml": `group: Core
+components:
+  Token:
+    script: DeployToken
+
+  Minter:
+    script: DeployMinter
+    deps: [Tokn]
+`,
+			},
+			wantErr: "lib/core.yaml:8: component 'core/Minter' depends on non-existent component 'Tokn'",
+		},
+		{
+			name: "missing included file",
+			files: map[string]string{"compose.yaml": `group: G
+components:
+  A:
+    script: DeployA
+include:
+  - file: missing.yaml
+`},
+			wantErr: "compose.yaml:6: failed to include missing.yaml",
+		},
+		{
+			name: "include cycle",
+			files: map[string]string{
+				"compose.yaml": "group: G\ninclude:\n  - file: other.yaml\n",
+				"other.yaml":   "group: O\ninclude:\n  - file: compose.yaml\n",
+			},
+			wantErr: "other.yaml:3: include cycle: compose.yaml → other.yaml → compose.yaml",
+		},
+		{
+			name: "duplicate component",
+			files: map[string]string{
+				"compose.yaml": "group: G\ninclude:\n  - file: other.yaml\ncomponents:\n  A:\n    script: DeployA\n",
+				"other.yaml":   "group: O\ncomponents:\n  A:\n    script: DeployA\n",
+			},
+			wantErr: "compose.yaml:3: component 'A' included from other.yaml is already defined",
+		},
+		{
+			name: "template of a component it does not depend on",
+			files: map[string]string{"compose.yaml": `group: G
+components:
+  A:
+    script: DeployA
+  B:
+    script: DeployB
+    env:
+      A: ${{ components.A.runId }}
+`},
+			wantErr: "compose.yaml:7: component 'B' references outputs of 'A' but does not depend on it",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			writeComposeFiles(t, tt.files)
+			_, err := (&ComposeDeployment{}).loadPlan("compose.yaml", nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
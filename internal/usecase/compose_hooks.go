@@ -0,0 +1,441 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Hooks of a compose run or component
+const (
+	HookPre       = "pre"        // Before the component runs, a failure fails the component
+	HookPost      = "post"       // After the component succeeded, a failure fails the component
+	HookOnFailure = "on_failure" // After the component failed, failures are only recorded
+)
+
+// Native hook actions
+const (
+	HookActionVerify = "verify" // Verify the deployments on block explorers
+	HookActionCheck  = "check"  // Check the deployments have code on chain
+	HookActionTag    = "tag"    // Add a tag to the deployments, written tag <tag>
+)
+
+// hookOutputLimit is the size of the end of the output kept in the compose state
+const hookOutputLimit = 4096
+
+// HooksConfig lists the hooks of a compose run or component
+type HooksConfig struct {
+	Pre       []HookConfig `yaml:"pre,omitempty" json:"pre,omitempty"`
+	Post      []HookConfig `yaml:"post,omitempty" json:"post,omitempty"`
+	OnFailure []HookConfig `yaml:"on_failure,omitempty" json:"on_failure,omitempty"`
+}
+
+// HookConfig is a shell command or a native treb action. A plain string is a shell command.
+type HookConfig struct {
+	Run    string `yaml:"run,omitempty" json:"run,omitempty"`
+	Action string `yaml:"action,omitempty" json:"action,omitempty"`
+}
+
+// UnmarshalYAML reads a hook from a shell command or a run/action mapping
+func (h *HookConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		h.Run = node.Value
+		return nil
+	}
+	type hookConfig HookConfig
+	return node.Decode((*hookConfig)(h))
+}
+
+// String returns the command or action of the hook
+func (h HookConfig) String() string {
+	return cmp.Or(h.Run, h.Action)
+}
+
+// validate checks the hook is a command or a known action
+func (h HookConfig) validate() error {
+	if (h.Run == "") == (h.Action == "") {
+		return fmt.Errorf("hook must set exactly one of run or action")
+	}
+	if h.Action == "" {
+		return nil
+	}
+	action, args := h.parseAction()
+	switch {
+	case action == HookActionVerify || action == HookActionCheck:
+		if len(args) > 0 {
+			return fmt.Errorf("hook action '%s' takes no arguments", action)
+		}
+	case action == HookActionTag:
+		if len(args) != 1 {
+			return fmt.Errorf("hook action 'tag' takes a single tag, e.g. tag v1.0.0")
+		}
+	default:
+		return fmt.Errorf("unknown hook action '%s' (use verify, check or tag <tag>)", action)
+	}
+	return nil
+}
+
+// parseAction splits the action of a hook into its name and arguments
+func (h HookConfig) parseAction() (string, []string) {
+	fields := strings.Fields(h.Action)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return fields[0], fields[1:]
+}
+
+// validate checks every hook
+func (c *HooksConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, hook := range c.all() {
+		if err := hook.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// all returns the hooks of every kind, for validation and substitution
+func (c *HooksConfig) all() []*HookConfig {
+	var hooks []*HookConfig
+	for _, list := range [][]HookConfig{c.Pre, c.Post, c.OnFailure} {
+		for i := range list {
+			hooks = append(hooks, &list[i])
+		}
+	}
+	return hooks
+}
+
+// Of returns the hooks of a kind
+func (c *HooksConfig) Of(hook string) []HookConfig {
+	if c == nil {
+		return nil
+	}
+	switch hook {
+	case HookPre:
+		return c.Pre
+	case HookPost:
+		return c.Post
+	case HookOnFailure:
+		return c.OnFailure
+	}
+	return nil
+}
+
+// HookResult is the outcome of a hook, recorded in the compose state
+type HookResult struct {
+	Hook    string `json:"hook"` // "pre", "post" or "on_failure"
+	Run     string `json:"run,omitempty"`
+	Action  string `json:"action,omitempty"`
+	Success bool   `json:"success"`
+	Output  string `json:"output,omitempty"` // End of the output of shell commands, or what the action did
+	Error   string `json:"error,omitempty"`
+}
+
+// HookPayload is the JSON document hooks receive on stdin
+type HookPayload struct {
+	Hook        string            `json:"hook"`
+	Group       string            `json:"group"`
+	Component   string            `json:"component,omitempty"` // Empty for the hooks of the compose run
+	Network     string            `json:"network,omitempty"`
+	ChainID     uint64            `json:"chainId,omitempty"`
+	Namespace   string            `json:"namespace"`
+	DryRun      bool              `json:"dryRun"`
+	Status      string            `json:"status,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	Outputs     map[string]string `json:"outputs,omitempty"`
+	Deployments []HookDeployment  `json:"deployments"`
+}
+
+// HookDeployment is a deployment of the component, or of the run, passed to hooks
+type HookDeployment struct {
+	ID       string `json:"id"`
+	Contract string `json:"contract"`
+	Label    string `json:"label,omitempty"`
+	Address  string `json:"address"`
+	ChainID  uint64 `json:"chainId"`
+	Type     string `json:"type"`
+}
+
+// hookContext is what the hooks of a compose run or component act on
+type hookContext struct {
+	hook        string
+	group       string
+	component   string
+	network     *config.Network
+	rpcURLs     map[uint64]string // RPC URL of the chains of the deployments, for check
+	namespace   string
+	dryRun      bool
+	status      string
+	err         string
+	outputs     map[string]string
+	deployments []*models.Deployment
+}
+
+// ComposeHooks runs the lifecycle hooks of compose runs and components
+type ComposeHooks struct {
+	repo    DeploymentRepository
+	runner  HookRunner
+	verify  *VerifyDeployment
+	checker BlockchainChecker
+
+	mu *RegistryLock // Actions update the registry and share the blockchain connection with runs
+}
+
+// NewComposeHooks creates the runner of compose hooks
+func NewComposeHooks(
+	repo DeploymentRepository,
+	runner HookRunner,
+	verify *VerifyDeployment,
+	checker BlockchainChecker,
+	mu *RegistryLock,
+) *ComposeHooks {
+	return &ComposeHooks{
+		repo:    repo,
+		runner:  runner,
+		verify:  verify,
+		checker: checker,
+		mu:      mu,
+	}
+}
+
+// run runs hooks in order. Pre and post hooks stop at the first failure, which is returned;
+// on_failure hooks all run and their failures are only recorded.
+func (h *ComposeHooks) run(ctx context.Context, hooks []HookConfig, hc *hookContext) ([]*HookResult, error) {
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+
+	var results []*HookResult
+	for _, hook := range hooks {
+		result := &HookResult{Hook: hc.hook, Run: hook.Run, Action: hook.Action, Success: true}
+		var err error
+		if hook.Run != "" {
+			result.Output, err = h.runCommand(ctx, hook.Run, hc)
+		} else {
+			result.Output, err = h.runAction(ctx, hook, hc)
+		}
+		results = append(results, result)
+
+		if err != nil {
+			result.Success = false
+			result.Error = err.Error()
+			if hc.hook != HookOnFailure {
+				return results, fmt.Errorf("%s hook '%s' failed: %w", hc.hook, hook, err)
+			}
+		}
+	}
+	return results, nil
+}
+
+// runCommand runs a shell hook with the context in env and as JSON on stdin
+func (h *ComposeHooks) runCommand(ctx context.Context, command string, hc *hookContext) (string, error) {
+	payload := hc.payload()
+	stdin, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode hook payload: %w", err)
+	}
+
+	var ids []string
+	for _, deployment := range payload.Deployments {
+		ids = append(ids, deployment.ID)
+	}
+	env := map[string]string{
+		"TREB_HOOK":          hc.hook,
+		"TREB_COMPOSE_GROUP": hc.group,
+		"TREB_COMPONENT":     hc.component,
+		"TREB_NETWORK":       payload.Network,
+		"TREB_CHAIN_ID":      "",
+		"TREB_RPC_URL":       hc.rpcURLs[payload.ChainID],
+		"TREB_NAMESPACE":     hc.namespace,
+		"TREB_DRY_RUN":       strconv.FormatBool(hc.dryRun),
+		"TREB_STATUS":        hc.status,
+		"TREB_ERROR":         hc.err,
+		"TREB_DEPLOYMENTS":   strings.Join(ids, ","),
+	}
+	if payload.ChainID != 0 {
+		env["TREB_CHAIN_ID"] = strconv.FormatUint(payload.ChainID, 10)
+	}
+
+	output, err := h.runner.RunHook(ctx, command, env, stdin)
+	output = strings.TrimSpace(output)
+	if len(output) > hookOutputLimit {
+		output = "…" + output[len(output)-hookOutputLimit:]
+	}
+	return output, err
+}
+
+// runAction runs a native action on the deployments of the hook. Actions change nothing
+// in a dry run.
+func (h *ComposeHooks) runAction(ctx context.Context, hook HookConfig, hc *hookContext) (string, error) {
+	if hc.dryRun {
+		return "skipped in dry run", nil
+	}
+	if len(hc.deployments) == 0 {
+		return "no deployments", nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	action, args := hook.parseAction()
+	switch action {
+	case HookActionVerify:
+		return h.verifyDeployments(ctx, hc.deployments)
+	case HookActionCheck:
+		return h.checkDeployments(ctx, hc.deployments, hc.rpcURLs)
+	case HookActionTag:
+		return h.tagDeployments(ctx, hc.deployments, args[0])
+	}
+	return "", fmt.Errorf("unknown hook action '%s'", action)
+}
+
+// verifyDeployments verifies the deployments that are not verified yet
+func (h *ComposeHooks) verifyDeployments(ctx context.Context, deployments []*models.Deployment) (string, error) {
+	var lines, failures []string
+	for _, deployment := range deployments {
+		if deployment.ChainID == 31337 {
+			lines = append(lines, fmt.Sprintf("%s: skipped on local chain", deployment.ContractDisplayName()))
+			continue
+		}
+		current, err := h.repo.GetDeployment(ctx, deployment.ID)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", deployment.ContractDisplayName(), err))
+			continue
+		}
+		if !shouldVerify(current) {
+			lines = append(lines, fmt.Sprintf("%s: already verified", current.ContractDisplayName()))
+			continue
+		}
+
+		result := h.verify.verifyDeployment(ctx, current, VerifyOptions{})
+		if !result.Success {
+			failures = append(failures, fmt.Sprintf("%s: %s", current.ContractDisplayName(), strings.Join(result.Errors, "; ")))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: verified", current.ContractDisplayName()))
+	}
+	if len(failures) > 0 {
+		return strings.Join(append(lines, failures...), "\n"), fmt.Errorf("failed to verify %s", strings.Join(failures, ", "))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// checkDeployments checks the deployments have code on their chain
+func (h *ComposeHooks) checkDeployments(ctx context.Context, deployments []*models.Deployment, rpcURLs map[uint64]string) (string, error) {
+	var lines, missing []string
+	byChain := make(map[uint64][]*models.Deployment)
+	for _, deployment := range deployments {
+		byChain[deployment.ChainID] = append(byChain[deployment.ChainID], deployment)
+	}
+	for _, chainID := range slices.Sorted(maps.Keys(byChain)) {
+		rpcURL, ok := rpcURLs[chainID]
+		if !ok {
+			return "", fmt.Errorf("no network of the compose run has chain ID %d", chainID)
+		}
+		if err := h.checker.Connect(ctx, rpcURL, chainID); err != nil {
+			return "", fmt.Errorf("failed to connect to chain %d: %w", chainID, err)
+		}
+		for _, deployment := range byChain[chainID] {
+			exists, reason, err := h.checker.CheckDeploymentExists(ctx, deployment.Address)
+			if err != nil {
+				return "", fmt.Errorf("failed to check code at %s: %w", deployment.Address, err)
+			}
+			if !exists {
+				missing = append(missing, fmt.Sprintf("%s at %s: %s", deployment.ContractDisplayName(), deployment.Address, reason))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s at %s: ok", deployment.ContractDisplayName(), deployment.Address))
+		}
+	}
+	if len(missing) > 0 {
+		return strings.Join(append(lines, missing...), "\n"), fmt.Errorf("no code for %s", strings.Join(missing, ", "))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// tagDeployments adds a tag to the deployments that do not have it yet
+func (h *ComposeHooks) tagDeployments(ctx context.Context, deployments []*models.Deployment, tag string) (string, error) {
+	var lines []string
+	for _, deployment := range deployments {
+		current, err := h.repo.GetDeployment(ctx, deployment.ID)
+		if err != nil {
+			return strings.Join(lines, "\n"), fmt.Errorf("failed to load %s: %w", deployment.ID, err)
+		}
+		if slices.Contains(current.Tags, tag) {
+			lines = append(lines, fmt.Sprintf("%s: already tagged %s", current.ContractDisplayName(), tag))
+			continue
+		}
+		current.Tags = append(current.Tags, tag)
+		if err := h.repo.SaveDeployment(ctx, current); err != nil {
+			return strings.Join(lines, "\n"), fmt.Errorf("failed to save %s: %w", deployment.ID, err)
+		}
+		lines = append(lines, fmt.Sprintf("%s: tagged %s", current.ContractDisplayName(), tag))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// outputDeployments loads the deployments whose IDs are in the outputs of steps, for hooks
+// of steps that completed in an earlier run and of the compose run
+func (h *ComposeHooks) outputDeployments(ctx context.Context, outputs ...map[string]string) []*models.Deployment {
+	if h == nil || h.repo == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var deployments []*models.Deployment
+	for _, stepOutputs := range outputs {
+		for _, key := range slices.Sorted(maps.Keys(stepOutputs)) {
+			id := stepOutputs[key]
+			if !strings.HasPrefix(key, "deployments.") || !strings.HasSuffix(key, ".id") || seen[id] {
+				continue
+			}
+			seen[id] = true
+			// Deployments of dry runs are not in the registry
+			if deployment, err := h.repo.GetDeployment(ctx, id); err == nil {
+				deployments = append(deployments, deployment)
+			}
+		}
+	}
+	return deployments
+}
+
+// payload returns the JSON document of the hook context
+func (hc *hookContext) payload() *HookPayload {
+	payload := &HookPayload{
+		Hook:        hc.hook,
+		Group:       hc.group,
+		Component:   hc.component,
+		Namespace:   hc.namespace,
+		DryRun:      hc.dryRun,
+		Status:      hc.status,
+		Error:       hc.err,
+		Outputs:     hc.outputs,
+		Deployments: []HookDeployment{},
+	}
+	if hc.network != nil {
+		payload.Network = hc.network.Name
+		payload.ChainID = hc.network.ChainID
+	}
+	for _, deployment := range hc.deployments {
+		payload.Deployments = append(payload.Deployments, HookDeployment{
+			ID:       deployment.ID,
+			Contract: deployment.ContractName,
+			Label:    deployment.Label,
+			Address:  deployment.Address,
+			ChainID:  deployment.ChainID,
+			Type:     string(deployment.Type),
+		})
+	}
+	return payload
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHookRunner records the hook commands it runs, failing the listed ones
+type fakeHookRunner struct {
+	mu       sync.Mutex
+	failing  map[string]bool
+	commands []string
+	env      map[string]map[string]string
+	payloads map[string]*HookPayload
+}
+
+func (f *fakeHookRunner) RunHook(_ context.Context, command string, env map[string]string, stdin []byte) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commands = append(f.commands, command)
+	f.env[command] = env
+
+	payload := &HookPayload{}
+	if err := json.Unmarshal(stdin, payload); err != nil {
+		return "", err
+	}
+	f.payloads[command] = payload
+	if f.failing[command] {
+		return "something went wrong", errors.New("exit status 1")
+	}
+	return "ok", nil
+}
+
+func TestComposeDeployment_Hooks(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("protocol.yaml", []byte(`group: Protocol
+hooks:
+  pre: [group-pre]
+  post: [group-post]
+  on_failure: [group-failed]
+components:
+  A:
+    script: DeployA
+    hooks:
+      pre: [a-pre]
+      post:
+        - a-post
+        - action: verify
+      on_failure: [a-failed]
+  B:
+    script: DeployB
+    deps: [A]
+`), 0644))
+
+	newCompose := func(hooks *fakeHookRunner, runner *fakeScriptRunner) *ComposeDeployment {
+		return &ComposeDeployment{runScript: runner, hooks: NewComposeHooks(nil, hooks, nil, nil, NewRegistryLock()), progress: nopComposeSink{}}
+	}
+	newHookRunner := func(failing ...string) *fakeHookRunner {
+		hooks := &fakeHookRunner{failing: map[string]bool{}, env: map[string]map[string]string{}, payloads: map[string]*HookPayload{}}
+		for _, command := range failing {
+			hooks.failing[command] = true
+		}
+		return hooks
+	}
+
+	t.Run("hooks run around components and the compose run", func(t *testing.T) {
+		hooks := newHookRunner()
+		runner := &fakeScriptRunner{broadcastDirs: map[string]string{}}
+		result, err := newCompose(hooks, runner).Execute(context.Background(), ComposeParams{ConfigPath: "protocol.yaml", DryRun: true})
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+
+		assert.Equal(t, []string{"group-pre", "a-pre", "a-post", "group-post"}, hooks.commands)
+		assert.Equal(t, "A", hooks.env["a-post"]["TREB_COMPONENT"])
+		assert.Equal(t, "post", hooks.env["a-post"]["TREB_HOOK"])
+		assert.Equal(t, "true", hooks.env["a-post"]["TREB_DRY_RUN"])
+		assert.Equal(t, "default/31337/DeployA", hooks.env["a-post"]["TREB_DEPLOYMENTS"])
+		require.Len(t, hooks.payloads["a-post"].Deployments, 1)
+		assert.Equal(t, common.BytesToAddress([]byte("A")).Hex(), hooks.payloads["a-post"].Deployments[0].Address)
+		assert.Equal(t, "completed", hooks.payloads["a-post"].Status)
+
+		stepA := result.ExecutedSteps[0]
+		require.Len(t, stepA.Hooks, 3)
+		assert.Equal(t, "verify", stepA.Hooks[2].Action)
+		assert.Equal(t, "skipped in dry run", stepA.Hooks[2].Output)
+		require.Len(t, result.Hooks, 2)
+
+		state, err := (&ComposeDeployment{}).loadState("protocol.yaml")
+		require.NoError(t, err)
+		assert.Len(t, state.ExecutedSteps["A"].Hooks, 3)
+		assert.Len(t, state.Hooks, 2)
+	})
+
+	t.Run("failed pre hook fails the component before it runs", func(t *testing.T) {
+		hooks := newHookRunner("a-pre")
+		runner := &fakeScriptRunner{broadcastDirs: map[string]string{}}
+		result, err := newCompose(hooks, runner).Execute(context.Background(), ComposeParams{ConfigPath: "protocol.yaml"})
+		require.NoError(t, err)
+		assert.False(t, result.Success)
+
+		assert.Empty(t, runner.ran)
+		assert.ErrorContains(t, result.FailedStep.Error, "pre hook 'a-pre' failed: exit status 1")
+		assert.Equal(t, []string{"group-pre", "a-pre", "a-failed", "group-failed"}, hooks.commands)
+		assert.Equal(t, "failed", hooks.payloads["a-failed"].Status)
+		assert.Equal(t, "something went wrong", result.FailedStep.Hooks[0].Output)
+	})
+
+	t.Run("failed group pre hook runs nothing", func(t *testing.T) {
+		hooks := newHookRunner("group-pre")
+		runner := &fakeScriptRunner{broadcastDirs: map[string]string{}}
+		result, err := newCompose(hooks, runner).Execute(context.Background(), ComposeParams{ConfigPath: "protocol.yaml"})
+		require.NoError(t, err)
+		assert.False(t, result.Success)
+		assert.Nil(t, result.FailedStep)
+		assert.ErrorContains(t, result.HookError, "pre hook 'group-pre' failed")
+		assert.Empty(t, runner.ran)
+		assert.Equal(t, []string{"group-pre", "group-failed"}, hooks.commands)
+	})
+
+	t.Run("resume after a failed post hook only runs the post hooks", func(t *testing.T) {
+		hooks := newHookRunner("a-post")
+		runner := &fakeScriptRunner{broadcastDirs: map[string]string{}}
+		result, err := newCompose(hooks, runner).Execute(context.Background(), ComposeParams{ConfigPath: "protocol.yaml", DryRun: true})
+		require.NoError(t, err)
+		assert.False(t, result.Success)
+		assert.Equal(t, []string{"A"}, runner.ran)
+
+		state, err := (&ComposeDeployment{}).loadState("protocol.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, StepStatusPostHookFailed, state.ExecutedSteps["A"].Status)
+		assert.NotEmpty(t, state.ExecutedSteps["A"].Outputs)
+
+		hooks = newHookRunner()
+		runner = &fakeScriptRunner{broadcastDirs: map[string]string{}}
+		result, err = newCompose(hooks, runner).Execute(context.Background(), ComposeParams{ConfigPath: "protocol.yaml", DryRun: true, Resume: true})
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, []string{"B"}, runner.ran)
+		assert.Equal(t, []string{"group-pre", "a-post", "group-post"}, hooks.commands)
+
+		state, err = (&ComposeDeployment{}).loadState("protocol.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, StepStatusCompleted, state.ExecutedSteps["A"].Status)
+		assert.Len(t, state.ExecutedSteps["A"].Hooks, 5)
+	})
+}
+
+func TestHooksConfig_Validate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("compose.yaml", []byte(`group: G
+components:
+  A:
+    script: DeployA
+    hooks:
+      post:
+        - action: publish
+`), 0644))
+
+	_, err := (&ComposeDeployment{}).loadPlan("compose.yaml", nil)
+	assert.ErrorContains(t, err, "compose.yaml:5: component 'A' hooks: unknown hook action 'publish'")
+
+	for _, hook := range []HookConfig{{Action: "tag"}, {Action: "verify now"}, {Run: "echo", Action: "check"}, {}} {
+		assert.Error(t, hook.validate(), hook)
+	}
+	for _, hook := range []HookConfig{{Action: "tag v1"}, {Action: "check"}, {Run: "echo done"}} {
+		assert.NoError(t, hook.validate(), hook)
+	}
+}
@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// composeTemplatePattern matches ${{ expression }} in component env values
+var composeTemplatePattern = regexp.MustCompile(`\$\{\{\s*(.*?)\s*\}\}`)
+
+// composeTemplateContext resolves template expressions against the runtime configuration
+// and the outputs of completed steps
+type composeTemplateContext struct {
+	namespace string
+	network   *config.Network // Network of the step the templates belong to
+	steps     map[string]*StepStateInfo
+}
+
+// resolveEnv substitutes the templates in the env of a step
+func (c *composeTemplateContext) resolveEnv(env map[string]string) (map[string]string, error) {
+	if env == nil {
+		return nil, nil
+	}
+	resolved := make(map[string]string, len(env))
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		var resolveErr error
+		resolved[key] = composeTemplatePattern.ReplaceAllStringFunc(env[key], func(match string) string {
+			expression := composeTemplatePattern.FindStringSubmatch(match)[1]
+			value, err := c.resolve(expression)
+			if err != nil && resolveErr == nil {
+				resolveErr = fmt.Errorf("env %s: %w", key, err)
+			}
+			return value
+		})
+		if resolveErr != nil {
+			return nil, resolveErr
+		}
+	}
+	return resolved, nil
+}
+
+// resolve evaluates a single template expression
+func (c *composeTemplateContext) resolve(expression string) (string, error) {
+	parts := strings.Split(expression, ".")
+	switch {
+	case expression == "namespace":
+		return c.namespace, nil
+	case parts[0] == "network" && len(parts) == 2:
+		if c.network == nil {
+			return "", fmt.Errorf("${{ %s }} requires a network", expression)
+		}
+		switch parts[1] {
+		case "name":
+			return c.network.Name, nil
+		case "chainId":
+			return strconv.FormatUint(c.network.ChainID, 10), nil
+		}
+	case parts[0] == "components" && len(parts) >= 3:
+		component, output := parts[1], strings.Join(parts[2:], ".")
+		step, ok := c.steps[component]
+		// A component running on several networks is referenced on the network of the step
+		if !ok && c.network != nil {
+			step, ok = c.steps[component+"@"+c.network.Name]
+		}
+		if !ok || !step.Success {
+			return "", fmt.Errorf("component '%s' has not completed", component)
+		}
+		value, ok := step.Outputs[output]
+		if !ok {
+			return "", fmt.Errorf("component '%s' has no output '%s' (available: %s)",
+				component, output, strings.Join(slices.Sorted(maps.Keys(step.Outputs)), ", "))
+		}
+		return value, nil
+	}
+	return "", fmt.Errorf("unknown template expression '%s'", expression)
+}
+
+// templateComponents returns the components referenced by the templates of an env
+func templateComponents(env map[string]string) []string {
+	var components []string
+	for _, value := range env {
+		for _, match := range composeTemplatePattern.FindAllStringSubmatch(value, -1) {
+			parts := strings.Split(match[1], ".")
+			if parts[0] == "components" && len(parts) >= 2 && !slices.Contains(components, parts[1]) {
+				components = append(components, parts[1])
+			}
+		}
+	}
+	slices.Sort(components)
+	return components
+}
+
+// validateTemplates checks that steps only reference the outputs of components they
+// depend on, directly or through other components, so the outputs exist when they run.
+// A component running on several networks is referenced as <component>@<network>, or by
+// its name from a step on one of its networks.
+func validateTemplates(plan *ExecutionPlan) error {
+	ancestors := make(map[string]map[string]bool, len(plan.Components))
+	exists := make(map[string]bool, len(plan.Components))
+	for _, step := range plan.Components {
+		exists[step.Name] = true
+	}
+
+	// Components are topologically sorted, so the ancestors of dependencies are known
+	for _, step := range plan.Components {
+		ancestors[step.Name] = make(map[string]bool)
+		for _, dep := range step.Dependencies {
+			ancestors[step.Name][dep] = true
+			maps.Copy(ancestors[step.Name], ancestors[dep])
+		}
+
+		for _, component := range templateComponents(step.Env) {
+			if !exists[component] && exists[component+"@"+step.Network] {
+				component += "@" + step.Network
+			}
+			if !exists[component] && slices.ContainsFunc(plan.Components, func(s *ExecutionStep) bool {
+				return strings.HasPrefix(s.Name, component+"@")
+			}) {
+				return step.source.errorf("component '%s' references '%s' which runs on several networks, use %s@<network>", step.Name, component, component)
+			}
+			if !exists[component] {
+				return step.source.errorf("component '%s' references non-existent component '%s'", step.Name, component)
+			}
+			if !ancestors[step.Name][component] {
+				return step.source.errorf("component '%s' references outputs of '%s' but does not depend on it", step.Name, component)
+			}
+		}
+	}
+	return nil
+}
+
+// stepOutputs collects the values a completed step exposes to the templates of later steps
+func stepOutputs(result *RunScriptResult) map[string]string {
+	outputs := make(map[string]string)
+	if result == nil {
+		return outputs
+	}
+	if result.RunID != "" {
+		outputs["runId"] = result.RunID
+	}
+
+	deployments := runDeployments(result)
+	contracts := make(map[string]int)
+	for _, deployment := range deployments {
+		contracts[deployment.ContractName]++
+	}
+	for _, deployment := range deployments {
+		keys := []string{deployment.GetShortID()}
+		// A labeled deployment can be referenced by its contract name when it is the only one
+		if deployment.Label != "" && contracts[deployment.ContractName] == 1 {
+			keys = append(keys, deployment.ContractName)
+		}
+		for _, key := range keys {
+			outputs["deployments."+key+".address"] = deployment.Address
+			outputs["deployments."+key+".id"] = deployment.ID
+		}
+	}
+
+	if result.Changeset != nil {
+		// Several hashes are joined with commas, the format of array parameters
+		create := result.Changeset.Create
+		var safeTxHashes, operationIDs []string
+		for _, safeTx := range create.SafeTransactions {
+			safeTxHashes = append(safeTxHashes, safeTx.SafeTxHash)
+		}
+		for _, op := range create.TimelockOperations {
+			operationIDs = append(operationIDs, op.OperationID)
+		}
+		if len(safeTxHashes) > 0 {
+			outputs["safeTxHash"] = strings.Join(safeTxHashes, ",")
+		}
+		if len(operationIDs) > 0 {
+			outputs["timelockOperationId"] = strings.Join(operationIDs, ",")
+		}
+	}
+
+	if result.RunResult != nil {
+		var proposalIDs []string
+		for _, proposal := range result.RunResult.GovernorProposals {
+			if proposal.ProposalId != nil {
+				proposalIDs = append(proposalIDs, proposal.ProposalId.String())
+			}
+		}
+		if len(proposalIDs) > 0 {
+			outputs["proposalId"] = strings.Join(proposalIDs, ",")
+		}
+	}
+	return outputs
+}
+
+// runDeployments returns the deployments a run added to the registry. Dry runs leave the
+// registry untouched and have no changeset, their deployments are taken from the simulation.
+func runDeployments(result *RunScriptResult) []*models.Deployment {
+	if result.Changeset != nil {
+		return result.Changeset.Create.Deployments
+	}
+	return simulatedDeployments(result.RunResult)
+}
+
+// simulatedDeployments returns the deployments of a run as the registry would record them
+func simulatedDeployments(runResult *forge.HydratedRunResult) []*models.Deployment {
+	if runResult == nil || runResult.RunResult == nil {
+		return nil
+	}
+	var deployments []*models.Deployment
+	for _, deployment := range runResult.Deployments {
+		var contractName, label string
+		if deployment.Contract != nil {
+			contractName = deployment.Contract.Name
+		}
+		if deployment.Event != nil {
+			label = deployment.Event.Label
+			if contractName == "" {
+				_, contractName, _ = strings.Cut(deployment.Event.Artifact, ":")
+			}
+		}
+		if contractName == "" {
+			continue
+		}
+
+		id := fmt.Sprintf("%s/%d/%s", runResult.Namespace, runResult.ChainID, contractName)
+		if label != "" {
+			id += ":" + label
+		}
+		deployments = append(deployments, &models.Deployment{
+			ID:           id,
+			Namespace:    runResult.Namespace,
+			ChainID:      runResult.ChainID,
+			ContractName: contractName,
+			Label:        label,
+			Address:      deployment.Address.Hex(),
+		})
+	}
+	return deployments
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+func TestComposeTemplateContext_ResolveEnv(t *testing.T) {
+	templates := &composeTemplateContext{
+		namespace: "production",
+		network:   &config.Network{Name: "celo", ChainID: 42220},
+		steps: map[string]*StepStateInfo{
+			"token": {Success: true, Outputs: map[string]string{
+				"deployments.Token.address":      "0x1111",
+				"deployments.Token:v1.2.address": "0x2222",
+			}},
+			"gov": {Success: false},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "static values are kept",
+			env:  map[string]string{"LABEL": "v1"},
+			want: map[string]string{"LABEL": "v1"},
+		},
+		{
+			name: "runtime values",
+			env:  map[string]string{"NS": "${{ namespace }}", "CHAIN": "${{network.chainId}}", "NAME": "${{ network.name }}"},
+			want: map[string]string{"NS": "production", "CHAIN": "42220", "NAME": "celo"},
+		},
+		{
+			name: "component outputs within text",
+			env:  map[string]string{"TOKENS": "${{ components.token.deployments.Token.address }},${{ components.token.deployments.Token:v1.2.address }}"},
+			want: map[string]string{"TOKENS": "0x1111,0x2222"},
+		},
+		{
+			name:    "failed component",
+			env:     map[string]string{"HASH": "${{ components.gov.safeTxHash }}"},
+			wantErr: "env HASH: component 'gov' has not completed",
+		},
+		{
+			name:    "missing output",
+			env:     map[string]string{"ORACLE": "${{ components.token.deployments.Oracle.address }}"},
+			wantErr: "component 'token' has no output 'deployments.Oracle.address' (available: deployments.Token.address, deployments.Token:v1.2.address)",
+		},
+		{
+			name:    "unknown expression",
+			env:     map[string]string{"X": "${{ network.rpcUrl }}"},
+			wantErr: "unknown template expression 'network.rpcUrl'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := templates.resolveEnv(tt.env)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateTemplates(t *testing.T) {
+	plan := func(components map[string]*ComponentConfig) *ExecutionPlan {
+		plan, err := (&ComposeDeployment{}).createExecutionPlan(&ComposeConfig{Group: "Protocol", Components: components}, "")
+		require.NoError(t, err)
+		return plan
+	}
+
+	assert.NoError(t, validateTemplates(plan(map[string]*ComponentConfig{
+		"token":  {Script: "DeployToken"},
+		"broker": {Script: "DeployBroker", Deps: []string{"token"}},
+		"reserve": {Script: "DeployReserve", Deps: []string{"broker"}, Env: map[string]string{
+			"TOKEN": "${{ components.token.deployments.Token.address }}",
+		}},
+	})))
+
+	err := validateTemplates(plan(map[string]*ComponentConfig{
+		"token":  {Script: "DeployToken"},
+		"broker": {Script: "DeployBroker", Env: map[string]string{"TOKEN": "${{ components.token.deployments.Token.address }}"}},
+	}))
+	assert.EqualError(t, err, "component 'broker' references outputs of 'token' but does not depend on it")
+
+	err = validateTemplates(plan(map[string]*ComponentConfig{
+		"broker": {Script: "DeployBroker", Env: map[string]string{"TOKEN": "${{ components.tokne.deployments.Token.address }}"}},
+	}))
+	assert.EqualError(t, err, "component 'broker' references non-existent component 'tokne'")
+}
+
+func TestStepOutputs(t *testing.T) {
+	changeset := &models.Changeset{}
+	changeset.Create.Deployments = []*models.Deployment{
+		{ID: "default/1/Token", ContractName: "Token", Address: "0x01"},
+		{ID: "default/1/ERC1967Proxy:token", ContractName: "ERC1967Proxy", Label: "token", Address: "0x02"},
+	}
+	changeset.Create.SafeTransactions = []*models.SafeTransaction{{SafeTxHash: "0xaa"}, {SafeTxHash: "0xbb"}}
+
+	outputs := stepOutputs(&RunScriptResult{
+		RunID:     "20250126-150405-a1b2c3",
+		Changeset: changeset,
+		RunResult: &forge.HydratedRunResult{GovernorProposals: []*forge.GovernorProposal{{ProposalId: big.NewInt(42)}}},
+	})
+
+	assert.Equal(t, map[string]string{
+		"runId":                                  "20250126-150405-a1b2c3",
+		"deployments.Token.address":              "0x01",
+		"deployments.Token.id":                   "default/1/Token",
+		"deployments.ERC1967Proxy:token.address": "0x02",
+		"deployments.ERC1967Proxy:token.id":      "default/1/ERC1967Proxy:token",
+		"deployments.ERC1967Proxy.address":       "0x02",
+		"deployments.ERC1967Proxy.id":            "default/1/ERC1967Proxy:token",
+		"safeTxHash":                             "0xaa,0xbb",
+		"proposalId":                             "42",
+	}, outputs)
+}
+
+func TestComposeDeployment_Templates(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("protocol.yaml", []byte(`group: Protocol
+components:
+  token:
+    script: DeployToken
+  broker:
+    script: DeployBroker
+    deps: [token]
+    env:
+      TOKEN: ${{ components.token.deployments.DeployToken.address }}
+      CHAIN: ${{ network.chainId }}
+`), 0644))
+
+	runner := &fakeScriptRunner{
+		broadcastDirs: map[string]string{},
+		parameters:    map[string]map[string]string{},
+		failing:       map[string]bool{"broker": true},
+	}
+	compose := &ComposeDeployment{
+		config:    &config.RuntimeConfig{Namespace: "default", Network: &config.Network{Name: "anvil", ChainID: 31337}},
+		runScript: runner,
+		progress:  nopComposeSink{},
+	}
+
+	result, err := compose.Execute(context.Background(), ComposeParams{ConfigPath: "protocol.yaml"})
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, map[string]string{"TOKEN": "0xtoken", "CHAIN": "31337"}, runner.parameters["broker"])
+
+	state, err := compose.loadState("protocol.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "0xtoken", state.ExecutedSteps["token"].Outputs["deployments.DeployToken.address"])
+	assert.Empty(t, state.ExecutedSteps["broker"].Outputs)
+
+	// The resumed run resolves the template from the persisted outputs of token
+	runner.failing = nil
+	result, err = compose.Execute(context.Background(), ComposeParams{ConfigPath: "protocol.yaml", Resume: true})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, []string{"token", "broker", "broker"}, runner.ran)
+	assert.Equal(t, "0xtoken", runner.parameters["broker"]["TOKEN"])
+}
+
+func TestComposeDeployment_DryRunTemplates(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("protocol.yaml", []byte(`group: Protocol
+components:
+  token:
+    script: DeployToken
+  broker:
+    script: DeployBroker
+    deps: [token]
+    env:
+      TOKEN: ${{ components.token.deployments.DeployToken.address }}
+      TOKEN_ID: ${{ components.token.deployments.DeployToken.id }}
+`), 0644))
+
+	runner := &fakeScriptRunner{broadcastDirs: map[string]string{}, parameters: map[string]map[string]string{}}
+	compose := &ComposeDeployment{
+		config:    &config.RuntimeConfig{Namespace: "default", Network: &config.Network{Name: "anvil", ChainID: 31337}},
+		runScript: runner,
+		progress:  nopComposeSink{},
+	}
+
+	result, err := compose.Execute(context.Background(), ComposeParams{ConfigPath: "protocol.yaml", DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, map[string]string{
+		"TOKEN":    common.BytesToAddress([]byte("token")).Hex(),
+		"TOKEN_ID": "default/31337/DeployToken",
+	}, runner.parameters["broker"])
+}
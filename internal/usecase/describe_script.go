@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// DescribeScriptParams contains parameters for describing scripts
+type DescribeScriptParams struct {
+	ScriptRef string // Script to describe, empty to describe every script under script/
+	Simulate  bool   // Dry-run the scripts to find the contracts they create
+}
+
+// DescribeScriptResult describes one or more scripts in the current namespace
+type DescribeScriptResult struct {
+	Namespace string
+	Network   *config.Network // nil when no network is selected
+	Scripts   []*ScriptDescription
+}
+
+// ScriptDescription is what a script needs and what it produces
+type ScriptDescription struct {
+	Script     *models.Contract
+	Parameters []domain.ScriptParameter
+	Senders    []*AccountInfo // Roles of @custom:senders, resolved in the current namespace
+	References []*ScriptReference
+
+	// Set when the script was simulated
+	Simulated       bool
+	Creates         []*models.Deployment
+	SimulationError string
+}
+
+// ScriptReference is a deployment a script depends on, either through a registry lookup in
+// its source or through a deployment parameter
+type ScriptReference struct {
+	Reference  string
+	Parameter  string             // Parameter the reference is passed through, empty for lookups
+	Deployment *models.Deployment // Set when the reference resolves on the current network
+	Error      string             // Why the reference could not be resolved
+}
+
+// DescribeScript is the use case for introspecting deployment scripts
+type DescribeScript struct {
+	config         *config.RuntimeConfig
+	scriptResolver ScriptResolver
+	paramResolver  ParameterResolver
+	sendersManager SendersManager
+	addresses      SenderAddressResolver
+	deployments    DeploymentResolver
+	registry       DeploymentRepositoryUpdater
+	runScript      *RunScript
+}
+
+// NewDescribeScript creates a new DescribeScript use case
+func NewDescribeScript(
+	cfg *config.RuntimeConfig,
+	scriptResolver ScriptResolver,
+	paramResolver ParameterResolver,
+	sendersManager SendersManager,
+	addresses SenderAddressResolver,
+	deployments DeploymentResolver,
+	registry DeploymentRepositoryUpdater,
+	runScript *RunScript,
+) *DescribeScript {
+	return &DescribeScript{
+		config:         cfg,
+		scriptResolver: scriptResolver,
+		paramResolver:  paramResolver,
+		sendersManager: sendersManager,
+		addresses:      addresses,
+		deployments:    deployments,
+		registry:       registry,
+		runScript:      runScript,
+	}
+}
+
+// Run executes the use case
+func (uc *DescribeScript) Run(ctx context.Context, params DescribeScriptParams) (*DescribeScriptResult, error) {
+	if params.Simulate && uc.config.Network == nil {
+		return nil, fmt.Errorf("network must be set to simulate (use 'treb config set network <name>' or --network flag)")
+	}
+
+	var scripts []*models.Contract
+	if params.ScriptRef != "" {
+		script, err := uc.scriptResolver.ResolveScript(ctx, params.ScriptRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve script: %w", err)
+		}
+		scripts = append(scripts, script)
+	} else {
+		var err error
+		if scripts, err = uc.scriptResolver.ListScripts(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &DescribeScriptResult{
+		Namespace: uc.config.Namespace,
+		Network:   uc.config.Network,
+	}
+	for _, script := range scripts {
+		description, err := uc.describe(ctx, script, params.Simulate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe %s: %w", script.Name, err)
+		}
+		result.Scripts = append(result.Scripts, description)
+	}
+	return result, nil
+}
+
+// describe gathers the parameters, senders and references of a script, and simulates it
+// when asked to
+func (uc *DescribeScript) describe(ctx context.Context, script *models.Contract, simulate bool) (*ScriptDescription, error) {
+	description := &ScriptDescription{Script: script}
+
+	var err error
+	if description.Parameters, err = uc.scriptResolver.GetScriptParameters(ctx, script); err != nil {
+		return nil, err
+	}
+
+	if script.Artifact != nil {
+		roles, err := uc.sendersManager.GetScriptSenders(script.Artifact)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range roles {
+			description.Senders = append(description.Senders, uc.describeRole(role))
+		}
+	}
+
+	if description.References, err = uc.references(ctx, script, description.Parameters); err != nil {
+		return nil, err
+	}
+
+	if simulate {
+		description.Simulated = true
+		run, err := uc.runScript.Run(ctx, RunScriptParams{
+			ScriptRef:      fmt.Sprintf("%s:%s", script.Path, script.Name),
+			DryRun:         true,
+			NonInteractive: true,
+		})
+		switch {
+		case err != nil:
+			description.SimulationError = err.Error()
+		case !run.Success && run.Error != nil:
+			description.SimulationError = run.Error.Error()
+		case !run.Success:
+			description.SimulationError = "simulation failed"
+		default:
+			if description.Creates, err = uc.simulatedCreates(ctx, run); err != nil {
+				description.SimulationError = err.Error()
+			}
+		}
+	}
+
+	return description, nil
+}
+
+// simulatedCreates returns the deployments a successful dry run would add to the registry.
+// Dry runs leave the registry untouched, so the changeset is built from the simulation.
+func (uc *DescribeScript) simulatedCreates(ctx context.Context, run *RunScriptResult) ([]*models.Deployment, error) {
+	if run.RunResult == nil {
+		return nil, nil
+	}
+	changeset, err := uc.registry.BuildChangesetFromRunResult(ctx, run.RunResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build changeset from simulation: %w", err)
+	}
+	return changeset.Create.Deployments, nil
+}
+
+// describeRole resolves a sender role in the current namespace
+func (uc *DescribeScript) describeRole(role string) *AccountInfo {
+	if uc.config.TrebConfig != nil {
+		if sender, ok := uc.config.TrebConfig.Senders[role]; ok {
+			return describeSender(uc.config, uc.addresses, role, sender)
+		}
+	}
+	return &AccountInfo{
+		Role:         role,
+		AddressError: fmt.Sprintf("not configured in namespace %s", uc.config.Namespace),
+	}
+}
+
+// references collects the registry lookups of the script source and the deployment
+// references passed through its parameters
+func (uc *DescribeScript) references(ctx context.Context, script *models.Contract, scriptParams []domain.ScriptParameter) ([]*ScriptReference, error) {
+	lookups, err := uc.scriptResolver.GetScriptReferences(ctx, script)
+	if err != nil {
+		return nil, err
+	}
+
+	var references []*ScriptReference
+	for _, ref := range lookups {
+		reference := &ScriptReference{Reference: ref}
+		uc.resolveReference(ctx, reference)
+		references = append(references, reference)
+	}
+
+	// Parameter values come from the environment and parameter files; values that fail
+	// to resolve are reported on the reference rather than failing the description
+	resolved, err := uc.paramResolver.ResolveParameters(ctx, script.Name, scriptParams, nil)
+	for _, param := range scriptParams {
+		if param.Type != domain.ParamTypeDeployment {
+			continue
+		}
+		reference := &ScriptReference{Parameter: param.Name}
+		switch {
+		case err != nil:
+			reference.Error = err.Error()
+		case resolved.Unresolved[param.Name] != nil:
+			reference.Error = resolved.Unresolved[param.Name].Error()
+		case resolved.Sources[param.Name].Reference != "":
+			reference.Reference = strings.TrimPrefix(resolved.Sources[param.Name].Reference, "@")
+		default:
+			reference.Reference = resolved.Values[param.Name]
+		}
+		if reference.Reference != "" && reference.Error == "" {
+			uc.resolveReference(ctx, reference)
+		}
+		references = append(references, reference)
+	}
+	return references, nil
+}
+
+// resolveReference looks a reference up in the registry of the current namespace and network
+func (uc *DescribeScript) resolveReference(ctx context.Context, reference *ScriptReference) {
+	if uc.config.Network == nil {
+		return
+	}
+	deployment, err := uc.deployments.ResolveDeployment(ctx, domain.DeploymentQuery{
+		Reference: reference.Reference,
+		ChainID:   uc.config.Network.ChainID,
+		Namespace: uc.config.Namespace,
+	})
+	if err != nil {
+		reference.Error = err.Error()
+		return
+	}
+	reference.Deployment = deployment
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// stubScriptResolver serves fixed scripts with their parameters and lookups
+type stubScriptResolver struct {
+	scripts    []*models.Contract
+	params     map[string][]domain.ScriptParameter
+	references map[string][]string
+}
+
+func (s stubScriptResolver) ResolveScript(_ context.Context, ref string) (*models.Contract, error) {
+	for _, script := range s.scripts {
+		if script.Name == ref || script.Path+":"+script.Name == ref {
+			return script, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (s stubScriptResolver) GetScriptParameters(_ context.Context, script *models.Contract) ([]domain.ScriptParameter, error) {
+	return s.params[script.Name], nil
+}
+
+func (s stubScriptResolver) ListScripts(context.Context) ([]*models.Contract, error) {
+	return s.scripts, nil
+}
+
+func (s stubScriptResolver) GetScriptReferences(_ context.Context, script *models.Contract) ([]string, error) {
+	return s.references[script.Name], nil
+}
+
+// stubScriptSenders declares the same senders for every script
+type stubScriptSenders struct {
+	SendersManager
+	roles []string
+}
+
+func (s stubScriptSenders) GetScriptSenders(*models.Artifact) ([]string, error) {
+	return s.roles, nil
+}
+
+// stubParameterResolver returns fixed resolved values
+type stubParameterResolver struct {
+	ParameterResolver
+	resolved *domain.ResolvedParameters
+}
+
+func (s stubParameterResolver) ResolveParameters(context.Context, string, []domain.ScriptParameter, map[string]string) (*domain.ResolvedParameters, error) {
+	return s.resolved, nil
+}
+
+func TestDescribeScript(t *testing.T) {
+	deployScript := &models.Contract{Name: "DeployToken", Path: "script/DeployToken.s.sol", Artifact: &models.Artifact{}}
+	upgradeScript := &models.Contract{Name: "UpgradeToken", Path: "script/UpgradeToken.s.sol", Artifact: &models.Artifact{}}
+	scripts := stubScriptResolver{
+		scripts: []*models.Contract{deployScript, upgradeScript},
+		params: map[string][]domain.ScriptParameter{
+			"DeployToken": {{Name: "OWNER", Type: domain.ParamTypeAddress}},
+			"UpgradeToken": {
+				{Name: "PROXY", Type: domain.ParamTypeDeployment},
+				{Name: "OLD", Type: domain.ParamTypeDeployment, Optional: true},
+			},
+		},
+		references: map[string][]string{"UpgradeToken": {"Registry:v1", "Missing"}},
+	}
+	registry := map[string]*models.Deployment{
+		"Registry:v1": {ID: "production/1/Registry:v1", Address: "0x0000000000000000000000000000000000000001"},
+		"Token:v2":    {ID: "production/1/Token:v2", Address: "0x0000000000000000000000000000000000000002"},
+	}
+	var queries []domain.DeploymentQuery
+	deployments := &mockDeploymentResolver{resolveFunc: func(_ context.Context, query domain.DeploymentQuery) (*models.Deployment, error) {
+		queries = append(queries, query)
+		if deployment, ok := registry[query.Reference]; ok {
+			return deployment, nil
+		}
+		return nil, domain.ErrNotFound
+	}}
+	params := stubParameterResolver{resolved: &domain.ResolvedParameters{
+		Values:  map[string]string{"PROXY": "0x0000000000000000000000000000000000000002"},
+		Sources: map[string]domain.ParameterSource{"PROXY": {Kind: domain.ParamSourceFile, Reference: "@Token:v2"}},
+	}}
+	cfg := &config.RuntimeConfig{
+		Namespace: "production",
+		Network:   &config.Network{Name: "mainnet", ChainID: 1},
+		TrebConfig: &config.TrebConfig{Senders: map[string]config.SenderConfig{
+			"deployer": {Type: config.SenderTypePrivateKey},
+		}},
+	}
+	uc := NewDescribeScript(cfg, scripts, params, stubScriptSenders{roles: []string{"deployer", "governance"}},
+		mockSenderAddresses{"deployer": testDeployer}, deployments, nil, nil)
+
+	result, err := uc.Run(context.Background(), DescribeScriptParams{})
+	require.NoError(t, err)
+	require.Len(t, result.Scripts, 2)
+
+	deploy := result.Scripts[0]
+	assert.Equal(t, "DeployToken", deploy.Script.Name)
+	require.Len(t, deploy.Senders, 2)
+	assert.Equal(t, testDeployer, deploy.Senders[0].Address)
+	assert.Equal(t, config.SenderTypePrivateKey, deploy.Senders[0].Type)
+	assert.Equal(t, "not configured in namespace production", deploy.Senders[1].AddressError)
+	assert.Empty(t, deploy.References)
+	assert.False(t, deploy.Simulated)
+
+	upgrade := result.Scripts[1]
+	require.Len(t, upgrade.References, 4)
+	assert.Equal(t, "production/1/Registry:v1", upgrade.References[0].Deployment.ID)
+	assert.Empty(t, upgrade.References[0].Parameter)
+	assert.Nil(t, upgrade.References[1].Deployment)
+	assert.Equal(t, domain.ErrNotFound.Error(), upgrade.References[1].Error)
+	assert.Equal(t, "Token:v2", upgrade.References[2].Reference)
+	assert.Equal(t, "PROXY", upgrade.References[2].Parameter)
+	assert.Equal(t, "production/1/Token:v2", upgrade.References[2].Deployment.ID)
+	assert.Equal(t, "OLD", upgrade.References[3].Parameter)
+	assert.Empty(t, upgrade.References[3].Reference)
+
+	assert.Equal(t, domain.DeploymentQuery{Reference: "Registry:v1", ChainID: 1, Namespace: "production"}, queries[0])
+
+	t.Run("single script", func(t *testing.T) {
+		result, err := uc.Run(context.Background(), DescribeScriptParams{ScriptRef: "UpgradeToken"})
+		require.NoError(t, err)
+		require.Len(t, result.Scripts, 1)
+		assert.Equal(t, "UpgradeToken", result.Scripts[0].Script.Name)
+	})
+
+	t.Run("simulation needs a network", func(t *testing.T) {
+		noNetwork := *cfg
+		noNetwork.Network = nil
+		uc := NewDescribeScript(&noNetwork, scripts, params, stubScriptSenders{}, mockSenderAddresses{}, deployments, nil, nil)
+		_, err := uc.Run(context.Background(), DescribeScriptParams{Simulate: true})
+		assert.ErrorContains(t, err, "network must be set to simulate")
+	})
+}
+
+func TestDescribeScript_Simulate(t *testing.T) {
+	runner := &stubForgeRunner{}
+	registry := &memoryRegistry{}
+	runScript := newTestRunScript(runner, registry, nil)
+	uc := NewDescribeScript(runScript.config, runScript.scriptResolver, stubParameterResolver{resolved: &domain.ResolvedParameters{}},
+		stubScriptSenders{}, mockSenderAddresses{}, &mockDeploymentResolver{}, registry, runScript)
+
+	result, err := uc.Run(context.Background(), DescribeScriptParams{ScriptRef: "DeployCounter", Simulate: true})
+	require.NoError(t, err)
+	require.Len(t, result.Scripts, 1)
+
+	description := result.Scripts[0]
+	assert.True(t, description.Simulated)
+	assert.Empty(t, description.SimulationError)
+	require.Len(t, description.Creates, 1)
+	assert.Equal(t, "default/31337/Counter", description.Creates[0].ID)
+	assert.Equal(t, []bool{true}, runner.dryRuns())
+	assert.Empty(t, registry.applied, "a simulation leaves the registry untouched")
+}
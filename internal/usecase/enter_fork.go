@@ -1,11 +1,13 @@
 package usecase
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -46,6 +48,8 @@ type EnterForkParams struct {
 	RPCURL     string // resolved RPC URL (after env var expansion)
 	ChainID    uint64 // chain ID
 	EnvVarName string // env var name that foundry.toml uses for the RPC endpoint
+	Block      string // block number or tag to pin the fork to (empty = [fork] block of treb.toml, or latest)
+	SkipSetup  bool   // don't run the SetupFork script (e.g. when importing a fork that already ran it)
 }
 
 // EnterForkResult contains the result of entering fork mode
@@ -68,6 +72,13 @@ func (uc *EnterFork) Execute(ctx context.Context, params EnterForkParams) (*Ente
 		return nil, fmt.Errorf("fork already active for network '%s'. Run 'treb fork exit %s' first", params.Network, params.Network)
 	}
 
+	// Resolve the block to pin the fork to before starting anvil
+	block := cmp.Or(params.Block, uc.cfg.ForkBlock)
+	forkBlock, err := resolveForkBlock(ctx, uc.anvilManager, params.RPCURL, block)
+	if err != nil {
+		return nil, err
+	}
+
 	// Find available port
 	port, err := getAvailablePort()
 	if err != nil {
@@ -81,12 +92,13 @@ func (uc *EnterFork) Execute(ctx context.Context, params EnterForkParams) (*Ente
 	}
 
 	instance := &domain.AnvilInstance{
-		Name:    fmt.Sprintf("fork-%s", params.Network),
-		Port:    fmt.Sprintf("%d", port),
-		ChainID: fmt.Sprintf("%d", params.ChainID),
-		ForkURL: params.RPCURL,
-		PidFile: filepath.Join(privDir, fmt.Sprintf("fork-%s.pid", params.Network)),
-		LogFile: filepath.Join(privDir, fmt.Sprintf("fork-%s.log", params.Network)),
+		Name:      fmt.Sprintf("fork-%s", params.Network),
+		Port:      fmt.Sprintf("%d", port),
+		ChainID:   fmt.Sprintf("%d", params.ChainID),
+		ForkURL:   params.RPCURL,
+		ForkBlock: forkBlock,
+		PidFile:   filepath.Join(privDir, fmt.Sprintf("fork-%s.pid", params.Network)),
+		LogFile:   filepath.Join(privDir, fmt.Sprintf("fork-%s.log", params.Network)),
 	}
 
 	// Start anvil (includes CreateX deployment)
@@ -105,17 +117,25 @@ func (uc *EnterFork) Execute(ctx context.Context, params EnterForkParams) (*Ente
 		return nil, fmt.Errorf("fork anvil started but is not healthy")
 	}
 
-	// Build fork env overrides for setup script execution
+	// Record the block an unpinned fork was created from (before SetupFork mines any)
 	forkURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+	if forkBlock == 0 {
+		forkBlock, _ = uc.anvilManager.GetBlockNumber(ctx, forkURL, "latest")
+	}
+
+	// Build fork env overrides for setup script execution
 	forkEnvOverrides := map[string]string{
 		params.EnvVarName: forkURL,
 	}
 
 	// Execute SetupFork script if configured
-	setupScriptRan, err := uc.executeSetupFork(ctx, params, forkEnvOverrides)
-	if err != nil {
-		_ = uc.anvilManager.Stop(ctx, instance)
-		return nil, fmt.Errorf("setup fork script failed: %w", err)
+	setupScriptRan := false
+	if !params.SkipSetup {
+		setupScriptRan, err = uc.executeSetupFork(ctx, params, forkEnvOverrides)
+		if err != nil {
+			_ = uc.anvilManager.Stop(ctx, instance)
+			return nil, fmt.Errorf("setup fork script failed: %w", err)
+		}
 	}
 
 	// Backup registry files to snapshot 0 (AFTER SetupFork so snapshot captures setup state)
@@ -138,6 +158,8 @@ func (uc *EnterFork) Execute(ctx context.Context, params EnterForkParams) (*Ente
 		EnvVarName:  params.EnvVarName,
 		OriginalRPC: params.RPCURL,
 		ForkURL:     forkURL,
+		ForkBlock:   forkBlock,
+		BlockPinned: block != "",
 		AnvilPID:    status.PID,
 		PidFile:     instance.PidFile,
 		LogFile:     instance.LogFile,
@@ -228,6 +250,22 @@ func (uc *EnterFork) executeSetupFork(ctx context.Context, params EnterForkParam
 	return true, nil
 }
 
+// resolveForkBlock resolves a block number or tag (latest, safe, finalized, ...) on the
+// network to the block number to fork at. An empty block returns 0, forking the latest block.
+func resolveForkBlock(ctx context.Context, anvilManager AnvilManager, rpcURL, block string) (uint64, error) {
+	if block == "" {
+		return 0, nil
+	}
+	if number, err := strconv.ParseUint(block, 10, 64); err == nil {
+		return number, nil
+	}
+	number, err := anvilManager.GetBlockNumber(ctx, rpcURL, block)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve fork block '%s': %w", block, err)
+	}
+	return number, nil
+}
+
 // getAvailablePort finds an available TCP port
 func getAvailablePort() (int, error) {
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
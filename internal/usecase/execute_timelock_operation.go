@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// ExecuteTimelockOperationParams contains parameters for executing a timelock operation
+type ExecuteTimelockOperationParams struct {
+	OperationID string // Operation ID or a unique prefix of it
+	Sender      string // Sender that executes the operation, defaults to the sender that scheduled it
+}
+
+// ExecuteTimelockOperationResult contains the result of executing a timelock operation
+type ExecuteTimelockOperationResult struct {
+	Operation       *models.TimelockOperation
+	Sender          string
+	TxHash          string
+	BlockNumber     uint64
+	AlreadyExecuted bool // Executed outside of treb, only the registry was updated
+}
+
+// ExecuteTimelockOperation executes operations scheduled on a TimelockController once their delay has passed
+type ExecuteTimelockOperation struct {
+	config         *config.RuntimeConfig
+	repo           DeploymentRepository
+	timelockClient TimelockClient
+	signers        TransactionSigners
+	addresses      SenderAddressResolver
+	forkStateStore ForkStateStore
+	progress       ProgressSink
+}
+
+// NewExecuteTimelockOperation creates a new ExecuteTimelockOperation use case
+func NewExecuteTimelockOperation(
+	config *config.RuntimeConfig,
+	repo DeploymentRepository,
+	timelockClient TimelockClient,
+	signers TransactionSigners,
+	addresses SenderAddressResolver,
+	forkStateStore ForkStateStore,
+	progress ProgressSink,
+) *ExecuteTimelockOperation {
+	return &ExecuteTimelockOperation{
+		config:         config,
+		repo:           repo,
+		timelockClient: timelockClient,
+		signers:        signers,
+		addresses:      addresses,
+		forkStateStore: forkStateStore,
+		progress:       progress,
+	}
+}
+
+// Run executes the timelock operation
+func (uc *ExecuteTimelockOperation) Run(ctx context.Context, params ExecuteTimelockOperationParams) (*ExecuteTimelockOperationResult, error) {
+	if uc.config.Network == nil {
+		return nil, fmt.Errorf("network is required to execute a timelock operation")
+	}
+
+	op, err := uc.findOperation(ctx, params.OperationID)
+	if err != nil {
+		return nil, err
+	}
+	if op.Status == models.TransactionStatusExecuted {
+		return nil, fmt.Errorf("timelock operation %s was already executed in transaction %s", op.OperationID, op.ExecutionTxHash)
+	}
+
+	uc.progress.OnProgress(ctx, ProgressEvent{
+		Stage:   "timelock",
+		Message: fmt.Sprintf("Checking operation on timelock %s", op.TimelockAddress),
+		Spinner: true,
+	})
+
+	if err := uc.timelockClient.Connect(ctx, networkRPCURL(ctx, uc.forkStateStore, uc.config.Network), uc.config.Network.ChainID); err != nil {
+		return nil, err
+	}
+
+	state, err := uc.timelockClient.GetOperationState(ctx, common.HexToAddress(op.TimelockAddress), common.HexToHash(op.OperationID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExecuteTimelockOperationResult{Operation: op}
+	switch {
+	case state.Done:
+		// Executed by someone else, bring the registry up to date
+		result.AlreadyExecuted = true
+		if err := uc.markExecuted(ctx, op, "", 0); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case !state.Scheduled:
+		return nil, fmt.Errorf("timelock operation %s is not scheduled on %s, it may have been canceled", op.OperationID, op.TimelockAddress)
+	case !state.Ready:
+		return nil, fmt.Errorf("timelock operation %s is not ready until %s (in %s)",
+			op.OperationID, state.ReadyAt.Format(time.RFC3339), time.Until(state.ReadyAt).Round(time.Second))
+	}
+
+	senderName := params.Sender
+	if senderName == "" {
+		if senderName, err = uc.defaultSender(op); err != nil {
+			return nil, err
+		}
+	}
+
+	from, signer, err := uc.signers.TransactionSigner(ctx, senderName, new(big.Int).SetUint64(uc.config.Network.ChainID))
+	if err != nil {
+		return nil, err
+	}
+
+	uc.progress.OnProgress(ctx, ProgressEvent{
+		Stage:   "timelock",
+		Message: fmt.Sprintf("Executing operation from %s (%s)", senderName, from.Hex()),
+		Spinner: true,
+	})
+
+	txHash, blockNumber, err := uc.timelockClient.Execute(ctx, op, from, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.markExecuted(ctx, op, txHash.Hex(), blockNumber); err != nil {
+		return nil, err
+	}
+
+	result.Sender = senderName
+	result.TxHash = txHash.Hex()
+	result.BlockNumber = blockNumber
+	return result, nil
+}
+
+// findOperation resolves an operation ID, or a unique prefix of one, on the current chain
+func (uc *ExecuteTimelockOperation) findOperation(ctx context.Context, ref string) (*models.TimelockOperation, error) {
+	ops, err := uc.repo.ListTimelockOperations(ctx, domain.TimelockOperationFilter{
+		ChainID: uc.config.Network.ChainID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list timelock operations: %w", err)
+	}
+
+	ref = strings.ToLower(ref)
+	if !strings.HasPrefix(ref, "0x") {
+		ref = "0x" + ref
+	}
+
+	var matches []*models.TimelockOperation
+	for _, op := range ops {
+		id := strings.ToLower(op.OperationID)
+		if id == ref {
+			return op, nil
+		}
+		if strings.HasPrefix(id, ref) {
+			matches = append(matches, op)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("timelock operation %s not found on chain %d", ref, uc.config.Network.ChainID)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("timelock operation prefix %s is ambiguous, it matches %d operations", ref, len(matches))
+	}
+}
+
+// defaultSender returns the configured sender that scheduled the operation, when it can sign
+// transactions itself. Senders are checked in name order so that the choice does not depend
+// on map iteration.
+func (uc *ExecuteTimelockOperation) defaultSender(op *models.TimelockOperation) (string, error) {
+	if uc.config.TrebConfig != nil && common.IsHexAddress(op.ScheduledBy) {
+		scheduledBy := common.HexToAddress(op.ScheduledBy)
+		senders := uc.config.TrebConfig.Senders
+		for _, name := range slices.Sorted(maps.Keys(senders)) {
+			switch senders[name].Type {
+			case config.SenderTypePrivateKey, config.SenderTypeKeystore, config.SenderTypeRemoteSigner:
+			default:
+				continue
+			}
+			if address, err := uc.addresses.SenderAddress(name); err == nil && address == scheduledBy {
+				return name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no sender configured that can sign as %s, which scheduled operation %s, choose the executor with --sender",
+		op.ScheduledBy, op.OperationID)
+}
+
+// markExecuted records the execution on the operation and the transactions it contains.
+// An empty txHash is an execution outside of treb whose transaction is unknown, it leaves
+// the recorded hashes and block numbers alone.
+func (uc *ExecuteTimelockOperation) markExecuted(ctx context.Context, op *models.TimelockOperation, txHash string, blockNumber uint64) error {
+	now := time.Now()
+	op.Status = models.TransactionStatusExecuted
+	op.ExecutedAt = &now
+	if txHash != "" {
+		op.ExecutionTxHash = txHash
+	}
+	if err := uc.repo.UpdateTimelockOperation(ctx, op); err != nil {
+		return fmt.Errorf("failed to update timelock operation: %w", err)
+	}
+
+	for _, txID := range op.TransactionIDs {
+		tx, err := uc.repo.GetTransaction(ctx, txID)
+		if err != nil {
+			continue
+		}
+		tx.Status = models.TransactionStatusExecuted
+		if txHash != "" {
+			tx.Hash = txHash
+			tx.BlockNumber = blockNumber
+		}
+		if err := uc.repo.SaveTransaction(ctx, tx); err != nil {
+			return fmt.Errorf("failed to update transaction %s: %w", txID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+const (
+	testTimelock = "0x1234567890123456789012345678901234567890"
+	testProposer = "0x4444444444444444444444444444444444444444"
+)
+
+// mockTimelockRepo keeps timelock operations and transactions in memory
+type mockTimelockRepo struct {
+	DeploymentRepository // embed to satisfy interface
+	ops                  map[string]*models.TimelockOperation
+	transactions         map[string]*models.Transaction
+}
+
+func (m *mockTimelockRepo) ListTimelockOperations(_ context.Context, filter domain.TimelockOperationFilter) ([]*models.TimelockOperation, error) {
+	var result []*models.TimelockOperation
+	for _, op := range m.ops {
+		if filter.ChainID == 0 || op.ChainID == filter.ChainID {
+			result = append(result, op)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockTimelockRepo) UpdateTimelockOperation(_ context.Context, op *models.TimelockOperation) error {
+	m.ops[op.OperationID] = op
+	return nil
+}
+
+func (m *mockTimelockRepo) GetTransaction(_ context.Context, id string) (*models.Transaction, error) {
+	if tx, ok := m.transactions[id]; ok {
+		return tx, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockTimelockRepo) SaveTransaction(_ context.Context, tx *models.Transaction) error {
+	m.transactions[tx.ID] = tx
+	return nil
+}
+
+// mockTimelockClient returns a fixed operation state and records executions
+type mockTimelockClient struct {
+	state    *domain.TimelockOperationState
+	executed []common.Address
+}
+
+func (m *mockTimelockClient) Connect(context.Context, string, uint64) error { return nil }
+
+func (m *mockTimelockClient) GetOperationState(context.Context, common.Address, common.Hash) (*domain.TimelockOperationState, error) {
+	return m.state, nil
+}
+
+func (m *mockTimelockClient) Execute(_ context.Context, _ *models.TimelockOperation, from common.Address, _ bind.SignerFn) (common.Hash, uint64, error) {
+	m.executed = append(m.executed, from)
+	return common.HexToHash("0xabc"), 42, nil
+}
+
+// mockTransactionSigners hands out a signer for any sender and records which was asked for
+type mockTransactionSigners struct {
+	requested []string
+}
+
+func (m *mockTransactionSigners) TransactionSigner(_ context.Context, sender string, _ *big.Int) (common.Address, bind.SignerFn, error) {
+	m.requested = append(m.requested, sender)
+	return common.HexToAddress("0x99"), func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) { return tx, nil }, nil
+}
+
+func newTimelockTestSetup(state *domain.TimelockOperationState) (*ExecuteTimelockOperation, *mockTimelockRepo, *mockTimelockClient, *mockTransactionSigners) {
+	operationID := common.HexToHash("0xfeed").Hex()
+	repo := &mockTimelockRepo{
+		ops: map[string]*models.TimelockOperation{
+			operationID: {
+				OperationID:     operationID,
+				TimelockAddress: testTimelock,
+				ChainID:         1,
+				Status:          models.TransactionStatusQueued,
+				TransactionIDs:  []string{"tx-internal-01"},
+				ScheduledBy:     testProposer,
+			},
+		},
+		transactions: map[string]*models.Transaction{
+			"tx-internal-01": {ID: "tx-internal-01", Status: models.TransactionStatusQueued},
+		},
+	}
+	client := &mockTimelockClient{state: state}
+	signers := &mockTransactionSigners{}
+	cfg := &config.RuntimeConfig{
+		Network: &config.Network{Name: "mainnet", ChainID: 1},
+		TrebConfig: &config.TrebConfig{
+			Senders: map[string]config.SenderConfig{
+				"deployer": {Type: config.SenderTypePrivateKey},
+				"proposer": {Type: config.SenderTypePrivateKey},
+				"safe":     {Type: config.SenderTypeSafe, Safe: testProposer, Signer: "deployer"},
+			},
+		},
+	}
+
+	addresses := mockSenderAddresses{
+		"deployer": common.HexToAddress("0x01"),
+		"proposer": common.HexToAddress(testProposer),
+		"safe":     common.HexToAddress(testProposer),
+	}
+
+	uc := NewExecuteTimelockOperation(cfg, repo, client, signers, addresses, &mockForkState{}, NopProgress{})
+	return uc, repo, client, signers
+}
+
+func TestExecuteTimelockOperation(t *testing.T) {
+	operationID := common.HexToHash("0xfeed").Hex()
+
+	t.Run("executes a ready operation from the sender that scheduled it", func(t *testing.T) {
+		uc, repo, client, signers := newTimelockTestSetup(&domain.TimelockOperationState{Scheduled: true, Ready: true})
+
+		// The ID may be given as a prefix
+		result, err := uc.Run(t.Context(), ExecuteTimelockOperationParams{OperationID: operationID[:len(operationID)-2]})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"proposer"}, signers.requested)
+		assert.Equal(t, []common.Address{common.HexToAddress("0x99")}, client.executed)
+		assert.Equal(t, common.HexToHash("0xabc").Hex(), result.TxHash)
+		assert.Equal(t, uint64(42), result.BlockNumber)
+
+		op := repo.ops[operationID]
+		assert.Equal(t, models.TransactionStatusExecuted, op.Status)
+		assert.Equal(t, result.TxHash, op.ExecutionTxHash)
+		assert.NotNil(t, op.ExecutedAt)
+
+		tx := repo.transactions["tx-internal-01"]
+		assert.Equal(t, models.TransactionStatusExecuted, tx.Status)
+		assert.Equal(t, result.TxHash, tx.Hash)
+		assert.Equal(t, uint64(42), tx.BlockNumber)
+	})
+
+	t.Run("uses the given sender", func(t *testing.T) {
+		uc, _, _, signers := newTimelockTestSetup(&domain.TimelockOperationState{Scheduled: true, Ready: true})
+
+		_, err := uc.Run(t.Context(), ExecuteTimelockOperationParams{OperationID: operationID, Sender: "executor"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"executor"}, signers.requested)
+	})
+
+	t.Run("refuses operations whose delay has not passed", func(t *testing.T) {
+		uc, repo, client, _ := newTimelockTestSetup(&domain.TimelockOperationState{
+			Scheduled: true,
+			ReadyAt:   time.Now().Add(time.Hour),
+		})
+
+		_, err := uc.Run(t.Context(), ExecuteTimelockOperationParams{OperationID: operationID})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not ready until")
+		assert.Empty(t, client.executed)
+		assert.Equal(t, models.TransactionStatusQueued, repo.ops[operationID].Status)
+	})
+
+	t.Run("records operations executed elsewhere", func(t *testing.T) {
+		uc, repo, client, _ := newTimelockTestSetup(&domain.TimelockOperationState{Done: true})
+		repo.transactions["tx-internal-01"].Hash = "0x01"
+		repo.transactions["tx-internal-01"].BlockNumber = 7
+
+		result, err := uc.Run(t.Context(), ExecuteTimelockOperationParams{OperationID: operationID})
+		require.NoError(t, err)
+		assert.True(t, result.AlreadyExecuted)
+		assert.Empty(t, client.executed)
+		assert.Equal(t, models.TransactionStatusExecuted, repo.ops[operationID].Status)
+
+		// The executing transaction is unknown, what was recorded is kept
+		tx := repo.transactions["tx-internal-01"]
+		assert.Equal(t, models.TransactionStatusExecuted, tx.Status)
+		assert.Equal(t, "0x01", tx.Hash)
+		assert.Equal(t, uint64(7), tx.BlockNumber)
+	})
+
+	t.Run("needs --sender when the scheduler cannot sign", func(t *testing.T) {
+		uc, _, client, signers := newTimelockTestSetup(&domain.TimelockOperationState{Scheduled: true, Ready: true})
+		// Only the Safe acts as the scheduling address, and it cannot sign the execution
+		delete(uc.config.TrebConfig.Senders, "proposer")
+
+		_, err := uc.Run(t.Context(), ExecuteTimelockOperationParams{OperationID: operationID})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no sender configured that can sign as "+testProposer+", which scheduled operation "+operationID+", choose the executor with --sender")
+		assert.Empty(t, signers.requested)
+		assert.Empty(t, client.executed)
+	})
+
+	t.Run("refuses canceled operations", func(t *testing.T) {
+		uc, _, _, _ := newTimelockTestSetup(&domain.TimelockOperationState{})
+
+		_, err := uc.Run(t.Context(), ExecuteTimelockOperationParams{OperationID: operationID})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not scheduled")
+	})
+
+	t.Run("unknown operation", func(t *testing.T) {
+		uc, _, _, _ := newTimelockTestSetup(&domain.TimelockOperationState{Scheduled: true, Ready: true})
+
+		_, err := uc.Run(t.Context(), ExecuteTimelockOperationParams{OperationID: "0xdead"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
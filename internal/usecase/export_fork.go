@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+)
+
+// ExportFork handles exporting the state of a fork to a file
+type ExportFork struct {
+	forkState    ForkStateStore
+	forkFiles    ForkFileManager
+	anvilManager AnvilManager
+}
+
+// NewExportFork creates a new ExportFork use case
+func NewExportFork(
+	forkState ForkStateStore,
+	forkFiles ForkFileManager,
+	anvilManager AnvilManager,
+) *ExportFork {
+	return &ExportFork{
+		forkState:    forkState,
+		forkFiles:    forkFiles,
+		anvilManager: anvilManager,
+	}
+}
+
+// ExportForkParams contains parameters for exporting a fork
+type ExportForkParams struct {
+	Network string // network of the fork to export
+	File    string // path of the export file
+}
+
+// ExportForkResult contains the result of exporting a fork
+type ExportForkResult struct {
+	Export  *domain.ForkExport
+	File    string
+	Message string
+}
+
+// Execute dumps the anvil state of the fork and writes it to the file together
+// with the registry files of .treb
+func (uc *ExportFork) Execute(ctx context.Context, params ExportForkParams) (*ExportForkResult, error) {
+	if params.Network == "" {
+		return nil, fmt.Errorf("no network specified. Use 'treb fork export <network> <file>'")
+	}
+
+	state, err := uc.forkState.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fork state: %w", err)
+	}
+
+	entry := state.GetActiveFork(params.Network)
+	if entry == nil {
+		return nil, fmt.Errorf("no active fork for network '%s'", params.Network)
+	}
+
+	instance := &domain.AnvilInstance{
+		Name:    fmt.Sprintf("fork-%s", entry.Network),
+		Port:    portFromURL(entry.ForkURL),
+		ChainID: fmt.Sprintf("%d", entry.ChainID),
+		PidFile: entry.PidFile,
+		LogFile: entry.LogFile,
+	}
+
+	anvilState, err := uc.anvilManager.DumpState(ctx, instance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dump fork state: %w", err)
+	}
+
+	files, err := uc.forkFiles.ReadRegistryFiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry files: %w", err)
+	}
+
+	export := &domain.ForkExport{
+		Version:    domain.ForkExportVersion,
+		Network:    entry.Network,
+		ChainID:    entry.ChainID,
+		ForkBlock:  entry.ForkBlock,
+		ExportedAt: time.Now(),
+		AnvilState: anvilState,
+		Registry:   make(map[string]json.RawMessage, len(files)),
+	}
+	for name, data := range files {
+		if !json.Valid(data) {
+			return nil, fmt.Errorf("registry file %s is not valid JSON", name)
+		}
+		export.Registry[name] = data
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal fork export: %w", err)
+	}
+	if err := os.WriteFile(params.File, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write fork export: %w", err)
+	}
+
+	return &ExportForkResult{
+		Export:  export,
+		File:    params.File,
+		Message: fmt.Sprintf("Fork '%s' exported to %s", entry.Network, params.File),
+	}, nil
+}
+
+// LoadForkExport reads a file written by 'treb fork export'
+func LoadForkExport(path string) (*domain.ForkExport, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-provided export file
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fork export: %w", err)
+	}
+
+	var export domain.ForkExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse fork export %s: %w", path, err)
+	}
+	if export.Version != domain.ForkExportVersion {
+		return nil, fmt.Errorf("unsupported fork export version %d in %s", export.Version, path)
+	}
+	if export.Network == "" || export.AnvilState == "" {
+		return nil, fmt.Errorf("fork export %s is missing the network or anvil state", path)
+	}
+
+	return &export, nil
+}
@@ -35,8 +35,9 @@ type ForkHistoryEntry struct {
 	Index      int
 	Command    string
 	Timestamp  string
-	IsCurrent  bool // true for the top of the stack (most recent)
-	IsInitial  bool // true for index 0 (fork enter point)
+	IsCurrent  bool   // true for the top of the stack (most recent)
+	IsInitial  bool   // true for index 0 (fork enter point)
+	Checkpoint string // name of the checkpoint, empty for snapshots taken before runs
 }
 
 // ForkHistoryResult contains the result of the fork history command
@@ -82,11 +83,12 @@ func buildHistoryEntries(fork *domain.ForkEntry) []ForkHistoryEntry {
 
 	for i, snap := range fork.Snapshots {
 		entries[i] = ForkHistoryEntry{
-			Index:     snap.Index,
-			Command:   snap.Command,
-			Timestamp: snap.Timestamp.Format("2006-01-02 15:04:05"),
-			IsCurrent: i == topIndex,
-			IsInitial: snap.Index == 0,
+			Index:      snap.Index,
+			Command:    snap.Command,
+			Timestamp:  snap.Timestamp.Format("2006-01-02 15:04:05"),
+			IsCurrent:  i == topIndex,
+			IsInitial:  snap.Index == 0,
+			Checkpoint: snap.Name,
 		}
 	}
 
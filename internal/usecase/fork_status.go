@@ -37,6 +37,9 @@ type ForkStatusEntry struct {
 	Network         string
 	ChainID         uint64
 	ForkURL         string
+	ForkBlock       uint64 // block the fork was created from, 0 if unknown
+	BlockPinned     bool   // true if the fork was pinned with --block or [fork] block
+	HeadBlock       uint64 // latest block of the live network, 0 if it could not be fetched
 	AnvilPID        int
 	Uptime          time.Duration
 	SnapshotCount   int
@@ -47,6 +50,14 @@ type ForkStatusEntry struct {
 	LogFile         string // path to anvil log file
 }
 
+// Drift returns how many blocks the live network is ahead of the fork block, or -1 if unknown
+func (e ForkStatusEntry) Drift() int64 {
+	if e.ForkBlock == 0 || e.HeadBlock == 0 {
+		return -1
+	}
+	return int64(e.HeadBlock) - int64(e.ForkBlock) //nolint:gosec // block numbers fit in int64
+}
+
 // ForkStatusResult contains the result of the fork status command
 type ForkStatusResult struct {
 	Entries  []ForkStatusEntry
@@ -87,6 +98,8 @@ func (uc *ForkStatus) buildStatusEntry(ctx context.Context, entry *domain.ForkEn
 		Network:       entry.Network,
 		ChainID:       entry.ChainID,
 		ForkURL:       entry.ForkURL,
+		ForkBlock:     entry.ForkBlock,
+		BlockPinned:   entry.BlockPinned,
 		AnvilPID:      entry.AnvilPID,
 		Uptime:        time.Since(entry.EnteredAt),
 		SnapshotCount: len(entry.Snapshots),
@@ -112,6 +125,11 @@ func (uc *ForkStatus) buildStatusEntry(ctx context.Context, entry *domain.ForkEn
 		se.HealthDetail = "healthy"
 	}
 
+	// Fetch the live head to show how far the fork has drifted from the network
+	if entry.ForkBlock > 0 {
+		se.HeadBlock, _ = uc.anvilManager.GetBlockNumber(ctx, entry.OriginalRPC, "latest")
+	}
+
 	// Count fork-added deployments
 	se.ForkDeployments = uc.countForkDeployments(entry.Network)
 
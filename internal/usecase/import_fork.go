@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+)
+
+// ImportFork handles starting a fork from a file written by 'treb fork export'
+type ImportFork struct {
+	forkState    ForkStateStore
+	forkFiles    ForkFileManager
+	anvilManager AnvilManager
+	enterFork    *EnterFork
+	exitFork     *ExitFork
+}
+
+// NewImportFork creates a new ImportFork use case
+func NewImportFork(
+	forkState ForkStateStore,
+	forkFiles ForkFileManager,
+	anvilManager AnvilManager,
+	enterFork *EnterFork,
+	exitFork *ExitFork,
+) *ImportFork {
+	return &ImportFork{
+		forkState:    forkState,
+		forkFiles:    forkFiles,
+		anvilManager: anvilManager,
+		enterFork:    enterFork,
+		exitFork:     exitFork,
+	}
+}
+
+// ImportForkParams contains parameters for importing a fork
+type ImportForkParams struct {
+	File   string             // path of the export file
+	Export *domain.ForkExport // contents of the export file, see LoadForkExport
+	Fork   EnterForkParams    // network, RPC URL, chain ID and env var of the exported network
+}
+
+// ImportForkResult contains the result of importing a fork
+type ImportForkResult struct {
+	ForkEntry *domain.ForkEntry
+	Export    *domain.ForkExport
+	Message   string
+}
+
+// Execute enters fork mode at the block of the export, then loads the exported anvil
+// state and registry files. The import is recorded as a snapshot on top of the fresh
+// fork, so 'treb fork revert' undoes it and 'treb fork exit' restores the registry
+// as it was before the import.
+func (uc *ImportFork) Execute(ctx context.Context, params ImportForkParams) (*ImportForkResult, error) {
+	export := params.Export
+	if params.Fork.ChainID != export.ChainID {
+		return nil, fmt.Errorf("fork export is of chain %d but network '%s' has chain ID %d", export.ChainID, params.Fork.Network, params.Fork.ChainID)
+	}
+
+	enter := params.Fork
+	enter.SkipSetup = true // the exported state already contains the setup
+	if export.ForkBlock > 0 {
+		enter.Block = strconv.FormatUint(export.ForkBlock, 10)
+	}
+
+	if _, err := uc.enterFork.Execute(ctx, enter); err != nil {
+		return nil, err
+	}
+
+	entry, err := uc.load(ctx, params)
+	if err != nil {
+		// Leave no half-imported fork behind
+		_, _ = uc.exitFork.Execute(ctx, ExitForkParams{Network: enter.Network})
+		return nil, err
+	}
+
+	return &ImportForkResult{
+		ForkEntry: entry,
+		Export:    export,
+		Message:   fmt.Sprintf("Fork imported for network '%s' from %s", entry.Network, params.File),
+	}, nil
+}
+
+// load snapshots the fresh fork and loads the exported state into it
+func (uc *ImportFork) load(ctx context.Context, params ImportForkParams) (*domain.ForkEntry, error) {
+	state, err := uc.forkState.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fork state: %w", err)
+	}
+
+	entry := state.GetActiveFork(params.Fork.Network)
+	if entry == nil {
+		return nil, fmt.Errorf("no active fork for network '%s'", params.Fork.Network)
+	}
+
+	instance := &domain.AnvilInstance{
+		Name:    fmt.Sprintf("fork-%s", entry.Network),
+		Port:    portFromURL(entry.ForkURL),
+		ChainID: fmt.Sprintf("%d", entry.ChainID),
+		PidFile: entry.PidFile,
+		LogFile: entry.LogFile,
+	}
+
+	// Snapshot before the import, like before each treb run
+	nextIndex := len(entry.Snapshots)
+	snapshotID, err := uc.anvilManager.TakeSnapshot(ctx, instance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to take EVM snapshot: %w", err)
+	}
+	if err := uc.forkFiles.BackupFiles(ctx, entry.Network, nextIndex); err != nil {
+		return nil, fmt.Errorf("failed to backup registry files: %w", err)
+	}
+
+	if err := uc.anvilManager.LoadState(ctx, instance, params.Export.AnvilState); err != nil {
+		return nil, fmt.Errorf("failed to load fork state: %w", err)
+	}
+
+	files := make(map[string][]byte, len(params.Export.Registry))
+	for name, data := range params.Export.Registry {
+		files[name] = data
+	}
+	if err := uc.forkFiles.WriteRegistryFiles(ctx, files); err != nil {
+		return nil, fmt.Errorf("failed to restore registry files: %w", err)
+	}
+
+	entry.Snapshots = append(entry.Snapshots, domain.SnapshotEntry{
+		Index:      nextIndex,
+		SnapshotID: snapshotID,
+		Command:    fmt.Sprintf("fork import %s", filepath.Base(params.File)),
+		Timestamp:  time.Now(),
+	})
+
+	if err := uc.forkState.Save(ctx, state); err != nil {
+		return nil, fmt.Errorf("failed to save fork state: %w", err)
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+)
+
+// ListAccountsResult describes the sender roles of the current namespace
+type ListAccountsResult struct {
+	Namespace    string
+	ConfigSource string
+	Network      *config.Network // nil when no network is selected
+	GasPrice     *big.Int        // Suggested gas price used for the affordability check
+	Accounts     []*AccountInfo  // Sorted by role
+}
+
+// AccountInfo is a sender role with its resolved identity and, when a network is selected, its on-chain state
+type AccountInfo struct {
+	Role         string
+	Account      string // Account name in treb.toml v2, empty for older configs
+	DefinedIn    string // Namespace section that set the role (treb.toml v2)
+	Type         config.SenderType
+	Address      common.Address
+	AddressError string // Why the address could not be resolved, e.g. a ledger without an address
+
+	// Type-specific configuration
+	Signer     string         // Safe signer, or governor proposer role
+	Confirmers []string       // Safe confirmers
+	Governor   common.Address // Governor of oz_governor senders
+	Timelock   common.Address // Timelock of oz_governor senders, read on-chain when not configured
+
+	// On-chain state, set when a network is selected
+	State   *domain.AccountState
+	Safe    *domain.SafeInfo
+	LastRun *AccountLastRun
+	// InsufficientFunds is set when the balance does not cover the gas of the last run at the current gas price
+	InsufficientFunds bool
+	Error             string // On-chain lookup failure
+}
+
+// AccountLastRun is the gas a sender's address broadcast in the most recent run that used it
+type AccountLastRun struct {
+	RunID   string
+	GasUsed uint64
+	GasCost *big.Int // GasUsed at the current gas price, nil without a gas price
+}
+
+// ListAccounts is the use case for showing what each sender role resolves to
+type ListAccounts struct {
+	config         *config.RuntimeConfig
+	addresses      SenderAddressResolver
+	inspector      AccountInspector
+	runs           RunRecordStore
+	forkStateStore ForkStateStore
+}
+
+// NewListAccounts creates a new ListAccounts use case
+func NewListAccounts(
+	config *config.RuntimeConfig,
+	addresses SenderAddressResolver,
+	inspector AccountInspector,
+	runs RunRecordStore,
+	forkStateStore ForkStateStore,
+) *ListAccounts {
+	return &ListAccounts{
+		config:         config,
+		addresses:      addresses,
+		inspector:      inspector,
+		runs:           runs,
+		forkStateStore: forkStateStore,
+	}
+}
+
+// Run executes the use case
+func (uc *ListAccounts) Run(ctx context.Context) (*ListAccountsResult, error) {
+	result := &ListAccountsResult{
+		Namespace:    uc.config.Namespace,
+		ConfigSource: uc.config.ConfigSource,
+		Network:      uc.config.Network,
+		Accounts:     []*AccountInfo{},
+	}
+	if uc.config.TrebConfig == nil {
+		return result, nil
+	}
+
+	for role, sender := range uc.config.TrebConfig.Senders {
+		result.Accounts = append(result.Accounts, describeSender(uc.config, uc.addresses, role, sender))
+	}
+	sort.Slice(result.Accounts, func(i, j int) bool {
+		return result.Accounts[i].Role < result.Accounts[j].Role
+	})
+
+	if uc.config.Network == nil {
+		return result, nil
+	}
+	if err := uc.inspector.Connect(ctx, networkRPCURL(ctx, uc.forkStateStore, uc.config.Network), uc.config.Network.ChainID); err != nil {
+		return nil, err
+	}
+	// Without a gas price the affordability check is skipped, balances are still shown
+	result.GasPrice, _ = uc.inspector.SuggestGasPrice(ctx)
+
+	lastRuns, err := uc.lastRunGas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range result.Accounts {
+		uc.inspectAccount(ctx, account, result.GasPrice, lastRuns)
+	}
+
+	return result, nil
+}
+
+// describeSender resolves a sender's identity from configuration alone
+func describeSender(cfg *config.RuntimeConfig, addresses SenderAddressResolver, role string, sender config.SenderConfig) *AccountInfo {
+	info := &AccountInfo{Role: role, Type: sender.Type}
+	if resolved := cfg.ResolvedNamespace; resolved != nil {
+		if binding, ok := resolved.Roles[role]; ok {
+			info.Account = binding.Account
+			info.DefinedIn = binding.Namespace
+		}
+	}
+
+	address, err := addresses.SenderAddress(role)
+	if err != nil {
+		info.AddressError = err.Error()
+	} else {
+		info.Address = address
+	}
+
+	switch sender.Type {
+	case config.SenderTypeSafe:
+		info.Signer = sender.Signer
+		info.Confirmers = sender.Confirmers
+	case config.SenderTypeOZGovernor:
+		info.Signer = sender.Proposer
+		if common.IsHexAddress(sender.Governor) {
+			info.Governor = common.HexToAddress(sender.Governor)
+		}
+		if common.IsHexAddress(sender.Timelock) {
+			info.Timelock = common.HexToAddress(sender.Timelock)
+		}
+	}
+
+	return info
+}
+
+// inspectAccount adds the on-chain state of an account. Lookup failures are recorded on
+// the account so the other accounts are still shown.
+func (uc *ListAccounts) inspectAccount(ctx context.Context, account *AccountInfo, gasPrice *big.Int, lastRuns map[common.Address]*AccountLastRun) {
+	if account.AddressError != "" {
+		return
+	}
+
+	state, err := uc.inspector.GetAccountState(ctx, account.Address)
+	if err != nil {
+		account.Error = err.Error()
+		return
+	}
+	account.State = state
+
+	switch account.Type {
+	case config.SenderTypeSafe:
+		safe, err := uc.inspector.GetSafeInfo(ctx, account.Address)
+		if err != nil {
+			account.Error = err.Error()
+		}
+		account.Safe = safe
+	case config.SenderTypeOZGovernor:
+		if account.Timelock == (common.Address{}) && account.Governor != (common.Address{}) {
+			timelock, err := uc.inspector.GetGovernorTimelock(ctx, account.Governor)
+			if err != nil {
+				account.Error = err.Error()
+			}
+			account.Timelock = timelock
+		}
+	}
+
+	if lastRun, ok := lastRuns[account.Address]; ok {
+		run := *lastRun
+		if gasPrice != nil {
+			run.GasCost = new(big.Int).Mul(new(big.Int).SetUint64(run.GasUsed), gasPrice)
+			account.InsufficientFunds = state.Balance.Cmp(run.GasCost) < 0
+		}
+		account.LastRun = &run
+	}
+}
+
+// lastRunGas finds, for each sender address, the gas it broadcast in the most recent run
+// on this network and namespace
+func (uc *ListAccounts) lastRunGas(ctx context.Context) (map[common.Address]*AccountLastRun, error) {
+	records, err := uc.runs.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs: %w", err)
+	}
+
+	lastRuns := make(map[common.Address]*AccountLastRun)
+	for _, record := range records {
+		if record.ChainID != uc.config.Network.ChainID || record.Namespace != uc.config.Namespace {
+			continue
+		}
+		for _, sender := range record.Senders {
+			if sender.GasUsed == 0 || !common.IsHexAddress(sender.Address) {
+				continue
+			}
+			address := common.HexToAddress(sender.Address)
+			if _, seen := lastRuns[address]; !seen {
+				lastRuns[address] = &AccountLastRun{RunID: record.ID, GasUsed: sender.GasUsed}
+			}
+		}
+	}
+	return lastRuns, nil
+}
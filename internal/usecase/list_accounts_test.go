@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+var (
+	testDeployer = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testSafe     = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	testGovernor = common.HexToAddress("0x3333333333333333333333333333333333333333")
+)
+
+// mockSenderAddresses resolves senders from a fixed map
+type mockSenderAddresses map[string]common.Address
+
+func (m mockSenderAddresses) SenderAddress(sender string) (common.Address, error) {
+	if address, ok := m[sender]; ok {
+		return address, nil
+	}
+	return common.Address{}, fmt.Errorf("ledger sender '%s' has no address configured", sender)
+}
+
+// mockAccountInspector serves balances from a map and records whether it was used
+type mockAccountInspector struct {
+	balances  map[common.Address]int64
+	connected bool
+}
+
+func (m *mockAccountInspector) Connect(context.Context, string, uint64) error {
+	m.connected = true
+	return nil
+}
+
+func (m *mockAccountInspector) GetAccountState(_ context.Context, address common.Address) (*domain.AccountState, error) {
+	return &domain.AccountState{Balance: big.NewInt(m.balances[address]), Nonce: 7}, nil
+}
+
+func (m *mockAccountInspector) GetSafeInfo(context.Context, common.Address) (*domain.SafeInfo, error) {
+	return &domain.SafeInfo{Owners: []common.Address{testDeployer, common.HexToAddress("0x44")}, Threshold: 2}, nil
+}
+
+func (m *mockAccountInspector) GetGovernorTimelock(context.Context, common.Address) (common.Address, error) {
+	return common.HexToAddress("0x55"), nil
+}
+
+func (m *mockAccountInspector) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return big.NewInt(10), nil
+}
+
+// mockRunRecords returns fixed run records, most recent first
+type mockRunRecords []*models.RunRecord
+
+func (m mockRunRecords) Save(context.Context, *models.RunRecord) error { return nil }
+
+func (m mockRunRecords) Get(context.Context, string) (*models.RunRecord, error) {
+	return nil, domain.ErrNotFound
+}
+
+func (m mockRunRecords) List(context.Context) ([]*models.RunRecord, error) { return m, nil }
+
+func newListAccountsSetup(network *config.Network) (*ListAccounts, *mockAccountInspector) {
+	cfg := &config.RuntimeConfig{
+		Namespace: "production",
+		Network:   network,
+		TrebConfig: &config.TrebConfig{
+			Senders: map[string]config.SenderConfig{
+				"deployer":   {Type: config.SenderTypePrivateKey},
+				"multisig":   {Type: config.SenderTypeSafe, Safe: testSafe.Hex(), Signer: "deployer"},
+				"governance": {Type: config.SenderTypeOZGovernor, Governor: testGovernor.Hex(), Proposer: "deployer"},
+				"hardware":   {Type: config.SenderTypeLedger},
+			},
+		},
+		ResolvedNamespace: &config.ResolvedNamespace{
+			Roles: map[string]config.RoleBinding{
+				"deployer": {Account: "prod-wallet", Namespace: "production"},
+				"multisig": {Account: "prod-safe", Namespace: "default"},
+			},
+		},
+	}
+	addresses := mockSenderAddresses{"deployer": testDeployer, "multisig": testSafe, "governance": testGovernor}
+	inspector := &mockAccountInspector{balances: map[common.Address]int64{testDeployer: 1000, testSafe: 5}}
+	runs := mockRunRecords{
+		{ID: "other-chain", Namespace: "production", ChainID: 10, Senders: []models.RunSender{{Address: testDeployer.Hex(), GasUsed: 1}}},
+		{ID: "latest", Namespace: "production", ChainID: 1, Senders: []models.RunSender{{Address: testDeployer.Hex(), GasUsed: 500}}},
+		{ID: "older", Namespace: "production", ChainID: 1, Senders: []models.RunSender{{Address: testDeployer.Hex(), GasUsed: 50}}},
+	}
+
+	return NewListAccounts(cfg, addresses, inspector, runs, &mockForkState{}), inspector
+}
+
+func TestListAccounts(t *testing.T) {
+	t.Run("resolves roles from configuration without a network", func(t *testing.T) {
+		uc, inspector := newListAccountsSetup(nil)
+
+		result, err := uc.Run(t.Context())
+		require.NoError(t, err)
+		assert.False(t, inspector.connected)
+
+		require.Len(t, result.Accounts, 4)
+		roles := []string{}
+		for _, account := range result.Accounts {
+			roles = append(roles, account.Role)
+			assert.Nil(t, account.State)
+		}
+		assert.Equal(t, []string{"deployer", "governance", "hardware", "multisig"}, roles)
+
+		deployer := result.Accounts[0]
+		assert.Equal(t, "prod-wallet", deployer.Account)
+		assert.Equal(t, "production", deployer.DefinedIn)
+		assert.Equal(t, testDeployer, deployer.Address)
+
+		hardware := result.Accounts[2]
+		assert.Contains(t, hardware.AddressError, "no address configured")
+
+		multisig := result.Accounts[3]
+		assert.Equal(t, "deployer", multisig.Signer)
+		assert.Equal(t, "default", multisig.DefinedIn)
+	})
+
+	t.Run("adds on-chain state with a network", func(t *testing.T) {
+		uc, inspector := newListAccountsSetup(&config.Network{Name: "mainnet", ChainID: 1})
+
+		result, err := uc.Run(t.Context())
+		require.NoError(t, err)
+		assert.True(t, inspector.connected)
+
+		deployer := result.Accounts[0]
+		require.NotNil(t, deployer.State)
+		assert.Equal(t, int64(1000), deployer.State.Balance.Int64())
+		assert.Equal(t, uint64(7), deployer.State.Nonce)
+		// The most recent run on this chain counts, at the current gas price of 10
+		require.NotNil(t, deployer.LastRun)
+		assert.Equal(t, "latest", deployer.LastRun.RunID)
+		assert.Equal(t, int64(5000), deployer.LastRun.GasCost.Int64())
+		assert.True(t, deployer.InsufficientFunds)
+
+		governance := result.Accounts[1]
+		assert.Equal(t, common.HexToAddress("0x55"), governance.Timelock)
+
+		hardware := result.Accounts[2]
+		assert.Nil(t, hardware.State)
+
+		multisig := result.Accounts[3]
+		require.NotNil(t, multisig.Safe)
+		assert.Equal(t, uint64(2), multisig.Safe.Threshold)
+		assert.Nil(t, multisig.LastRun)
+		assert.False(t, multisig.InsufficientFunds)
+	})
+}
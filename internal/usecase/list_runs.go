@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// ListRunsParams contains filters for listing run records
+type ListRunsParams struct {
+	Namespace string // Only runs in this namespace
+	ChainID   uint64 // Only runs on this chain
+	Script    string // Only runs of this script, matched by name or path
+	Limit     int    // Maximum number of runs, 0 for all
+}
+
+// ListRunsResult contains the matching run records, most recent first
+type ListRunsResult struct {
+	Runs []*models.RunRecord
+}
+
+// ListRuns is the use case for browsing run records
+type ListRuns struct {
+	runs RunRecordStore
+}
+
+// NewListRuns creates a new ListRuns use case
+func NewListRuns(runs RunRecordStore) *ListRuns {
+	return &ListRuns{runs: runs}
+}
+
+// Run executes the use case
+func (uc *ListRuns) Run(ctx context.Context, params ListRunsParams) (*ListRunsResult, error) {
+	records, err := uc.runs.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListRunsResult{Runs: []*models.RunRecord{}}
+	for _, record := range records {
+		if params.Namespace != "" && record.Namespace != params.Namespace {
+			continue
+		}
+		if params.ChainID != 0 && record.ChainID != params.ChainID {
+			continue
+		}
+		if params.Script != "" && !strings.EqualFold(record.Script, params.Script) && record.ScriptPath != params.Script {
+			continue
+		}
+
+		result.Runs = append(result.Runs, record)
+		if params.Limit > 0 && len(result.Runs) == params.Limit {
+			break
+		}
+	}
+
+	return result, nil
+}
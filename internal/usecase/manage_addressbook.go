@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// ManageAddressBook adds, lists and removes address book entries
+type ManageAddressBook struct {
+	config *config.RuntimeConfig
+	store  AddressBookStore
+}
+
+// NewManageAddressBook creates a new address book use case
+func NewManageAddressBook(cfg *config.RuntimeConfig, store AddressBookStore) *ManageAddressBook {
+	return &ManageAddressBook{
+		config: cfg,
+		store:  store,
+	}
+}
+
+// AddAddressBookEntryParams contains parameters for adding an address book entry
+type AddAddressBookEntryParams struct {
+	Name     string
+	Address  string
+	Artifact string // Project contract or interface whose ABI decodes calls (optional)
+	ABI      string // JSON ABI file relative to the project root (optional)
+	Force    bool   // Replace an existing entry with the same name
+}
+
+// Add records an entry on the current network
+func (m *ManageAddressBook) Add(ctx context.Context, params AddAddressBookEntryParams) (*models.AddressBookEntry, error) {
+	chainID, err := m.chainID()
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Name == "" || strings.ContainsAny(params.Name, "/:@ ") {
+		return nil, fmt.Errorf("invalid name '%s': names cannot be empty or contain '/', ':', '@' or spaces", params.Name)
+	}
+	if !common.IsHexAddress(params.Address) {
+		return nil, fmt.Errorf("invalid address: %s", params.Address)
+	}
+	if params.Artifact != "" && params.ABI != "" {
+		return nil, fmt.Errorf("--artifact and --abi cannot be combined")
+	}
+
+	if !params.Force {
+		existing, err := m.store.Get(ctx, chainID, params.Name)
+		if err == nil {
+			return nil, fmt.Errorf("'%s' is already in the address book at %s, use --force to replace it", params.Name, existing.Address)
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	entry := &models.AddressBookEntry{
+		Name:     params.Name,
+		ChainID:  chainID,
+		Address:  common.HexToAddress(params.Address).Hex(),
+		Artifact: params.Artifact,
+		ABI:      params.ABI,
+	}
+	if err := m.store.Save(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// List returns the entries of the current network, or of every network when none is set
+func (m *ManageAddressBook) List(ctx context.Context) ([]*models.AddressBookEntry, error) {
+	entries, err := m.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if m.config.Network == nil {
+		return entries, nil
+	}
+
+	var filtered []*models.AddressBookEntry
+	for _, entry := range entries {
+		if entry.ChainID == m.config.Network.ChainID {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered, nil
+}
+
+// Remove deletes an entry from the current network
+func (m *ManageAddressBook) Remove(ctx context.Context, name string) (*models.AddressBookEntry, error) {
+	chainID, err := m.chainID()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := m.store.Get(ctx, chainID, name)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("'%s' is not in the address book of %s", name, m.config.Network.Name)
+		}
+		return nil, err
+	}
+	if err := m.store.Delete(ctx, chainID, name); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (m *ManageAddressBook) chainID() (uint64, error) {
+	if m.config.Network == nil {
+		return 0, fmt.Errorf("network must be set (use 'treb config set network <name>' or --network flag)")
+	}
+	return m.config.Network.ChainID, nil
+}
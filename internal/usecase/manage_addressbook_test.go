@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// memoryAddressBook keeps entries in memory, keyed by chain and name
+type memoryAddressBook map[string]*models.AddressBookEntry
+
+func (m memoryAddressBook) key(chainID uint64, name string) string {
+	return fmt.Sprintf("%d/%s", chainID, name)
+}
+
+func (m memoryAddressBook) List(context.Context) ([]*models.AddressBookEntry, error) {
+	entries := []*models.AddressBookEntry{}
+	for _, entry := range m {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (m memoryAddressBook) Get(_ context.Context, chainID uint64, name string) (*models.AddressBookEntry, error) {
+	if entry, ok := m[m.key(chainID, name)]; ok {
+		return entry, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m memoryAddressBook) GetByAddress(context.Context, uint64, string) (*models.AddressBookEntry, error) {
+	return nil, domain.ErrNotFound
+}
+
+func (m memoryAddressBook) Save(_ context.Context, entry *models.AddressBookEntry) error {
+	m[m.key(entry.ChainID, entry.Name)] = entry
+	return nil
+}
+
+func (m memoryAddressBook) Delete(_ context.Context, chainID uint64, name string) error {
+	delete(m, m.key(chainID, name))
+	return nil
+}
+
+func TestManageAddressBook(t *testing.T) {
+	cfg := &config.RuntimeConfig{Network: &config.Network{Name: "mainnet", ChainID: 1}}
+	store := memoryAddressBook{}
+	uc := NewManageAddressBook(cfg, store)
+	ctx := context.Background()
+
+	entry, err := uc.Add(ctx, AddAddressBookEntryParams{Name: "USDC", Address: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", Artifact: "IERC20"})
+	require.NoError(t, err)
+	assert.Equal(t, "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", entry.Address)
+	assert.Equal(t, uint64(1), entry.ChainID)
+
+	tests := []struct {
+		name   string
+		params AddAddressBookEntryParams
+		errMsg string
+	}{
+		{name: "duplicate", params: AddAddressBookEntryParams{Name: "USDC", Address: "0x0000000000000000000000000000000000000001"}, errMsg: "use --force to replace it"},
+		{name: "reference syntax in name", params: AddAddressBookEntryParams{Name: "USDC:v2", Address: "0x0000000000000000000000000000000000000001"}, errMsg: "invalid name"},
+		{name: "invalid address", params: AddAddressBookEntryParams{Name: "DAI", Address: "0x1234"}, errMsg: "invalid address"},
+		{name: "two ABI sources", params: AddAddressBookEntryParams{Name: "DAI", Address: "0x0000000000000000000000000000000000000001", Artifact: "IERC20", ABI: "dai.json"}, errMsg: "cannot be combined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Add(ctx, tt.params)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+
+	_, err = uc.Add(ctx, AddAddressBookEntryParams{Name: "USDC", Address: "0x0000000000000000000000000000000000000001", Force: true})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(ctx, &models.AddressBookEntry{Name: "USDC", ChainID: 42220, Address: "0x0000000000000000000000000000000000000002"}))
+
+	entries, err := uc.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "0x0000000000000000000000000000000000000001", entries[0].Address)
+
+	removed, err := uc.Remove(ctx, "USDC")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), removed.ChainID)
+	_, err = uc.Remove(ctx, "USDC")
+	assert.ErrorContains(t, err, "'USDC' is not in the address book of mainnet")
+
+	cfg.Network = nil
+	entries, err = uc.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+	_, err = uc.Remove(ctx, "USDC")
+	assert.ErrorContains(t, err, "network must be set")
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+)
+
+// networkRPCURL returns the RPC URL of a network, or of its active fork
+func networkRPCURL(ctx context.Context, forkStateStore ForkStateStore, network *config.Network) string {
+	if forkStateStore == nil {
+		return network.RPCURL
+	}
+	if forkState, err := forkStateStore.Load(ctx); err == nil {
+		if fork := forkState.GetActiveFork(network.Name); fork != nil {
+			return fork.ForkURL
+		}
+	}
+	return network.RPCURL
+}
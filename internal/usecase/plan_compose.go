@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+)
+
+// PlanComposeParams contains parameters for planning a compose file
+type PlanComposeParams struct {
+	ConfigPath string
+	Simulate   bool              // Dry-run the steps in order to find the contracts they create
+	Vars       map[string]string // Values of the variables declared by the compose files
+}
+
+// ComposePlanResult is the validated execution plan of a compose file
+type ComposePlanResult struct {
+	ConfigPath string
+	Namespace  string
+	Plan       *ExecutionPlan
+	Levels     [][]*ExecutionStep
+	Steps      map[string]*ScriptDescription // Script of each step by step name
+}
+
+// PlanCompose is the use case for reviewing a compose file without running it
+type PlanCompose struct {
+	config    *config.RuntimeConfig
+	compose   *ComposeDeployment
+	describe  *DescribeScript
+	scripts   ScriptResolver
+	runScript scriptRunner
+}
+
+// NewPlanCompose creates a new PlanCompose use case
+func NewPlanCompose(
+	cfg *config.RuntimeConfig,
+	compose *ComposeDeployment,
+	describe *DescribeScript,
+	scripts ScriptResolver,
+	runScript *RunScript,
+) *PlanCompose {
+	return &PlanCompose{
+		config:    cfg,
+		compose:   compose,
+		describe:  describe,
+		scripts:   scripts,
+		runScript: runScript,
+	}
+}
+
+// Run executes the use case
+func (uc *PlanCompose) Run(ctx context.Context, params PlanComposeParams) (*ComposePlanResult, error) {
+	plan, err := uc.compose.loadPlan(params.ConfigPath, params.Vars)
+	if err != nil {
+		return nil, err
+	}
+	networks, err := uc.compose.resolveNetworks(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	// Steps switch the network of the runtime configuration, restore it once done
+	defer func(network *config.Network) { uc.config.Network = network }(uc.config.Network)
+
+	result := &ComposePlanResult{
+		ConfigPath: params.ConfigPath,
+		Namespace:  uc.config.Namespace,
+		Plan:       plan,
+		Levels:     plan.Levels(),
+		Steps:      make(map[string]*ScriptDescription, len(plan.Components)),
+	}
+
+	// Simulated steps expose their outputs to the templates of later steps, as they
+	// would in a compose run
+	simulated := make(map[string]*StepStateInfo)
+	for _, step := range plan.Components {
+		if network := networks[step.Network]; network != nil {
+			uc.config.Network = network
+		}
+
+		script, err := uc.scripts.ResolveScript(ctx, step.Script)
+		if err != nil {
+			return nil, fmt.Errorf("component '%s': failed to resolve script: %w", step.Name, err)
+		}
+		description, err := uc.describe.describe(ctx, script, false)
+		if err != nil {
+			return nil, fmt.Errorf("component '%s': %w", step.Name, err)
+		}
+		result.Steps[step.Name] = description
+
+		if params.Simulate {
+			simulated[step.Name] = uc.simulate(ctx, step, description, networks, simulated)
+		}
+	}
+	return result, nil
+}
+
+// simulate dry-runs a step with its env resolved from the simulated steps before it
+func (uc *PlanCompose) simulate(
+	ctx context.Context,
+	step *ExecutionStep,
+	description *ScriptDescription,
+	networks map[string]*config.Network,
+	simulated map[string]*StepStateInfo,
+) *StepStateInfo {
+	description.Simulated = true
+	templates := &composeTemplateContext{
+		namespace: uc.config.Namespace,
+		network:   networks[step.Network],
+		steps:     simulated,
+	}
+	env, err := templates.resolveEnv(step.Env)
+	if err != nil {
+		description.SimulationError = err.Error()
+		return &StepStateInfo{Step: step}
+	}
+
+	run, err := uc.runScript.Run(ctx, RunScriptParams{
+		ScriptRef:      step.Script,
+		Parameters:     env,
+		DryRun:         true,
+		NonInteractive: true,
+	})
+	switch {
+	case err != nil:
+		description.SimulationError = err.Error()
+	case !run.Success && run.Error != nil:
+		description.SimulationError = run.Error.Error()
+	case !run.Success:
+		description.SimulationError = "simulation failed"
+	default:
+		if description.Creates, err = uc.describe.simulatedCreates(ctx, run); err != nil {
+			description.SimulationError = err.Error()
+		}
+		return &StepStateInfo{Step: step, Success: true, Outputs: stepOutputs(run)}
+	}
+	return &StepStateInfo{Step: step}
+}
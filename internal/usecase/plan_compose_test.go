@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// simulationRunner dry-runs scripts, creating a contract named after each script
+type simulationRunner struct {
+	failing    map[string]bool
+	parameters map[string]map[string]string
+}
+
+func (s *simulationRunner) Run(_ context.Context, params RunScriptParams) (*RunScriptResult, error) {
+	if !params.DryRun {
+		return nil, errors.New("plan must only simulate")
+	}
+	s.parameters[params.ScriptRef] = params.Parameters
+	if s.failing[params.ScriptRef] {
+		return &RunScriptResult{Success: false, Error: errors.New("execution reverted")}, nil
+	}
+	// Dry runs leave the registry untouched, so there is no changeset
+	return &RunScriptResult{Success: true, RunResult: &forge.HydratedRunResult{
+		RunResult: &forge.RunResult{Namespace: "default", ChainID: 31337},
+		Deployments: []*forge.Deployment{{
+			Address:  common.BytesToAddress([]byte(params.ScriptRef)),
+			Contract: &models.Contract{Name: params.ScriptRef},
+		}},
+	}}, nil
+}
+
+func TestPlanCompose(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("protocol.yaml", []byte(`group: Protocol
+components:
+  Token:
+    script: DeployToken
+    env:
+      OWNER: "0x0000000000000000000000000000000000000001"
+  Vault:
+    script: DeployVault
+    deps: [Token]
+    env:
+      TOKEN: ${{ components.Token.deployments.DeployToken.address }}
+`), 0644))
+
+	scripts := stubScriptResolver{
+		scripts: []*models.Contract{
+			{Name: "DeployToken", Path: "script/DeployToken.s.sol", Artifact: &models.Artifact{}},
+			{Name: "DeployVault", Path: "script/DeployVault.s.sol", Artifact: &models.Artifact{}},
+		},
+		params: map[string][]domain.ScriptParameter{
+			"DeployToken": {{Name: "OWNER", Type: domain.ParamTypeAddress}},
+			"DeployVault": {{Name: "TOKEN", Type: domain.ParamTypeAddress}},
+		},
+	}
+	cfg := &config.RuntimeConfig{
+		Namespace: "default",
+		Network:   &config.Network{Name: "anvil", ChainID: 31337},
+		TrebConfig: &config.TrebConfig{Senders: map[string]config.SenderConfig{
+			"deployer": {Type: config.SenderTypePrivateKey},
+		}},
+	}
+	describe := NewDescribeScript(cfg, scripts, stubParameterResolver{resolved: &domain.ResolvedParameters{}},
+		stubScriptSenders{roles: []string{"deployer"}}, mockSenderAddresses{"deployer": testDeployer},
+		&mockDeploymentResolver{}, &memoryRegistry{}, nil)
+	newPlan := func(runner scriptRunner) *PlanCompose {
+		compose := &ComposeDeployment{config: cfg, runScript: runner, progress: nopComposeSink{}}
+		return &PlanCompose{config: cfg, compose: compose, describe: describe, scripts: scripts, runScript: runner}
+	}
+
+	t.Run("describes the steps of each level", func(t *testing.T) {
+		runner := &simulationRunner{parameters: map[string]map[string]string{}}
+		result, err := newPlan(runner).Run(context.Background(), PlanComposeParams{ConfigPath: "protocol.yaml"})
+		require.NoError(t, err)
+
+		require.Len(t, result.Levels, 2)
+		assert.Equal(t, "Token", result.Levels[0][0].Name)
+		assert.Equal(t, "Vault", result.Levels[1][0].Name)
+		assert.Equal(t, "default", result.Namespace)
+
+		token := result.Steps["Token"]
+		assert.Equal(t, "OWNER", token.Parameters[0].Name)
+		require.Len(t, token.Senders, 1)
+		assert.Equal(t, testDeployer, token.Senders[0].Address)
+		assert.False(t, token.Simulated)
+		assert.Empty(t, runner.parameters)
+	})
+
+	t.Run("simulation resolves templates from earlier steps", func(t *testing.T) {
+		runner := &simulationRunner{parameters: map[string]map[string]string{}}
+		result, err := newPlan(runner).Run(context.Background(), PlanComposeParams{ConfigPath: "protocol.yaml", Simulate: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, common.BytesToAddress([]byte("DeployToken")).Hex(), runner.parameters["DeployVault"]["TOKEN"])
+		vault := result.Steps["Vault"]
+		assert.True(t, vault.Simulated)
+		require.Len(t, vault.Creates, 1)
+		assert.Equal(t, "DeployVault", vault.Creates[0].ContractName)
+		assert.Equal(t, "default/31337/DeployVault", vault.Creates[0].ID)
+	})
+
+	t.Run("failed simulation is reported on the step and its dependents", func(t *testing.T) {
+		runner := &simulationRunner{parameters: map[string]map[string]string{}, failing: map[string]bool{"DeployToken": true}}
+		result, err := newPlan(runner).Run(context.Background(), PlanComposeParams{ConfigPath: "protocol.yaml", Simulate: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, "execution reverted", result.Steps["Token"].SimulationError)
+		assert.Contains(t, result.Steps["Vault"].SimulationError, "component 'Token' has not completed")
+	})
+
+	t.Run("unknown script", func(t *testing.T) {
+		require.NoError(t, os.WriteFile("broken.yaml", []byte(`group: Broken
+components:
+  Token:
+    script: DeployMissing
+`), 0644))
+		_, err := newPlan(&simulationRunner{}).Run(context.Background(), PlanComposeParams{ConfigPath: "broken.yaml"})
+		assert.ErrorContains(t, err, "component 'Token': failed to resolve script")
+	})
+}
@@ -3,8 +3,10 @@ package usecase
 import (
 	"context"
 	"io"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/trebuchet-org/treb-cli/internal/domain"
 	"github.com/trebuchet-org/treb-cli/internal/domain/config"
@@ -29,6 +31,9 @@ type DeploymentRepository interface {
 	SaveSafeTransaction(ctx context.Context, safeTx *models.SafeTransaction) error
 	UpdateSafeTransaction(ctx context.Context, safeTx *models.SafeTransaction) error
 	GetAllSafeTransactions(ctx context.Context) map[string]*models.SafeTransaction
+	GetTimelockOperation(ctx context.Context, operationID string) (*models.TimelockOperation, error)
+	ListTimelockOperations(ctx context.Context, filter domain.TimelockOperationFilter) ([]*models.TimelockOperation, error)
+	UpdateTimelockOperation(ctx context.Context, op *models.TimelockOperation) error
 }
 
 // ContractIndexer provides access to compiled contracts
@@ -161,6 +166,11 @@ type DeploymentSelector interface {
 	SelectDeployment(ctx context.Context, deployments []*models.Deployment, prompt string) (*models.Deployment, error)
 }
 
+// Confirmer asks the user to confirm an action before it is carried out
+type Confirmer interface {
+	Confirm(ctx context.Context, prompt string) (bool, error)
+}
+
 // AnvilManager manages local anvil node instances
 type AnvilManager interface {
 	Start(ctx context.Context, instance *domain.AnvilInstance) error
@@ -169,6 +179,9 @@ type AnvilManager interface {
 	StreamLogs(ctx context.Context, instance *domain.AnvilInstance, writer io.Writer) error
 	TakeSnapshot(ctx context.Context, instance *domain.AnvilInstance) (string, error)
 	RevertSnapshot(ctx context.Context, instance *domain.AnvilInstance, snapshotID string) error
+	GetBlockNumber(ctx context.Context, rpcURL string, tag string) (uint64, error)
+	DumpState(ctx context.Context, instance *domain.AnvilInstance) (string, error)
+	LoadState(ctx context.Context, instance *domain.AnvilInstance, state string) error
 }
 
 // ContractResolver resolves contract references to actual contracts
@@ -210,6 +223,42 @@ type SafeClient interface {
 	GetTransactionExecutionInfo(ctx context.Context, safeTxHash string) (*models.SafeExecutionInfo, error)
 }
 
+// TimelockClient reads and executes operations on OpenZeppelin TimelockController contracts
+type TimelockClient interface {
+	Connect(ctx context.Context, rpcURL string, chainID uint64) error
+	GetOperationState(ctx context.Context, timelock common.Address, operationID common.Hash) (*domain.TimelockOperationState, error)
+	// Execute sends execute or executeBatch for the operation and waits for it to be mined
+	Execute(ctx context.Context, op *models.TimelockOperation, from common.Address, signer bind.SignerFn) (txHash common.Hash, blockNumber uint64, err error)
+}
+
+// TransactionSigners signs transactions that treb sends itself rather than through forge
+type TransactionSigners interface {
+	// TransactionSigner returns the address of a configured sender and a signer for its transactions
+	TransactionSigner(ctx context.Context, sender string, chainID *big.Int) (common.Address, bind.SignerFn, error)
+}
+
+// SafeConfirmer confirms transactions proposed to a Safe as one of its owners
+type SafeConfirmer interface {
+	// ConfirmSafeTransaction signs the Safe transaction as a configured sender and submits the
+	// confirmation to the Safe Transaction Service, returning the confirming owner
+	ConfirmSafeTransaction(ctx context.Context, chainID uint64, safeTxHash common.Hash, sender string) (common.Address, error)
+}
+
+// SenderAddressResolver resolves the address each configured sender acts as
+type SenderAddressResolver interface {
+	SenderAddress(sender string) (common.Address, error)
+}
+
+// AccountInspector reads the on-chain state of sender accounts
+type AccountInspector interface {
+	Connect(ctx context.Context, rpcURL string, chainID uint64) error
+	GetAccountState(ctx context.Context, address common.Address) (*domain.AccountState, error)
+	GetSafeInfo(ctx context.Context, safe common.Address) (*domain.SafeInfo, error)
+	// GetGovernorTimelock returns the timelock of an OpenZeppelin Governor, or the zero address if it has none
+	GetGovernorTimelock(ctx context.Context, governor common.Address) (common.Address, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
 // DeploymentResolver resolves deployment references to actual deployments
 type DeploymentResolver interface {
 	// ResolveDeployment resolves a deployment reference to a deployment
@@ -222,21 +271,27 @@ type ScriptResolver interface {
 	ResolveScript(ctx context.Context, scriptRef string) (*models.Contract, error)
 	// GetScriptParameters extracts parameters from a script's artifact
 	GetScriptParameters(ctx context.Context, script *models.Contract) ([]domain.ScriptParameter, error)
+	// ListScripts returns the scripts under script/ that have a run() entry point
+	ListScripts(ctx context.Context) ([]*models.Contract, error)
+	// GetScriptReferences returns the deployments a script looks up in the registry
+	GetScriptReferences(ctx context.Context, script *models.Contract) ([]string, error)
 }
 
 // Parameter Handling Ports
 
 // ParameterResolver resolves script parameter values
 type ParameterResolver interface {
-	// ResolveParameters resolves parameter values from various sources
-	ResolveParameters(ctx context.Context, params []domain.ScriptParameter, values map[string]string) (map[string]string, error)
-	// ValidateParameters validates that all required parameters have values
+	// ResolveParameters resolves parameter values from various sources, recording where each came from
+	ResolveParameters(ctx context.Context, script string, params []domain.ScriptParameter, values map[string]string) (*domain.ResolvedParameters, error)
+	// ValidateParameters validates that all required parameters have values that meet their constraints
 	ValidateParameters(ctx context.Context, params []domain.ScriptParameter, values map[string]string) error
 }
 
 // ParameterPrompter prompts for missing parameter values in interactive mode
 type ParameterPrompter interface {
-	// PromptForParameters prompts the user for missing parameter values
+	// PromptForParameters prompts the user for missing parameter values. Answers are
+	// returned as typed and resolved again by the ParameterResolver, which parses and
+	// normalises rich types such as arrays, units, durations and JSON files.
 	PromptForParameters(ctx context.Context, params []domain.ScriptParameter, existing map[string]string) (map[string]string, error)
 }
 
@@ -256,6 +311,7 @@ type RunScriptConfig struct {
 	FoundryProfile     string            // Foundry profile to set via FOUNDRY_PROFILE env var
 	Parameters         map[string]string // Includes resolved parameters and sender configs
 	DryRun             bool
+	Resume             bool // Resume the broadcast recorded in the latest broadcast file
 	Debug              bool
 	DebugJSON          bool
 	Slow               bool
@@ -263,6 +319,55 @@ type RunScriptConfig struct {
 	SenderScriptConfig config.SenderScriptConfig
 	Progress           ProgressSink
 	ForkEnvOverrides   map[string]string // env var overrides for fork mode (e.g. NETWORK_RPC_URL=http://localhost:PORT)
+	BroadcastDir       string            // Broadcast directory relative to the project root, empty for forge's default
+}
+
+// BroadcastReader reads the broadcast files forge writes while sending transactions
+type BroadcastReader interface {
+	// LatestBroadcastPath returns the path of the latest broadcast file for a script on a chain,
+	// in broadcastDir when it is set
+	LatestBroadcastPath(broadcastDir string, script *models.Contract, chainID uint64) string
+	// ReadBroadcast reads a broadcast file
+	ReadBroadcast(path string) (*domain.BroadcastFile, error)
+}
+
+// BroadcastCheckpointStore persists interrupted broadcasts so they can be resumed
+type BroadcastCheckpointStore interface {
+	// Load returns the checkpoint for a script on a chain, or nil if there is none. Runs with
+	// their own broadcast directory, such as the steps of a parallel compose, have their own
+	// checkpoints; an empty broadcastDir is forge's default.
+	Load(ctx context.Context, broadcastDir, scriptPath string, chainID uint64) (*forge.BroadcastCheckpoint, error)
+	Save(ctx context.Context, checkpoint *forge.BroadcastCheckpoint) error
+	Delete(ctx context.Context, broadcastDir, scriptPath string, chainID uint64) error
+}
+
+// AddressBookStore persists named third-party contract addresses per chain
+type AddressBookStore interface {
+	List(ctx context.Context) ([]*models.AddressBookEntry, error)
+	Get(ctx context.Context, chainID uint64, name string) (*models.AddressBookEntry, error)
+	GetByAddress(ctx context.Context, chainID uint64, address string) (*models.AddressBookEntry, error)
+	Save(ctx context.Context, entry *models.AddressBookEntry) error
+	Delete(ctx context.Context, chainID uint64, name string) error
+}
+
+// RunRecordStore persists the records of broadcast runs
+type RunRecordStore interface {
+	Save(ctx context.Context, record *models.RunRecord) error
+	// Get returns the run with the given ID, or domain.ErrNotFound
+	Get(ctx context.Context, id string) (*models.RunRecord, error)
+	// List returns all runs, most recent first
+	List(ctx context.Context) ([]*models.RunRecord, error)
+}
+
+// RunEnvironmentInspector captures the tool versions, git state and operator of a run
+type RunEnvironmentInspector interface {
+	Inspect(ctx context.Context) (*models.RunEnvironment, error)
+}
+
+// HookRunner runs the shell commands of compose hooks
+type HookRunner interface {
+	// RunHook runs a command with extra env and stdin, returning its combined output
+	RunHook(ctx context.Context, command string, env map[string]string, stdin []byte) (output string, err error)
 }
 
 // RunResultHydrator hydrated RunResults with domain models.
@@ -286,6 +391,7 @@ const (
 	StageResolving    ExecutionStage = "Resolving"
 	StageParameters   ExecutionStage = "Parameters"
 	StageSimulating   ExecutionStage = "Simulating"
+	StagePreview      ExecutionStage = "Preview"
 	StageBroadcasting ExecutionStage = "Broadcasting"
 	StageParsing      ExecutionStage = "Parsing"
 	StageUpdating     ExecutionStage = "Updating"
@@ -307,6 +413,7 @@ type LibraryReference struct {
 
 type SendersManager interface {
 	BuildSenderScriptConfig(script *models.Artifact) (*config.SenderScriptConfig, error)
+	GetScriptSenders(script *models.Artifact) ([]string, error)
 }
 
 // ForkStateStore handles persistence of fork mode state
@@ -321,4 +428,6 @@ type ForkFileManager interface {
 	BackupFiles(ctx context.Context, network string, snapshotIndex int) error
 	RestoreFiles(ctx context.Context, network string, snapshotIndex int) error
 	CleanupForkDir(ctx context.Context, network string) error
+	ReadRegistryFiles(ctx context.Context) (map[string][]byte, error)
+	WriteRegistryFiles(ctx context.Context, files map[string][]byte) error
 }
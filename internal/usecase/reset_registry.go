@@ -74,11 +74,20 @@ func (uc *ResetRegistry) Run(ctx context.Context, params ResetRegistryParams) (*
 		return nil, fmt.Errorf("failed to list safe transactions: %w", err)
 	}
 
+	// Collect timelock operations matching chainID
+	timelockOperations, err := uc.repo.ListTimelockOperations(ctx, domain.TimelockOperationFilter{
+		ChainID: chainID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list timelock operations: %w", err)
+	}
+
 	changeset := &models.Changeset{
 		Delete: models.ChangesetModels{
-			Deployments:      deployments,
-			Transactions:     transactions,
-			SafeTransactions: safeTransactions,
+			Deployments:        deployments,
+			Transactions:       transactions,
+			SafeTransactions:   safeTransactions,
+			TimelockOperations: timelockOperations,
 		},
 	}
 
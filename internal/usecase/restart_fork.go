@@ -51,7 +51,8 @@ type RestartForkResult struct {
 }
 
 // Execute restarts a fork: stops dead process, restores files from initial backup,
-// starts fresh fork, re-runs SetupFork if configured, takes new initial snapshot.
+// starts fresh fork (at the pinned block, if any), re-runs SetupFork if configured,
+// takes new initial snapshot.
 func (uc *RestartFork) Execute(ctx context.Context, params RestartForkParams) (*RestartForkResult, error) {
 	if params.Network == "" {
 		return nil, fmt.Errorf("no network specified. Use 'treb fork restart <network>' or configure a network")
@@ -106,6 +107,10 @@ func (uc *RestartFork) Execute(ctx context.Context, params RestartForkParams) (*
 		PidFile: entry.PidFile,
 		LogFile: entry.LogFile,
 	}
+	// A pinned fork restarts from the same block, otherwise it forks the latest block again
+	if entry.BlockPinned {
+		newInstance.ForkBlock = entry.ForkBlock
+	}
 
 	if err := uc.anvilManager.Start(ctx, newInstance); err != nil {
 		return nil, fmt.Errorf("failed to start fresh fork anvil: %w", err)
@@ -122,6 +127,11 @@ func (uc *RestartFork) Execute(ctx context.Context, params RestartForkParams) (*
 	}
 
 	forkURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+	forkBlock := newInstance.ForkBlock
+	if forkBlock == 0 {
+		forkBlock, _ = uc.anvilManager.GetBlockNumber(ctx, forkURL, "latest")
+	}
+
 	forkEnvOverrides := map[string]string{
 		entry.EnvVarName: forkURL,
 	}
@@ -149,6 +159,7 @@ func (uc *RestartFork) Execute(ctx context.Context, params RestartForkParams) (*
 
 	// Update fork entry with new state
 	entry.ForkURL = forkURL
+	entry.ForkBlock = forkBlock
 	entry.AnvilPID = status.PID
 	entry.EnteredAt = time.Now()
 	entry.Snapshots = []domain.SnapshotEntry{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/trebuchet-org/treb-cli/internal/domain"
 	"github.com/trebuchet-org/treb-cli/internal/domain/config"
@@ -36,12 +37,13 @@ func NewRevertFork(
 type RevertForkParams struct {
 	Network string // network name (empty = use current configured network)
 	All     bool   // revert to initial state (revert all runs)
+	To      string // name of a checkpoint to revert to
 }
 
 // RevertForkResult contains the result of reverting fork state
 type RevertForkResult struct {
 	RevertedCommand    string // the command that was reverted (single revert)
-	RevertedCount      int    // number of snapshots reverted
+	RevertedCount      int    // number of runs reverted, not counting checkpoints
 	RemainingSnapshots int    // number of snapshots remaining
 	Message            string
 }
@@ -62,23 +64,31 @@ func (uc *RevertFork) Execute(ctx context.Context, params RevertForkParams) (*Re
 		return nil, fmt.Errorf("no active fork for network '%s'", params.Network)
 	}
 
+	if params.All && params.To != "" {
+		return nil, fmt.Errorf("--all and --to cannot be used together")
+	}
+
 	if params.All {
 		return uc.revertAll(ctx, state, entry)
 	}
 
+	if params.To != "" {
+		return uc.revertTo(ctx, state, entry, params.To)
+	}
+
 	return uc.revertLast(ctx, state, entry)
 }
 
-// revertLast reverts the most recent treb run
+// revertLast reverts the most recent treb run. Checkpoints taken before the run are
+// kept; checkpoints taken after it marked state the revert undoes and are dropped.
 func (uc *RevertFork) revertLast(ctx context.Context, state *domain.ForkState, entry *domain.ForkEntry) (*RevertForkResult, error) {
-	if len(entry.Snapshots) <= 1 {
-		return nil, fmt.Errorf("nothing to revert - already at initial fork state")
+	position := entry.LastRun()
+	if position < 0 {
+		return nil, fmt.Errorf("nothing to revert - no runs since the fork was entered")
 	}
+	run := entry.Snapshots[position]
 
-	// Pop the top entry from the snapshot stack
-	topSnapshot := entry.Snapshots[len(entry.Snapshots)-1]
-
-	// Revert EVM state to this snapshot's ID
+	// Revert EVM state to the snapshot taken before the run
 	instance := &domain.AnvilInstance{
 		Name:    fmt.Sprintf("fork-%s", entry.Network),
 		Port:    portFromURL(entry.ForkURL),
@@ -87,32 +97,43 @@ func (uc *RevertFork) revertLast(ctx context.Context, state *domain.ForkState, e
 		LogFile: entry.LogFile,
 	}
 
-	if err := uc.anvilManager.RevertSnapshot(ctx, instance, topSnapshot.SnapshotID); err != nil {
+	if err := uc.anvilManager.RevertSnapshot(ctx, instance, run.SnapshotID); err != nil {
 		return nil, fmt.Errorf("failed to revert EVM snapshot: %w", err)
 	}
 
-	// Restore registry files from the popped snapshot's directory
-	if err := uc.forkFiles.RestoreFiles(ctx, entry.Network, topSnapshot.Index); err != nil {
+	// Restore registry files from the run's snapshot directory
+	if err := uc.forkFiles.RestoreFiles(ctx, entry.Network, run.Index); err != nil {
 		return nil, fmt.Errorf("failed to restore registry files: %w", err)
 	}
 
-	// Remove the snapshot directory after restore
-	snapshotDir := fmt.Sprintf("%s/.treb/priv/fork/%s/snapshots/%d", uc.cfg.ProjectRoot, entry.Network, topSnapshot.Index)
-	_ = os.RemoveAll(snapshotDir)
+	// Remove the snapshot directories of the run and of any checkpoint after it
+	var dropped []string
+	for i := len(entry.Snapshots) - 1; i >= position; i-- {
+		if name := entry.Snapshots[i].Name; name != "" {
+			dropped = append([]string{name}, dropped...)
+		}
+		snapshotDir := fmt.Sprintf("%s/.treb/priv/fork/%s/snapshots/%d", uc.cfg.ProjectRoot, entry.Network, entry.Snapshots[i].Index)
+		_ = os.RemoveAll(snapshotDir)
+	}
 
-	// Update snapshot stack - remove the top entry
-	entry.Snapshots = entry.Snapshots[:len(entry.Snapshots)-1]
+	// Update snapshot stack - remove the run and everything above it
+	entry.Snapshots = entry.Snapshots[:position]
 
 	// Save updated fork state
 	if err := uc.forkState.Save(ctx, state); err != nil {
 		return nil, fmt.Errorf("failed to save fork state: %w", err)
 	}
 
+	message := fmt.Sprintf("Reverted '%s' on fork '%s'", run.Command, entry.Network)
+	if len(dropped) > 0 {
+		message += fmt.Sprintf(" - removed checkpoint(s) taken after it: %s", strings.Join(dropped, ", "))
+	}
+
 	return &RevertForkResult{
-		RevertedCommand:    topSnapshot.Command,
+		RevertedCommand:    run.Command,
 		RevertedCount:      1,
 		RemainingSnapshots: len(entry.Snapshots),
-		Message:            fmt.Sprintf("Reverted '%s' on fork '%s'", topSnapshot.Command, entry.Network),
+		Message:            message,
 	}, nil
 }
 
@@ -143,7 +164,7 @@ func (uc *RevertFork) revertAll(ctx context.Context, state *domain.ForkState, en
 	}
 
 	// Remove all snapshot directories except 0
-	revertedCount := len(entry.Snapshots) - 1
+	revertedCount := entry.RunsAfter(0)
 	for i := len(entry.Snapshots) - 1; i >= 1; i-- {
 		snapshotDir := fmt.Sprintf("%s/.treb/priv/fork/%s/snapshots/%d", uc.cfg.ProjectRoot, entry.Network, entry.Snapshots[i].Index)
 		_ = os.RemoveAll(snapshotDir)
@@ -163,3 +184,56 @@ func (uc *RevertFork) revertAll(ctx context.Context, state *domain.ForkState, en
 		Message:            fmt.Sprintf("Reverted %d run(s) on fork '%s' - restored to initial fork state", revertedCount, entry.Network),
 	}, nil
 }
+
+// revertTo reverts to a named checkpoint, keeping the checkpoint so that it can be
+// reverted to again
+func (uc *RevertFork) revertTo(ctx context.Context, state *domain.ForkState, entry *domain.ForkEntry, name string) (*RevertForkResult, error) {
+	position := entry.GetCheckpoint(name)
+	if position < 0 {
+		return nil, fmt.Errorf("no checkpoint '%s' on fork '%s'", name, entry.Network)
+	}
+	checkpoint := &entry.Snapshots[position]
+
+	instance := &domain.AnvilInstance{
+		Name:    fmt.Sprintf("fork-%s", entry.Network),
+		Port:    portFromURL(entry.ForkURL),
+		ChainID: fmt.Sprintf("%d", entry.ChainID),
+		PidFile: entry.PidFile,
+		LogFile: entry.LogFile,
+	}
+
+	if err := uc.anvilManager.RevertSnapshot(ctx, instance, checkpoint.SnapshotID); err != nil {
+		return nil, fmt.Errorf("failed to revert EVM snapshot to checkpoint '%s': %w", name, err)
+	}
+
+	// Reverting consumes the EVM snapshot, take a new one for the checkpoint
+	snapshotID, err := uc.anvilManager.TakeSnapshot(ctx, instance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to take EVM snapshot: %w", err)
+	}
+	checkpoint.SnapshotID = snapshotID
+
+	// Restore registry files from the checkpoint's backup
+	if err := uc.forkFiles.RestoreFiles(ctx, entry.Network, checkpoint.Index); err != nil {
+		return nil, fmt.Errorf("failed to restore registry files: %w", err)
+	}
+
+	// Remove the snapshot directories after the checkpoint
+	revertedCount := entry.RunsAfter(position)
+	for i := len(entry.Snapshots) - 1; i > position; i-- {
+		snapshotDir := fmt.Sprintf("%s/.treb/priv/fork/%s/snapshots/%d", uc.cfg.ProjectRoot, entry.Network, entry.Snapshots[i].Index)
+		_ = os.RemoveAll(snapshotDir)
+	}
+
+	entry.Snapshots = entry.Snapshots[:position+1]
+
+	if err := uc.forkState.Save(ctx, state); err != nil {
+		return nil, fmt.Errorf("failed to save fork state: %w", err)
+	}
+
+	return &RevertForkResult{
+		RevertedCount:      revertedCount,
+		RemainingSnapshots: len(entry.Snapshots),
+		Message:            fmt.Sprintf("Reverted %d run(s) on fork '%s' - restored to checkpoint '%s'", revertedCount, entry.Network, name),
+	}, nil
+}
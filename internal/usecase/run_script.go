@@ -1,10 +1,14 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/trebuchet-org/treb-cli/internal/domain"
 	"github.com/trebuchet-org/treb-cli/internal/domain/config"
 	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
@@ -21,6 +25,12 @@ type RunScriptParams struct {
 	Verbose        bool
 	NonInteractive bool
 	DumpCommand    bool
+	ShowParams     bool                   // Resolve the script parameters and report their sources without running
+	Resume         bool                   // Resume an interrupted broadcast of the script
+	Confirm        bool                   // Review the simulated changeset and confirm before broadcasting
+	Yes            bool                   // Pre-approve the confirmation gate (required when non-interactive)
+	Compose        *models.RunComposeStep // Compose step the run belongs to, recorded in the run record
+	BroadcastDir   string                 // Directory forge writes broadcast files to, relative to the project root (default broadcast/)
 }
 
 // RunScriptResult contains the result of running a script
@@ -28,25 +38,60 @@ type RunScriptResult struct {
 	RunResult     *forge.HydratedRunResult
 	Changeset     *models.Changeset
 	Success       bool
+	Aborted       bool // Broadcast was declined at the confirmation gate
 	Error         error
 	DumpedCommand string
+	Attempts      []forge.BroadcastAttempt // Transactions landed per broadcast attempt when resuming
+	RunID         string                   // ID of the stored run record, empty for dry runs
+	ScriptParams  []domain.ScriptParameter // Parameters declared by the script
+	Parameters    *domain.ResolvedParameters
+}
+
+// ChangesetPreview is the simulated outcome of a run, shown before broadcasting
+type ChangesetPreview struct {
+	RunResult *forge.HydratedRunResult
+	Changeset *models.Changeset
 }
 
 // RunScript is the main use case for running deployment scripts
 type RunScript struct {
-	config            *config.RuntimeConfig
-	scriptResolver    ScriptResolver
-	paramResolver     ParameterResolver
-	sendersManager    SendersManager
-	forgeScriptRunner ForgeScriptRunner
-	runResultHydrator RunResultHydrator
-	registryUpdater   DeploymentRepositoryUpdater
-	libraryResolver   LibraryResolver
-	progress          RunProgressSink
-	forkStateStore    ForkStateStore
-	anvilManager      AnvilManager
-	forkFileManager   ForkFileManager
-	// paramPrompter   ParameterPrompter
+	config               *config.RuntimeConfig
+	scriptResolver       ScriptResolver
+	paramResolver        ParameterResolver
+	sendersManager       SendersManager
+	forgeScriptRunner    ForgeScriptRunner
+	runResultHydrator    RunResultHydrator
+	registryUpdater      DeploymentRepositoryUpdater
+	libraryResolver      LibraryResolver
+	progress             RunProgressSink
+	forkStateStore       ForkStateStore
+	anvilManager         AnvilManager
+	forkFileManager      ForkFileManager
+	confirmer            Confirmer
+	blockchainChecker    BlockchainChecker
+	broadcastReader      BroadcastReader
+	checkpointStore      BroadcastCheckpointStore
+	runRecordStore       RunRecordStore
+	environmentInspector RunEnvironmentInspector
+	paramPrompter        ParameterPrompter
+	safeConfirmer        SafeConfirmer
+
+	// Runs can execute in parallel (compose --parallel): registry writes and the shared
+	// chain clients are serialized, and broadcasting senders are locked per address so
+	// that two runs never race for the same nonces
+	sharedMu    *RegistryLock
+	senderLocks sync.Map // common.Address -> *sync.Mutex
+}
+
+// RegistryLock serializes the registry writes and the use of the shared chain clients of
+// runs and compose hooks that execute in parallel
+type RegistryLock struct {
+	sync.Mutex
+}
+
+// NewRegistryLock creates the lock shared by the use cases of a command
+func NewRegistryLock() *RegistryLock {
+	return &RegistryLock{}
 }
 
 // NewRunScript creates a new RunScript use case
@@ -63,21 +108,38 @@ func NewRunScript(
 	forkStateStore ForkStateStore,
 	anvilManager AnvilManager,
 	forkFileManager ForkFileManager,
+	confirmer Confirmer,
+	blockchainChecker BlockchainChecker,
+	broadcastReader BroadcastReader,
+	checkpointStore BroadcastCheckpointStore,
+	runRecordStore RunRecordStore,
+	environmentInspector RunEnvironmentInspector,
+	paramPrompter ParameterPrompter,
+	safeConfirmer SafeConfirmer,
+	sharedMu *RegistryLock,
 ) *RunScript {
 	return &RunScript{
-		config:            cfg,
-		scriptResolver:    scriptResolver,
-		paramResolver:     paramResolver,
-		sendersManager:    sendersManager,
-		forgeScriptRunner: forgeScriptRunner,
-		runResultHydrator: runResultHydrator,
-		registryUpdater:   registryUpdater,
-		libraryResolver:   libraryResolver,
-		progress:          progress,
-		forkStateStore:    forkStateStore,
-		anvilManager:      anvilManager,
-		forkFileManager:   forkFileManager,
-		// paramPrompter:   paramPrompter,
+		config:               cfg,
+		scriptResolver:       scriptResolver,
+		paramResolver:        paramResolver,
+		sendersManager:       sendersManager,
+		forgeScriptRunner:    forgeScriptRunner,
+		runResultHydrator:    runResultHydrator,
+		registryUpdater:      registryUpdater,
+		libraryResolver:      libraryResolver,
+		progress:             progress,
+		forkStateStore:       forkStateStore,
+		anvilManager:         anvilManager,
+		forkFileManager:      forkFileManager,
+		confirmer:            confirmer,
+		blockchainChecker:    blockchainChecker,
+		broadcastReader:      broadcastReader,
+		checkpointStore:      checkpointStore,
+		runRecordStore:       runRecordStore,
+		environmentInspector: environmentInspector,
+		paramPrompter:        paramPrompter,
+		safeConfirmer:        safeConfirmer,
+		sharedMu:             sharedMu,
 	}
 }
 
@@ -90,6 +152,10 @@ func (uc *RunScript) Run(ctx context.Context, params RunScriptParams) (*RunScrip
 		Success: false,
 	}
 
+	if params.Resume && params.DryRun {
+		return result, fmt.Errorf("--resume cannot be combined with --dry-run")
+	}
+
 	// Stage 1: Resolve script
 	script, err := uc.scriptResolver.ResolveScript(ctx, params.ScriptRef)
 	if err != nil {
@@ -104,24 +170,31 @@ func (uc *RunScript) Run(ctx context.Context, params RunScriptParams) (*RunScrip
 	}
 
 	// Resolve parameter values
-	resolvedParams, err := uc.paramResolver.ResolveParameters(ctx, scriptParams, params.Parameters)
+	resolved, err := uc.paramResolver.ResolveParameters(ctx, script.Name, scriptParams, params.Parameters)
 	if err != nil {
 		return result, err
-		// TODO: fix this after fixing parameters
-		// if !params.NonInteractive {
-		// 	// Try prompting for missing parameters
-		// 	prompted, promptErr := uc.paramPrompter.PromptForParameters(ctx, scriptParams, resolvedParams)
-		// 	if promptErr != nil {
-		// 		result.Error = fmt.Errorf("failed to prompt for parameters: %w", promptErr)
-		// 		return result, nil
-		// 	}
-		// 	resolvedParams = prompted
-		// } else {
-		// 	result.Error = fmt.Errorf("parameter resolution failed: %w", err)
-		// 	return result, nil
-		// }
 	}
 
+	// Prompt for required values that could not be resolved, then resolve them again so
+	// that references and rich types are handled like any other value
+	if !params.ShowParams && !params.NonInteractive && !uc.config.NonInteractive {
+		resolved, err = uc.promptForMissingParameters(ctx, script.Name, scriptParams, params.Parameters, resolved)
+		if err != nil {
+			return result, err
+		}
+	}
+	if err := resolved.Err(); err != nil {
+		return result, err
+	}
+
+	result.ScriptParams = scriptParams
+	result.Parameters = resolved
+	if params.ShowParams {
+		result.Success = true
+		return result, nil
+	}
+	resolvedParams := resolved.Values
+
 	// Validate all required parameters have values
 	if err := uc.paramResolver.ValidateParameters(ctx, scriptParams, resolvedParams); err != nil {
 		return result, fmt.Errorf("parameter validation failed: %w", err)
@@ -160,14 +233,14 @@ func (uc *RunScript) Run(ctx context.Context, params RunScriptParams) (*RunScrip
 
 	// Check for active fork and build env overrides
 	var forkEnvOverrides map[string]string
-	if uc.config.Network != nil {
-		forkState, forkErr := uc.forkStateStore.Load(ctx)
-		if forkErr == nil {
-			if fork := forkState.GetActiveFork(uc.config.Network.Name); fork != nil && fork.EnvVarName != "" {
-				forkEnvOverrides = map[string]string{
-					fork.EnvVarName: fork.ForkURL,
-				}
+	rpcURL := uc.config.Network.RPCURL
+	forkState, forkErr := uc.forkStateStore.Load(ctx)
+	if forkErr == nil {
+		if fork := forkState.GetActiveFork(uc.config.Network.Name); fork != nil && fork.EnvVarName != "" {
+			forkEnvOverrides = map[string]string{
+				fork.EnvVarName: fork.ForkURL,
 			}
+			rpcURL = fork.ForkURL
 		}
 	}
 
@@ -179,23 +252,86 @@ func (uc *RunScript) Run(ctx context.Context, params RunScriptParams) (*RunScrip
 		Parameters:         resolvedParams,
 		Libraries:          libraryStrings,
 		DryRun:             params.DryRun,
+		Resume:             params.Resume,
 		Debug:              params.Debug,
 		DebugJSON:          params.DebugJSON,
 		Progress:           uc.progress,
 		SenderScriptConfig: *senderScriptConfig,
 		Slow:               uc.config.Slow,
 		ForkEnvOverrides:   forkEnvOverrides,
+		BroadcastDir:       params.BroadcastDir,
+	}
+
+	// Broadcasts can be gated behind a reviewed changeset, either per run or per namespace.
+	// A resumed broadcast was already approved when it was first started.
+	requireConfirmation := !params.DryRun && !params.DumpCommand && !params.Resume &&
+		(params.Confirm || uc.config.RequireConfirmation)
+	if requireConfirmation && !params.Yes && (params.NonInteractive || uc.config.NonInteractive) {
+		return result, fmt.Errorf("broadcast requires confirmation but prompts are disabled, pass --yes to approve it")
 	}
 
-	// Fork mode pre-run checks: health check + snapshot
+	// Fork mode pre-run check: health check before anything touches the fork
 	if forkEnvOverrides != nil && uc.config.Network != nil {
-		// Health-check fork anvil before proceeding
 		if err := uc.checkForkHealth(ctx); err != nil {
 			return result, err
 		}
+	}
+
+	// Parameters marked contract must point at deployed code before forge is launched
+	if !params.DumpCommand {
+		uc.sharedMu.Lock()
+		err := uc.checkContractParameters(ctx, scriptParams, resolvedParams, rpcURL)
+		uc.sharedMu.Unlock()
+		if err != nil {
+			return result, err
+		}
+	}
+
+	// Forge reads the nonces of the senders when it starts, so a broadcast holds its senders
+	// until the run is over
+	if !params.DryRun && !params.DumpCommand {
+		defer uc.lockSenders(senderScriptConfig)()
+	}
+
+	if requireConfirmation {
+		uc.progress.OnProgress(ctx, ProgressEvent{
+			Stage:    string(StageSimulating),
+			Message:  "Simulating",
+			Metadata: &runScriptConfig,
+		})
 
+		preview, err := uc.previewChangeset(ctx, runScriptConfig)
+		if err != nil {
+			result.Error = err
+			return result, nil
+		}
+
+		uc.progress.OnProgress(ctx, ProgressEvent{
+			Stage:    string(StagePreview),
+			Message:  "Review changes",
+			Metadata: preview,
+		})
+
+		if !params.Yes {
+			approved, err := uc.confirmer.Confirm(ctx, "Broadcast these changes?")
+			if err != nil {
+				return result, fmt.Errorf("confirmation failed: %w", err)
+			}
+			if !approved {
+				result.Aborted = true
+				result.Error = fmt.Errorf("broadcast cancelled by user")
+				return result, nil
+			}
+		}
+	}
+
+	// Fork mode pre-run snapshot
+	if forkEnvOverrides != nil && uc.config.Network != nil {
 		// Take EVM snapshot and backup files before execution
-		if snapshotErr := uc.takePreRunSnapshot(ctx, params.ScriptRef); snapshotErr != nil {
+		uc.sharedMu.Lock()
+		snapshotErr := uc.takePreRunSnapshot(ctx, params.ScriptRef)
+		uc.sharedMu.Unlock()
+		if snapshotErr != nil {
 			return result, fmt.Errorf("failed to take pre-run fork snapshot: %w", snapshotErr)
 		}
 	}
@@ -216,23 +352,65 @@ func (uc *RunScript) Run(ctx context.Context, params RunScriptParams) (*RunScrip
 		return result, nil
 	}
 
-	uc.progress.OnProgress(ctx, ProgressEvent{
-		Stage:    string(StageSimulating),
-		Message:  "Simulating",
-		Metadata: &runScriptConfig,
-	})
+	var checkpoint *forge.BroadcastCheckpoint
+	if params.Resume {
+		checkpoint, err = uc.prepareResume(ctx, runScriptConfig, rpcURL)
+		if err != nil {
+			return result, fmt.Errorf("failed to resume broadcast: %w", err)
+		}
+	}
+
+	// Every broadcast leaves a record of how it was produced, whatever its outcome.
+	// Runs against a fork are recorded too, marked as such.
+	var runID string
+	if !params.DryRun {
+		record := uc.newRunRecord(ctx, runScriptConfig, scriptParams, params, startTime)
+		runID = record.ID
+		defer uc.finishRunRecord(ctx, record, result)
+	}
+
+	if requireConfirmation {
+		uc.progress.OnProgress(ctx, ProgressEvent{
+			Stage:   string(StageBroadcasting),
+			Message: "Broadcasting",
+		})
+	} else {
+		uc.progress.OnProgress(ctx, ProgressEvent{
+			Stage:    string(StageSimulating),
+			Message:  "Simulating",
+			Metadata: &runScriptConfig,
+		})
+	}
 
 	runResult, err := uc.forgeScriptRunner.RunScript(ctx, runScriptConfig)
 	result.RunResult = &forge.HydratedRunResult{RunResult: runResult}
 
-	if err != nil {
-		result.Error = fmt.Errorf("script execution failed: %w", err)
+	if err != nil || !runResult.Success {
+		// Keep what is needed to resume a broadcast that died halfway. A resumed run
+		// already has a checkpoint, reconciled before forge was started.
+		if !params.DryRun && checkpoint == nil {
+			if saveErr := uc.saveCheckpoint(ctx, runScriptConfig, runResult, startTime); saveErr != nil {
+				uc.progress.Info(fmt.Sprintf("Warning: failed to save broadcast checkpoint: %v", saveErr))
+			}
+		}
+		if err != nil {
+			result.Error = fmt.Errorf("script execution failed: %w", err)
+		} else {
+			result.Error = fmt.Errorf("script execution failed")
+		}
 		return result, nil
 	}
 
-	if !runResult.Success {
-		result.Error = fmt.Errorf("script execution failed")
-		return result, nil
+	// Forge does not re-execute the script when resuming, so hydrate from the original output
+	if checkpoint != nil {
+		if runResult.ParsedOutput == nil {
+			runResult.ParsedOutput = &forge.ParsedOutput{}
+		}
+		runResult.ParsedOutput.ScriptOutput = checkpoint.ScriptOutput
+		runResult.ParsedOutput.TraceOutputs = slices.Concat(checkpoint.TraceOutputs, runResult.ParsedOutput.TraceOutputs)
+		if runResult.BroadcastPath == "" {
+			runResult.BroadcastPath = checkpoint.BroadcastPath
+		}
 	}
 
 	// Stage 4: Parse execution
@@ -263,21 +441,33 @@ func (uc *RunScript) Run(ctx context.Context, params RunScriptParams) (*RunScrip
 			Stage: string(StageParsing),
 		})
 
-		changeset, err := uc.registryUpdater.BuildChangesetFromRunResult(ctx, result.RunResult)
-		if err != nil {
-			result.Error = fmt.Errorf("failed to prepare registry updates: %w", err)
+		if err := uc.updateRegistry(ctx, result, runID); err != nil {
+			result.Error = err
 			return result, nil
 		}
+	}
 
-		if changeset.HasChanges() {
-			if err := uc.registryUpdater.ApplyChangeset(ctx, changeset); err != nil {
-				result.Error = fmt.Errorf("failed to update registry: %w", err)
-				return result, nil
-			}
-			result.Changeset = changeset
+	if checkpoint != nil {
+		uc.sharedMu.Lock()
+		_, err := uc.reconcileBroadcast(ctx, checkpoint, rpcURL, true)
+		uc.sharedMu.Unlock()
+		if err != nil {
+			uc.progress.Info(fmt.Sprintf("Warning: failed to reconcile resumed broadcast: %v", err))
+		}
+		result.Attempts = checkpoint.Attempts
+	}
+	if !params.DryRun {
+		// The broadcast completed, so any interrupted broadcast of this script is settled
+		if err := uc.checkpointStore.Delete(ctx, params.BroadcastDir, script.Path, uc.config.Network.ChainID); err != nil {
+			uc.progress.Info(fmt.Sprintf("Warning: failed to clear broadcast checkpoint: %v", err))
 		}
 	}
 
+	// The Safe Transaction Service only knows about Safe transactions proposed on the real chain
+	if !params.DryRun && forkEnvOverrides == nil {
+		uc.confirmSafeTransactions(ctx, result.RunResult.SafeTransactions)
+	}
+
 	// Stage 6: Complete
 	uc.progress.OnProgress(ctx, ProgressEvent{
 		Stage: string(StageCompleted),
@@ -287,6 +477,126 @@ func (uc *RunScript) Run(ctx context.Context, params RunScriptParams) (*RunScrip
 	return result, nil
 }
 
+// confirmSafeTransactions confirms the Safe transactions a broadcast proposed as the confirmers
+// configured on the Safe's sender. A failed confirmation does not fail the run: the transaction
+// stays proposed and can still be confirmed in the Safe UI.
+func (uc *RunScript) confirmSafeTransactions(ctx context.Context, safeTxs []*forge.SafeTransaction) {
+	if uc.safeConfirmer == nil || uc.config.TrebConfig == nil || uc.config.Network == nil {
+		return
+	}
+
+	for _, safeTx := range safeTxs {
+		if safeTx.Executed {
+			continue
+		}
+		safeTxHash := common.Hash(safeTx.SafeTxHash)
+		for _, confirmer := range uc.safeConfirmers(safeTx.Safe) {
+			owner, err := uc.safeConfirmer.ConfirmSafeTransaction(ctx, uc.config.Network.ChainID, safeTxHash, confirmer)
+			if err != nil {
+				uc.progress.Info(fmt.Sprintf("Warning: failed to confirm Safe transaction %s as %s: %v", safeTxHash.Hex(), confirmer, err))
+				continue
+			}
+			uc.progress.Info(fmt.Sprintf("Confirmed Safe transaction %s as %s (%s)", safeTxHash.Hex(), confirmer, owner.Hex()))
+		}
+	}
+}
+
+// safeConfirmers lists the confirmers configured on the Safe senders of a Safe
+func (uc *RunScript) safeConfirmers(safe common.Address) []string {
+	var confirmers []string
+	for _, sender := range uc.config.TrebConfig.Senders {
+		if sender.Type == config.SenderTypeSafe && common.HexToAddress(sender.Safe) == safe {
+			confirmers = append(confirmers, sender.Confirmers...)
+		}
+	}
+	slices.Sort(confirmers)
+	return slices.Compact(confirmers)
+}
+
+// updateRegistry records the deployments of a run. The changeset is built and applied in one
+// step so that parallel runs see each other's deployments.
+func (uc *RunScript) updateRegistry(ctx context.Context, result *RunScriptResult, runID string) error {
+	uc.sharedMu.Lock()
+	defer uc.sharedMu.Unlock()
+
+	changeset, err := uc.registryUpdater.BuildChangesetFromRunResult(ctx, result.RunResult)
+	if err != nil {
+		return fmt.Errorf("failed to prepare registry updates: %w", err)
+	}
+
+	for _, deployment := range changeset.Create.Deployments {
+		deployment.RunID = runID
+	}
+
+	if changeset.HasChanges() {
+		if err := uc.registryUpdater.ApplyChangeset(ctx, changeset); err != nil {
+			return fmt.Errorf("failed to update registry: %w", err)
+		}
+		result.Changeset = changeset
+	}
+	return nil
+}
+
+// lockSenders locks every address that broadcasts in the run, in address order so that runs
+// sharing senders cannot deadlock, and returns the function releasing them
+func (uc *RunScript) lockSenders(senderConfig *config.SenderScriptConfig) func() {
+	var addresses []common.Address
+	for _, sender := range senderConfig.SenderInitConfigs {
+		if sender.CanBroadcast && !slices.Contains(addresses, sender.Account) {
+			addresses = append(addresses, sender.Account)
+		}
+	}
+	slices.SortFunc(addresses, func(a, b common.Address) int {
+		return bytes.Compare(a.Bytes(), b.Bytes())
+	})
+
+	locks := make([]*sync.Mutex, 0, len(addresses))
+	for _, address := range addresses {
+		lock, _ := uc.senderLocks.LoadOrStore(address, &sync.Mutex{})
+		lock.(*sync.Mutex).Lock()
+		locks = append(locks, lock.(*sync.Mutex))
+	}
+
+	return func() {
+		for i := len(locks) - 1; i >= 0; i-- {
+			locks[i].Unlock()
+		}
+	}
+}
+
+// previewChangeset simulates the script without broadcasting and builds the changeset
+// the registry would receive, so it can be reviewed before anything is sent on chain.
+func (uc *RunScript) previewChangeset(ctx context.Context, runScriptConfig RunScriptConfig) (*ChangesetPreview, error) {
+	simulation := runScriptConfig
+	simulation.DryRun = true
+
+	runResult, err := uc.forgeScriptRunner.RunScript(ctx, simulation)
+	if err != nil {
+		return nil, fmt.Errorf("simulation failed: %w", err)
+	}
+	if !runResult.Success {
+		if runResult.Error != nil {
+			return nil, fmt.Errorf("simulation failed: %w", runResult.Error)
+		}
+		return nil, fmt.Errorf("simulation failed")
+	}
+
+	hydrated, err := uc.runResultHydrator.Hydrate(ctx, runResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hydrate simulation result: %w", err)
+	}
+
+	changeset, err := uc.registryUpdater.BuildChangesetFromRunResult(ctx, hydrated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build changeset preview: %w", err)
+	}
+
+	return &ChangesetPreview{
+		RunResult: hydrated,
+		Changeset: changeset,
+	}, nil
+}
+
 // checkForkHealth verifies the fork anvil process is alive and responsive before a fork mode run.
 func (uc *RunScript) checkForkHealth(ctx context.Context) error {
 	networkName := uc.config.Network.Name
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+)
+
+// promptForMissingParameters asks for the required parameters that have no value and
+// resolves the parameters again with the answers, so prompted values are parsed and
+// normalised like values from any other source
+func (uc *RunScript) promptForMissingParameters(
+	ctx context.Context,
+	script string,
+	scriptParams []domain.ScriptParameter,
+	values map[string]string,
+	resolved *domain.ResolvedParameters,
+) (*domain.ResolvedParameters, error) {
+	var missing []domain.ScriptParameter
+	for _, param := range scriptParams {
+		if !param.Optional && resolved.Values[param.Name] == "" {
+			missing = append(missing, param)
+		}
+	}
+	if len(missing) == 0 || uc.paramPrompter == nil {
+		return resolved, nil
+	}
+
+	prompted, err := uc.paramPrompter.PromptForParameters(ctx, missing, resolved.Values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prompt for parameters: %w", err)
+	}
+
+	merged := make(map[string]string, len(values)+len(prompted))
+	maps.Copy(merged, values)
+	for _, param := range missing {
+		if value := prompted[param.Name]; value != "" {
+			merged[param.Name] = value
+		}
+	}
+
+	resolved, err = uc.paramResolver.ResolveParameters(ctx, script, scriptParams, merged)
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range missing {
+		if prompted[param.Name] != "" {
+			source := resolved.Sources[param.Name]
+			source.Kind, source.Location = domain.ParamSourcePrompt, ""
+			resolved.Sources[param.Name] = source
+		}
+	}
+	return resolved, nil
+}
+
+// checkContractParameters verifies that the addresses of parameters declared with the
+// contract constraint have code on the target chain
+func (uc *RunScript) checkContractParameters(
+	ctx context.Context,
+	scriptParams []domain.ScriptParameter,
+	values map[string]string,
+	rpcURL string,
+) error {
+	type check struct{ param, address string }
+	var checks []check
+	for _, param := range scriptParams {
+		if !param.Constraints.Contract || values[param.Name] == "" {
+			continue
+		}
+		for _, address := range strings.Split(values[param.Name], ",") {
+			checks = append(checks, check{param: param.Name, address: strings.TrimSpace(address)})
+		}
+	}
+	if len(checks) == 0 {
+		return nil
+	}
+
+	if err := uc.blockchainChecker.Connect(ctx, rpcURL, uc.config.Network.ChainID); err != nil {
+		return fmt.Errorf("failed to connect to %s to check contract parameters: %w", uc.config.Network.Name, err)
+	}
+
+	var invalid []string
+	for _, c := range checks {
+		exists, reason, err := uc.blockchainChecker.CheckDeploymentExists(ctx, c.address)
+		if err != nil {
+			return fmt.Errorf("failed to check code at %s: %w", c.address, err)
+		}
+		if !exists {
+			invalid = append(invalid, fmt.Sprintf("%s: %s is not a contract on %s (%s)", c.param, c.address, uc.config.Network.Name, reason))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid parameter values:\n  %s", strings.Join(invalid, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// newRunRecord starts the record of a broadcast run from its resolved inputs and environment
+func (uc *RunScript) newRunRecord(
+	ctx context.Context,
+	runScriptConfig RunScriptConfig,
+	scriptParams []domain.ScriptParameter,
+	params RunScriptParams,
+	startTime time.Time,
+) *models.RunRecord {
+	record := &models.RunRecord{
+		ID:             newRunID(startTime),
+		Script:         runScriptConfig.Script.Name,
+		ScriptPath:     runScriptConfig.Script.Path,
+		Namespace:      runScriptConfig.Namespace,
+		Network:        runScriptConfig.Network.Name,
+		ChainID:        runScriptConfig.Network.ChainID,
+		FoundryProfile: runScriptConfig.FoundryProfile,
+		Parameters:     redactParameters(scriptParams, runScriptConfig.Parameters),
+		Senders:        runSenders(runScriptConfig.SenderScriptConfig),
+		Compose:        params.Compose,
+		Resumed:        params.Resume,
+		Fork:           runScriptConfig.ForkEnvOverrides != nil,
+		StartedAt:      startTime,
+	}
+
+	env, err := uc.environmentInspector.Inspect(ctx)
+	if err != nil {
+		uc.progress.Info(fmt.Sprintf("Warning: failed to inspect run environment: %v", err))
+	} else {
+		record.TrebVersion = env.TrebVersion
+		record.ForgeVersion = env.ForgeVersion
+		record.Git = env.Git
+		record.Operator = env.Operator
+	}
+
+	return record
+}
+
+// finishRunRecord stores the outcome of the run. A record that cannot be written is reported
+// but does not fail a broadcast that already happened.
+func (uc *RunScript) finishRunRecord(ctx context.Context, record *models.RunRecord, result *RunScriptResult) {
+	record.CompletedAt = time.Now()
+	record.Status = models.RunStatusCompleted
+	if !result.Success {
+		record.Status = models.RunStatusFailed
+		if result.Error != nil {
+			record.Error = result.Error.Error()
+		}
+	}
+	record.Changeset = runChangeset(result.Changeset)
+	addSenderGas(record.Senders, result.RunResult)
+
+	if err := uc.runRecordStore.Save(ctx, record); err != nil {
+		uc.progress.Info(fmt.Sprintf("Warning: failed to save run record: %v", err))
+		return
+	}
+	result.RunID = record.ID
+}
+
+// newRunID returns a sortable, unique run ID such as "20250126-150405-a1b2c3"
+func newRunID(startTime time.Time) string {
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s-%s", startTime.UTC().Format("20060102-150405"), hex.EncodeToString(suffix))
+}
+
+// redactParameters copies the resolved parameters, hiding the values of secret-typed ones
+func redactParameters(scriptParams []domain.ScriptParameter, values map[string]string) map[string]string {
+	secret := make(map[string]bool)
+	for _, param := range scriptParams {
+		if param.Type == domain.ParamTypeSecret {
+			secret[param.Name] = true
+		}
+	}
+
+	redacted := make(map[string]string, len(values))
+	for name, value := range values {
+		if secret[name] && value != "" {
+			value = models.RedactedValue
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+// runSenders lists the configured senders of a run, leaving out private keys
+func runSenders(senderConfig config.SenderScriptConfig) []models.RunSender {
+	senders := make([]models.RunSender, 0, len(senderConfig.SenderInitConfigs))
+	for _, sender := range senderConfig.SenderInitConfigs {
+		base := sender.BaseConfig
+		senders = append(senders, models.RunSender{
+			Name:           sender.Name,
+			Type:           string(base.Type),
+			Address:        sender.Account.Hex(),
+			Safe:           base.Safe,
+			Signer:         base.Signer,
+			DerivationPath: base.DerivationPath,
+			Governor:       base.Governor,
+			Timelock:       base.Timelock,
+			Proposer:       base.Proposer,
+			Keystore:       base.Keystore,
+		})
+	}
+	return senders
+}
+
+// addSenderGas totals the gas of the transactions each sender broadcast itself during the run.
+// Transactions routed through a Safe, Governor or timelock are paid for by their proposer.
+func addSenderGas(senders []models.RunSender, runResult *forge.HydratedRunResult) {
+	if runResult == nil {
+		return
+	}
+	for _, tx := range runResult.Transactions {
+		if tx.GasUsed == nil || tx.SafeTransaction != nil || tx.GovernorProposal != nil || tx.TimelockOperation != nil {
+			continue
+		}
+		for i := range senders {
+			if strings.EqualFold(senders[i].Address, tx.Sender.Hex()) {
+				senders[i].GasUsed += *tx.GasUsed
+				break
+			}
+		}
+	}
+}
+
+// runChangeset collects the registry IDs created or updated by a run
+func runChangeset(changeset *models.Changeset) models.RunChangeset {
+	ids := models.RunChangeset{
+		Deployments:      []string{},
+		Transactions:     []string{},
+		SafeTransactions: []string{},
+	}
+	if changeset == nil {
+		return ids
+	}
+
+	for _, set := range []models.ChangesetModels{changeset.Create, changeset.Update} {
+		for _, dep := range set.Deployments {
+			ids.Deployments = append(ids.Deployments, dep.ID)
+		}
+		for _, tx := range set.Transactions {
+			ids.Transactions = append(ids.Transactions, tx.ID)
+		}
+		for _, safeTx := range set.SafeTransactions {
+			ids.SafeTransactions = append(ids.SafeTransactions, safeTx.SafeTxHash)
+		}
+		for _, op := range set.TimelockOperations {
+			ids.TimelockOperations = append(ids.TimelockOperations, op.OperationID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/bindings"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+func TestRedactParameters(t *testing.T) {
+	scriptParams := []domain.ScriptParameter{
+		{Name: "OWNER", Type: domain.ParamTypeAddress},
+		{Name: "API_KEY", Type: domain.ParamTypeSecret},
+		{Name: "OPTIONAL_SECRET", Type: domain.ParamTypeSecret, Optional: true},
+	}
+	values := map[string]string{
+		"OWNER":           "0x0000000000000000000000000000000000000001",
+		"API_KEY":         "sk-live-123",
+		"OPTIONAL_SECRET": "",
+		"EXTRA":           "value",
+	}
+
+	redacted := redactParameters(scriptParams, values)
+
+	assert.Equal(t, map[string]string{
+		"OWNER":           "0x0000000000000000000000000000000000000001",
+		"API_KEY":         models.RedactedValue,
+		"OPTIONAL_SECRET": "",
+		"EXTRA":           "value",
+	}, redacted)
+	assert.Equal(t, "sk-live-123", values["API_KEY"], "input must not be modified")
+}
+
+func TestRunSenders_OmitsKeyMaterial(t *testing.T) {
+	senders := runSenders(config.SenderScriptConfig{
+		SenderInitConfigs: []config.SenderInitConfig{
+			{
+				Name:    "deployer",
+				Account: common.HexToAddress("0x01"),
+				BaseConfig: config.SenderConfig{
+					Type:       config.SenderType("private_key"),
+					PrivateKey: "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+				},
+			},
+			{
+				Name:    "multisig",
+				Account: common.HexToAddress("0x02"),
+				BaseConfig: config.SenderConfig{
+					Type:   config.SenderType("safe"),
+					Safe:   "0x0000000000000000000000000000000000000002",
+					Signer: "deployer",
+				},
+			},
+		},
+	})
+
+	require.Len(t, senders, 2)
+	assert.Equal(t, models.RunSender{
+		Name:    "deployer",
+		Type:    "private_key",
+		Address: common.HexToAddress("0x01").Hex(),
+	}, senders[0])
+	assert.Equal(t, "deployer", senders[1].Signer)
+	assert.Equal(t, "0x0000000000000000000000000000000000000002", senders[1].Safe)
+}
+
+func TestAddSenderGas(t *testing.T) {
+	deployer := common.HexToAddress("0x01")
+	gas := func(g uint64) *uint64 { return &g }
+	tx := func(sender common.Address, gasUsed *uint64) *forge.Transaction {
+		return &forge.Transaction{SimulatedTransaction: bindings.SimulatedTransaction{Sender: sender}, GasUsed: gasUsed}
+	}
+
+	senders := []models.RunSender{
+		{Name: "deployer", Address: deployer.Hex()},
+		{Name: "multisig", Address: common.HexToAddress("0x02").Hex()},
+	}
+	addSenderGas(senders, &forge.HydratedRunResult{Transactions: []*forge.Transaction{
+		tx(deployer, gas(21000)),
+		tx(deployer, gas(100000)),
+		tx(common.HexToAddress("0x02"), nil), // Queued Safe transaction, not broadcast
+	}})
+
+	assert.Equal(t, uint64(121000), senders[0].GasUsed)
+	assert.Zero(t, senders[1].GasUsed)
+
+	// Dry runs and failures before broadcast have no result
+	addSenderGas(senders, nil)
+	assert.Equal(t, uint64(121000), senders[0].GasUsed)
+}
+
+func TestRunChangeset(t *testing.T) {
+	assert.Equal(t, models.RunChangeset{
+		Deployments:      []string{},
+		Transactions:     []string{},
+		SafeTransactions: []string{},
+	}, runChangeset(nil))
+
+	changeset := &models.Changeset{
+		Create: models.ChangesetModels{
+			Deployments:      []*models.Deployment{{ID: "default/1/Counter"}},
+			Transactions:     []*models.Transaction{{ID: "tx-0x01"}},
+			SafeTransactions: []*models.SafeTransaction{{SafeTxHash: "0xsafe"}},
+		},
+		Update: models.ChangesetModels{
+			Deployments: []*models.Deployment{{ID: "default/1/Proxy"}},
+		},
+	}
+
+	ids := runChangeset(changeset)
+	assert.Equal(t, []string{"default/1/Counter", "default/1/Proxy"}, ids.Deployments)
+	assert.Equal(t, []string{"tx-0x01"}, ids.Transactions)
+	assert.Equal(t, []string{"0xsafe"}, ids.SafeTransactions)
+}
+
+func TestNewRunID(t *testing.T) {
+	assert.Regexp(t, `^\d{8}-\d{6}-[0-9a-f]{6}$`, newRunID(time.Date(2025, 1, 26, 15, 4, 5, 0, time.UTC)))
+}
+
+func TestNewRunRecord_MarksForkRuns(t *testing.T) {
+	uc := &RunScript{environmentInspector: noEnvironment{}, progress: NopProgress{}}
+	runScriptConfig := RunScriptConfig{
+		Network: &config.Network{Name: "sepolia", ChainID: 11155111},
+		Script:  &models.Contract{Name: "DeployCounter", Path: "script/DeployCounter.s.sol"},
+	}
+
+	record := uc.newRunRecord(t.Context(), runScriptConfig, nil, RunScriptParams{}, time.Now())
+	assert.False(t, record.Fork)
+
+	runScriptConfig.ForkEnvOverrides = map[string]string{"SEPOLIA_RPC_URL": "http://127.0.0.1:54321"}
+	record = uc.newRunRecord(t.Context(), runScriptConfig, nil, RunScriptParams{}, time.Now())
+	assert.True(t, record.Fork)
+}
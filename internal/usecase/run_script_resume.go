@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// prepareResume loads the checkpoint of an interrupted broadcast, reconciles it with on-chain
+// state and records every transaction that already landed in the registry before forge resumes.
+func (uc *RunScript) prepareResume(ctx context.Context, runScriptConfig RunScriptConfig, rpcURL string) (*forge.BroadcastCheckpoint, error) {
+	script := runScriptConfig.Script
+	chainID := runScriptConfig.Network.ChainID
+
+	checkpoint, err := uc.checkpointStore.Load(ctx, runScriptConfig.BroadcastDir, script.Path, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint == nil {
+		return nil, fmt.Errorf("no interrupted broadcast of %s on chain %d to resume", script.Name, chainID)
+	}
+	if checkpoint.Namespace != runScriptConfig.Namespace {
+		return nil, fmt.Errorf("cannot resume: broadcast was started in namespace '%s', not '%s'", checkpoint.Namespace, runScriptConfig.Namespace)
+	}
+
+	uc.progress.Info("Reconciling broadcast with on-chain state...")
+
+	pending, err := uc.reconcileBroadcast(ctx, checkpoint, rpcURL, false)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint.UpdatedAt = time.Now()
+	if err := uc.checkpointStore.Save(ctx, checkpoint); err != nil {
+		return nil, err
+	}
+
+	mined := checkpoint.MinedHashes()
+	if len(mined) > 0 {
+		recovered, err := uc.recoverMinedTransactions(ctx, runScriptConfig, checkpoint, mined)
+		if err != nil {
+			return nil, err
+		}
+		uc.progress.Info(fmt.Sprintf("Recovered %d already mined transaction(s) into the registry", recovered))
+	}
+	uc.progress.Info(fmt.Sprintf("Resuming broadcast with %d pending transaction(s)", pending))
+
+	return checkpoint, nil
+}
+
+// reconcileBroadcast checks every sent transaction in the broadcast file against the chain and
+// records the ones that were mined since the last reconciliation as a new attempt.
+// It returns the number of transactions that are still pending.
+func (uc *RunScript) reconcileBroadcast(ctx context.Context, checkpoint *forge.BroadcastCheckpoint, rpcURL string, resumed bool) (int, error) {
+	broadcast, err := uc.broadcastReader.ReadBroadcast(checkpoint.BroadcastPath)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := uc.blockchainChecker.Connect(ctx, rpcURL, checkpoint.ChainID); err != nil {
+		return 0, fmt.Errorf("failed to connect to network: %w", err)
+	}
+
+	known := checkpoint.MinedHashes()
+	attempt := forge.BroadcastAttempt{
+		Number:  len(checkpoint.Attempts) + 1,
+		Resumed: resumed,
+	}
+	pending := 0
+
+	for _, tx := range broadcast.Transactions {
+		hash := strings.ToLower(tx.Hash)
+		if known[hash] {
+			continue
+		}
+		if hash == "" {
+			pending++
+			continue
+		}
+
+		exists, blockNumber, _, err := uc.blockchainChecker.CheckTransactionExists(ctx, hash)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check transaction %s: %w", hash, err)
+		}
+		if !exists {
+			pending++
+			continue
+		}
+
+		attempt.Transactions = append(attempt.Transactions, forge.BroadcastAttemptTx{
+			Hash:        hash,
+			BlockNumber: blockNumber,
+			Description: describeBroadcastTx(tx.ContractName, tx.Function),
+		})
+	}
+
+	if len(attempt.Transactions) > 0 {
+		checkpoint.Attempts = append(checkpoint.Attempts, attempt)
+	}
+
+	return pending, nil
+}
+
+// recoverMinedTransactions hydrates the checkpointed script output and applies the part of the
+// changeset that belongs to mined transactions. It returns the number of recovered transactions.
+func (uc *RunScript) recoverMinedTransactions(
+	ctx context.Context,
+	runScriptConfig RunScriptConfig,
+	checkpoint *forge.BroadcastCheckpoint,
+	mined map[string]bool,
+) (int, error) {
+	runResult := &forge.RunResult{
+		Script:        runScriptConfig.Script,
+		Success:       true,
+		ParsedOutput:  &forge.ParsedOutput{ScriptOutput: checkpoint.ScriptOutput, TraceOutputs: checkpoint.TraceOutputs},
+		Senders:       runScriptConfig.SenderScriptConfig,
+		BroadcastPath: checkpoint.BroadcastPath,
+		Network:       checkpoint.Network,
+		ChainID:       checkpoint.ChainID,
+		Namespace:     checkpoint.Namespace,
+	}
+
+	hydrated, err := uc.runResultHydrator.Hydrate(ctx, runResult)
+	if err != nil {
+		return 0, fmt.Errorf("failed to hydrate interrupted broadcast: %w", err)
+	}
+
+	landed := minedSubset(hydrated, mined)
+	if len(landed.Transactions) == 0 {
+		return 0, nil
+	}
+
+	changeset, err := uc.registryUpdater.BuildChangesetFromRunResult(ctx, landed)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare registry updates for mined transactions: %w", err)
+	}
+	if changeset.HasChanges() {
+		if err := uc.registryUpdater.ApplyChangeset(ctx, changeset); err != nil {
+			return 0, fmt.Errorf("failed to record mined transactions: %w", err)
+		}
+	}
+
+	return len(landed.Transactions), nil
+}
+
+// saveCheckpoint records a failed broadcast so that it can be resumed. Nothing is saved when
+// forge never got as far as writing a broadcast file for this run.
+func (uc *RunScript) saveCheckpoint(ctx context.Context, runScriptConfig RunScriptConfig, runResult *forge.RunResult, startTime time.Time) error {
+	if runResult == nil || runResult.ParsedOutput == nil || runResult.ParsedOutput.ScriptOutput == nil {
+		return nil
+	}
+
+	broadcastPath := runResult.BroadcastPath
+	if broadcastPath == "" {
+		broadcastPath = uc.broadcastReader.LatestBroadcastPath(runScriptConfig.BroadcastDir, runScriptConfig.Script, runScriptConfig.Network.ChainID)
+	}
+
+	broadcast, err := uc.broadcastReader.ReadBroadcast(broadcastPath)
+	if err != nil || broadcast.UpdatedAt.Before(startTime) {
+		return nil
+	}
+
+	now := time.Now()
+	return uc.checkpointStore.Save(ctx, &forge.BroadcastCheckpoint{
+		Script:        runScriptConfig.Script.Path,
+		BroadcastDir:  runScriptConfig.BroadcastDir,
+		Namespace:     runScriptConfig.Namespace,
+		Network:       runScriptConfig.Network.Name,
+		ChainID:       runScriptConfig.Network.ChainID,
+		BroadcastPath: broadcastPath,
+		ScriptOutput:  runResult.ParsedOutput.ScriptOutput,
+		TraceOutputs:  runResult.ParsedOutput.TraceOutputs,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+}
+
+// minedSubset narrows a hydrated run result down to the transactions whose hashes were mined
+func minedSubset(hydrated *forge.HydratedRunResult, mined map[string]bool) *forge.HydratedRunResult {
+	subset := *hydrated
+	subset.Transactions = nil
+	subset.Deployments = nil
+	subset.SafeTransactions = nil
+	subset.GovernorProposals = nil
+	subset.TimelockOperations = nil
+	subset.ProxyRelationships = make(map[common.Address]*forge.ProxyRelationship)
+
+	landed := make(map[[32]byte]bool)
+	for _, tx := range hydrated.Transactions {
+		if tx.TxHash == nil || tx.Status != models.TransactionStatusExecuted || !mined[strings.ToLower(tx.TxHash.Hex())] {
+			continue
+		}
+		landed[tx.TransactionId] = true
+		subset.Transactions = append(subset.Transactions, tx)
+	}
+
+	deployed := make(map[common.Address]bool)
+	for _, dep := range hydrated.Deployments {
+		if landed[dep.TransactionID] {
+			subset.Deployments = append(subset.Deployments, dep)
+			deployed[dep.Address] = true
+		}
+	}
+
+	for _, safeTx := range hydrated.SafeTransactions {
+		for _, txID := range safeTx.TransactionIds {
+			if landed[txID] {
+				subset.SafeTransactions = append(subset.SafeTransactions, safeTx)
+				break
+			}
+		}
+	}
+
+	// Upgrades of existing proxies cannot be tied to a transaction, so only relationships of
+	// proxies deployed by mined transactions are recovered; the rest follow once the run completes
+	for proxy, rel := range hydrated.ProxyRelationships {
+		if deployed[proxy] {
+			subset.ProxyRelationships[proxy] = rel
+		}
+	}
+
+	return &subset
+}
+
+// describeBroadcastTx gives a short human readable label for a broadcast transaction
+func describeBroadcastTx(contractName, function string) string {
+	switch {
+	case function != "" && contractName != "":
+		return fmt.Sprintf("%s.%s", contractName, function)
+	case function != "":
+		return function
+	case contractName != "":
+		return fmt.Sprintf("create %s", contractName)
+	default:
+		return "transaction"
+	}
+}
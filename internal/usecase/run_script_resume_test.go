@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain/bindings"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+func TestMinedSubset(t *testing.T) {
+	minedHash := common.HexToHash("0x01")
+	pendingHash := common.HexToHash("0x02")
+	minedID := [32]byte{1}
+	pendingID := [32]byte{2}
+	proxy := common.HexToAddress("0x10")
+	other := common.HexToAddress("0x20")
+
+	hydrated := &forge.HydratedRunResult{
+		RunResult: &forge.RunResult{Namespace: "default", ChainID: 1},
+		Transactions: []*forge.Transaction{
+			{SimulatedTransaction: bindings.SimulatedTransaction{TransactionId: minedID}, Status: models.TransactionStatusExecuted, TxHash: &minedHash},
+			{SimulatedTransaction: bindings.SimulatedTransaction{TransactionId: pendingID}, Status: models.TransactionStatusExecuted, TxHash: &pendingHash},
+		},
+		Deployments: []*forge.Deployment{
+			{TransactionID: minedID, Address: proxy},
+			{TransactionID: pendingID, Address: other},
+		},
+		SafeTransactions: []*forge.SafeTransaction{
+			{TransactionIds: [][32]byte{pendingID}},
+		},
+		ProxyRelationships: map[common.Address]*forge.ProxyRelationship{
+			proxy: {ProxyAddress: proxy},
+			other: {ProxyAddress: other},
+		},
+	}
+
+	subset := minedSubset(hydrated, map[string]bool{minedHash.Hex(): true})
+
+	require.Len(t, subset.Transactions, 1)
+	assert.Equal(t, minedID, subset.Transactions[0].TransactionId)
+	require.Len(t, subset.Deployments, 1)
+	assert.Equal(t, proxy, subset.Deployments[0].Address)
+	assert.Empty(t, subset.SafeTransactions)
+	assert.Len(t, subset.ProxyRelationships, 1)
+	assert.Contains(t, subset.ProxyRelationships, proxy)
+	assert.Equal(t, "default", subset.Namespace)
+
+	// The original result is left untouched
+	assert.Len(t, hydrated.Transactions, 2)
+}
+
+func TestDescribeBroadcastTx(t *testing.T) {
+	assert.Equal(t, "CreateX.deployCreate3(bytes32,bytes)", describeBroadcastTx("CreateX", "deployCreate3(bytes32,bytes)"))
+	assert.Equal(t, "create Counter", describeBroadcastTx("Counter", ""))
+	assert.Equal(t, "transfer(address,uint256)", describeBroadcastTx("", "transfer(address,uint256)"))
+	assert.Equal(t, "transaction", describeBroadcastTx("", ""))
+}
@@ -0,0 +1,432 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/config"
+	"github.com/trebuchet-org/treb-cli/internal/domain/forge"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// stubForgeRunner records the scripts it runs and deploys one contract per run
+type stubForgeRunner struct {
+	mu      sync.Mutex
+	configs []RunScriptConfig
+	fail    func(config RunScriptConfig) bool
+}
+
+func (s *stubForgeRunner) RunScript(_ context.Context, cfg RunScriptConfig) (*forge.RunResult, error) {
+	s.mu.Lock()
+	s.configs = append(s.configs, cfg)
+	s.mu.Unlock()
+
+	success := s.fail == nil || !s.fail(cfg)
+	return &forge.RunResult{
+		DryRun:    cfg.DryRun,
+		Script:    cfg.Script,
+		Success:   success,
+		Namespace: cfg.Namespace,
+		ChainID:   cfg.Network.ChainID,
+		ParsedOutput: &forge.ParsedOutput{
+			ScriptOutput: &forge.ScriptOutput{},
+		},
+	}, nil
+}
+
+func (s *stubForgeRunner) dryRuns() []bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var dryRuns []bool
+	for _, cfg := range s.configs {
+		dryRuns = append(dryRuns, cfg.DryRun)
+	}
+	return dryRuns
+}
+
+// stubHydrator turns every run into the deployment of the script's contract
+type stubHydrator struct{}
+
+func (stubHydrator) Hydrate(_ context.Context, runResult *forge.RunResult) (*forge.HydratedRunResult, error) {
+	return &forge.HydratedRunResult{
+		RunResult: runResult,
+		Deployments: []*forge.Deployment{{
+			Address:  common.HexToAddress("0xA"),
+			Contract: &models.Contract{Name: "Counter"},
+		}},
+	}, nil
+}
+
+// memoryRegistry builds changesets from hydrated deployments and keeps what is applied
+type memoryRegistry struct {
+	mu      sync.Mutex
+	applied []*models.Changeset
+}
+
+func (m *memoryRegistry) BuildChangesetFromRunResult(_ context.Context, result *forge.HydratedRunResult) (*models.Changeset, error) {
+	changeset := &models.Changeset{}
+	for _, deployment := range result.Deployments {
+		changeset.Create.Deployments = append(changeset.Create.Deployments, &models.Deployment{
+			ID:           "default/31337/" + deployment.Contract.Name,
+			ContractName: deployment.Contract.Name,
+			Address:      deployment.Address.Hex(),
+		})
+	}
+	return changeset, nil
+}
+
+func (m *memoryRegistry) ApplyChangeset(_ context.Context, changeset *models.Changeset) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.applied = append(m.applied, changeset)
+	return nil
+}
+
+// passthroughParameterResolver resolves every parameter to the value it was given
+type passthroughParameterResolver struct{}
+
+func (passthroughParameterResolver) ResolveParameters(_ context.Context, _ string, _ []domain.ScriptParameter, values map[string]string) (*domain.ResolvedParameters, error) {
+	resolved := &domain.ResolvedParameters{Values: map[string]string{}, Sources: map[string]domain.ParameterSource{}}
+	for name, value := range values {
+		resolved.Values[name] = value
+	}
+	return resolved, nil
+}
+
+func (passthroughParameterResolver) ValidateParameters(context.Context, []domain.ScriptParameter, map[string]string) error {
+	return nil
+}
+
+// staticSenders gives every script an empty sender configuration
+type staticSenders struct{}
+
+func (staticSenders) BuildSenderScriptConfig(*models.Artifact) (*config.SenderScriptConfig, error) {
+	return &config.SenderScriptConfig{}, nil
+}
+
+func (staticSenders) GetScriptSenders(*models.Artifact) ([]string, error) { return nil, nil }
+
+type noLibraries struct{}
+
+func (noLibraries) GetDeployedLibraries(context.Context, string, uint64) ([]LibraryReference, error) {
+	return nil, nil
+}
+
+type noEnvironment struct{}
+
+func (noEnvironment) Inspect(context.Context) (*models.RunEnvironment, error) {
+	return nil, errors.New("not inspected in tests")
+}
+
+// memoryCheckpointStore keeps broadcast checkpoints in memory
+type memoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]*forge.BroadcastCheckpoint
+}
+
+func newMemoryCheckpointStore() *memoryCheckpointStore {
+	return &memoryCheckpointStore{checkpoints: map[string]*forge.BroadcastCheckpoint{}}
+}
+
+func checkpointKey(broadcastDir, scriptPath string, chainID uint64) string {
+	return fmt.Sprintf("%s/%s@%d", broadcastDir, scriptPath, chainID)
+}
+
+func (m *memoryCheckpointStore) Load(_ context.Context, broadcastDir, scriptPath string, chainID uint64) (*forge.BroadcastCheckpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.checkpoints[checkpointKey(broadcastDir, scriptPath, chainID)], nil
+}
+
+func (m *memoryCheckpointStore) Save(_ context.Context, checkpoint *forge.BroadcastCheckpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkpoints[checkpointKey(checkpoint.BroadcastDir, checkpoint.Script, checkpoint.ChainID)] = checkpoint
+	return nil
+}
+
+func (m *memoryCheckpointStore) Delete(_ context.Context, broadcastDir, scriptPath string, chainID uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.checkpoints, checkpointKey(broadcastDir, scriptPath, chainID))
+	return nil
+}
+
+// freshBroadcasts reports a broadcast file written just now for every run
+type freshBroadcasts struct{}
+
+func (freshBroadcasts) LatestBroadcastPath(broadcastDir string, script *models.Contract, chainID uint64) string {
+	return path.Join(cmp.Or(broadcastDir, "broadcast"), path.Base(script.Path), fmt.Sprint(chainID), "run-latest.json")
+}
+
+func (freshBroadcasts) ReadBroadcast(string) (*domain.BroadcastFile, error) {
+	return &domain.BroadcastFile{UpdatedAt: time.Now()}, nil
+}
+
+// stubConfirmer answers every confirmation with the same decision
+type stubConfirmer struct {
+	approve bool
+	prompts []string
+}
+
+func (s *stubConfirmer) Confirm(_ context.Context, prompt string) (bool, error) {
+	s.prompts = append(s.prompts, prompt)
+	return s.approve, nil
+}
+
+// newTestRunScript builds a RunScript on a local network with in-memory adapters
+func newTestRunScript(runner ForgeScriptRunner, registry DeploymentRepositoryUpdater, confirmer Confirmer) *RunScript {
+	cfg := &config.RuntimeConfig{
+		Namespace: "default",
+		Network:   &config.Network{Name: "anvil", ChainID: 31337, RPCURL: "http://localhost:8545"},
+	}
+	scripts := stubScriptResolver{scripts: []*models.Contract{
+		{Name: "DeployCounter", Path: "script/DeployCounter.s.sol", Artifact: &models.Artifact{}},
+	}}
+	return NewRunScript(cfg, scripts, passthroughParameterResolver{}, staticSenders{}, stubHydrator{}, registry,
+		noLibraries{}, NopProgress{}, runner, &mockForkState{}, nil, nil, confirmer, nil, nil,
+		newMemoryCheckpointStore(), mockRunRecords{}, noEnvironment{}, nil, nil, NewRegistryLock())
+}
+
+func TestRunScript_ConfirmationGate(t *testing.T) {
+	t.Run("approved broadcast runs after the preview", func(t *testing.T) {
+		runner := &stubForgeRunner{}
+		registry := &memoryRegistry{}
+		confirmer := &stubConfirmer{approve: true}
+		uc := newTestRunScript(runner, registry, confirmer)
+
+		result, err := uc.Run(context.Background(), RunScriptParams{ScriptRef: "DeployCounter", Confirm: true})
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.False(t, result.Aborted)
+		assert.Equal(t, []string{"Broadcast these changes?"}, confirmer.prompts)
+		assert.Equal(t, []bool{true, false}, runner.dryRuns(), "simulation first, then the broadcast")
+		require.Len(t, registry.applied, 1)
+		require.NotNil(t, result.Changeset)
+		assert.Equal(t, "default/31337/Counter", result.Changeset.Create.Deployments[0].ID)
+	})
+
+	t.Run("declined broadcast is aborted after the preview", func(t *testing.T) {
+		runner := &stubForgeRunner{}
+		registry := &memoryRegistry{}
+		confirmer := &stubConfirmer{approve: false}
+		uc := newTestRunScript(runner, registry, confirmer)
+
+		result, err := uc.Run(context.Background(), RunScriptParams{ScriptRef: "DeployCounter", Confirm: true})
+		require.NoError(t, err)
+		assert.False(t, result.Success)
+		assert.True(t, result.Aborted)
+		assert.EqualError(t, result.Error, "broadcast cancelled by user")
+		assert.Len(t, confirmer.prompts, 1)
+		assert.Equal(t, []bool{true}, runner.dryRuns(), "only the simulation runs")
+		assert.Empty(t, registry.applied)
+	})
+
+	t.Run("--yes approves without prompting", func(t *testing.T) {
+		runner := &stubForgeRunner{}
+		confirmer := &stubConfirmer{}
+		uc := newTestRunScript(runner, &memoryRegistry{}, confirmer)
+
+		result, err := uc.Run(context.Background(), RunScriptParams{ScriptRef: "DeployCounter", Confirm: true, Yes: true, NonInteractive: true})
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Empty(t, confirmer.prompts)
+		assert.Equal(t, []bool{true, false}, runner.dryRuns())
+	})
+
+	t.Run("non-interactive broadcast needs --yes", func(t *testing.T) {
+		runner := &stubForgeRunner{}
+		uc := newTestRunScript(runner, &memoryRegistry{}, &stubConfirmer{approve: true})
+
+		_, err := uc.Run(context.Background(), RunScriptParams{ScriptRef: "DeployCounter", Confirm: true, NonInteractive: true})
+		require.EqualError(t, err, "broadcast requires confirmation but prompts are disabled, pass --yes to approve it")
+		assert.Empty(t, runner.dryRuns())
+	})
+
+	t.Run("failed simulation never asks", func(t *testing.T) {
+		runner := &stubForgeRunner{fail: func(cfg RunScriptConfig) bool { return cfg.DryRun }}
+		confirmer := &stubConfirmer{approve: true}
+		uc := newTestRunScript(runner, &memoryRegistry{}, confirmer)
+
+		result, err := uc.Run(context.Background(), RunScriptParams{ScriptRef: "DeployCounter", Confirm: true})
+		require.NoError(t, err)
+		assert.False(t, result.Success)
+		assert.EqualError(t, result.Error, "simulation failed")
+		assert.Empty(t, confirmer.prompts)
+		assert.Equal(t, []bool{true}, runner.dryRuns())
+	})
+}
+
+// registryParameterResolver resolves deployment parameters from a fixed registry and
+// leaves the others to the prompt
+type registryParameterResolver struct {
+	deployments map[string]string
+}
+
+func (r registryParameterResolver) ResolveParameters(_ context.Context, _ string, params []domain.ScriptParameter, values map[string]string) (*domain.ResolvedParameters, error) {
+	resolved := &domain.ResolvedParameters{Values: map[string]string{}, Sources: map[string]domain.ParameterSource{}, Unresolved: map[string]error{}}
+	for _, param := range params {
+		switch {
+		case values[param.Name] != "":
+			resolved.Values[param.Name] = values[param.Name]
+		case r.deployments[param.Name] != "":
+			resolved.Values[param.Name] = r.deployments[param.Name]
+		default:
+			resolved.Unresolved[param.Name] = domain.ErrNotFound
+		}
+	}
+	return resolved, nil
+}
+
+func (registryParameterResolver) ValidateParameters(context.Context, []domain.ScriptParameter, map[string]string) error {
+	return nil
+}
+
+// stubPrompter answers every parameter prompt with the same values
+type stubPrompter struct {
+	answers  map[string]string
+	prompted []string
+}
+
+func (s *stubPrompter) PromptForParameters(_ context.Context, params []domain.ScriptParameter, _ map[string]string) (map[string]string, error) {
+	for _, param := range params {
+		s.prompted = append(s.prompted, param.Name)
+	}
+	return s.answers, nil
+}
+
+func TestRunScript_PromptsForUnresolvedParameters(t *testing.T) {
+	newRunScript := func(runner ForgeScriptRunner, prompter ParameterPrompter) *RunScript {
+		uc := newTestRunScript(runner, &memoryRegistry{}, &stubConfirmer{approve: true})
+		uc.scriptResolver = stubScriptResolver{
+			scripts: []*models.Contract{{Name: "DeployCounter", Path: "script/DeployCounter.s.sol", Artifact: &models.Artifact{}}},
+			params: map[string][]domain.ScriptParameter{"DeployCounter": {
+				{Name: "OWNER", Type: domain.ParamTypeAddress},
+				{Name: "TOKEN", Type: domain.ParamTypeDeployment},
+			}},
+		}
+		uc.paramResolver = registryParameterResolver{deployments: map[string]string{"OWNER": "0x0000000000000000000000000000000000000001"}}
+		uc.paramPrompter = prompter
+		return uc
+	}
+
+	t.Run("interactive run prompts for a deployment that cannot be resolved", func(t *testing.T) {
+		runner := &stubForgeRunner{}
+		prompter := &stubPrompter{answers: map[string]string{"TOKEN": "0x0000000000000000000000000000000000000042"}}
+		uc := newRunScript(runner, prompter)
+
+		result, err := uc.Run(context.Background(), RunScriptParams{ScriptRef: "DeployCounter", DryRun: true})
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, []string{"TOKEN"}, prompter.prompted)
+		assert.Equal(t, "0x0000000000000000000000000000000000000042", result.Parameters.Values["TOKEN"])
+		assert.Equal(t, domain.ParamSourcePrompt, result.Parameters.Sources["TOKEN"].Kind)
+	})
+
+	t.Run("non-interactive run reports why the parameter could not be resolved", func(t *testing.T) {
+		runner := &stubForgeRunner{}
+		prompter := &stubPrompter{}
+		uc := newRunScript(runner, prompter)
+
+		_, err := uc.Run(context.Background(), RunScriptParams{ScriptRef: "DeployCounter", DryRun: true, NonInteractive: true})
+		require.EqualError(t, err, "failed to resolve parameter TOKEN: not found")
+		assert.Empty(t, prompter.prompted)
+		assert.Empty(t, runner.dryRuns())
+	})
+}
+
+func TestRunScript_ParallelCheckpoints(t *testing.T) {
+	// Every broadcast fails after forge wrote its broadcast file
+	runner := &stubForgeRunner{fail: func(cfg RunScriptConfig) bool { return !cfg.DryRun }}
+	uc := newTestRunScript(runner, &memoryRegistry{}, nil)
+	uc.broadcastReader = freshBroadcasts{}
+	store := uc.checkpointStore.(*memoryCheckpointStore)
+
+	// Parallel compose steps running the same script broadcast to their own directories
+	dirs := []string{"broadcast/compose/protocol/a", "broadcast/compose/protocol/b"}
+	var wg sync.WaitGroup
+	for _, dir := range dirs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := uc.Run(context.Background(), RunScriptParams{ScriptRef: "DeployCounter", BroadcastDir: dir, NonInteractive: true})
+			assert.NoError(t, err)
+			assert.False(t, result.Success)
+		}()
+	}
+	wg.Wait()
+
+	for _, dir := range dirs {
+		checkpoint, err := store.Load(context.Background(), dir, "script/DeployCounter.s.sol", 31337)
+		require.NoError(t, err)
+		require.NotNil(t, checkpoint, dir)
+		assert.Equal(t, dir, checkpoint.BroadcastDir)
+		assert.Equal(t, dir+"/DeployCounter.s.sol/31337/run-latest.json", checkpoint.BroadcastPath)
+	}
+	assert.Len(t, store.checkpoints, 2)
+}
+
+// safeTxHydrator reports the Safe transactions a broadcast proposed
+type safeTxHydrator struct {
+	safeTxs []*forge.SafeTransaction
+}
+
+func (h safeTxHydrator) Hydrate(_ context.Context, runResult *forge.RunResult) (*forge.HydratedRunResult, error) {
+	return &forge.HydratedRunResult{RunResult: runResult, SafeTransactions: h.safeTxs}, nil
+}
+
+// recordingSafeConfirmer records the confirmations it is asked for
+type recordingSafeConfirmer struct {
+	confirmed []string
+}
+
+func (r *recordingSafeConfirmer) ConfirmSafeTransaction(_ context.Context, _ uint64, safeTxHash common.Hash, sender string) (common.Address, error) {
+	r.confirmed = append(r.confirmed, fmt.Sprintf("%x:%s", safeTxHash[:1], sender))
+	return common.Address{}, nil
+}
+
+func TestRunScript_ConfirmsProposedSafeTransactions(t *testing.T) {
+	safe := common.HexToAddress("0x3D33783D1fd1B6D849d299aD2E711f844fC16d2F")
+	newUseCase := func() (*RunScript, *recordingSafeConfirmer) {
+		uc := newTestRunScript(&stubForgeRunner{}, &memoryRegistry{}, nil)
+		uc.config.TrebConfig = &config.TrebConfig{Senders: map[string]config.SenderConfig{
+			"safe0": {Type: config.SenderTypeSafe, Safe: safe.Hex(), Signer: "deployer", Confirmers: []string{"ops", "ops2"}},
+		}}
+		uc.runResultHydrator = safeTxHydrator{safeTxs: []*forge.SafeTransaction{
+			{SafeTxHash: [32]byte{0x01}, Safe: safe},
+			{SafeTxHash: [32]byte{0x02}, Safe: safe, Executed: true},
+			{SafeTxHash: [32]byte{0x03}, Safe: common.HexToAddress("0xB")},
+		}}
+		safeConfirmer := &recordingSafeConfirmer{}
+		uc.safeConfirmer = safeConfirmer
+		return uc, safeConfirmer
+	}
+
+	t.Run("broadcast confirms as every confirmer", func(t *testing.T) {
+		uc, safeConfirmer := newUseCase()
+		result, err := uc.Run(context.Background(), RunScriptParams{ScriptRef: "DeployCounter", NonInteractive: true})
+		require.NoError(t, err)
+		require.True(t, result.Success)
+
+		// Executed transactions and other Safes are left alone
+		assert.Equal(t, []string{"01:ops", "01:ops2"}, safeConfirmer.confirmed)
+	})
+
+	t.Run("dry run confirms nothing", func(t *testing.T) {
+		uc, safeConfirmer := newUseCase()
+		result, err := uc.Run(context.Background(), RunScriptParams{ScriptRef: "DeployCounter", DryRun: true})
+		require.NoError(t, err)
+		require.True(t, result.Success)
+		assert.Empty(t, safeConfirmer.confirmed)
+	})
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/trebuchet-org/treb-cli/internal/domain"
+	"github.com/trebuchet-org/treb-cli/internal/domain/models"
+)
+
+// ShowRunParams contains parameters for showing a run record
+type ShowRunParams struct {
+	// RunRef is a run ID, a unique prefix of one, or "latest"
+	RunRef string
+}
+
+// ShowRunResult contains the run record and the registry entries it produced
+type ShowRunResult struct {
+	Run         *models.RunRecord
+	Deployments []*models.Deployment // Deployments of the changeset that are still in the registry
+}
+
+// ShowRun is the use case for inspecting a single run record
+type ShowRun struct {
+	runs RunRecordStore
+	repo DeploymentRepository
+}
+
+// NewShowRun creates a new ShowRun use case
+func NewShowRun(runs RunRecordStore, repo DeploymentRepository) *ShowRun {
+	return &ShowRun{runs: runs, repo: repo}
+}
+
+// Run executes the use case
+func (uc *ShowRun) Run(ctx context.Context, params ShowRunParams) (*ShowRunResult, error) {
+	record, err := uc.resolveRun(ctx, params.RunRef)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ShowRunResult{Run: record}
+	for _, id := range record.Changeset.Deployments {
+		deployment, err := uc.repo.GetDeployment(ctx, id)
+		if err != nil {
+			continue
+		}
+		result.Deployments = append(result.Deployments, deployment)
+	}
+
+	return result, nil
+}
+
+// resolveRun finds a run by exact ID first, then by unique ID prefix
+func (uc *ShowRun) resolveRun(ctx context.Context, ref string) (*models.RunRecord, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("run ID is required")
+	}
+
+	if ref != "latest" {
+		record, err := uc.runs.Get(ctx, ref)
+		if err == nil {
+			return record, nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	records, err := uc.runs.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ref == "latest" {
+		if len(records) == 0 {
+			return nil, fmt.Errorf("no runs recorded yet")
+		}
+		return records[0], nil
+	}
+
+	var matches []*models.RunRecord
+	for _, record := range records {
+		if strings.HasPrefix(record.ID, ref) {
+			matches = append(matches, record)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("run '%s' not found", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, match := range matches {
+			ids[i] = match.ID
+		}
+		return nil, fmt.Errorf("run reference '%s' is ambiguous, matches: %s", ref, strings.Join(ids, ", "))
+	}
+}
@@ -1,6 +1,7 @@
 package safe
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // TransactionServiceURLs contains the Safe Transaction Service URLs for different networks
@@ -102,6 +104,37 @@ func (c *SafeClient) GetTransaction(safeTxHash common.Hash) (*MultisigTransactio
 	return &tx, nil
 }
 
+// ConfirmTransaction submits an owner signature confirming a Safe transaction
+func (c *SafeClient) ConfirmTransaction(safeTxHash common.Hash, signature []byte) error {
+	url := fmt.Sprintf("%s/api/v1/multisig-transactions/%s/confirmations/", c.serviceURL, safeTxHash.Hex())
+
+	payload, err := json.Marshal(map[string]string{"signature": hexutil.Encode(signature)})
+	if err != nil {
+		return fmt.Errorf("failed to encode confirmation: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req) //nolint:gosec // URL is constructed from configured Safe service endpoint
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
 // IsTransactionExecuted checks if a Safe transaction has been executed
 func (c *SafeClient) IsTransactionExecuted(safeTxHash common.Hash) (bool, *common.Hash, error) {
 	tx, err := c.GetTransaction(safeTxHash)
@@ -122,6 +122,13 @@ func (n TargetedHashNormalizer) Normalize(output string) string {
 	return output
 }
 
+// RunIDNormalizer replaces run record IDs (e.g. 20250126-150405-a1b2c3)
+type RunIDNormalizer struct{}
+
+func (n RunIDNormalizer) Normalize(output string) string {
+	return regexp.MustCompile(`\b\d{8}-\d{6}-[0-9a-f]{6}\b`).ReplaceAllString(output, "<RUN_ID>")
+}
+
 // BlockNumberNormalizer replaces block numbers
 type BlockNumberNormalizer struct{}
 
@@ -238,6 +245,7 @@ func GetDefaultNormalizers() []Normalizer {
 		FoundryWarningsNormalizer{},
 		LineClearArtifactNormalizer{},
 		SpinnerNormalizer{},
+		RunIDNormalizer{},
 		TimestampNormalizer{},
 		VersionNormalizer{},
 		TargetedGitCommitNormalizer{},
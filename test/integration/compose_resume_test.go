@@ -38,7 +38,7 @@ func TestComposeResume(t *testing.T) {
 			},
 			OutputArtifacts: []string{
 				".treb/deployments.json",
-				"out/.treb/compose-compose-resume-test.json",
+				"out/.treb/compose-compose-resume-test-default-anvil-31337.json",
 			},
 		},
 	}
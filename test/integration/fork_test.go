@@ -1508,3 +1508,139 @@ func TestForkCommandGuards(t *testing.T) {
 
 	RunIntegrationTests(t, tests)
 }
+
+func TestForkExportImportCommands(t *testing.T) {
+	tests := []IntegrationTest{
+		{
+			Name: "fork_import_restores_exported_state",
+			PreSetup: func(t *testing.T, ctx *helpers.TestContext) {
+				setupForkEnvVars(t, ctx)
+			},
+			SetupCmds: [][]string{
+				s("config set network anvil-31337"),
+				{"gen", "deploy", "src/Counter.sol:Counter"},
+				{"fork", "enter", "anvil-31337"},
+				{"run", "script/deploy/DeployCounter.s.sol"},
+			},
+			TestCmds:   [][]string{},
+			SkipGolden: true,
+			PostTest: func(t *testing.T, ctx *helpers.TestContext, _ string) {
+				workDir := ctx.TrebContext.GetWorkDir()
+				deploymentsPath := filepath.Join(workDir, ".treb", "deployments.json")
+				assertNoCounter := func(msg string) {
+					data, err := os.ReadFile(deploymentsPath)
+					if err == nil {
+						assert.NotContains(t, string(data), "Counter", msg)
+					}
+				}
+				exportPath := filepath.Join(workDir, "fork-export.json")
+				address := getDeploymentAddress(t, workDir, "Counter")
+				require.NotEmpty(t, address, "Counter should be deployed on the fork")
+
+				output, err := ctx.TrebContext.Treb("fork", "export", "anvil-31337", exportPath)
+				require.NoError(t, err, "fork export should succeed")
+				assert.Contains(t, output, "exported to")
+				assert.FileExists(t, exportPath)
+
+				// Exiting drops the deployment from the registry and stops the fork
+				_, err = ctx.TrebContext.Treb("fork", "exit", "anvil-31337")
+				require.NoError(t, err)
+				assertNoCounter("Counter should be gone after fork exit")
+
+				output, err = ctx.TrebContext.Treb("fork", "import", exportPath)
+				require.NoError(t, err, "fork import should succeed")
+				defer cleanupForkAnvil(t, ctx, "anvil-31337")
+				assert.Contains(t, output, "Fork imported")
+
+				// Registry and EVM state are both back
+				assert.Equal(t, address, getDeploymentAddress(t, workDir, "Counter"))
+				state := readForkState(t, ctx)
+				fork := state.Forks["anvil-31337"]
+				require.NotNil(t, fork, "fork entry should exist after import")
+				assert.NotEqual(t, "0x", ethGetCode(t, fork.ForkURL, address), "imported fork should have the Counter code")
+				require.Len(t, fork.Snapshots, 2)
+				assert.Equal(t, "fork import fork-export.json", fork.Snapshots[1].Command)
+
+				// Reverting undoes the import
+				_, err = ctx.TrebContext.Treb("fork", "revert", "anvil-31337")
+				require.NoError(t, err)
+				assertNoCounter("Counter should be gone after reverting the import")
+			},
+		},
+	}
+
+	RunIntegrationTests(t, tests)
+}
+
+func TestForkCheckpointCommand(t *testing.T) {
+	tests := []IntegrationTest{
+		{
+			Name: "fork_revert_to_checkpoint",
+			PreSetup: func(t *testing.T, ctx *helpers.TestContext) {
+				setupForkEnvVars(t, ctx)
+			},
+			SetupCmds: [][]string{
+				s("config set network anvil-31337"),
+				{"gen", "deploy", "src/Counter.sol:Counter"},
+				{"gen", "deploy", "src/SampleToken.sol:SampleToken"},
+				{"fork", "enter", "anvil-31337"},
+				{"run", "script/deploy/DeployCounter.s.sol"},
+				{"fork", "checkpoint", "after-counter"},
+				{"run", "script/deploy/DeploySampleToken.s.sol"},
+			},
+			TestCmds: [][]string{
+				{"fork", "history", "anvil-31337"},
+			},
+			SkipGolden: true,
+			PostTest: func(t *testing.T, ctx *helpers.TestContext, output string) {
+				defer cleanupForkAnvil(t, ctx, "anvil-31337")
+				workDir := ctx.TrebContext.GetWorkDir()
+				deploymentsPath := filepath.Join(workDir, ".treb", "deployments.json")
+
+				assert.Contains(t, output, "[2] checkpoint 'after-counter'")
+				assert.Contains(t, output, "DeploySampleToken")
+
+				// Revert to the checkpoint twice: the checkpoint survives a revert
+				for range 2 {
+					output, err := ctx.TrebContext.Treb("fork", "revert", "--to", "after-counter")
+					require.NoError(t, err, "fork revert --to should succeed")
+					assert.Contains(t, output, "Reverted 1 run(s)")
+					assert.Contains(t, output, "restored to checkpoint 'after-counter'")
+
+					data, err := os.ReadFile(deploymentsPath)
+					require.NoError(t, err)
+					assert.Contains(t, string(data), "Counter")
+					assert.NotContains(t, string(data), "SampleToken")
+
+					state := readForkState(t, ctx)
+					fork := state.Forks["anvil-31337"]
+					require.NotNil(t, fork)
+					require.Len(t, fork.Snapshots, 3)
+					assert.Equal(t, "after-counter", fork.Snapshots[2].Name)
+
+					_, err = ctx.TrebContext.Treb("run", "script/deploy/DeploySampleToken.s.sol")
+					require.NoError(t, err)
+				}
+
+				// A plain revert undoes the last run and keeps the checkpoint below it
+				_, err := ctx.TrebContext.Treb("fork", "revert")
+				require.NoError(t, err, "fork revert should succeed")
+				state := readForkState(t, ctx)
+				require.Len(t, state.Forks["anvil-31337"].Snapshots, 3)
+				assert.Equal(t, "after-counter", state.Forks["anvil-31337"].Snapshots[2].Name)
+
+				// With only the checkpoint on top, a plain revert undoes the run before it
+				output, err = ctx.TrebContext.Treb("fork", "revert")
+				require.NoError(t, err, "fork revert should succeed")
+				assert.Contains(t, output, "DeployCounter")
+				state = readForkState(t, ctx)
+				require.Len(t, state.Forks["anvil-31337"].Snapshots, 1)
+
+				_, err = ctx.TrebContext.Treb("fork", "revert", "--to", "missing")
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	RunIntegrationTests(t, tests)
+}